@@ -0,0 +1,140 @@
+package aml
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	"os"
+	"time"
+)
+
+// Structuring looks for accounts splitting money into many transfers just under the amount that would get them
+// reported.
+type Structuring struct {
+	Currency  string `json:"currency"`
+	Threshold int64  `json:"threshold"`
+	// How far under the threshold a transfer still counts as just under it, in basis points of the threshold
+	MarginBps   int64 `json:"margin_bps"`
+	MinCount    int64 `json:"min_count"`
+	WindowHours int   `json:"window_hours"`
+}
+
+// RapidMovement looks for accounts that money passes straight through: at least MinAmount comes in and most of it
+// goes out again within the window.
+type RapidMovement struct {
+	Currency  string `json:"currency"`
+	MinAmount int64  `json:"min_amount"`
+	// How much of the inflow has to go out again, in basis points
+	OutflowBps  int64 `json:"outflow_bps"`
+	WindowHours int   `json:"window_hours"`
+}
+
+// DormantReactivation looks for accounts that send at least MinAmount out within the window after DormantDays without
+// any activity. Fees and interest postings don't count as activity.
+type DormantReactivation struct {
+	Currency    string `json:"currency"`
+	DormantDays int    `json:"dormant_days"`
+	MinAmount   int64  `json:"min_amount"`
+	WindowHours int    `json:"window_hours"`
+}
+
+// Scenarios are the patterns transactions are monitored for. Each scenario can be configured once per currency, or
+// left out.
+type Scenarios struct {
+	Structuring         []Structuring         `json:"structuring"`
+	RapidMovement       []RapidMovement       `json:"rapid_movement"`
+	DormantReactivation []DormantReactivation `json:"dormant_reactivation"`
+}
+
+// LoadScenarios reads JSON monitoring scenarios. An empty path gives no scenarios, so nothing is monitored.
+func LoadScenarios(path string) (Scenarios, error) {
+	var scenarios Scenarios
+	if path == "" {
+		return scenarios, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return scenarios, err
+	}
+
+	if err := json.Unmarshal(data, &scenarios); err != nil {
+		return scenarios, fmt.Errorf("cannot parse AML scenarios: %w", err)
+	}
+
+	return scenarios, scenarios.Validate()
+}
+
+func (scenarios Scenarios) Validate() error {
+	seen := map[string]bool{}
+	check := func(scenario string, currencyCode string, windowHours int) error {
+		if _, ok := currency.Lookup(currencyCode); !ok {
+			return fmt.Errorf("%s scenario has unknown currency %q", scenario, currencyCode)
+		}
+		if seen[scenario+"/"+currencyCode] {
+			return fmt.Errorf("%s scenario in %s is defined twice", scenario, currencyCode)
+		}
+		seen[scenario+"/"+currencyCode] = true
+		if windowHours <= 0 {
+			return fmt.Errorf("%s scenario in %s needs a window", scenario, currencyCode)
+		}
+		return nil
+	}
+
+	for _, s := range scenarios.Structuring {
+		if err := check("structuring", s.Currency, s.WindowHours); err != nil {
+			return err
+		}
+		if s.Threshold <= 0 || s.MarginBps <= 0 || s.MarginBps > 10000 || s.MinCount < 2 {
+			return fmt.Errorf(
+				"structuring scenario in %s needs a threshold, a margin of up to 10000 bps and a count of at least 2",
+				s.Currency,
+			)
+		}
+	}
+
+	for _, s := range scenarios.RapidMovement {
+		if err := check("rapid_movement", s.Currency, s.WindowHours); err != nil {
+			return err
+		}
+		if s.MinAmount <= 0 || s.OutflowBps <= 0 || s.OutflowBps > 10000 {
+			return fmt.Errorf(
+				"rapid_movement scenario in %s needs a minimum amount and an outflow of up to 10000 bps", s.Currency,
+			)
+		}
+	}
+
+	for _, s := range scenarios.DormantReactivation {
+		if err := check("dormant_reactivation", s.Currency, s.WindowHours); err != nil {
+			return err
+		}
+		if s.DormantDays <= 0 || s.MinAmount <= 0 {
+			return fmt.Errorf("dormant_reactivation scenario in %s needs dormant days and a minimum amount", s.Currency)
+		}
+	}
+
+	return nil
+}
+
+// MinAmount is the smallest transfer that counts as just under the threshold.
+func (s Structuring) MinAmount() int64 {
+	return s.Threshold - s.Threshold*s.MarginBps/10000
+}
+
+func (s Structuring) Window() time.Duration {
+	return time.Duration(s.WindowHours) * time.Hour
+}
+
+func (s RapidMovement) Window() time.Duration {
+	return time.Duration(s.WindowHours) * time.Hour
+}
+
+func (s DormantReactivation) Window() time.Duration {
+	return time.Duration(s.WindowHours) * time.Hour
+}
+
+// DormantSince is how far back an account must have had no activity, for activity in the window starting at
+// windowStart to count as reactivating it.
+func (s DormantReactivation) DormantSince(windowStart time.Time) time.Time {
+	return windowStart.AddDate(0, 0, -s.DormantDays)
+}
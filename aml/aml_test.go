@@ -0,0 +1,67 @@
+package aml
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	valid := Scenarios{
+		Structuring: []Structuring{
+			{Currency: "USD", Threshold: 1000000, MarginBps: 1000, MinCount: 3, WindowHours: 72},
+		},
+		RapidMovement: []RapidMovement{
+			{Currency: "USD", MinAmount: 500000, OutflowBps: 9000, WindowHours: 24},
+		},
+		DormantReactivation: []DormantReactivation{
+			{Currency: "USD", DormantDays: 180, MinAmount: 100000, WindowHours: 24},
+		},
+	}
+	require.NoError(t, valid.Validate())
+	require.NoError(t, Scenarios{}.Validate())
+
+	testCases := []struct {
+		name      string
+		scenarios Scenarios
+	}{
+		{name: "UnknownCurrency", scenarios: Scenarios{Structuring: []Structuring{{Currency: "XYZ", Threshold: 100, MarginBps: 100, MinCount: 2, WindowHours: 1}}}},
+		{name: "Duplicate", scenarios: Scenarios{RapidMovement: []RapidMovement{valid.RapidMovement[0], valid.RapidMovement[0]}}},
+		{name: "NoWindow", scenarios: Scenarios{RapidMovement: []RapidMovement{{Currency: "USD", MinAmount: 100, OutflowBps: 9000}}}},
+		{name: "NoThreshold", scenarios: Scenarios{Structuring: []Structuring{{Currency: "USD", MarginBps: 100, MinCount: 2, WindowHours: 1}}}},
+		{name: "MarginTooWide", scenarios: Scenarios{Structuring: []Structuring{{Currency: "USD", Threshold: 100, MarginBps: 10001, MinCount: 2, WindowHours: 1}}}},
+		{name: "SingleTransfer", scenarios: Scenarios{Structuring: []Structuring{{Currency: "USD", Threshold: 100, MarginBps: 100, MinCount: 1, WindowHours: 1}}}},
+		{name: "NoOutflow", scenarios: Scenarios{RapidMovement: []RapidMovement{{Currency: "USD", MinAmount: 100, WindowHours: 1}}}},
+		{name: "NoDormantDays", scenarios: Scenarios{DormantReactivation: []DormantReactivation{{Currency: "USD", MinAmount: 100, WindowHours: 1}}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				require.Error(t, tc.scenarios.Validate())
+			},
+		)
+	}
+}
+
+func TestLoadScenarios(t *testing.T) {
+	scenarios, err := LoadScenarios("")
+	require.NoError(t, err)
+	require.Empty(t, scenarios.Structuring)
+
+	scenarios, err = LoadScenarios("../aml.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, scenarios.Structuring)
+	require.NotEmpty(t, scenarios.RapidMovement)
+	require.NotEmpty(t, scenarios.DormantReactivation)
+}
+
+func TestWindows(t *testing.T) {
+	structuring := Structuring{Threshold: 1000000, MarginBps: 1000, WindowHours: 72}
+	require.Equal(t, int64(900000), structuring.MinAmount())
+	require.Equal(t, 72*time.Hour, structuring.Window())
+
+	dormant := DormantReactivation{DormantDays: 180, WindowHours: 24}
+	windowStart := time.Date(2023, time.March, 15, 10, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2022, time.September, 16, 10, 0, 0, 0, time.UTC), dormant.DormantSince(windowStart))
+}
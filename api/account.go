@@ -4,16 +4,75 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
 	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
 	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/CrunchyBlue/Golang-Bank/util"
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
 	"net/http"
+	"time"
 )
 
+type accountResponse struct {
+	ID                      int64     `json:"id"`
+	AccountNumber           string    `json:"account_number"`
+	IBAN                    *string   `json:"iban"`
+	Nickname                *string   `json:"nickname"`
+	Owner                   string    `json:"owner"`
+	OrganizationID          *int64    `json:"organization_id"`
+	Balance                 int64     `json:"balance"`
+	BalanceDecimal          string    `json:"balance_decimal"`
+	AvailableBalance        int64     `json:"available_balance"`
+	AvailableBalanceDecimal string    `json:"available_balance_decimal"`
+	Currency                string    `json:"currency"`
+	Product                 string    `json:"product"`
+	Status                  string    `json:"status"`
+	CreatedAt               time.Time `json:"created_at"`
+}
+
+func newAccountResponse(account db.Account) accountResponse {
+	availableBalance := account.Balance - account.HeldBalance
+
+	res := accountResponse{
+		ID:                      account.ID,
+		AccountNumber:           account.AccountNumber,
+		Owner:                   account.Owner,
+		Balance:                 account.Balance,
+		BalanceDecimal:          currency.Decimal(account.Currency, account.Balance),
+		AvailableBalance:        availableBalance,
+		AvailableBalanceDecimal: currency.Decimal(account.Currency, availableBalance),
+		Currency:                account.Currency,
+		Product:                 account.Product,
+		Status:                  account.Status,
+		CreatedAt:               account.CreatedAt,
+	}
+	if account.Iban.Valid {
+		res.IBAN = &account.Iban.String
+	}
+	if account.Nickname.Valid {
+		res.Nickname = &account.Nickname.String
+	}
+	if account.OrganizationID.Valid {
+		res.OrganizationID = &account.OrganizationID.Int64
+	}
+	return res
+}
+
+func newAccountsResponse(accounts []db.Account) []accountResponse {
+	res := make([]accountResponse, len(accounts))
+	for i, account := range accounts {
+		res[i] = newAccountResponse(account)
+	}
+	return res
+}
+
 type getAccountsRequest struct {
-	PageNumber int32 `form:"page_number" binding:"required,min=1"`
-	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+	PageNumber int32  `form:"page_number" binding:"required,min=1"`
+	PageSize   int32  `form:"page_size" binding:"required,min=10,max=50"`
+	Currency   string `form:"currency" binding:"omitempty,currency"`
+	Product    string `form:"product" binding:"omitempty,oneof=checking savings"`
+	Status     string `form:"status" binding:"omitempty,oneof=active frozen dormant closed"`
 }
 
 func (server *Server) getAccounts(ctx *gin.Context) {
@@ -27,9 +86,12 @@ func (server *Server) getAccounts(ctx *gin.Context) {
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
 	arg := db.GetAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: (req.PageNumber - 1) * req.PageSize,
+		Owner:    authPayload.Username,
+		Currency: sql.NullString{String: req.Currency, Valid: req.Currency != ""},
+		Product:  sql.NullString{String: req.Product, Valid: req.Product != ""},
+		Status:   sql.NullString{String: req.Status, Valid: req.Status != ""},
+		Limit:    req.PageSize,
+		Offset:   (req.PageNumber - 1) * req.PageSize,
 	}
 
 	accounts, err := server.store.GetAccounts(ctx, arg)
@@ -38,7 +100,7 @@ func (server *Server) getAccounts(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, accounts)
+	ctx.JSON(http.StatusOK, newAccountsResponse(accounts))
 }
 
 type getAccountRequest struct {
@@ -53,128 +115,182 @@ func (server *Server) getAccount(ctx *gin.Context) {
 		return
 	}
 
-	account, err := server.store.GetAccount(ctx, req.ID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	account, valid := server.loadAuthorizedAccount(ctx, req.ID, accountActionView)
+	if !valid {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
-		err := errors.New("account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
+}
 
-	ctx.JSON(http.StatusOK, account)
+type getAccountBalanceUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
-type createAccountRequest struct {
-	Currency string `json:"currency" binding:"required,currency"`
+type getAccountBalanceQueryParams struct {
+	// RFC 3339 timestamp. Entries made at or after it aren't counted.
+	AsOf time.Time `form:"as_of" binding:"required"`
 }
 
-func (server *Server) createAccount(ctx *gin.Context) {
-	var req createAccountRequest
+type getAccountBalanceRequest struct {
+	UriParams   getAccountBalanceUriParams
+	QueryParams getAccountBalanceQueryParams
+}
 
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+type accountBalanceResponse struct {
+	AccountID      int64      `json:"account_id"`
+	AsOf           time.Time  `json:"as_of"`
+	Balance        int64      `json:"balance"`
+	BalanceDecimal string     `json:"balance_decimal"`
+	Currency       string     `json:"currency"`
+	SnapshotDate   *time.Time `json:"snapshot_date"`
+}
+
+func (server *Server) getAccountBalance(ctx *gin.Context) {
+	var req getAccountBalanceRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
-	arg := db.CreateAccountParams{
-		Owner:    authPayload.Username,
-		Currency: req.Currency,
-		Balance:  0,
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.ID, accountActionView)
+	if !valid {
+		return
 	}
 
-	account, err := server.store.CreateAccount(ctx, arg)
+	asOf := req.QueryParams.AsOf
+	if asOf.Before(account.CreatedAt) {
+		err := errors.New("account wasn't open yet at as_of")
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.BalanceAsOf(ctx, account.ID, asOf)
 	if err != nil {
-		var pqErr *pq.Error
-		if errors.As(err, &pqErr) {
-			switch pqErr.Code.Name() {
-			case constants.ForeignKeyViolation, constants.UniqueViolation:
-				ctx.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
-		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
-}
+	res := accountBalanceResponse{
+		AccountID:      account.ID,
+		AsOf:           asOf,
+		Balance:        result.Balance,
+		BalanceDecimal: currency.Decimal(account.Currency, result.Balance),
+		Currency:       account.Currency,
+	}
+	if result.SnapshotDate.Valid {
+		res.SnapshotDate = &result.SnapshotDate.Time
+	}
 
-type updateAccountUriParams struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
+	ctx.JSON(http.StatusOK, res)
 }
 
-type updateAccountBody struct {
-	Owner    string `json:"owner" binding:"required"`
-	Balance  int64  `json:"balance" binding:"required"`
+type createAccountRequest struct {
 	Currency string `json:"currency" binding:"required,currency"`
+	// Defaults to a checking account.
+	Product string `json:"product" binding:"omitempty,oneof=checking savings"`
+	// Tells the owner's accounts apart. Unique per owner.
+	Nickname string `json:"nickname" binding:"omitempty,max=50"`
 }
 
-type updateAccountRequest struct {
-	UriParams updateAccountUriParams
-	Body      updateAccountBody
-}
-
-func (server *Server) updateAccount(ctx *gin.Context) {
-	var req updateAccountRequest
+func (server *Server) createAccount(ctx *gin.Context) {
+	var req createAccountRequest
 
-	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg, err := server.newCreateAccountParams(authPayload.Username, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	arg := db.UpdateAccountParams{
-		ID:       req.UriParams.ID,
-		Owner:    req.Body.Owner,
-		Balance:  req.Body.Balance,
-		Currency: req.Body.Currency,
-	}
+	server.openAccount(
+		ctx, db.CreateAccountTxParams{
+			CreateAccountParams:    arg,
+			MaxAccounts:            server.config.MaxAccountsPerUser,
+			MaxAccountsPerCurrency: server.config.MaxAccountsPerCurrency,
+		},
+	)
+}
 
-	account, err := server.store.UpdateAccount(ctx, arg)
+// newCreateAccountParams gives a new account for owner its account number and, if the bank has one configured, IBAN.
+func (server *Server) newCreateAccountParams(owner string, req createAccountRequest) (db.CreateAccountParams, error) {
+	accountNumber, err := util.NewAccountNumber()
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
+		return db.CreateAccountParams{}, err
+	}
+
+	arg := db.CreateAccountParams{
+		Owner:         owner,
+		Currency:      req.Currency,
+		Balance:       0,
+		Product:       req.Product,
+		AccountNumber: accountNumber,
+		Nickname:      sql.NullString{String: req.Nickname, Valid: req.Nickname != ""},
+	}
+	if arg.Product == "" {
+		arg.Product = constants.ProductChecking
+	}
+	if server.config.IBANCountryCode != "" {
+		iban, err := util.NewIBAN(server.config.IBANCountryCode, server.config.IBANBankCode, accountNumber)
+		if err != nil {
+			return db.CreateAccountParams{}, err
 		}
+		arg.Iban = sql.NullString{String: iban, Valid: true}
+	}
 
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	return arg, nil
+}
+
+// openAccount creates the account and writes it, or the error, as the response.
+func (server *Server) openAccount(ctx *gin.Context, arg db.CreateAccountTxParams) {
+	account, err := server.store.CreateAccountTx(ctx, arg)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code.Name() {
+			case constants.ForeignKeyViolation:
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			case constants.UniqueViolation:
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
 }
 
-type updateAccountBalanceUriParams struct {
+type updateAccountNicknameUriParams struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
-type updateAccountBalanceBody struct {
-	Amount int64 `json:"amount" binding:"required"`
+type updateAccountNicknameBody struct {
+	// An empty nickname removes the current one.
+	Nickname string `json:"nickname" binding:"omitempty,max=50"`
 }
 
-type updateAccountBalanceRequest struct {
-	UriParams updateAccountBalanceUriParams
-	Body      updateAccountBalanceBody
+type updateAccountNicknameRequest struct {
+	UriParams updateAccountNicknameUriParams
+	Body      updateAccountNicknameBody
 }
 
-func (server *Server) updateAccountBalance(ctx *gin.Context) {
-	var req updateAccountBalanceRequest
+func (server *Server) updateAccountNickname(ctx *gin.Context) {
+	var req updateAccountNicknameRequest
 
 	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
@@ -186,47 +302,26 @@ func (server *Server) updateAccountBalance(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.UpdateAccountBalanceParams{
-		ID:     req.UriParams.ID,
-		Amount: req.Body.Amount,
-	}
-
-	account, err := server.store.UpdateAccountBalance(ctx, arg)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.ID, accountActionManage)
+	if !valid {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
-}
-
-type deleteAccountRequest struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
-}
-
-func (server *Server) deleteAccount(ctx *gin.Context) {
-	var req deleteAccountRequest
-
-	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
+	arg := db.UpdateAccountNicknameParams{
+		ID:       account.ID,
+		Nickname: sql.NullString{String: req.Body.Nickname, Valid: req.Body.Nickname != ""},
 	}
 
-	err := server.store.DeleteAccount(ctx, req.ID)
+	account, err := server.store.UpdateAccountNickname(ctx, arg)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == constants.UniqueViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
 			return
 		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
-	ctx.Status(http.StatusOK)
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
 }
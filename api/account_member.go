@@ -0,0 +1,404 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"net/http"
+	"time"
+)
+
+type accountMemberResponse struct {
+	AccountID     int64     `json:"account_id"`
+	Username      string    `json:"username"`
+	Role          string    `json:"role"`
+	TransferLimit *int64    `json:"transfer_limit"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func newAccountMemberResponse(member db.AccountMember) accountMemberResponse {
+	res := accountMemberResponse{
+		AccountID: member.AccountID,
+		Username:  member.Username,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}
+	if member.TransferLimit.Valid {
+		res.TransferLimit = &member.TransferLimit.Int64
+	}
+	return res
+}
+
+type accountInvitationResponse struct {
+	ID            int64      `json:"id"`
+	AccountID     int64      `json:"account_id"`
+	Invitee       string     `json:"invitee"`
+	Role          string     `json:"role"`
+	TransferLimit *int64     `json:"transfer_limit"`
+	InvitedBy     string     `json:"invited_by"`
+	Status        string     `json:"status"`
+	CreatedAt     time.Time  `json:"created_at"`
+	RespondedAt   *time.Time `json:"responded_at"`
+}
+
+func newAccountInvitationResponse(invitation db.AccountInvitation) accountInvitationResponse {
+	res := accountInvitationResponse{
+		ID:        invitation.ID,
+		AccountID: invitation.AccountID,
+		Invitee:   invitation.Invitee,
+		Role:      invitation.Role,
+		InvitedBy: invitation.InvitedBy,
+		Status:    invitation.Status,
+		CreatedAt: invitation.CreatedAt,
+	}
+	if invitation.TransferLimit.Valid {
+		res.TransferLimit = &invitation.TransferLimit.Int64
+	}
+	if invitation.RespondedAt.Valid {
+		res.RespondedAt = &invitation.RespondedAt.Time
+	}
+	return res
+}
+
+func newAccountInvitationResponses(invitations []db.AccountInvitation) []accountInvitationResponse {
+	res := make([]accountInvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		res[i] = newAccountInvitationResponse(invitation)
+	}
+	return res
+}
+
+type getAccountMembersRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getAccountMembers(ctx *gin.Context) {
+	var req getAccountMembersRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.ID, accountActionView)
+	if !valid {
+		return
+	}
+
+	members, err := server.store.GetAccountMembers(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]accountMemberResponse, len(members))
+	for i, member := range members {
+		res[i] = newAccountMemberResponse(member)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type removeAccountMemberRequest struct {
+	ID       int64  `uri:"id" binding:"required,min=1"`
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+// removeAccountMember lets owners and co-owners remove members, and any member leave. The owner can't be removed.
+// A removed member's scheduled transfers from the account are cancelled.
+func (server *Server) removeAccountMember(ctx *gin.Context) {
+	var req removeAccountMemberRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	action := accountActionManage
+	if req.Username == authPayload.Username {
+		action = accountActionView
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.ID, action)
+	if !valid {
+		return
+	}
+
+	if req.Username == account.Owner {
+		err := errors.New("the account's owner can't be removed")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	member, err := server.store.RemoveAccountMemberTx(
+		ctx, db.DeleteAccountMemberParams{AccountID: account.ID, Username: req.Username},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountMemberResponse(member))
+}
+
+type createAccountInvitationUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type createAccountInvitationBody struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Role     string `json:"role" binding:"required,oneof=co_owner viewer spender"`
+	// Largest amount a spender can move in one transfer. Required for spenders and ignored otherwise.
+	TransferLimit int64 `json:"transfer_limit" binding:"required_if=Role spender,omitempty,min=1"`
+}
+
+type createAccountInvitationRequest struct {
+	UriParams createAccountInvitationUriParams
+	Body      createAccountInvitationBody
+}
+
+func (server *Server) createAccountInvitation(ctx *gin.Context) {
+	var req createAccountInvitationRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.ID, accountActionManage)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.InviteAccountMemberTxParams{
+		AccountID: account.ID,
+		Invitee:   req.Body.Username,
+		Role:      req.Body.Role,
+		InvitedBy: authPayload.Username,
+	}
+	if req.Body.Role == constants.AccountRoleSpender {
+		arg.TransferLimit = sql.NullInt64{Int64: req.Body.TransferLimit, Valid: true}
+	}
+
+	invitation, err := server.store.InviteAccountMemberTx(ctx, arg)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code.Name() {
+			case constants.ForeignKeyViolation:
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			case constants.UniqueViolation:
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountInvitationResponse(invitation))
+}
+
+type getAccountInvitationsUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type getAccountInvitationsQueryParams struct {
+	PageNumber int32 `form:"page_number" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+type getAccountInvitationsRequest struct {
+	UriParams   getAccountInvitationsUriParams
+	QueryParams getAccountInvitationsQueryParams
+}
+
+func (server *Server) getAccountInvitations(ctx *gin.Context) {
+	var req getAccountInvitationsRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.ID, accountActionManage)
+	if !valid {
+		return
+	}
+
+	arg := db.GetAccountInvitationsParams{
+		AccountID: account.ID,
+		Limit:     req.QueryParams.PageSize,
+		Offset:    (req.QueryParams.PageNumber - 1) * req.QueryParams.PageSize,
+	}
+
+	invitations, err := server.store.GetAccountInvitations(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountInvitationResponses(invitations))
+}
+
+type revokeAccountInvitationRequest struct {
+	ID           int64 `uri:"id" binding:"required,min=1"`
+	InvitationID int64 `uri:"invitation_id" binding:"required,min=1"`
+}
+
+func (server *Server) revokeAccountInvitation(ctx *gin.Context) {
+	var req revokeAccountInvitationRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.ID, accountActionManage)
+	if !valid {
+		return
+	}
+
+	invitation, err := server.store.GetAccountInvitation(ctx, req.InvitationID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	if invitation.AccountID != account.ID {
+		err := errors.New("invitation isn't for this account")
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+
+	server.answerInvitation(ctx, invitation, constants.InvitationStatusRevoked)
+}
+
+type getInvitationsRequest struct {
+	PageNumber int32 `form:"page_number" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+// getInvitations lists the invitations waiting for the caller to answer.
+func (server *Server) getInvitations(ctx *gin.Context) {
+	var req getInvitationsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.GetPendingInvitationsForUserParams{
+		Invitee: authPayload.Username,
+		Limit:   req.PageSize,
+		Offset:  (req.PageNumber - 1) * req.PageSize,
+	}
+
+	invitations, err := server.store.GetPendingInvitationsForUser(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountInvitationResponses(invitations))
+}
+
+type invitationRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type acceptInvitationResponse struct {
+	Invitation accountInvitationResponse `json:"invitation"`
+	Member     accountMemberResponse     `json:"member"`
+}
+
+func (server *Server) acceptInvitation(ctx *gin.Context) {
+	invitation, valid := server.loadInviteeInvitation(ctx)
+	if !valid {
+		return
+	}
+
+	result, err := server.store.AcceptAccountInvitationTx(ctx, invitation.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK, acceptInvitationResponse{
+			Invitation: newAccountInvitationResponse(result.Invitation),
+			Member:     newAccountMemberResponse(result.Member),
+		},
+	)
+}
+
+func (server *Server) declineInvitation(ctx *gin.Context) {
+	invitation, valid := server.loadInviteeInvitation(ctx)
+	if !valid {
+		return
+	}
+
+	server.answerInvitation(ctx, invitation, constants.InvitationStatusDeclined)
+}
+
+// loadInviteeInvitation loads the invitation in the request path if it was sent to the caller.
+func (server *Server) loadInviteeInvitation(ctx *gin.Context) (db.AccountInvitation, bool) {
+	var req invitationRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.AccountInvitation{}, false
+	}
+
+	invitation, err := server.store.GetAccountInvitation(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return invitation, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if invitation.Invitee != authPayload.Username {
+		err := errors.New("invitation wasn't sent to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return invitation, false
+	}
+
+	return invitation, true
+}
+
+// answerInvitation closes a pending invitation without adding a member.
+func (server *Server) answerInvitation(ctx *gin.Context, invitation db.AccountInvitation, status string) {
+	arg := db.UpdateAccountInvitationStatusParams{
+		ID:     invitation.ID,
+		Status: status,
+	}
+
+	invitation, err := server.store.UpdateAccountInvitationStatus(ctx, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = db.ErrInvitationNotPending
+		}
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountInvitationResponse(invitation))
+}
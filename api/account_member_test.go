@@ -0,0 +1,447 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func generateMockInvitation(account db.Account, invitee string, role string) db.AccountInvitation {
+	return db.AccountInvitation{
+		ID:        util.RandomInt(1, 1000),
+		AccountID: account.ID,
+		Invitee:   invitee,
+		Role:      role,
+		InvitedBy: account.Owner,
+		Status:    constants.InvitationStatusPending,
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestCreateAccountInvitationAPI(t *testing.T) {
+	owner, _ := generateMockUser(t)
+	invitee, _ := generateMockUser(t)
+	account := generateMockAccounts(owner.Username, 1)[0]
+	invitation := generateMockInvitation(account, invitee.Username, constants.AccountRoleSpender)
+	invitation.TransferLimit = sql.NullInt64{Int64: 500, Valid: true}
+
+	testCases := []struct {
+		name          string
+		body          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: fmt.Sprintf(`{"username": "%s", "role": "spender", "transfer_limit": 500}`, invitee.Username),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, owner.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.InviteAccountMemberTxParams{
+					AccountID:     account.ID,
+					Invitee:       invitee.Username,
+					Role:          constants.AccountRoleSpender,
+					TransferLimit: sql.NullInt64{Int64: 500, Valid: true},
+					InvitedBy:     owner.Username,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().InviteAccountMemberTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(invitation, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got accountInvitationResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, invitation.ID, got.ID)
+				require.Equal(t, constants.InvitationStatusPending, got.Status)
+				require.NotNil(t, got.TransferLimit)
+				require.Equal(t, int64(500), *got.TransferLimit)
+			},
+		},
+		{
+			name: "SpenderWithoutLimit",
+			body: fmt.Sprintf(`{"username": "%s", "role": "spender"}`, invitee.Username),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, owner.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().InviteAccountMemberTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OwnerRole",
+			body: fmt.Sprintf(`{"username": "%s", "role": "owner"}`, invitee.Username),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, owner.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().InviteAccountMemberTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Viewer",
+			body: fmt.Sprintf(`{"username": "%s", "role": "viewer"}`, invitee.Username),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, invitee.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				member := db.AccountMember{
+					AccountID: account.ID,
+					Username:  invitee.Username,
+					Role:      constants.AccountRoleViewer,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(member, nil)
+				store.EXPECT().InviteAccountMemberTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyMember",
+			body: fmt.Sprintf(`{"username": "%s", "role": "viewer"}`, invitee.Username),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, owner.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().InviteAccountMemberTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountInvitation{}, db.ErrAlreadyMember,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownInvitee",
+			body: `{"username": "nobody", "role": "viewer"}`,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, owner.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().InviteAccountMemberTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountInvitation{}, &pq.Error{Code: "23503"},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/account/%d/invitations", account.ID)
+				request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestAnswerInvitationAPI(t *testing.T) {
+	owner, _ := generateMockUser(t)
+	invitee, _ := generateMockUser(t)
+	account := generateMockAccounts(owner.Username, 1)[0]
+	invitation := generateMockInvitation(account, invitee.Username, constants.AccountRoleCoOwner)
+
+	accepted := invitation
+	accepted.Status = constants.InvitationStatusAccepted
+	declined := invitation
+	declined.Status = constants.InvitationStatusDeclined
+
+	testCases := []struct {
+		name          string
+		answer        string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Accept",
+			answer: "accept",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, invitee.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				result := db.AcceptAccountInvitationTxResult{
+					Invitation: accepted,
+					Member: db.AccountMember{
+						AccountID: account.ID,
+						Username:  invitee.Username,
+						Role:      constants.AccountRoleCoOwner,
+					},
+				}
+
+				store.EXPECT().GetAccountInvitation(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(invitation, nil)
+				store.EXPECT().AcceptAccountInvitationTx(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got acceptInvitationResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.InvitationStatusAccepted, got.Invitation.Status)
+				require.Equal(t, constants.AccountRoleCoOwner, got.Member.Role)
+			},
+		},
+		{
+			name:   "AcceptAnswered",
+			answer: "accept",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, invitee.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountInvitation(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(invitation, nil)
+				store.EXPECT().AcceptAccountInvitationTx(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(
+					db.AcceptAccountInvitationTxResult{}, db.ErrInvitationNotPending,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "AcceptSomeoneElses",
+			answer: "accept",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, owner.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountInvitation(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(invitation, nil)
+				store.EXPECT().AcceptAccountInvitationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "Decline",
+			answer: "decline",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, invitee.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateAccountInvitationStatusParams{
+					ID:     invitation.ID,
+					Status: constants.InvitationStatusDeclined,
+				}
+
+				store.EXPECT().GetAccountInvitation(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(invitation, nil)
+				store.EXPECT().UpdateAccountInvitationStatus(gomock.Any(), gomock.Eq(arg)).Times(1).Return(declined, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "DeclineAnswered",
+			answer: "decline",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, invitee.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountInvitation(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(invitation, nil)
+				store.EXPECT().UpdateAccountInvitationStatus(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountInvitation{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/invitations/%d/%s", invitation.ID, tc.answer)
+				request, err := http.NewRequest(http.MethodPost, url, nil)
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestRemoveAccountMemberAPI(t *testing.T) {
+	owner, _ := generateMockUser(t)
+	member, _ := generateMockUser(t)
+	account := generateMockAccounts(owner.Username, 1)[0]
+
+	viewer := db.AccountMember{
+		AccountID: account.ID,
+		Username:  member.Username,
+		Role:      constants.AccountRoleViewer,
+	}
+	removeArg := db.DeleteAccountMemberParams{AccountID: account.ID, Username: member.Username}
+
+	testCases := []struct {
+		name          string
+		username      string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OwnerRemoves",
+			username: member.Username,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, owner.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().RemoveAccountMemberTx(gomock.Any(), gomock.Eq(removeArg)).Times(1).Return(viewer, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "MemberLeaves",
+			username: member.Username,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, member.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(viewer, nil)
+				store.EXPECT().RemoveAccountMemberTx(gomock.Any(), gomock.Eq(removeArg)).Times(1).Return(viewer, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "ViewerRemovesOthers",
+			username: "someoneelse",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, member.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(viewer, nil)
+				store.EXPECT().RemoveAccountMemberTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "RemoveOwner",
+			username: owner.Username,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, owner.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().RemoveAccountMemberTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "NotAMember",
+			username: member.Username,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, owner.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().RemoveAccountMemberTx(gomock.Any(), gomock.Eq(removeArg)).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/account/%d/members/%s", account.ID, tc.username)
+				request, err := http.NewRequest(http.MethodDelete, url, nil)
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
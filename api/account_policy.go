@@ -0,0 +1,138 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type accountAction int
+
+const (
+	// accountActionView covers reading an account and its balance, entries, transfers, holds, fees and statements.
+	accountActionView accountAction = iota
+	// accountActionSpend covers moving money out of an account, by transfer, hold or scheduled transfer.
+	accountActionSpend
+	// accountActionManage covers renaming an account and managing its members and invitations.
+	accountActionManage
+	// accountActionClose covers closing an account.
+	accountActionClose
+)
+
+var (
+	errNotAccountMember      = errors.New("account doesn't belong to the authenticated user")
+	errAccountActionDenied   = errors.New("account role doesn't allow this action")
+	errTransferLimitExceeded = errors.New("amount exceeds the member's per-transfer limit")
+)
+
+// accountPolicy decides whether a member may take action on their account. Amount is what a spend would move.
+// Owners can do anything, co-owners anything but close the account, viewers only view, and spenders view and spend
+// up to their per-transfer limit. On organization accounts the member's organization role applies instead: admins can
+// do anything, initiators view and spend, and approvers only view.
+func accountPolicy(member db.AccountMember, action accountAction, amount int64) error {
+	switch member.Role {
+	case constants.AccountRoleOwner, constants.OrganizationRoleAdmin:
+		return nil
+	case constants.AccountRoleCoOwner:
+		if action == accountActionClose {
+			return errAccountActionDenied
+		}
+		return nil
+	case constants.OrganizationRoleInitiator:
+		if action != accountActionView && action != accountActionSpend {
+			return errAccountActionDenied
+		}
+		return nil
+	case constants.AccountRoleViewer, constants.OrganizationRoleApprover:
+		if action != accountActionView {
+			return errAccountActionDenied
+		}
+		return nil
+	case constants.AccountRoleSpender:
+		switch action {
+		case accountActionView:
+			return nil
+		case accountActionSpend:
+			if !member.TransferLimit.Valid || amount > member.TransferLimit.Int64 {
+				return fmt.Errorf("%w of %d", errTransferLimitExceeded, member.TransferLimit.Int64)
+			}
+			return nil
+		}
+		return errAccountActionDenied
+	}
+	return errAccountActionDenied
+}
+
+// checkAccountAccess reports whether the caller may take action on account, and the status to respond with if not.
+// The account's owner is a member without needing a lookup, and admins can view any account. Organization accounts
+// have no owner in that sense: everyone's access, their creator's included, comes from their organization role.
+func (server *Server) checkAccountAccess(
+	ctx *gin.Context, account db.Account, action accountAction, amount int64,
+) (int, error) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	member := db.AccountMember{AccountID: account.ID, Username: account.Owner, Role: constants.AccountRoleOwner}
+	if account.Owner != authPayload.Username || account.OrganizationID.Valid {
+		if action == accountActionView && authPayload.Role == constants.RoleAdmin {
+			return http.StatusOK, nil
+		}
+
+		var err error
+		member, err = server.getAccountMember(ctx, account, authPayload.Username)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return http.StatusUnauthorized, errNotAccountMember
+			}
+			return http.StatusInternalServerError, err
+		}
+	}
+
+	if err := accountPolicy(member, action, amount); err != nil {
+		return http.StatusForbidden, err
+	}
+	return http.StatusOK, nil
+}
+
+// getAccountMember looks up username's membership of account, which for an organization account is their membership
+// of the organization.
+func (server *Server) getAccountMember(ctx *gin.Context, account db.Account, username string) (
+	db.AccountMember, error,
+) {
+	if !account.OrganizationID.Valid {
+		return server.store.GetAccountMember(ctx, db.GetAccountMemberParams{AccountID: account.ID, Username: username})
+	}
+
+	member, err := server.store.GetOrganizationMember(
+		ctx, db.GetOrganizationMemberParams{OrganizationID: account.OrganizationID.Int64, Username: username},
+	)
+	if err != nil {
+		return db.AccountMember{}, err
+	}
+	return db.AccountMember{AccountID: account.ID, Username: member.Username, Role: member.Role}, nil
+}
+
+// authorizeAccount checks that the caller may take action on account, writing the error response if not.
+func (server *Server) authorizeAccount(ctx *gin.Context, account db.Account, action accountAction, amount int64) bool {
+	status, err := server.checkAccountAccess(ctx, account, action, amount)
+	if err != nil {
+		ctx.JSON(status, errorResponse(err))
+		return false
+	}
+	return true
+}
+
+// loadAuthorizedAccount loads an account and checks that the caller may take action on it.
+func (server *Server) loadAuthorizedAccount(ctx *gin.Context, accountID int64, action accountAction) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return account, false
+	}
+
+	return account, server.authorizeAccount(ctx, account, action, 0)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAccountPolicy(t *testing.T) {
+	spender := db.AccountMember{
+		Role:          constants.AccountRoleSpender,
+		TransferLimit: sql.NullInt64{Int64: 100, Valid: true},
+	}
+
+	testCases := []struct {
+		name    string
+		member  db.AccountMember
+		action  accountAction
+		amount  int64
+		allowed bool
+	}{
+		{"OwnerCloses", db.AccountMember{Role: constants.AccountRoleOwner}, accountActionClose, 0, true},
+		{"OwnerSpends", db.AccountMember{Role: constants.AccountRoleOwner}, accountActionSpend, 1_000_000, true},
+		{"CoOwnerManages", db.AccountMember{Role: constants.AccountRoleCoOwner}, accountActionManage, 0, true},
+		{"CoOwnerSpends", db.AccountMember{Role: constants.AccountRoleCoOwner}, accountActionSpend, 1_000_000, true},
+		{"CoOwnerCloses", db.AccountMember{Role: constants.AccountRoleCoOwner}, accountActionClose, 0, false},
+		{"ViewerViews", db.AccountMember{Role: constants.AccountRoleViewer}, accountActionView, 0, true},
+		{"ViewerSpends", db.AccountMember{Role: constants.AccountRoleViewer}, accountActionSpend, 1, false},
+		{"SpenderViews", spender, accountActionView, 0, true},
+		{"SpenderWithinLimit", spender, accountActionSpend, 100, true},
+		{"SpenderOverLimit", spender, accountActionSpend, 101, false},
+		{"SpenderWithoutLimit", db.AccountMember{Role: constants.AccountRoleSpender}, accountActionSpend, 1, false},
+		{"SpenderManages", spender, accountActionManage, 0, false},
+		{"OrganizationAdminCloses", db.AccountMember{Role: constants.OrganizationRoleAdmin}, accountActionClose, 0, true},
+		{"InitiatorSpends", db.AccountMember{Role: constants.OrganizationRoleInitiator}, accountActionSpend, 1_000_000, true},
+		{"InitiatorManages", db.AccountMember{Role: constants.OrganizationRoleInitiator}, accountActionManage, 0, false},
+		{"ApproverViews", db.AccountMember{Role: constants.OrganizationRoleApprover}, accountActionView, 0, true},
+		{"ApproverSpends", db.AccountMember{Role: constants.OrganizationRoleApprover}, accountActionSpend, 1, false},
+		{"UnknownRole", db.AccountMember{Role: "auditor"}, accountActionView, 0, false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				err := accountPolicy(tc.member, tc.action, tc.amount)
+				if tc.allowed {
+					require.NoError(t, err)
+				} else {
+					require.Error(t, err)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetAccountProductsAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	products := []db.AccountProduct{
+		{
+			Code:               constants.ProductChecking,
+			Name:               "Checking",
+			Kind:               constants.AccountKindChecking,
+			DayCountConvention: constants.DayCountActual365,
+		},
+		{
+			Code:               constants.ProductSavings,
+			Name:               "Savings",
+			Kind:               constants.AccountKindSavings,
+			InterestRateBps:    200,
+			DayCountConvention: constants.DayCountActual365,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountProducts(gomock.Any()).Times(1).Return(products, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var fetchedProducts []db.AccountProduct
+				err := json.Unmarshal(recorder.Body.Bytes(), &fetchedProducts)
+				require.NoError(t, err)
+				require.Equal(t, products, fetchedProducts)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountProducts(gomock.Any()).Times(1).Return(
+					[]db.AccountProduct{}, sql.ErrConnDone,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodGet, "/account-products", nil)
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
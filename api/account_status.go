@@ -0,0 +1,192 @@
+package api
+
+import (
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type accountStatusChangeResponse struct {
+	ID         int64     `json:"id"`
+	AccountID  int64     `json:"account_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedBy  string    `json:"changed_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func newAccountStatusChangeResponse(change db.AccountStatusChange) accountStatusChangeResponse {
+	return accountStatusChangeResponse{
+		ID:         change.ID,
+		AccountID:  change.AccountID,
+		FromStatus: change.FromStatus,
+		ToStatus:   change.ToStatus,
+		Reason:     change.Reason,
+		ChangedBy:  change.ChangedBy,
+		CreatedAt:  change.CreatedAt,
+	}
+}
+
+type accountStatusResponse struct {
+	Account      accountResponse             `json:"account"`
+	StatusChange accountStatusChangeResponse `json:"status_change"`
+}
+
+type changeAccountStatusUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type changeAccountStatusBody struct {
+	Status string `json:"status" binding:"required,oneof=active frozen dormant closed"`
+	Reason string `json:"reason" binding:"required"`
+}
+
+type changeAccountStatusRequest struct {
+	UriParams changeAccountStatusUriParams
+	Body      changeAccountStatusBody
+}
+
+// changeAccountStatus lets staff move an account between any of the lifecycle states the state machine allows.
+func (server *Server) changeAccountStatus(ctx *gin.Context) {
+	var req changeAccountStatusRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, req.UriParams.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	server.transitionAccount(ctx, account, req.Body.Status, req.Body.Reason)
+}
+
+type closeAccountUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type closeAccountBody struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type closeAccountRequest struct {
+	UriParams closeAccountUriParams
+	Body      closeAccountBody
+}
+
+// closeAccount lets owners close their own empty accounts. The account and its history are kept.
+func (server *Server) closeAccount(ctx *gin.Context) {
+	var req closeAccountRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.ID, accountActionClose)
+	if !valid {
+		return
+	}
+
+	server.transitionAccount(ctx, account, constants.AccountStatusClosed, req.Body.Reason)
+}
+
+func (server *Server) transitionAccount(ctx *gin.Context, account db.Account, status string, reason string) {
+	if account.Product == constants.ProductInternal {
+		err := errors.New("internal accounts can't change status")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.store.ChangeAccountStatusTx(
+		ctx, db.ChangeAccountStatusTxParams{
+			AccountID: account.ID,
+			Status:    status,
+			Reason:    reason,
+			ChangedBy: authPayload.Username,
+		},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK, accountStatusResponse{
+			Account:      newAccountResponse(result.Account),
+			StatusChange: newAccountStatusChangeResponse(result.StatusChange),
+		},
+	)
+}
+
+type getAccountStatusChangesUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type getAccountStatusChangesQueryParams struct {
+	PageNumber int32 `form:"page_number" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+type getAccountStatusChangesRequest struct {
+	UriParams   getAccountStatusChangesUriParams
+	QueryParams getAccountStatusChangesQueryParams
+}
+
+func (server *Server) getAccountStatusChanges(ctx *gin.Context) {
+	var req getAccountStatusChangesRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.ID, accountActionView)
+	if !valid {
+		return
+	}
+
+	changes, err := server.store.GetAccountStatusChanges(
+		ctx, db.GetAccountStatusChangesParams{
+			AccountID: account.ID,
+			Limit:     req.QueryParams.PageSize,
+			Offset:    (req.QueryParams.PageNumber - 1) * req.QueryParams.PageSize,
+		},
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]accountStatusChangeResponse, len(changes))
+	for i, change := range changes {
+		res[i] = newAccountStatusChangeResponse(change)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,306 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func generateMockStatusChange(account db.Account, status string, changedBy string) db.ChangeAccountStatusTxResult {
+	changed := account
+	changed.Status = status
+
+	return db.ChangeAccountStatusTxResult{
+		Account: changed,
+		StatusChange: db.AccountStatusChange{
+			ID:         1,
+			AccountID:  account.ID,
+			FromStatus: account.Status,
+			ToStatus:   status,
+			Reason:     "court order",
+			ChangedBy:  changedBy,
+			CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		},
+	}
+}
+
+func TestChangeAccountStatusAPI(t *testing.T) {
+	teller, _ := generateMockUser(t)
+	customer, _ := generateMockUser(t)
+	account := generateMockAccounts(customer.Username, 1)[0]
+	account.Product = constants.ProductChecking
+
+	internalAccount := generateMockAccounts(constants.BankUsername, 1)[0]
+	internalAccount.Product = constants.ProductInternal
+
+	result := generateMockStatusChange(account, constants.AccountStatusFrozen, teller.Username)
+
+	testCases := []struct {
+		name          string
+		accountID     int64
+		body          gin.H
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			accountID: account.ID,
+			body:      gin.H{"status": constants.AccountStatusFrozen, "reason": "court order"},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ChangeAccountStatusTxParams{
+					AccountID: account.ID,
+					Status:    constants.AccountStatusFrozen,
+					Reason:    "court order",
+					ChangedBy: teller.Username,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ChangeAccountStatusTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var res accountStatusResponse
+				err = json.Unmarshal(data, &res)
+				require.NoError(t, err)
+				require.Equal(t, constants.AccountStatusFrozen, res.Account.Status)
+				require.Equal(t, newAccountStatusChangeResponse(result.StatusChange), res.StatusChange)
+			},
+		},
+		{
+			name:      "Customer",
+			accountID: account.ID,
+			body:      gin.H{"status": constants.AccountStatusActive, "reason": "please"},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, customer.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ChangeAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidTransition",
+			accountID: account.ID,
+			body:      gin.H{"status": constants.AccountStatusActive, "reason": "reopen"},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ChangeAccountStatusTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.ChangeAccountStatusTxResult{}, db.ErrInvalidStatusTransition,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalAccount",
+			accountID: internalAccount.ID,
+			body:      gin.H{"status": constants.AccountStatusFrozen, "reason": "court order"},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(internalAccount.ID)).Times(1).Return(
+					internalAccount, nil,
+				)
+				store.EXPECT().ChangeAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidStatus",
+			accountID: account.ID,
+			body:      gin.H{"status": "deleted", "reason": "court order"},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "MissingReason",
+			accountID: account.ID,
+			body:      gin.H{"status": constants.AccountStatusFrozen},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				data, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+
+				url := fmt.Sprintf("/account/%d/status", tc.accountID)
+				request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestCloseAccountAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	account := generateMockAccounts(user.Username, 1)[0]
+	account.Product = constants.ProductChecking
+
+	result := generateMockStatusChange(account, constants.AccountStatusClosed, user.Username)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"reason": "moving banks"},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ChangeAccountStatusTxParams{
+					AccountID: account.ID,
+					Status:    constants.AccountStatusClosed,
+					Reason:    "moving banks",
+					ChangedBy: user.Username,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ChangeAccountStatusTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotEmpty",
+			body: gin.H{"reason": "moving banks"},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ChangeAccountStatusTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.ChangeAccountStatusTxResult{}, db.ErrAccountNotEmpty,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			body: gin.H{"reason": "moving banks"},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ChangeAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				data, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+
+				url := fmt.Sprintf("/account/%d/close", account.ID)
+				request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
@@ -5,15 +5,19 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
 	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
 	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
 	"github.com/CrunchyBlue/Golang-Bank/token"
 	"github.com/CrunchyBlue/Golang-Bank/util"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -25,6 +29,7 @@ func TestGetAccountAPI(t *testing.T) {
 	testCases := []struct {
 		name          string
 		accountID     int64
+		accountParam  string
 		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
@@ -43,6 +48,55 @@ func TestGetAccountAPI(t *testing.T) {
 				requireBodyMatchesAccount(t, recorder.Body, account)
 			},
 		},
+		{
+			name:         "AccountNumber",
+			accountParam: account.AccountNumber,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.AccountNumber)).Times(1).Return(
+					account, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesAccount(t, recorder.Body, account)
+			},
+		},
+		{
+			name:         "IBAN",
+			accountParam: "GB82WEST12345698765432",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				iban := sql.NullString{String: "GB82WEST12345698765432", Valid: true}
+				store.EXPECT().GetAccountByIban(gomock.Any(), gomock.Eq(iban)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesAccount(t, recorder.Body, account)
+			},
+		},
+		{
+			name:         "AccountNumberNotFound",
+			accountParam: account.AccountNumber,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.AccountNumber)).Times(1).Return(
+					db.Account{}, sql.ErrNoRows,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name:      "UnauthorizedUser",
 			accountID: account.ID,
@@ -51,6 +105,9 @@ func TestGetAccountAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -128,6 +185,9 @@ func TestGetAccountAPI(t *testing.T) {
 				recorder := httptest.NewRecorder()
 
 				url := fmt.Sprintf("/account/%d", tc.accountID)
+				if tc.accountParam != "" {
+					url = fmt.Sprintf("/account/%s", tc.accountParam)
+				}
 				request, err := http.NewRequest(http.MethodGet, url, nil)
 				require.NoError(t, err)
 
@@ -148,6 +208,7 @@ func TestGetAccountsAPI(t *testing.T) {
 		name          string
 		pageSize      int
 		pageNumber    int
+		filters       string
 		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
@@ -160,13 +221,58 @@ func TestGetAccountsAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(1).Return(accounts, nil)
+				arg := db.GetAccountsParams{
+					Owner:  user.Username,
+					Limit:  10,
+					Offset: 0,
+				}
+
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				requireBodyMatchesAccounts(t, recorder.Body, accounts)
 			},
 		},
+		{
+			name:       "Filtered",
+			pageSize:   10,
+			pageNumber: 2,
+			filters:    "&currency=EUR&product=savings&status=active",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetAccountsParams{
+					Owner:    user.Username,
+					Currency: sql.NullString{String: constants.EUR, Valid: true},
+					Product:  sql.NullString{String: constants.ProductSavings, Valid: true},
+					Status:   sql.NullString{String: constants.AccountStatusActive, Valid: true},
+					Limit:    10,
+					Offset:   10,
+				}
+
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidFilter",
+			pageSize:   10,
+			pageNumber: 1,
+			filters:    "&status=deleted",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name:       "InternalError",
 			pageSize:   10,
@@ -213,7 +319,7 @@ func TestGetAccountsAPI(t *testing.T) {
 				server := newTestServer(t, store)
 				recorder := httptest.NewRecorder()
 
-				url := fmt.Sprintf("/accounts?page_size=%d&page_number=%d", tc.pageSize, tc.pageNumber)
+				url := fmt.Sprintf("/accounts?page_size=%d&page_number=%d%s", tc.pageSize, tc.pageNumber, tc.filters)
 				request, err := http.NewRequest(http.MethodGet, url, nil)
 				require.NoError(t, err)
 
@@ -226,64 +332,110 @@ func TestGetAccountsAPI(t *testing.T) {
 	}
 }
 
-func TestCreateAccountAPI(t *testing.T) {
+func TestGetAccountBalanceAPI(t *testing.T) {
 	user, _ := generateMockUser(t)
-	owner := user.Username
-	currency := util.RandomCurrency()
+	account := generateMockAccounts(user.Username, 1)[0]
+	account.CreatedAt = time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC)
 
-	account := db.Account{
-		ID:       util.RandomInt(1, 1000),
-		Owner:    owner,
-		Currency: currency,
-		Balance:  0,
+	asOf := time.Date(2023, time.March, 15, 17, 30, 0, 0, time.UTC)
+	snapshotDate := time.Date(2023, time.March, 14, 0, 0, 0, 0, time.UTC)
+	result := db.BalanceAsOfResult{
+		Balance:      util.RandomInt(0, 1000),
+		SnapshotDate: sql.NullTime{Time: snapshotDate, Valid: true},
 	}
 
 	testCases := []struct {
 		name          string
-		owner         string
-		currency      string
+		asOf          string
 		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:     "OK",
-			owner:    owner,
-			currency: currency,
+			name: "OK",
+			asOf: asOf.Format(time.RFC3339),
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().BalanceAsOf(gomock.Any(), gomock.Eq(account.ID), gomock.Eq(asOf)).Times(1).Return(
+					result, nil,
+				)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchesCreatedAccount(t, recorder.Body, account)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var res accountBalanceResponse
+				err = json.Unmarshal(data, &res)
+				require.NoError(t, err)
+				require.Equal(t, account.ID, res.AccountID)
+				require.True(t, asOf.Equal(res.AsOf))
+				require.Equal(t, result.Balance, res.Balance)
+				require.NotNil(t, res.SnapshotDate)
+				require.True(t, snapshotDate.Equal(*res.SnapshotDate))
 			},
 		},
 		{
-			name:     "InternalError",
-			owner:    owner,
-			currency: currency,
+			name: "Admin",
+			asOf: asOf.Format(time.RFC3339),
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, "auditor", constants.RoleAdmin, time.Minute,
+				)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(
-					db.Account{}, sql.ErrConnDone,
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().BalanceAsOf(gomock.Any(), gomock.Eq(account.ID), gomock.Any()).Times(1).Return(
+					result, nil,
 				)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
-			name: "BadRequest",
+			name: "UnauthorizedUser",
+			asOf: asOf.Format(time.RFC3339),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().BalanceAsOf(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "BeforeAccountOpened",
+			asOf: account.CreatedAt.Add(-time.Hour).Format(time.RFC3339),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().BalanceAsOf(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidAsOf",
+			asOf: "yesterday",
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -305,13 +457,8 @@ func TestCreateAccountAPI(t *testing.T) {
 				server := newTestServer(t, store)
 				recorder := httptest.NewRecorder()
 
-				url := fmt.Sprint("/account")
-
-				jsonAccount := fmt.Sprintf(`{"owner": "%s", "currency": "%s"}`, tc.owner, tc.currency)
-				jsonBody := []byte(jsonAccount)
-				bodyReader := bytes.NewReader(jsonBody)
-
-				request, err := http.NewRequest(http.MethodPost, url, bodyReader)
+				url := fmt.Sprintf("/account/%d/balance?as_of=%s", account.ID, tc.asOf)
+				request, err := http.NewRequest(http.MethodGet, url, nil)
 				require.NoError(t, err)
 
 				tc.setupAuth(t, request, server.tokenMaker)
@@ -323,207 +470,251 @@ func TestCreateAccountAPI(t *testing.T) {
 	}
 }
 
-func TestUpdateAccountAPI(t *testing.T) {
-	user, _ := generateMockUser(t)
-	account := generateMockAccounts(user.Username, 1)[0]
+type eqCreateAccountTxParamsMatcher struct {
+	arg db.CreateAccountTxParams
+}
+
+func (e eqCreateAccountTxParamsMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.CreateAccountTxParams)
+	if !ok {
+		return false
+	}
+
+	if !util.ValidAccountNumber(arg.AccountNumber) {
+		return false
+	}
+	if e.arg.Iban.Valid {
+		if !util.ValidIBAN(arg.Iban.String) || !strings.HasSuffix(arg.Iban.String, arg.AccountNumber) {
+			return false
+		}
+		e.arg.Iban.String = arg.Iban.String
+	}
+
+	e.arg.AccountNumber = arg.AccountNumber
+	return reflect.DeepEqual(e.arg, arg)
+}
+
+func (e eqCreateAccountTxParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v with a generated account number", e.arg)
+}
 
-	owner := util.RandomOwner()
+// EqCreateAccountTxParams matches arg with any valid account number, and any valid IBAN for it when arg has one.
+func EqCreateAccountTxParams(arg db.CreateAccountTxParams) gomock.Matcher {
+	return eqCreateAccountTxParamsMatcher{arg}
+}
+
+func TestCreateAccountAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	owner := user.Username
 	currency := util.RandomCurrency()
-	balance := util.RandomInt(-1000, 1000)
-
-	expectedAccount := db.Account{
-		ID:        account.ID,
-		Owner:     owner,
-		Currency:  currency,
-		Balance:   balance,
-		CreatedAt: account.CreatedAt,
+
+	account := db.Account{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    owner,
+		Currency: currency,
+		Balance:  0,
 	}
 
+	var maxAccounts, maxAccountsPerCurrency int64 = 20, 5
+
 	testCases := []struct {
-		name          string
-		accountID     int64
-		owner         string
-		currency      string
-		balance       int64
-		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		name            string
+		owner           string
+		currency        string
+		product         string
+		nickname        string
+		ibanCountryCode string
+		setupAuth       func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs      func(store *mockdb.MockStore)
+		checkResponse   func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:      "OK",
-			accountID: account.ID,
-			owner:     owner,
-			currency:  currency,
-			balance:   balance,
+			name:     "OK",
+			owner:    owner,
+			currency: currency,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).Times(1).Return(
-					expectedAccount, nil,
-				)
+				arg := db.CreateAccountTxParams{
+					CreateAccountParams: db.CreateAccountParams{
+						Owner:    owner,
+						Currency: currency,
+						Balance:  0,
+						Product:  constants.ProductChecking,
+					},
+					MaxAccounts:            maxAccounts,
+					MaxAccountsPerCurrency: maxAccountsPerCurrency,
+				}
+
+				store.EXPECT().CreateAccountTx(gomock.Any(), EqCreateAccountTxParams(arg)).Times(1).Return(account, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchesUpdatedAccount(t, recorder.Body, account, owner, currency, balance)
+				requireBodyMatchesCreatedAccount(t, recorder.Body, account)
 			},
 		},
 		{
-			name:      "NotFound",
-			accountID: account.ID,
-			owner:     owner,
-			currency:  currency,
-			balance:   balance,
+			name:     "Savings",
+			owner:    owner,
+			currency: currency,
+			product:  constants.ProductSavings,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).Times(1).Return(
-					db.Account{}, sql.ErrNoRows,
-				)
+				arg := db.CreateAccountTxParams{
+					CreateAccountParams: db.CreateAccountParams{
+						Owner:    owner,
+						Currency: currency,
+						Balance:  0,
+						Product:  constants.ProductSavings,
+					},
+					MaxAccounts:            maxAccounts,
+					MaxAccountsPerCurrency: maxAccountsPerCurrency,
+				}
+
+				store.EXPECT().CreateAccountTx(gomock.Any(), EqCreateAccountTxParams(arg)).Times(1).Return(account, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
-			name:      "InternalError",
-			accountID: account.ID,
-			owner:     owner,
-			currency:  currency,
-			balance:   balance,
+			name:            "IBAN",
+			owner:           owner,
+			currency:        currency,
+			ibanCountryCode: "DE",
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).Times(1).Return(
-					db.Account{}, sql.ErrConnDone,
-				)
+				arg := db.CreateAccountTxParams{
+					CreateAccountParams: db.CreateAccountParams{
+						Owner:    owner,
+						Currency: currency,
+						Balance:  0,
+						Product:  constants.ProductChecking,
+						Iban:     sql.NullString{Valid: true},
+					},
+					MaxAccounts:            maxAccounts,
+					MaxAccountsPerCurrency: maxAccountsPerCurrency,
+				}
+
+				store.EXPECT().CreateAccountTx(gomock.Any(), EqCreateAccountTxParams(arg)).Times(1).Return(account, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
-			name:      "BadRequest",
-			accountID: 0,
-			owner:     owner,
-			currency:  currency,
-			balance:   balance,
+			name:     "Nickname",
+			owner:    owner,
+			currency: currency,
+			product:  constants.ProductSavings,
+			nickname: "Holiday fund",
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).Times(0)
+				arg := db.CreateAccountTxParams{
+					CreateAccountParams: db.CreateAccountParams{
+						Owner:    owner,
+						Currency: currency,
+						Balance:  0,
+						Product:  constants.ProductSavings,
+						Nickname: sql.NullString{String: "Holiday fund", Valid: true},
+					},
+					MaxAccounts:            maxAccounts,
+					MaxAccountsPerCurrency: maxAccountsPerCurrency,
+				}
+
+				account := account
+				account.Nickname = arg.Nickname
+				store.EXPECT().CreateAccountTx(gomock.Any(), EqCreateAccountTxParams(arg)).Times(1).Return(account, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
-	}
-
-	for i := range testCases {
-		tc := testCases[i]
-
-		t.Run(
-			tc.name, func(t *testing.T) {
-				ctrl := gomock.NewController(t)
-				defer ctrl.Finish()
-
-				store := mockdb.NewMockStore(ctrl)
-				tc.buildStubs(store)
-
-				server := newTestServer(t, store)
-				recorder := httptest.NewRecorder()
-
-				url := fmt.Sprintf("/account/%d", tc.accountID)
-
-				jsonAccount := fmt.Sprintf(
-					`{"owner": "%s", "currency": "%s", "balance": %d}`, tc.owner, tc.currency, tc.balance,
-				)
-				jsonBody := []byte(jsonAccount)
-				bodyReader := bytes.NewReader(jsonBody)
+				require.Equal(t, http.StatusOK, recorder.Code)
 
-				request, err := http.NewRequest(http.MethodPut, url, bodyReader)
+				var gotAccount accountResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &gotAccount)
 				require.NoError(t, err)
-
-				tc.setupAuth(t, request, server.tokenMaker)
-
-				server.router.ServeHTTP(recorder, request)
-				tc.checkResponse(t, recorder)
+				require.NotNil(t, gotAccount.Nickname)
+				require.Equal(t, "Holiday fund", *gotAccount.Nickname)
 			},
-		)
-	}
-}
-
-func TestUpdateAccountBalanceAPI(t *testing.T) {
-	user, _ := generateMockUser(t)
-	account := generateMockAccounts(user.Username, 1)[0]
-
-	amount := util.RandomInt(-1000, 1000)
-
-	expectedAccount := db.Account{
-		ID:        account.ID,
-		Owner:     account.Owner,
-		Currency:  account.Currency,
-		Balance:   account.Balance + amount,
-		CreatedAt: account.CreatedAt,
-	}
-
-	testCases := []struct {
-		name          string
-		accountID     int64
-		owner         string
-		currency      string
-		amount        int64
-		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
-	}{
+		},
 		{
-			name:      "OK",
-			accountID: account.ID,
-			amount:    amount,
+			name:     "NicknameInUse",
+			owner:    owner,
+			currency: currency,
+			nickname: "Holiday fund",
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateAccountBalance(gomock.Any(), gomock.Any()).Times(1).Return(
-					expectedAccount, nil,
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.Account{}, &pq.Error{Code: "23505"},
 				)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchesUpdatedAccount(
-					t, recorder.Body, account, account.Owner, account.Currency, account.Balance+amount,
-				)
+				require.Equal(t, http.StatusConflict, recorder.Code)
 			},
 		},
 		{
-			name:      "NotFound",
-			accountID: account.ID,
-			amount:    amount,
+			name:     "AccountLimitReached",
+			owner:    owner,
+			currency: currency,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateAccountBalance(gomock.Any(), gomock.Any()).Times(1).Return(
-					db.Account{}, sql.ErrNoRows,
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.Account{}, db.ErrAccountLimitReached,
 				)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
-			name:      "InternalError",
-			accountID: account.ID,
-			amount:    amount,
+			name:     "NicknameTooLong",
+			owner:    owner,
+			currency: currency,
+			nickname: util.RandomString(51),
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateAccountBalance(gomock.Any(), gomock.Any()).Times(1).Return(
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalProduct",
+			owner:    owner,
+			currency: currency,
+			product:  constants.ProductInternal,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			owner:    owner,
+			currency: currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(
 					db.Account{}, sql.ErrConnDone,
 				)
 			},
@@ -532,14 +723,12 @@ func TestUpdateAccountBalanceAPI(t *testing.T) {
 			},
 		},
 		{
-			name:      "BadRequest",
-			accountID: 0,
-			amount:    amount,
+			name: "BadRequest",
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateAccountBalance(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -559,17 +748,22 @@ func TestUpdateAccountBalanceAPI(t *testing.T) {
 				tc.buildStubs(store)
 
 				server := newTestServer(t, store)
+				server.config.IBANCountryCode = tc.ibanCountryCode
+				server.config.IBANBankCode = "GOLB"
+				server.config.MaxAccountsPerUser = maxAccounts
+				server.config.MaxAccountsPerCurrency = maxAccountsPerCurrency
 				recorder := httptest.NewRecorder()
 
-				url := fmt.Sprintf("/account/%d/balance", tc.accountID)
+				url := fmt.Sprint("/account")
 
 				jsonAccount := fmt.Sprintf(
-					`{"amount": %d}`, tc.amount,
+					`{"owner": "%s", "currency": "%s", "product": "%s", "nickname": "%s"}`, tc.owner, tc.currency,
+					tc.product, tc.nickname,
 				)
 				jsonBody := []byte(jsonAccount)
 				bodyReader := bytes.NewReader(jsonBody)
 
-				request, err := http.NewRequest(http.MethodPut, url, bodyReader)
+				request, err := http.NewRequest(http.MethodPost, url, bodyReader)
 				require.NoError(t, err)
 
 				tc.setupAuth(t, request, server.tokenMaker)
@@ -581,13 +775,18 @@ func TestUpdateAccountBalanceAPI(t *testing.T) {
 	}
 }
 
-func TestDeleteAccountAPI(t *testing.T) {
+func TestUpdateAccountNicknameAPI(t *testing.T) {
 	user, _ := generateMockUser(t)
 	account := generateMockAccounts(user.Username, 1)[0]
 
+	nickname := "Rainy day"
+	renamedAccount := account
+	renamedAccount.Nickname = sql.NullString{String: nickname, Valid: true}
+
 	testCases := []struct {
 		name          string
 		accountID     int64
+		nickname      string
 		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
@@ -595,54 +794,104 @@ func TestDeleteAccountAPI(t *testing.T) {
 		{
 			name:      "OK",
 			accountID: account.ID,
+			nickname:  nickname,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(nil)
+				arg := db.UpdateAccountNicknameParams{
+					ID:       account.ID,
+					Nickname: sql.NullString{String: nickname, Valid: true},
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdateAccountNickname(gomock.Any(), gomock.Eq(arg)).Times(1).Return(renamedAccount, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesAccount(t, recorder.Body, renamedAccount)
 			},
 		},
 		{
-			name:      "NotFound",
+			name:      "Clear",
 			accountID: account.ID,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
-					sql.ErrNoRows,
+				arg := db.UpdateAccountNicknameParams{
+					ID: account.ID,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(renamedAccount, nil)
+				store.EXPECT().UpdateAccountNickname(gomock.Any(), gomock.Eq(arg)).Times(1).Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesAccount(t, recorder.Body, account)
+			},
+		},
+		{
+			name:      "NicknameInUse",
+			accountID: account.ID,
+			nickname:  nickname,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdateAccountNickname(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.Account{}, &pq.Error{Code: "23505"},
 				)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Equal(t, http.StatusConflict, recorder.Code)
 			},
 		},
 		{
-			name:      "InternalError",
+			name:      "UnauthorizedUser",
+			accountID: account.ID,
+			nickname:  nickname,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdateAccountNickname(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
 			accountID: account.ID,
+			nickname:  nickname,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
-					sql.ErrConnDone,
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					db.Account{}, sql.ErrNoRows,
 				)
+				store.EXPECT().UpdateAccountNickname(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
 		{
 			name:      "BadRequest",
 			accountID: 0,
+			nickname:  nickname,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -664,8 +913,10 @@ func TestDeleteAccountAPI(t *testing.T) {
 				server := newTestServer(t, store)
 				recorder := httptest.NewRecorder()
 
-				url := fmt.Sprintf("/account/%d", tc.accountID)
-				request, err := http.NewRequest(http.MethodDelete, url, nil)
+				url := fmt.Sprintf("/account/%d/nickname", tc.accountID)
+				jsonBody := []byte(fmt.Sprintf(`{"nickname": "%s"}`, tc.nickname))
+
+				request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(jsonBody))
 				require.NoError(t, err)
 
 				tc.setupAuth(t, request, server.tokenMaker)
@@ -681,22 +932,22 @@ func requireBodyMatchesAccount(t *testing.T, body *bytes.Buffer, account db.Acco
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var fetchedAccount db.Account
+	var fetchedAccount accountResponse
 	err = json.Unmarshal(data, &fetchedAccount)
 	require.NoError(t, err)
-	require.Equal(t, account, fetchedAccount)
+	require.Equal(t, newAccountResponse(account), fetchedAccount)
 }
 
 func requireBodyMatchesAccounts(t *testing.T, body *bytes.Buffer, accounts []db.Account) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var fetchedAccounts []db.Account
+	var fetchedAccounts []accountResponse
 	err = json.Unmarshal(data, &fetchedAccounts)
 	require.NoError(t, err)
 
 	for i, _ := range accounts {
-		require.Equal(t, accounts[i], fetchedAccounts[i])
+		require.Equal(t, newAccountResponse(accounts[i]), fetchedAccounts[i])
 	}
 }
 
@@ -712,21 +963,3 @@ func requireBodyMatchesCreatedAccount(t *testing.T, body *bytes.Buffer, account
 	require.Equal(t, account.Currency, createdAccount.Currency)
 	require.Equal(t, account.Balance, createdAccount.Balance)
 }
-
-func requireBodyMatchesUpdatedAccount(
-	t *testing.T, body *bytes.Buffer, account db.Account, owner string, currency string, balance int64,
-) {
-	data, err := io.ReadAll(body)
-	require.NoError(t, err)
-
-	var updatedAccount db.Account
-	err = json.Unmarshal(data, &updatedAccount)
-	require.NoError(t, err)
-
-	require.Equal(t, account.ID, updatedAccount.ID)
-	require.Equal(t, account.CreatedAt, updatedAccount.CreatedAt)
-
-	require.Equal(t, owner, updatedAccount.Owner)
-	require.Equal(t, currency, updatedAccount.Currency)
-	require.Equal(t, balance, updatedAccount.Balance)
-}
@@ -0,0 +1,334 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type amlAlertResponse struct {
+	ID          int64      `json:"id"`
+	Scenario    string     `json:"scenario"`
+	AccountID   int64      `json:"account_id"`
+	Amount      int64      `json:"amount"`
+	EventCount  int64      `json:"event_count"`
+	Details     string     `json:"details"`
+	WindowStart time.Time  `json:"window_start"`
+	WindowEnd   time.Time  `json:"window_end"`
+	Status      string     `json:"status"`
+	AssignedTo  *string    `json:"assigned_to"`
+	Resolution  *string    `json:"resolution"`
+	ClosedBy    *string    `json:"closed_by"`
+	ClosedAt    *time.Time `json:"closed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func newAmlAlertResponse(alert db.AmlAlert) amlAlertResponse {
+	res := amlAlertResponse{
+		ID:          alert.ID,
+		Scenario:    alert.Scenario,
+		AccountID:   alert.AccountID,
+		Amount:      alert.Amount,
+		EventCount:  alert.EventCount,
+		Details:     alert.Details,
+		WindowStart: alert.WindowStart,
+		WindowEnd:   alert.WindowEnd,
+		Status:      alert.Status,
+		AssignedTo:  nullStringPointer(alert.AssignedTo),
+		Resolution:  nullStringPointer(alert.Resolution),
+		ClosedBy:    nullStringPointer(alert.ClosedBy),
+		CreatedAt:   alert.CreatedAt,
+	}
+	if alert.ClosedAt.Valid {
+		res.ClosedAt = &alert.ClosedAt.Time
+	}
+	return res
+}
+
+type getAmlAlertsRequest struct {
+	Status     string `form:"status" binding:"omitempty,oneof=open assigned closed"`
+	Scenario   string `form:"scenario" binding:"omitempty,oneof=structuring rapid_movement dormant_reactivation"`
+	AssignedTo string `form:"assigned_to"`
+	PageNumber int32  `form:"page_number" binding:"required,min=1"`
+	PageSize   int32  `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+// getAmlAlerts lists alerts newest first. Analysts find their own cases by filtering on assigned_to.
+func (server *Server) getAmlAlerts(ctx *gin.Context) {
+	var req getAmlAlertsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	alerts, err := server.store.GetAmlAlerts(
+		ctx, db.GetAmlAlertsParams{
+			Status:     sql.NullString{String: req.Status, Valid: req.Status != ""},
+			Scenario:   sql.NullString{String: req.Scenario, Valid: req.Scenario != ""},
+			AssignedTo: sql.NullString{String: req.AssignedTo, Valid: req.AssignedTo != ""},
+			Limit:      req.PageSize,
+			Offset:     (req.PageNumber - 1) * req.PageSize,
+		},
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]amlAlertResponse, len(alerts))
+	for i, alert := range alerts {
+		res[i] = newAmlAlertResponse(alert)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type amlAlertRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type getAmlAlertResponse struct {
+	amlAlertResponse
+	Comments []db.AmlAlertComment `json:"comments"`
+}
+
+func (server *Server) getAmlAlert(ctx *gin.Context) {
+	var req amlAlertRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	alert, err := server.store.GetAmlAlert(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	comments, err := server.store.GetAmlAlertComments(ctx, alert.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, getAmlAlertResponse{amlAlertResponse: newAmlAlertResponse(alert), Comments: comments})
+}
+
+type assignAmlAlertBody struct {
+	Assignee string `json:"assignee" binding:"required"`
+}
+
+type assignAmlAlertRequest struct {
+	UriParams amlAlertRequest
+	Body      assignAmlAlertBody
+}
+
+func (server *Server) assignAmlAlert(ctx *gin.Context) {
+	var req assignAmlAlertRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	alert, err := server.store.AssignAmlAlertTx(
+		ctx, db.AssignAmlAlertTxParams{
+			ID:       req.UriParams.ID,
+			Assignee: req.Body.Assignee,
+		},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAmlAlertResponse(alert))
+}
+
+type createAmlAlertCommentBody struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+type createAmlAlertCommentRequest struct {
+	UriParams amlAlertRequest
+	Body      createAmlAlertCommentBody
+}
+
+func (server *Server) createAmlAlertComment(ctx *gin.Context) {
+	var req createAmlAlertCommentRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	comment, err := server.store.CreateAmlAlertComment(
+		ctx, db.CreateAmlAlertCommentParams{
+			AlertID: req.UriParams.ID,
+			Author:  authPayload.Username,
+			Body:    req.Body.Body,
+		},
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == constants.ForeignKeyViolation {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comment)
+}
+
+type closeAmlAlertBody struct {
+	Resolution string `json:"resolution" binding:"required,oneof=false_positive no_action reported"`
+	Comment    string `json:"comment" binding:"omitempty,max=2000"`
+}
+
+type closeAmlAlertRequest struct {
+	UriParams amlAlertRequest
+	Body      closeAmlAlertBody
+}
+
+type closeAmlAlertResponse struct {
+	Alert   amlAlertResponse    `json:"alert"`
+	Comment *db.AmlAlertComment `json:"comment"`
+}
+
+func (server *Server) closeAmlAlert(ctx *gin.Context) {
+	var req closeAmlAlertRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.CloseAmlAlertTx(
+		ctx, db.CloseAmlAlertTxParams{
+			ID:         req.UriParams.ID,
+			ClosedBy:   authPayload.Username,
+			Resolution: req.Body.Resolution,
+			Comment:    req.Body.Comment,
+		},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, closeAmlAlertResponse{Alert: newAmlAlertResponse(result.Alert), Comment: result.Comment})
+}
+
+type exportAmlAlertsRequest struct {
+	From time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	// Inclusive.
+	To         time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	Resolution string    `form:"resolution" binding:"omitempty,oneof=false_positive no_action reported"`
+}
+
+var amlAlertCSVHeader = []string{
+	"alert_id", "created_at", "scenario", "account_id", "owner", "currency", "amount", "event_count", "window_start",
+	"window_end", "details", "status", "assigned_to", "resolution", "closed_by", "closed_at",
+}
+
+// exportAmlAlerts gives a CSV of the alerts raised between two days, for regulatory reporting. Filtering by the
+// reported resolution gives the suspicious activity that was reported.
+func (server *Server) exportAmlAlerts(ctx *gin.Context) {
+	var req exportAmlAlertsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.To.Before(req.From) {
+		err := errors.New("to must not be before from")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	alerts, err := server.store.GetAmlAlertsForExport(
+		ctx, db.GetAmlAlertsForExportParams{
+			FromTime:   req.From,
+			ToTime:     req.To.AddDate(0, 0, 1),
+			Resolution: sql.NullString{String: req.Resolution, Valid: req.Resolution != ""},
+		},
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := writeAmlAlertsCSV(&buf, alerts); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	filename := fmt.Sprintf("aml-alerts-%s-%s.csv", req.From.Format("2006-01-02"), req.To.Format("2006-01-02"))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Data(http.StatusOK, "text/csv", buf.Bytes())
+}
+
+// writeAmlAlertsCSV writes one row per alert. Amounts are decimal strings in the account's currency.
+func writeAmlAlertsCSV(w io.Writer, alerts []db.GetAmlAlertsForExportRow) error {
+	writer := csv.NewWriter(w)
+	formatTime := func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	rows := [][]string{amlAlertCSVHeader}
+	for _, alert := range alerts {
+		closedAt := ""
+		if alert.ClosedAt.Valid {
+			closedAt = formatTime(alert.ClosedAt.Time)
+		}
+		rows = append(
+			rows, []string{
+				strconv.FormatInt(alert.ID, 10), formatTime(alert.CreatedAt), alert.Scenario,
+				strconv.FormatInt(alert.AccountID, 10), alert.Owner, alert.Currency,
+				currency.Decimal(alert.Currency, alert.Amount), strconv.FormatInt(alert.EventCount, 10),
+				formatTime(alert.WindowStart), formatTime(alert.WindowEnd), alert.Details, alert.Status,
+				alert.AssignedTo.String, alert.Resolution.String, alert.ClosedBy.String, closedAt,
+			},
+		)
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
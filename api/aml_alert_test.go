@@ -0,0 +1,405 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetAmlAlertsAPI(t *testing.T) {
+	analyst, _ := generateMockUser(t)
+	user, _ := generateMockUser(t)
+
+	alert := db.AmlAlert{
+		ID:         1,
+		Scenario:   constants.AmlScenarioStructuring,
+		AccountID:  1,
+		Amount:     28500,
+		EventCount: 3,
+		Status:     constants.AmlAlertStatusAssigned,
+		AssignedTo: sql.NullString{String: analyst.Username, Valid: true},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?status=assigned&assigned_to=" + analyst.Username + "&page_number=1&page_size=10",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, analyst.Username, constants.RoleAnalyst, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetAmlAlertsParams{
+					Status:     sql.NullString{String: constants.AmlAlertStatusAssigned, Valid: true},
+					AssignedTo: sql.NullString{String: analyst.Username, Valid: true},
+					Limit:      10,
+				}
+
+				store.EXPECT().GetAmlAlerts(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.AmlAlert{alert}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []amlAlertResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, 1)
+				require.Equal(t, analyst.Username, *got[0].AssignedTo)
+				require.Nil(t, got[0].Resolution)
+			},
+		},
+		{
+			name:  "InvalidScenario",
+			query: "?scenario=smurfing&page_number=1&page_size=10",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, analyst.Username, constants.RoleAnalyst, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAmlAlerts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Customer",
+			query: "?page_number=1&page_size=10",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAmlAlerts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodGet, "/aml-alerts"+tc.query, nil)
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestAmlAlertCaseAPI(t *testing.T) {
+	analyst, _ := generateMockUser(t)
+	teller, _ := generateMockUser(t)
+
+	asAnalyst := func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+		addRoleAuthorization(
+			t, req, tokenMaker, authorizationTypeBearer, analyst.Username, constants.RoleAnalyst, time.Minute,
+		)
+	}
+
+	testCases := []struct {
+		name          string
+		url           string
+		body          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "Assign",
+			url:       "/aml-alerts/1/assign",
+			body:      `{"assignee": "` + analyst.Username + `"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.AssignAmlAlertTxParams{ID: 1, Assignee: analyst.Username}
+
+				store.EXPECT().AssignAmlAlertTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					db.AmlAlert{
+						ID:         1,
+						Status:     constants.AmlAlertStatusAssigned,
+						AssignedTo: sql.NullString{String: analyst.Username, Valid: true},
+					}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got amlAlertResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.AmlAlertStatusAssigned, got.Status)
+			},
+		},
+		{
+			name:      "AssignToCustomer",
+			url:       "/aml-alerts/1/assign",
+			body:      `{"assignee": "someone"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AssignAmlAlertTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AmlAlert{}, db.ErrAmlAssigneeNotAnalyst,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:      "Comment",
+			url:       "/aml-alerts/1/comments",
+			body:      `{"body": "Customer explained the deposits as cash sales"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAmlAlertCommentParams{
+					AlertID: 1,
+					Author:  analyst.Username,
+					Body:    "Customer explained the deposits as cash sales",
+				}
+
+				store.EXPECT().CreateAmlAlertComment(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					db.AmlAlertComment{ID: 1, AlertID: 1, Author: analyst.Username, Body: arg.Body}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "Close",
+			url:       "/aml-alerts/1/close",
+			body:      `{"resolution": "reported", "comment": "Filed with the regulator"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CloseAmlAlertTxParams{
+					ID:         1,
+					ClosedBy:   analyst.Username,
+					Resolution: constants.AmlResolutionReported,
+					Comment:    "Filed with the regulator",
+				}
+
+				store.EXPECT().CloseAmlAlertTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					db.CloseAmlAlertTxResult{
+						Alert: db.AmlAlert{
+							ID:         1,
+							Status:     constants.AmlAlertStatusClosed,
+							Resolution: sql.NullString{String: constants.AmlResolutionReported, Valid: true},
+							ClosedAt:   sql.NullTime{Time: time.Now(), Valid: true},
+						},
+						Comment: &db.AmlAlertComment{ID: 2, AlertID: 1, Author: analyst.Username, Body: arg.Comment},
+					}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got closeAmlAlertResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.AmlResolutionReported, *got.Alert.Resolution)
+				require.NotNil(t, got.Alert.ClosedAt)
+				require.NotNil(t, got.Comment)
+			},
+		},
+		{
+			name:      "AlreadyClosed",
+			url:       "/aml-alerts/1/close",
+			body:      `{"resolution": "no_action"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CloseAmlAlertTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.CloseAmlAlertTxResult{}, db.ErrAmlAlertClosed,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "UnknownResolution",
+			url:       "/aml-alerts/1/close",
+			body:      `{"resolution": "ignored"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CloseAmlAlertTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Teller",
+			url:  "/aml-alerts/1/close",
+			body: `{"resolution": "no_action"}`,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CloseAmlAlertTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestExportAmlAlertsAPI(t *testing.T) {
+	analyst, _ := generateMockUser(t)
+
+	createdAt := time.Date(2023, time.March, 2, 9, 0, 0, 0, time.UTC)
+	row := db.GetAmlAlertsForExportRow{
+		ID:          7,
+		Scenario:    constants.AmlScenarioRapidMovement,
+		AccountID:   3,
+		Amount:      590000,
+		EventCount:  3,
+		Details:     "received 6000.00 USD and sent 5900.00 USD out in 24 hours",
+		WindowStart: createdAt.Add(-24 * time.Hour),
+		WindowEnd:   createdAt,
+		Status:      constants.AmlAlertStatusClosed,
+		Resolution:  sql.NullString{String: constants.AmlResolutionReported, Valid: true},
+		ClosedBy:    sql.NullString{String: analyst.Username, Valid: true},
+		ClosedAt:    sql.NullTime{Time: createdAt.Add(48 * time.Hour), Valid: true},
+		CreatedAt:   createdAt,
+		Owner:       "jdoe",
+		Currency:    constants.USD,
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?from=2023-03-01&to=2023-03-31&resolution=reported",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetAmlAlertsForExportParams{
+					FromTime:   time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC),
+					ToTime:     time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+					Resolution: sql.NullString{String: constants.AmlResolutionReported, Valid: true},
+				}
+
+				store.EXPECT().GetAmlAlertsForExport(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					[]db.GetAmlAlertsForExportRow{row}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), "aml-alerts-2023-03-01-2023-03-31.csv")
+
+				lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+				require.Len(t, lines, 2)
+				require.Equal(t, strings.Join(amlAlertCSVHeader, ","), lines[0])
+				require.Equal(
+					t, "7,2023-03-02T09:00:00Z,rapid_movement,3,jdoe,USD,5900.00,3,2023-03-01T09:00:00Z,"+
+						"2023-03-02T09:00:00Z,received 6000.00 USD and sent 5900.00 USD out in 24 hours,closed,,reported,"+
+						analyst.Username+",2023-03-04T09:00:00Z",
+					lines[1],
+				)
+			},
+		},
+		{
+			name:  "ToBeforeFrom",
+			query: "?from=2023-03-31&to=2023-03-01",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAmlAlertsForExport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodGet, "/aml-alerts/export"+tc.query, nil)
+				require.NoError(t, err)
+
+				addRoleAuthorization(
+					t, request, server.tokenMaker, authorizationTypeBearer, analyst.Username, constants.RoleAnalyst,
+					time.Minute,
+				)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
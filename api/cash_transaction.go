@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type cashTransactionResponse struct {
+	ID                   int64     `json:"id"`
+	AccountID            int64     `json:"account_id"`
+	Kind                 string    `json:"kind"`
+	Amount               int64     `json:"amount"`
+	AmountDecimal        string    `json:"amount_decimal"`
+	Reference            string    `json:"reference"`
+	Channel              string    `json:"channel"`
+	IdempotencyKey       string    `json:"idempotency_key"`
+	CreatedBy            string    `json:"created_by"`
+	JournalTransactionID *int64    `json:"journal_transaction_id"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+func newCashTransactionResponse(cashTransaction db.CashTransaction, currencyCode string) cashTransactionResponse {
+	res := cashTransactionResponse{
+		ID:             cashTransaction.ID,
+		AccountID:      cashTransaction.AccountID,
+		Kind:           cashTransaction.Kind,
+		Amount:         cashTransaction.Amount,
+		AmountDecimal:  currency.Decimal(currencyCode, cashTransaction.Amount),
+		Reference:      cashTransaction.Reference,
+		Channel:        cashTransaction.Channel,
+		IdempotencyKey: cashTransaction.IdempotencyKey,
+		CreatedBy:      cashTransaction.CreatedBy,
+		CreatedAt:      cashTransaction.CreatedAt,
+	}
+	if cashTransaction.JournalTransactionID.Valid {
+		res.JournalTransactionID = &cashTransaction.JournalTransactionID.Int64
+	}
+	return res
+}
+
+type cashTxResponse struct {
+	CashTransaction cashTransactionResponse `json:"cash_transaction"`
+	Entry           entryResponse           `json:"entry"`
+	Account         accountResponse         `json:"account"`
+	Replayed        bool                    `json:"replayed"`
+}
+
+func newCashTxResponse(result db.CashTxResult) cashTxResponse {
+	return cashTxResponse{
+		CashTransaction: newCashTransactionResponse(result.CashTransaction, result.Account.Currency),
+		Entry:           newEntryResponse(result.Entry, result.Account.Currency),
+		Account:         newAccountResponse(result.Account),
+		Replayed:        result.Replayed,
+	}
+}
+
+type cashTxUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type cashTxHeaders struct {
+	IdempotencyKey string `header:"Idempotency-Key" binding:"required,max=255"`
+}
+
+type cashTxBody struct {
+	Amount    int64  `json:"amount" binding:"required,min=1"`
+	Currency  string `json:"currency" binding:"required,currency"`
+	Reference string `json:"reference" binding:"max=140"`
+	Channel   string `json:"channel" binding:"required,oneof=branch atm ach wire"`
+}
+
+type cashTxRequest struct {
+	UriParams cashTxUriParams
+	Headers   cashTxHeaders
+	Body      cashTxBody
+}
+
+func (server *Server) depositToAccount(ctx *gin.Context) {
+	server.cashTx(ctx, server.store.DepositTx)
+}
+
+func (server *Server) withdrawFromAccount(ctx *gin.Context) {
+	server.cashTx(ctx, server.store.WithdrawTx)
+}
+
+func (server *Server) cashTx(ctx *gin.Context, tx func(context.Context, db.CashTxParams) (db.CashTxResult, error)) {
+	var req cashTxRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindHeader(&req.Headers); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.validateAccount(ctx, req.UriParams.ID, req.Body.Currency)
+	if !valid {
+		return
+	}
+
+	if account.Product == constants.ProductInternal {
+		err := errors.New("cash can't be moved in or out of an internal account")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CashTxParams{
+		AccountID:      account.ID,
+		Amount:         req.Body.Amount,
+		Reference:      req.Body.Reference,
+		Channel:        req.Body.Channel,
+		IdempotencyKey: req.Headers.IdempotencyKey,
+		CreatedBy:      authPayload.Username,
+	}
+
+	result, err := tx(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newCashTxResponse(result))
+}
@@ -0,0 +1,469 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDepositToAccountAPI(t *testing.T) {
+	teller, _ := generateMockUser(t)
+	customer, _ := generateMockUser(t)
+
+	account := generateMockAccounts(customer.Username, 1)[0]
+	account.Currency = constants.USD
+	account.Product = constants.ProductChecking
+
+	internalAccount := generateMockAccounts(constants.BankUsername, 1)[0]
+	internalAccount.Currency = constants.USD
+	internalAccount.Product = constants.ProductInternal
+
+	idempotencyKey := util.RandomString(16)
+	amount := util.RandomInt(1, 1000)
+	result := generateMockCashTxResult(account, constants.JournalKindDeposit, amount, idempotencyKey, teller.Username)
+
+	testCases := []struct {
+		name           string
+		accountID      int64
+		currency       string
+		channel        string
+		idempotencyKey string
+		setupAuth      func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs     func(store *mockdb.MockStore)
+		checkResponse  func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:           "OK",
+			accountID:      account.ID,
+			currency:       constants.USD,
+			channel:        constants.ChannelBranch,
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CashTxParams{
+					AccountID:      account.ID,
+					Amount:         amount,
+					Reference:      "counter",
+					Channel:        constants.ChannelBranch,
+					IdempotencyKey: idempotencyKey,
+					CreatedBy:      teller.Username,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesCashTxResult(t, recorder.Body, result)
+			},
+		},
+		{
+			name:           "Rail",
+			accountID:      account.ID,
+			currency:       constants.USD,
+			channel:        constants.ChannelACH,
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleRail, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:           "Customer",
+			accountID:      account.ID,
+			currency:       constants.USD,
+			channel:        constants.ChannelBranch,
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, customer.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			accountID:      account.ID,
+			currency:       constants.USD,
+			channel:        constants.ChannelBranch,
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "MissingIdempotencyKey",
+			accountID: account.ID,
+			currency:  constants.USD,
+			channel:   constants.ChannelBranch,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:           "InvalidChannel",
+			accountID:      account.ID,
+			currency:       constants.USD,
+			channel:        "carrier-pigeon",
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:           "CurrencyMismatch",
+			accountID:      account.ID,
+			currency:       constants.EUR,
+			channel:        constants.ChannelBranch,
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:           "InternalAccount",
+			accountID:      internalAccount.ID,
+			currency:       constants.USD,
+			channel:        constants.ChannelBranch,
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(internalAccount.ID)).Times(1).Return(
+					internalAccount, nil,
+				)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:           "NotFound",
+			accountID:      account.ID,
+			currency:       constants.USD,
+			channel:        constants.ChannelBranch,
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					db.Account{}, sql.ErrNoRows,
+				)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:           "IdempotencyKeyReused",
+			accountID:      account.ID,
+			currency:       constants.USD,
+			channel:        constants.ChannelBranch,
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.CashTxResult{}, db.ErrIdempotencyKeyReused,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/account/%d/deposit", tc.accountID)
+
+				jsonBody := fmt.Sprintf(
+					`{"amount": %d, "currency": "%s", "reference": "counter", "channel": "%s"}`,
+					amount, tc.currency, tc.channel,
+				)
+				bodyReader := bytes.NewReader([]byte(jsonBody))
+
+				request, err := http.NewRequest(http.MethodPost, url, bodyReader)
+				require.NoError(t, err)
+				if tc.idempotencyKey != "" {
+					request.Header.Set("Idempotency-Key", tc.idempotencyKey)
+				}
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestWithdrawFromAccountAPI(t *testing.T) {
+	teller, _ := generateMockUser(t)
+	customer, _ := generateMockUser(t)
+
+	account := generateMockAccounts(customer.Username, 1)[0]
+	account.Currency = constants.USD
+	account.Product = constants.ProductChecking
+
+	idempotencyKey := util.RandomString(16)
+	amount := util.RandomInt(1, 1000)
+	result := generateMockCashTxResult(account, constants.JournalKindWithdrawal, amount, idempotencyKey, teller.Username)
+	replayed := result
+	replayed.Replayed = true
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CashTxParams{
+					AccountID:      account.ID,
+					Amount:         amount,
+					Reference:      "counter",
+					Channel:        constants.ChannelATM,
+					IdempotencyKey: idempotencyKey,
+					CreatedBy:      teller.Username,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesCashTxResult(t, recorder.Body, result)
+			},
+		},
+		{
+			name: "Replayed",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(1).Return(replayed, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesCashTxResult(t, recorder.Body, replayed)
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.CashTxResult{}, db.ErrInsufficientFunds,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "FrozenAccount",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, teller.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.CashTxResult{}, db.ErrAccountFrozen,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "Customer",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, customer.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/account/%d/withdraw", account.ID)
+
+				jsonBody := fmt.Sprintf(
+					`{"amount": %d, "currency": "%s", "reference": "counter", "channel": "%s"}`,
+					amount, constants.USD, constants.ChannelATM,
+				)
+				bodyReader := bytes.NewReader([]byte(jsonBody))
+
+				request, err := http.NewRequest(http.MethodPost, url, bodyReader)
+				require.NoError(t, err)
+				request.Header.Set("Idempotency-Key", idempotencyKey)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func generateMockCashTxResult(
+	account db.Account, kind string, amount int64, idempotencyKey string, createdBy string,
+) db.CashTxResult {
+	journalTransactionID := util.RandomInt(1, 1000)
+	entryAmount := amount
+	if kind == constants.JournalKindWithdrawal {
+		entryAmount = -amount
+	}
+
+	account.Balance += entryAmount
+	return db.CashTxResult{
+		CashTransaction: db.CashTransaction{
+			ID:                   util.RandomInt(1, 1000),
+			AccountID:            account.ID,
+			Kind:                 kind,
+			Amount:               amount,
+			Reference:            "counter",
+			IdempotencyKey:       idempotencyKey,
+			CreatedBy:            createdBy,
+			JournalTransactionID: sql.NullInt64{Int64: journalTransactionID, Valid: true},
+		},
+		JournalTransaction: db.JournalTransaction{ID: journalTransactionID, Kind: kind},
+		Entry: db.Entry{
+			ID:                   util.RandomInt(1, 1000),
+			AccountID:            account.ID,
+			Amount:               entryAmount,
+			JournalTransactionID: sql.NullInt64{Int64: journalTransactionID, Valid: true},
+		},
+		Account: account,
+	}
+}
+
+func requireBodyMatchesCashTxResult(t *testing.T, body *bytes.Buffer, result db.CashTxResult) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var fetchedResult cashTxResponse
+	err = json.Unmarshal(data, &fetchedResult)
+	require.NoError(t, err)
+	require.Equal(t, newCashTxResponse(result), fetchedResult)
+}
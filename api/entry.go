@@ -3,16 +3,52 @@ package api
 import (
 	"database/sql"
 	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
 	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"time"
 )
 
+type entryResponse struct {
+	ID                   int64     `json:"id"`
+	AccountID            int64     `json:"account_id"`
+	Amount               int64     `json:"amount"`
+	AmountDecimal        string    `json:"amount_decimal"`
+	JournalTransactionID *int64    `json:"journal_transaction_id"`
+	CreatedAt            time.Time `json:"created_at"`
+	remittanceResponse
+}
+
+func newEntryResponse(entry db.Entry, currencyCode string) entryResponse {
+	res := entryResponse{
+		ID:            entry.ID,
+		AccountID:     entry.AccountID,
+		Amount:        entry.Amount,
+		AmountDecimal: currency.Decimal(currencyCode, entry.Amount),
+		CreatedAt:     entry.CreatedAt,
+		remittanceResponse: newRemittanceResponse(
+			db.Remittance{
+				Memo:          entry.Memo,
+				Reference:     entry.Reference,
+				Category:      entry.Category,
+				InvoiceNumber: entry.InvoiceNumber,
+				EndToEndID:    entry.EndToEndID,
+			},
+		),
+	}
+	if entry.JournalTransactionID.Valid {
+		res.JournalTransactionID = &entry.JournalTransactionID.Int64
+	}
+	return res
+}
+
 type getEntriesRequest struct {
 	PageNumber int32 `form:"page_number" binding:"required,min=1"`
 	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
 }
 
+// getEntries lists every entry in the bank, for admins.
 func (server *Server) getEntries(ctx *gin.Context) {
 	var req getEntriesRequest
 
@@ -62,6 +98,10 @@ func (server *Server) getEntriesForAccount(ctx *gin.Context) {
 		return
 	}
 
+	if _, valid := server.loadAuthorizedAccount(ctx, req.UriParams.AccountID, accountActionView); !valid {
+		return
+	}
+
 	arg := db.GetEntriesForAccountParams{
 		AccountID: req.UriParams.AccountID,
 		Limit:     req.QueryParams.PageSize,
@@ -100,103 +140,9 @@ func (server *Server) getEntry(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, entry)
-}
-
-type createEntryRequest struct {
-	AccountID int64 `json:"account_id" binding:"required,min=1"`
-	Amount    int64 `json:"amount" binding:"required"`
-}
-
-func (server *Server) createEntry(ctx *gin.Context) {
-	var req createEntryRequest
-
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	arg := db.CreateEntryParams{
-		AccountID: req.AccountID,
-		Amount:    req.Amount,
-	}
-
-	entry, err := server.store.CreateEntry(ctx, arg)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	ctx.JSON(http.StatusOK, entry)
-}
-
-type updateEntryUriParams struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
-}
-
-type updateEntryBody struct {
-	Amount int64 `json:"amount" binding:"required"`
-}
-
-type updateEntryRequest struct {
-	UriParams updateEntryUriParams
-	Body      updateEntryBody
-}
-
-func (server *Server) updateEntry(ctx *gin.Context) {
-	var req updateEntryRequest
-
-	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	arg := db.UpdateEntryParams{
-		ID:     req.UriParams.ID,
-		Amount: req.Body.Amount,
-	}
-
-	entry, err := server.store.UpdateEntry(ctx, arg)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	if _, valid := server.loadAuthorizedAccount(ctx, entry.AccountID, accountActionView); !valid {
 		return
 	}
 
 	ctx.JSON(http.StatusOK, entry)
 }
-
-type deleteEntryRequest struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
-}
-
-func (server *Server) deleteEntry(ctx *gin.Context) {
-	var req deleteEntryRequest
-
-	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	err := server.store.DeleteEntry(ctx, req.ID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	ctx.Status(http.StatusOK)
-}
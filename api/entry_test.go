@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
 	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
 	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
 	"github.com/CrunchyBlue/Golang-Bank/token"
@@ -20,8 +21,9 @@ import (
 
 func TestGetEntryAPI(t *testing.T) {
 	user, _ := generateMockUser(t)
-	accountID := util.RandomInt(1, 1000)
-	entry := generateMockEntries(1, accountID)[0]
+	otherUser, _ := generateMockUser(t)
+	account := generateMockAccounts(user.Username, 1)[0]
+	entry := generateMockEntries(1, account.ID)[0]
 
 	testCases := []struct {
 		name          string
@@ -38,12 +40,30 @@ func TestGetEntryAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetEntry(gomock.Any(), gomock.Eq(entry.ID)).Times(1).Return(entry, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				requireBodyMatchesEntry(t, recorder.Body, entry)
 			},
 		},
+		{
+			name:    "UnauthorizedUser",
+			entryID: entry.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEntry(gomock.Any(), gomock.Eq(entry.ID)).Times(1).Return(entry, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
 		{
 			name:    "NotFound",
 			entryID: entry.ID,
@@ -134,7 +154,9 @@ func TestGetEntriesAPI(t *testing.T) {
 			pageSize:   10,
 			pageNumber: 1,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, user.Username, constants.RoleAdmin, time.Minute,
+				)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetEntries(gomock.Any(), gomock.Any()).Times(1).Return(entries, nil)
@@ -149,7 +171,9 @@ func TestGetEntriesAPI(t *testing.T) {
 			pageSize:   10,
 			pageNumber: 1,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, user.Username, constants.RoleAdmin, time.Minute,
+				)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetEntries(gomock.Any(), gomock.Any()).Times(1).Return(
@@ -160,12 +184,28 @@ func TestGetEntriesAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:       "NotAdmin",
+			pageSize:   10,
+			pageNumber: 1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name:       "BadRequest",
 			pageSize:   -1,
 			pageNumber: -1,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, user.Username, constants.RoleAdmin, time.Minute,
+				)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetEntries(gomock.Any(), gomock.Any()).Times(0)
@@ -205,7 +245,8 @@ func TestGetEntriesAPI(t *testing.T) {
 
 func TestGetEntriesForAccountAPI(t *testing.T) {
 	user, _ := generateMockUser(t)
-	accountID := util.RandomInt(1, 1000)
+	account := generateMockAccounts(user.Username, 1)[0]
+	accountID := account.ID
 	entries := generateMockEntries(10, accountID)
 
 	testCases := []struct {
@@ -226,6 +267,7 @@ func TestGetEntriesForAccountAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountID)).Times(1).Return(account, nil)
 				store.EXPECT().GetEntriesForAccount(gomock.Any(), gomock.Any()).Times(1).Return(entries, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -242,6 +284,7 @@ func TestGetEntriesForAccountAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountID)).Times(1).Return(account, nil)
 				store.EXPECT().GetEntriesForAccount(gomock.Any(), gomock.Any()).Times(1).Return(
 					[]db.Entry{}, sql.ErrConnDone,
 				)
@@ -251,331 +294,34 @@ func TestGetEntriesForAccountAPI(t *testing.T) {
 			},
 		},
 		{
-			name:       "BadRequest",
+			name:       "UnauthorizedUser",
 			accountID:  accountID,
-			pageSize:   -1,
-			pageNumber: -1,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetEntriesForAccount(gomock.Any(), gomock.Any()).Times(0)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
-	}
-
-	for i := range testCases {
-		tc := testCases[i]
-
-		t.Run(
-			tc.name, func(t *testing.T) {
-				ctrl := gomock.NewController(t)
-				defer ctrl.Finish()
-
-				store := mockdb.NewMockStore(ctrl)
-				tc.buildStubs(store)
-
-				server := newTestServer(t, store)
-				recorder := httptest.NewRecorder()
-
-				url := fmt.Sprintf("/entries/%d?page_size=%d&page_number=%d", tc.accountID, tc.pageSize, tc.pageNumber)
-				request, err := http.NewRequest(http.MethodGet, url, nil)
-				require.NoError(t, err)
-
-				tc.setupAuth(t, request, server.tokenMaker)
-
-				server.router.ServeHTTP(recorder, request)
-				tc.checkResponse(t, recorder)
-			},
-		)
-	}
-}
-
-func TestCreateEntryAPI(t *testing.T) {
-	user, _ := generateMockUser(t)
-	accountID := util.RandomInt(1, 1000)
-	amount := util.RandomInt(-1000, 1000)
-
-	entry := db.Entry{
-		ID:        util.RandomInt(1, 1000),
-		AccountID: accountID,
-		Amount:    amount,
-	}
-
-	testCases := []struct {
-		name          string
-		accountID     int64
-		amount        int64
-		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
-	}{
-		{
-			name:      "OK",
-			accountID: accountID,
-			amount:    amount,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Times(1).Return(entry, nil)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchesCreatedEntry(t, recorder.Body, entry)
-			},
-		},
-		{
-			name:      "InternalError",
-			accountID: accountID,
-			amount:    amount,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Times(1).Return(
-					db.Entry{}, sql.ErrConnDone,
-				)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-		{
-			name: "BadRequest",
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Times(0)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
-	}
-
-	for i := range testCases {
-		tc := testCases[i]
-
-		t.Run(
-			tc.name, func(t *testing.T) {
-				ctrl := gomock.NewController(t)
-				defer ctrl.Finish()
-
-				store := mockdb.NewMockStore(ctrl)
-				tc.buildStubs(store)
-
-				server := newTestServer(t, store)
-				recorder := httptest.NewRecorder()
-
-				url := fmt.Sprint("/entry")
-
-				jsonEntry := fmt.Sprintf(`{"account_id": %d, "amount": %d}`, tc.accountID, tc.amount)
-				jsonBody := []byte(jsonEntry)
-				bodyReader := bytes.NewReader(jsonBody)
-
-				request, err := http.NewRequest(http.MethodPost, url, bodyReader)
-				require.NoError(t, err)
-
-				tc.setupAuth(t, request, server.tokenMaker)
-
-				server.router.ServeHTTP(recorder, request)
-				tc.checkResponse(t, recorder)
-			},
-		)
-	}
-}
-
-func TestUpdateEntryAPI(t *testing.T) {
-	user, _ := generateMockUser(t)
-	accountID := util.RandomInt(1, 1000)
-	entry := generateMockEntries(1, accountID)[0]
-
-	amount := util.RandomInt(-1000, 1000)
-
-	expectedEntry := db.Entry{
-		ID:        entry.ID,
-		AccountID: entry.AccountID,
-		Amount:    amount,
-		CreatedAt: entry.CreatedAt,
-	}
-
-	testCases := []struct {
-		name          string
-		entryID       int64
-		amount        int64
-		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
-	}{
-		{
-			name:    "OK",
-			entryID: expectedEntry.ID,
-			amount:  amount,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateEntry(gomock.Any(), gomock.Any()).Times(1).Return(
-					expectedEntry, nil,
-				)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchesUpdatedEntry(
-					t, recorder.Body, entry, amount,
-				)
-			},
-		},
-		{
-			name:    "NotFound",
-			entryID: expectedEntry.ID,
-			amount:  amount,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateEntry(gomock.Any(), gomock.Any()).Times(1).Return(
-					db.Entry{}, sql.ErrNoRows,
-				)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
-			},
-		},
-		{
-			name:    "InternalError",
-			entryID: expectedEntry.ID,
-			amount:  amount,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateEntry(gomock.Any(), gomock.Any()).Times(1).Return(
-					db.Entry{}, sql.ErrConnDone,
-				)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-		{
-			name:    "BadRequest",
-			entryID: 0,
-			amount:  amount,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateEntry(gomock.Any(), gomock.Any()).Times(0)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
-	}
-
-	for i := range testCases {
-		tc := testCases[i]
-
-		t.Run(
-			tc.name, func(t *testing.T) {
-				ctrl := gomock.NewController(t)
-				defer ctrl.Finish()
-
-				store := mockdb.NewMockStore(ctrl)
-				tc.buildStubs(store)
-
-				server := newTestServer(t, store)
-				recorder := httptest.NewRecorder()
-
-				url := fmt.Sprintf("/entry/%d", tc.entryID)
-
-				jsonAccount := fmt.Sprintf(
-					`{"amount": %d}`, tc.amount,
-				)
-				jsonBody := []byte(jsonAccount)
-				bodyReader := bytes.NewReader(jsonBody)
-
-				request, err := http.NewRequest(http.MethodPut, url, bodyReader)
-				require.NoError(t, err)
-
-				tc.setupAuth(t, request, server.tokenMaker)
-
-				server.router.ServeHTTP(recorder, request)
-				tc.checkResponse(t, recorder)
-			},
-		)
-	}
-}
-
-func TestDeleteEntryAPI(t *testing.T) {
-	user, _ := generateMockUser(t)
-	accountID := util.RandomInt(1, 1000)
-	entry := generateMockEntries(1, accountID)[0]
-
-	testCases := []struct {
-		name          string
-		entryID       int64
-		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
-	}{
-		{
-			name:    "OK",
-			entryID: entry.ID,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteEntry(gomock.Any(), gomock.Eq(entry.ID)).Times(1).Return(nil)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-			},
-		},
-		{
-			name:    "NotFound",
-			entryID: entry.ID,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteEntry(gomock.Any(), gomock.Eq(entry.ID)).Times(1).Return(
-					sql.ErrNoRows,
-				)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
-			},
-		},
-		{
-			name:    "InternalError",
-			entryID: entry.ID,
+			pageSize:   10,
+			pageNumber: 1,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteEntry(gomock.Any(), gomock.Eq(entry.ID)).Times(1).Return(
-					sql.ErrConnDone,
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
 				)
+				store.EXPECT().GetEntriesForAccount(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
 		{
-			name:    "BadRequest",
-			entryID: 0,
+			name:       "BadRequest",
+			accountID:  accountID,
+			pageSize:   -1,
+			pageNumber: -1,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteEntry(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetEntriesForAccount(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -597,8 +343,8 @@ func TestDeleteEntryAPI(t *testing.T) {
 				server := newTestServer(t, store)
 				recorder := httptest.NewRecorder()
 
-				url := fmt.Sprintf("/entry/%d", tc.entryID)
-				request, err := http.NewRequest(http.MethodDelete, url, nil)
+				url := fmt.Sprintf("/entries/%d?page_size=%d&page_number=%d", tc.accountID, tc.pageSize, tc.pageNumber)
+				request, err := http.NewRequest(http.MethodGet, url, nil)
 				require.NoError(t, err)
 
 				tc.setupAuth(t, request, server.tokenMaker)
@@ -632,32 +378,3 @@ func requireBodyMatchesEntries(t *testing.T, body *bytes.Buffer, entries []db.En
 		require.Equal(t, entries[i], fetchedEntries[i])
 	}
 }
-
-func requireBodyMatchesCreatedEntry(t *testing.T, body *bytes.Buffer, entry db.Entry) {
-	data, err := io.ReadAll(body)
-	require.NoError(t, err)
-
-	var createdEntry db.Entry
-	err = json.Unmarshal(data, &createdEntry)
-	require.NoError(t, err)
-
-	require.Equal(t, entry.AccountID, createdEntry.AccountID)
-	require.Equal(t, entry.Amount, createdEntry.Amount)
-}
-
-func requireBodyMatchesUpdatedEntry(
-	t *testing.T, body *bytes.Buffer, entry db.Entry, amount int64,
-) {
-	data, err := io.ReadAll(body)
-	require.NoError(t, err)
-
-	var updatedEntry db.Entry
-	err = json.Unmarshal(data, &updatedEntry)
-	require.NoError(t, err)
-
-	require.Equal(t, entry.ID, updatedEntry.ID)
-	require.Equal(t, entry.AccountID, updatedEntry.AccountID)
-	require.Equal(t, entry.CreatedAt, updatedEntry.CreatedAt)
-
-	require.Equal(t, amount, updatedEntry.Amount)
-}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type feeResponse struct {
+	ID            int64      `json:"id"`
+	AccountID     int64      `json:"account_id"`
+	Kind          string     `json:"kind"`
+	Name          string     `json:"name"`
+	Amount        int64      `json:"amount"`
+	AmountDecimal string     `json:"amount_decimal"`
+	TransferID    *int64     `json:"transfer_id"`
+	FeeTransferID *int64     `json:"fee_transfer_id"`
+	PeriodStart   *time.Time `json:"period_start"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func newFeeResponse(fee db.Fee, currencyCode string) feeResponse {
+	res := feeResponse{
+		ID:            fee.ID,
+		AccountID:     fee.AccountID,
+		Kind:          fee.Kind,
+		Name:          fee.Name,
+		Amount:        fee.Amount,
+		AmountDecimal: currency.Decimal(currencyCode, fee.Amount),
+		CreatedAt:     fee.CreatedAt,
+	}
+	if fee.TransferID.Valid {
+		res.TransferID = &fee.TransferID.Int64
+	}
+	if fee.FeeTransferID.Valid {
+		res.FeeTransferID = &fee.FeeTransferID.Int64
+	}
+	if fee.PeriodStart.Valid {
+		res.PeriodStart = &fee.PeriodStart.Time
+	}
+	return res
+}
+
+func newFeeResponses(fees []db.Fee, currencyCode string) []feeResponse {
+	res := make([]feeResponse, len(fees))
+	for i, fee := range fees {
+		res[i] = newFeeResponse(fee, currencyCode)
+	}
+	return res
+}
+
+type getFeesForAccountUriParams struct {
+	AccountID int64 `uri:"account_id" binding:"required,min=1"`
+}
+
+type getFeesForAccountQueryParams struct {
+	PageNumber int32 `form:"page_number" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+type getFeesForAccountRequest struct {
+	UriParams   getFeesForAccountUriParams
+	QueryParams getFeesForAccountQueryParams
+}
+
+func (server *Server) getFeesForAccount(ctx *gin.Context) {
+	var req getFeesForAccountRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.AccountID, accountActionView)
+	if !valid {
+		return
+	}
+
+	arg := db.GetFeesForAccountParams{
+		AccountID: req.UriParams.AccountID,
+		Limit:     req.QueryParams.PageSize,
+		Offset:    (req.QueryParams.PageNumber - 1) * req.QueryParams.PageSize,
+	}
+
+	fees, err := server.store.GetFeesForAccount(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newFeeResponses(fees, account.Currency))
+}
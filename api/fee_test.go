@@ -0,0 +1,164 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetFeesForAccountAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	account := generateMockAccounts(user.Username, 1)[0]
+	fees := []db.Fee{generateMockFee(account.ID, 1), generateMockFee(account.ID, 2)}
+
+	testCases := []struct {
+		name          string
+		accountID     int64
+		pageSize      int
+		pageNumber    int
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			accountID:  account.ID,
+			pageSize:   10,
+			pageNumber: 1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetFeesForAccountParams{
+					AccountID: account.ID,
+					Limit:     10,
+					Offset:    0,
+				}
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetFeesForAccount(gomock.Any(), gomock.Eq(arg)).Times(1).Return(fees, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesFees(t, recorder.Body, fees, account.Currency)
+			},
+		},
+		{
+			name:       "UnauthorizedUser",
+			accountID:  account.ID,
+			pageSize:   10,
+			pageNumber: 1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetFeesForAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:       "AccountNotFound",
+			accountID:  account.ID,
+			pageSize:   10,
+			pageNumber: 1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					db.Account{}, sql.ErrNoRows,
+				)
+				store.EXPECT().GetFeesForAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			accountID:  account.ID,
+			pageSize:   10,
+			pageNumber: 1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetFeesForAccount(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.Fee{}, sql.ErrConnDone,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:       "BadRequest",
+			accountID:  account.ID,
+			pageSize:   -1,
+			pageNumber: -1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetFeesForAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/fees/%d?page_size=%d&page_number=%d", tc.accountID, tc.pageSize, tc.pageNumber)
+				request, err := http.NewRequest(http.MethodGet, url, nil)
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func requireBodyMatchesFees(t *testing.T, body *bytes.Buffer, fees []db.Fee, currencyCode string) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var fetchedFees []feeResponse
+	err = json.Unmarshal(data, &fetchedFees)
+	require.NoError(t, err)
+	require.Equal(t, newFeeResponses(fees, currencyCode), fetchedFees)
+}
@@ -0,0 +1,321 @@
+package api
+
+import (
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type holdResponse struct {
+	ID                   int64     `json:"id"`
+	AccountID            int64     `json:"account_id"`
+	DestinationAccountID int64     `json:"destination_account_id"`
+	Amount               int64     `json:"amount"`
+	AmountDecimal        string    `json:"amount_decimal"`
+	CapturedAmount       int64     `json:"captured_amount"`
+	Status               string    `json:"status"`
+	Reference            string    `json:"reference"`
+	TransferID           *int64    `json:"transfer_id"`
+	ExpiresAt            time.Time `json:"expires_at"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+func newHoldResponse(hold db.Hold, currencyCode string) holdResponse {
+	res := holdResponse{
+		ID:                   hold.ID,
+		AccountID:            hold.AccountID,
+		DestinationAccountID: hold.DestinationAccountID,
+		Amount:               hold.Amount,
+		AmountDecimal:        currency.Decimal(currencyCode, hold.Amount),
+		CapturedAmount:       hold.CapturedAmount,
+		Status:               hold.Status,
+		Reference:            hold.Reference,
+		ExpiresAt:            hold.ExpiresAt,
+		CreatedAt:            hold.CreatedAt,
+	}
+	if hold.TransferID.Valid {
+		res.TransferID = &hold.TransferID.Int64
+	}
+	return res
+}
+
+type getHoldsForAccountUriParams struct {
+	AccountID int64 `uri:"account_id" binding:"required,min=1"`
+}
+
+type getHoldsForAccountQueryParams struct {
+	PageNumber int32 `form:"page_number" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+type getHoldsForAccountRequest struct {
+	UriParams   getHoldsForAccountUriParams
+	QueryParams getHoldsForAccountQueryParams
+}
+
+func (server *Server) getHoldsForAccount(ctx *gin.Context) {
+	var req getHoldsForAccountRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.AccountID, accountActionView)
+	if !valid {
+		return
+	}
+
+	arg := db.GetHoldsForAccountParams{
+		AccountID: req.UriParams.AccountID,
+		Limit:     req.QueryParams.PageSize,
+		Offset:    (req.QueryParams.PageNumber - 1) * req.QueryParams.PageSize,
+	}
+
+	holds, err := server.store.GetHoldsForAccount(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]holdResponse, len(holds))
+	for i, hold := range holds {
+		res[i] = newHoldResponse(hold, account.Currency)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type getHoldRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getHold(ctx *gin.Context) {
+	var req getHoldRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hold, err := server.store.GetHold(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizeHold(ctx, hold, accountActionView)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newHoldResponse(hold, account.Currency))
+}
+
+type createHoldRequest struct {
+	AccountID int64 `json:"account_id" binding:"required_without=AccountNumber,excluded_with=AccountNumber,omitempty,min=1"`
+	// Account number or IBAN of the account, in place of its ID.
+	AccountNumber        string `json:"account_number" binding:"omitempty,account_number"`
+	DestinationAccountID int64  `json:"destination_account_id" binding:"required_without=DestinationAccountNumber,excluded_with=DestinationAccountNumber,omitempty,min=1"`
+	// Account number or IBAN of the destination account, in place of its ID.
+	DestinationAccountNumber string `json:"destination_account_number" binding:"omitempty,account_number"`
+	Amount                   int64  `json:"amount" binding:"required,min=1"`
+	Currency                 string `json:"currency" binding:"required,currency"`
+	Reference                string `json:"reference" binding:"max=140"`
+}
+
+func (server *Server) createHold(ctx *gin.Context) {
+	var req createHoldRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.AccountNumber != "" {
+		account, err := server.getAccountByNumber(ctx, req.AccountNumber)
+		if err != nil {
+			ctx.JSON(errorStatus(err), errorResponse(err))
+			return
+		}
+		req.AccountID = account.ID
+	}
+
+	if req.DestinationAccountNumber != "" {
+		destinationAccount, err := server.getAccountByNumber(ctx, req.DestinationAccountNumber)
+		if err != nil {
+			ctx.JSON(errorStatus(err), errorResponse(err))
+			return
+		}
+		req.DestinationAccountID = destinationAccount.ID
+	}
+
+	account, valid := server.validateAccount(ctx, req.AccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	if !server.authorizeAccount(ctx, account, accountActionSpend, req.Amount) {
+		return
+	}
+
+	if !server.authorizeWithoutApproval(ctx, account, req.Amount) {
+		return
+	}
+
+	destinationAccount, valid := server.validateAccount(ctx, req.DestinationAccountID, req.Currency)
+	if !valid || !server.authorizeDestination(ctx, destinationAccount, req.Amount) {
+		return
+	}
+
+	arg := db.PlaceHoldTxParams{
+		AccountID:            req.AccountID,
+		DestinationAccountID: req.DestinationAccountID,
+		Amount:               req.Amount,
+		Reference:            req.Reference,
+		ExpiresAt:            time.Now().Add(server.config.HoldDuration),
+	}
+
+	hold, err := server.store.PlaceHoldTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newHoldResponse(hold, req.Currency))
+}
+
+type captureHoldUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type captureHoldBody struct {
+	// Amount to settle. Omit to capture the full hold.
+	Amount int64 `json:"amount" binding:"omitempty,min=1"`
+}
+
+type captureHoldRequest struct {
+	UriParams captureHoldUriParams
+	Body      captureHoldBody
+}
+
+type captureHoldResponse struct {
+	Hold     holdResponse       `json:"hold"`
+	Transfer transferTxResponse `json:"transfer"`
+}
+
+func (server *Server) captureHold(ctx *gin.Context) {
+	var req captureHoldRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hold, err := server.store.GetHold(ctx, req.UriParams.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizeHold(ctx, hold, accountActionSpend)
+	if !valid {
+		return
+	}
+
+	amount := req.Body.Amount
+	if amount == 0 {
+		amount = hold.Amount
+	}
+
+	arg := db.CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Amount: amount,
+	}
+
+	result, err := server.store.CaptureHoldTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	res := captureHoldResponse{
+		Hold:     newHoldResponse(result.Hold, account.Currency),
+		Transfer: newTransferTxResponse(result.Transfer, account.Currency),
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type voidHoldRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) voidHold(ctx *gin.Context) {
+	var req voidHoldRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hold, err := server.store.GetHold(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	account, valid := server.authorizeHold(ctx, hold, accountActionSpend)
+	if !valid {
+		return
+	}
+
+	hold, err = server.store.VoidHoldTx(ctx, hold.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newHoldResponse(hold, account.Currency))
+}
+
+// authorizeHold allows anyone who may take action on either side of a hold to do so and returns the held account.
+func (server *Server) authorizeHold(ctx *gin.Context, hold db.Hold, action accountAction) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, hold.AccountID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return account, false
+	}
+
+	status, err := server.checkAccountAccess(ctx, account, action, hold.Amount)
+	if err == nil {
+		return account, true
+	}
+	if status == http.StatusInternalServerError {
+		ctx.JSON(status, errorResponse(err))
+		return account, false
+	}
+
+	destinationAccount, err := server.store.GetAccount(ctx, hold.DestinationAccountID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return account, false
+	}
+
+	if !server.authorizeAccount(ctx, destinationAccount, action, hold.Amount) {
+		return account, false
+	}
+	return account, true
+}
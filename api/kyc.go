@@ -0,0 +1,465 @@
+package api
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/blob"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"time"
+)
+
+// kycFormOverhead is how much larger than the document itself an upload's body may be, for the form's other field
+// and its part headers.
+const kycFormOverhead = 64 << 10
+
+var (
+	errKycDocumentStoreMissing = errors.New("KYC document uploads are not configured")
+	errKycDocumentTooLarge     = errors.New("KYC document is too large")
+	errKycDocumentType         = errors.New("KYC document must be a PDF, JPEG or PNG file")
+	errNotKycDocumentOwner     = errors.New("KYC document doesn't belong to the authenticated user")
+)
+
+type kycDocumentResponse struct {
+	ID           int64     `json:"id"`
+	SubmissionID *int64    `json:"submission_id"`
+	DocumentType string    `json:"document_type"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	SizeBytes    int64     `json:"size_bytes"`
+	Sha256       string    `json:"sha256"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func newKycDocumentResponse(document db.KycDocument) kycDocumentResponse {
+	return kycDocumentResponse{
+		ID:           document.ID,
+		SubmissionID: nullInt64Pointer(document.SubmissionID),
+		DocumentType: document.DocumentType,
+		FileName:     document.FileName,
+		ContentType:  document.ContentType,
+		SizeBytes:    document.SizeBytes,
+		Sha256:       document.Sha256,
+		CreatedAt:    document.CreatedAt,
+	}
+}
+
+func newKycDocumentResponses(documents []db.KycDocument) []kycDocumentResponse {
+	res := make([]kycDocumentResponse, len(documents))
+	for i, document := range documents {
+		res[i] = newKycDocumentResponse(document)
+	}
+	return res
+}
+
+type kycResponse struct {
+	KycStatus string                `json:"kyc_status"`
+	Documents []kycDocumentResponse `json:"documents"`
+}
+
+// getKyc returns the authenticated user's KYC status and every document they have uploaded.
+func (server *Server) getKyc(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	documents, err := server.store.GetKycDocuments(ctx, user.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, kycResponse{KycStatus: user.KycStatus, Documents: newKycDocumentResponses(documents)})
+}
+
+type uploadKycDocumentRequest struct {
+	DocumentType string                `form:"document_type" binding:"required,oneof=passport national_id drivers_license proof_of_address"`
+	File         *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+// uploadKycDocument stores a document for the authenticated user's next KYC submission. Files over the configured
+// size are refused with 413, and files that aren't PDF, JPEG or PNG, judged by their content rather than the type
+// the client gives, with 415.
+func (server *Server) uploadKycDocument(ctx *gin.Context) {
+	if server.documents == nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(errKycDocumentStoreMissing))
+		return
+	}
+
+	maxSize := server.config.KYCDocumentMaxSize
+	if ctx.Request.ContentLength > maxSize+kycFormOverhead {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(errKycDocumentTooLarge))
+		return
+	}
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize+kycFormOverhead)
+
+	var req uploadKycDocumentRequest
+
+	if err := ctx.ShouldBindWith(&req, binding.FormMultipart); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.File.Size > maxSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(errKycDocumentTooLarge))
+		return
+	}
+
+	data, err := readFormFile(req.File)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	contentType := http.DetectContentType(data)
+	if !kycDocumentContentType(contentType) {
+		ctx.JSON(http.StatusUnsupportedMediaType, errorResponse(errKycDocumentType))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+	if !db.KycEditable(user.KycStatus) {
+		ctx.JSON(http.StatusConflict, errorResponse(db.ErrKycNotEditable))
+		return
+	}
+
+	key := fmt.Sprintf("kyc/%s/%s", user.Username, uuid.New())
+	if err := server.documents.Put(ctx, key, data); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	digest := sha256.Sum256(data)
+	document, err := server.store.CreateKycDocument(
+		ctx, db.CreateKycDocumentParams{
+			Username:     user.Username,
+			DocumentType: req.DocumentType,
+			FileName:     filepath.Base(filepath.Clean("/" + req.File.Filename)),
+			ContentType:  contentType,
+			SizeBytes:    int64(len(data)),
+			Sha256:       hex.EncodeToString(digest[:]),
+			BlobKey:      key,
+		},
+	)
+	if err != nil {
+		server.deleteKycBlob(ctx, key)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newKycDocumentResponse(document))
+}
+
+func readFormFile(header *multipart.FileHeader) ([]byte, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
+
+func kycDocumentContentType(contentType string) bool {
+	for _, allowed := range constants.KycDocumentContentTypes {
+		if contentType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteKycBlob removes a document's file once its row is gone, or was never written. A file left behind is only
+// logged, since nothing refers to it any more.
+func (server *Server) deleteKycBlob(ctx *gin.Context, key string) {
+	if err := server.documents.Delete(ctx, key); err != nil {
+		log.Printf("cannot delete KYC document %s: %v", key, err)
+	}
+}
+
+type kycDocumentRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// loadKycDocument loads a document, checking that it belongs to the authenticated user. Analysts and admins can load
+// anyone's documents when allowReviewers is set.
+func (server *Server) loadKycDocument(ctx *gin.Context, id int64, allowReviewers bool) (db.KycDocument, bool) {
+	document, err := server.store.GetKycDocument(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return document, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	reviewer := authPayload.Role == constants.RoleAnalyst || authPayload.Role == constants.RoleAdmin
+	if document.Username != authPayload.Username && !(allowReviewers && reviewer) {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errNotKycDocumentOwner))
+		return document, false
+	}
+
+	return document, true
+}
+
+// getKycDocumentContent downloads a document's file, decrypted.
+func (server *Server) getKycDocumentContent(ctx *gin.Context) {
+	var req kycDocumentRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if server.documents == nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(errKycDocumentStoreMissing))
+		return
+	}
+
+	document, valid := server.loadKycDocument(ctx, req.ID, true)
+	if !valid {
+		return
+	}
+
+	data, err := server.documents.Get(ctx, document.BlobKey)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, blob.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		ctx.JSON(status, errorResponse(err))
+		return
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName})
+	ctx.Header("Content-Disposition", disposition)
+	ctx.Data(http.StatusOK, document.ContentType, data)
+}
+
+// deleteKycDocument deletes one of the authenticated user's documents that hasn't been submitted yet.
+func (server *Server) deleteKycDocument(ctx *gin.Context) {
+	var req kycDocumentRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	document, valid := server.loadKycDocument(ctx, req.ID, false)
+	if !valid {
+		return
+	}
+
+	// Submitted documents are kept as the record of what was reviewed
+	document, err := server.store.DeleteKycDocument(ctx, document.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusConflict, errorResponse(db.ErrKycNotEditable))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if server.documents != nil {
+		server.deleteKycBlob(ctx, document.BlobKey)
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+type kycSubmissionResponse struct {
+	ID              int64                 `json:"id"`
+	Username        string                `json:"username"`
+	Status          string                `json:"status"`
+	ReviewedBy      *string               `json:"reviewed_by"`
+	RejectionReason *string               `json:"rejection_reason"`
+	ReviewedAt      *time.Time            `json:"reviewed_at"`
+	CreatedAt       time.Time             `json:"created_at"`
+	Documents       []kycDocumentResponse `json:"documents,omitempty"`
+}
+
+func newKycSubmissionResponse(submission db.KycSubmission, documents []db.KycDocument) kycSubmissionResponse {
+	res := kycSubmissionResponse{
+		ID:              submission.ID,
+		Username:        submission.Username,
+		Status:          submission.Status,
+		ReviewedBy:      nullStringPointer(submission.ReviewedBy),
+		RejectionReason: nullStringPointer(submission.RejectionReason),
+		CreatedAt:       submission.CreatedAt,
+	}
+	if submission.ReviewedAt.Valid {
+		res.ReviewedAt = &submission.ReviewedAt.Time
+	}
+	if documents != nil {
+		res.Documents = newKycDocumentResponses(documents)
+	}
+	return res
+}
+
+// submitKyc sends the authenticated user's uploaded documents for review. At least one of them must be an identity
+// document.
+func (server *Server) submitKyc(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	submission, err := server.store.SubmitKycTx(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newKycSubmissionResponse(submission, nil))
+}
+
+type getKycSubmissionsRequest struct {
+	Status     string `form:"status" binding:"omitempty,oneof=pending verified rejected"`
+	PageNumber int32  `form:"page_number" binding:"required,min=1"`
+	PageSize   int32  `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+// getKycSubmissions lists submissions oldest first, so the review queue, the submissions with status pending, is
+// worked in the order they came in.
+func (server *Server) getKycSubmissions(ctx *gin.Context) {
+	var req getKycSubmissionsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	submissions, err := server.store.GetKycSubmissions(
+		ctx, db.GetKycSubmissionsParams{
+			Status: sql.NullString{String: req.Status, Valid: req.Status != ""},
+			Limit:  req.PageSize,
+			Offset: (req.PageNumber - 1) * req.PageSize,
+		},
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]kycSubmissionResponse, len(submissions))
+	for i, submission := range submissions {
+		res[i] = newKycSubmissionResponse(submission, nil)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type kycSubmissionRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getKycSubmission returns a submission with the documents that were sent in it.
+func (server *Server) getKycSubmission(ctx *gin.Context) {
+	var req kycSubmissionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	submission, err := server.store.GetKycSubmission(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	documents, err := server.store.GetKycSubmissionDocuments(ctx, sql.NullInt64{Int64: submission.ID, Valid: true})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newKycSubmissionResponse(submission, documents))
+}
+
+type approveKycSubmissionBody struct {
+	Tier string `json:"tier" binding:"omitempty,oneof=standard premium business"`
+}
+
+type approveKycSubmissionRequest struct {
+	UriParams kycSubmissionRequest
+	Body      approveKycSubmissionBody
+}
+
+// approveKycSubmission verifies the user, who has their tier's limits from then on. A tier can be given to move them
+// to at the same time.
+func (server *Server) approveKycSubmission(ctx *gin.Context) {
+	var req approveKycSubmissionRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.reviewKycSubmission(
+		ctx, db.ReviewKycSubmissionTxParams{ID: req.UriParams.ID, Approve: true, Tier: req.Body.Tier},
+	)
+}
+
+type rejectKycSubmissionBody struct {
+	Reason string `json:"reason" binding:"required,max=2000"`
+}
+
+type rejectKycSubmissionRequest struct {
+	UriParams kycSubmissionRequest
+	Body      rejectKycSubmissionBody
+}
+
+// rejectKycSubmission rejects a submission with a reason the user is shown. They can upload new documents and submit
+// again.
+func (server *Server) rejectKycSubmission(ctx *gin.Context) {
+	var req rejectKycSubmissionRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.reviewKycSubmission(ctx, db.ReviewKycSubmissionTxParams{ID: req.UriParams.ID, Reason: req.Body.Reason})
+}
+
+func (server *Server) reviewKycSubmission(ctx *gin.Context, arg db.ReviewKycSubmissionTxParams) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg.ReviewedBy = authPayload.Username
+
+	submission, err := server.store.ReviewKycSubmissionTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newKycSubmissionResponse(submission, nil))
+}
@@ -0,0 +1,592 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/blob"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testKycDocumentMaxSize = 1024
+
+// testPNG is enough of a PNG file for its type to be detected.
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), bytes.Repeat([]byte{0}, 100)...)
+
+func newTestDocumentStore(t *testing.T) blob.Store {
+	local, err := blob.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	store, err := blob.NewEncryptedStore(local, []byte("12345678901234567890123456789012"))
+	require.NoError(t, err)
+	return store
+}
+
+// kycDocumentForm builds a multipart form uploading data as a KYC document.
+func kycDocumentForm(t *testing.T, documentType string, fileName string, data []byte) (string, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	require.NoError(t, writer.WriteField("document_type", documentType))
+
+	file, err := writer.CreateFormFile("file", fileName)
+	require.NoError(t, err)
+	_, err = file.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return body.String(), writer.FormDataContentType()
+}
+
+func generateMockKycDocument(username string) db.KycDocument {
+	return db.KycDocument{
+		ID:           1,
+		Username:     username,
+		DocumentType: constants.KycDocumentPassport,
+		FileName:     "passport.png",
+		ContentType:  "image/png",
+		SizeBytes:    int64(len(testPNG)),
+		BlobKey:      "kyc/" + username + "/1",
+		CreatedAt:    time.Now(),
+	}
+}
+
+func TestUploadKycDocumentAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	user.KycStatus = constants.KycStatusUnverified
+
+	digest := sha256.Sum256(testPNG)
+	var failedKey string
+
+	testCases := []struct {
+		name          string
+		documentType  string
+		data          []byte
+		buildStubs    func(store *mockdb.MockStore, documents blob.Store)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, documents blob.Store)
+	}{
+		{
+			name:         "OK",
+			documentType: constants.KycDocumentPassport,
+			data:         testPNG,
+			buildStubs: func(store *mockdb.MockStore, documents blob.Store) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().CreateKycDocument(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreateKycDocumentParams) (db.KycDocument, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, constants.KycDocumentPassport, arg.DocumentType)
+						require.Equal(t, "passport.png", arg.FileName)
+						require.Equal(t, "image/png", arg.ContentType)
+						require.Equal(t, int64(len(testPNG)), arg.SizeBytes)
+						require.Equal(t, hex.EncodeToString(digest[:]), arg.Sha256)
+						require.True(t, strings.HasPrefix(arg.BlobKey, "kyc/"+user.Username+"/"))
+
+						stored, err := documents.Get(context.Background(), arg.BlobKey)
+						require.NoError(t, err)
+						require.Equal(t, testPNG, stored)
+
+						return db.KycDocument{
+							ID:           1,
+							Username:     arg.Username,
+							DocumentType: arg.DocumentType,
+							FileName:     arg.FileName,
+							ContentType:  arg.ContentType,
+							SizeBytes:    arg.SizeBytes,
+							Sha256:       arg.Sha256,
+							BlobKey:      arg.BlobKey,
+						}, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, documents blob.Store) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got kycDocumentResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, "image/png", got.ContentType)
+				require.Nil(t, got.SubmissionID)
+			},
+		},
+		{
+			name:         "TooLarge",
+			documentType: constants.KycDocumentPassport,
+			data:         append(testPNG, make([]byte, testKycDocumentMaxSize)...),
+			buildStubs: func(store *mockdb.MockStore, documents blob.Store) {
+				store.EXPECT().CreateKycDocument(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, documents blob.Store) {
+				require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+			},
+		},
+		{
+			name:         "UnsupportedType",
+			documentType: constants.KycDocumentPassport,
+			data:         []byte("#!/bin/sh\necho not a passport\n"),
+			buildStubs: func(store *mockdb.MockStore, documents blob.Store) {
+				store.EXPECT().CreateKycDocument(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, documents blob.Store) {
+				require.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+			},
+		},
+		{
+			name:         "InvalidDocumentType",
+			documentType: "selfie",
+			data:         testPNG,
+			buildStubs: func(store *mockdb.MockStore, documents blob.Store) {
+				store.EXPECT().CreateKycDocument(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, documents blob.Store) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:         "UnderReview",
+			documentType: constants.KycDocumentPassport,
+			data:         testPNG,
+			buildStubs: func(store *mockdb.MockStore, documents blob.Store) {
+				pending := user
+				pending.KycStatus = constants.KycStatusPending
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(pending, nil)
+				store.EXPECT().CreateKycDocument(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, documents blob.Store) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:         "InternalError",
+			documentType: constants.KycDocumentPassport,
+			data:         testPNG,
+			buildStubs: func(store *mockdb.MockStore, documents blob.Store) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().CreateKycDocument(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreateKycDocumentParams) (db.KycDocument, error) {
+						failedKey = arg.BlobKey
+						return db.KycDocument{}, sql.ErrConnDone
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, documents blob.Store) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+				// The file is removed again once its row can't be written
+				require.NotEmpty(t, failedKey)
+				_, err := documents.Get(context.Background(), failedKey)
+				require.ErrorIs(t, err, blob.ErrNotFound)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				server := newTestServer(t, store)
+				server.documents = newTestDocumentStore(t)
+				server.config.KYCDocumentMaxSize = testKycDocumentMaxSize
+				tc.buildStubs(store, server.documents)
+				recorder := httptest.NewRecorder()
+
+				body, contentType := kycDocumentForm(t, tc.documentType, "passport.png", tc.data)
+				request, err := http.NewRequest(http.MethodPost, "/kyc/documents", strings.NewReader(body))
+				require.NoError(t, err)
+				request.Header.Set("Content-Type", contentType)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder, server.documents)
+			},
+		)
+	}
+}
+
+func TestGetKycDocumentContentAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	otherUser, _ := generateMockUser(t)
+	analyst, _ := generateMockUser(t)
+	document := generateMockKycDocument(user.Username)
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Owner",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename=passport.png`, recorder.Header().Get("Content-Disposition"))
+				require.Equal(t, testPNG, recorder.Body.Bytes())
+			},
+		},
+		{
+			name: "Analyst",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, analyst.Username, constants.RoleAnalyst, time.Minute,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, testPNG, recorder.Body.Bytes())
+			},
+		},
+		{
+			name: "OtherUser",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				store.EXPECT().GetKycDocument(gomock.Any(), gomock.Eq(document.ID)).Times(1).Return(document, nil)
+
+				server := newTestServer(t, store)
+				server.documents = newTestDocumentStore(t)
+				require.NoError(t, server.documents.Put(context.Background(), document.BlobKey, testPNG))
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/kyc/documents/%d/content", document.ID)
+				request, err := http.NewRequest(http.MethodGet, url, nil)
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestDeleteKycDocumentAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	document := generateMockKycDocument(user.Username)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, documents blob.Store)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteKycDocument(gomock.Any(), gomock.Eq(document.ID)).Times(1).Return(document, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, documents blob.Store) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				_, err := documents.Get(context.Background(), document.BlobKey)
+				require.ErrorIs(t, err, blob.ErrNotFound)
+			},
+		},
+		{
+			name: "Submitted",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteKycDocument(gomock.Any(), gomock.Eq(document.ID)).Times(1).Return(
+					db.KycDocument{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, documents blob.Store) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+
+				_, err := documents.Get(context.Background(), document.BlobKey)
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				store.EXPECT().GetKycDocument(gomock.Any(), gomock.Eq(document.ID)).Times(1).Return(document, nil)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				server.documents = newTestDocumentStore(t)
+				require.NoError(t, server.documents.Put(context.Background(), document.BlobKey, testPNG))
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/kyc/documents/%d", document.ID)
+				request, err := http.NewRequest(http.MethodDelete, url, nil)
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder, server.documents)
+			},
+		)
+	}
+}
+
+func TestSubmitKycAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SubmitKycTx(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(
+					db.KycSubmission{ID: 1, Username: user.Username, Status: constants.KycStatusPending}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got kycSubmissionResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.KycStatusPending, got.Status)
+			},
+		},
+		{
+			name: "DocumentsMissing",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SubmitKycTx(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(
+					db.KycSubmission{}, db.ErrKycDocumentsMissing,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyVerified",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SubmitKycTx(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(
+					db.KycSubmission{}, db.ErrKycNotEditable,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodPost, "/kyc/submit", nil)
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestReviewKycSubmissionAPI(t *testing.T) {
+	analyst, _ := generateMockUser(t)
+	user, _ := generateMockUser(t)
+
+	asAnalyst := func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+		addRoleAuthorization(
+			t, req, tokenMaker, authorizationTypeBearer, analyst.Username, constants.RoleAnalyst, time.Minute,
+		)
+	}
+
+	testCases := []struct {
+		name          string
+		url           string
+		body          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "Approve",
+			url:       "/kyc/submissions/1/approve",
+			body:      `{"tier": "premium"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReviewKycSubmissionTxParams{
+					ID:         1,
+					ReviewedBy: analyst.Username,
+					Approve:    true,
+					Tier:       constants.UserTierPremium,
+				}
+
+				store.EXPECT().ReviewKycSubmissionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					db.KycSubmission{
+						ID:         1,
+						Username:   user.Username,
+						Status:     constants.KycStatusVerified,
+						ReviewedBy: sql.NullString{String: analyst.Username, Valid: true},
+						ReviewedAt: sql.NullTime{Time: time.Now(), Valid: true},
+					}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got kycSubmissionResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.KycStatusVerified, got.Status)
+				require.NotNil(t, got.ReviewedAt)
+				require.Nil(t, got.RejectionReason)
+			},
+		},
+		{
+			name:      "UnverifiedTier",
+			url:       "/kyc/submissions/1/approve",
+			body:      `{"tier": "unverified"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewKycSubmissionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "Reject",
+			url:       "/kyc/submissions/1/reject",
+			body:      `{"reason": "Passport has expired"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReviewKycSubmissionTxParams{
+					ID:         1,
+					ReviewedBy: analyst.Username,
+					Reason:     "Passport has expired",
+				}
+
+				store.EXPECT().ReviewKycSubmissionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					db.KycSubmission{
+						ID:              1,
+						Status:          constants.KycStatusRejected,
+						RejectionReason: sql.NullString{String: "Passport has expired", Valid: true},
+					}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "NoReason",
+			url:       "/kyc/submissions/1/reject",
+			body:      `{}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewKycSubmissionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "NotPending",
+			url:       "/kyc/submissions/1/approve",
+			body:      `{}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewKycSubmissionTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.KycSubmission{}, db.ErrKycSubmissionNotPending,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "Customer",
+			url:  "/kyc/submissions/1/approve",
+			body: `{}`,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewKycSubmissionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodPost, tc.url, strings.NewReader(tc.body))
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
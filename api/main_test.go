@@ -1,6 +1,8 @@
 package api
 
 import (
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
 	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
 	"github.com/CrunchyBlue/Golang-Bank/util"
 	"github.com/gin-gonic/gin"
@@ -16,10 +18,11 @@ func generateMockUser(t *testing.T) (user db.User, password string) {
 	require.NoError(t, err)
 
 	user = db.User{
-		Username:       util.RandomOwner(),
-		HashedPassword: hashedPassword,
-		FullName:       util.RandomOwner(),
-		Email:          util.RandomEmail(),
+		Username:        util.RandomOwner(),
+		HashedPassword:  hashedPassword,
+		FullName:        util.RandomOwner(),
+		Email:           util.RandomEmail(),
+		ScreeningStatus: constants.ScreeningStatusClear,
 	}
 	return
 }
@@ -30,10 +33,12 @@ func generateMockAccounts(owner string, numAccounts int) []db.Account {
 	for i := 0; i < numAccounts; i++ {
 		accounts = append(
 			accounts, db.Account{
-				ID:       util.RandomInt(1, 1000),
-				Owner:    owner,
-				Balance:  util.RandomInt(0, 1000),
-				Currency: util.RandomCurrency(),
+				ID:            util.RandomInt(1, 1000),
+				Owner:         owner,
+				Balance:       util.RandomInt(0, 1000),
+				Currency:      util.RandomCurrency(),
+				Status:        constants.AccountStatusActive,
+				AccountNumber: util.RandomAccountNumber(),
 			},
 		)
 	}
@@ -71,6 +76,48 @@ func generateMockTransfers(numTransfers int, sourceAccountID int64, destinationA
 	return transfers
 }
 
+func generateMockHold(accountID int64, destinationAccountID int64) db.Hold {
+	return db.Hold{
+		ID:                   util.RandomInt(1, 1000),
+		AccountID:            accountID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               util.RandomInt(1, 1000),
+		Status:               constants.HoldStatusActive,
+		ExpiresAt:            time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+	}
+}
+
+func generateMockScheduledTransfer(
+	owner string, sourceAccount db.Account, destinationAccountID int64,
+) db.ScheduledTransfer {
+	nextOccurrenceAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	return db.ScheduledTransfer{
+		ID:                   util.RandomInt(1, 1000),
+		Owner:                owner,
+		SourceAccountID:      sourceAccount.ID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               util.RandomInt(1, 1000),
+		Currency:             sourceAccount.Currency,
+		Frequency:            constants.FrequencyWeekly,
+		NextOccurrenceAt:     nextOccurrenceAt,
+		NextAttemptAt:        nextOccurrenceAt,
+		Status:               constants.ScheduledTransferStatusActive,
+	}
+}
+
+func generateMockFee(accountID int64, transferID int64) db.Fee {
+	return db.Fee{
+		ID:            util.RandomInt(1, 1000),
+		AccountID:     accountID,
+		Kind:          constants.FeeKindTransfer,
+		Name:          "cross_currency_transfer",
+		Amount:        util.RandomInt(1, 100),
+		TransferID:    sql.NullInt64{Int64: transferID, Valid: true},
+		FeeTransferID: sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true},
+	}
+}
+
 func generateMockLogin() {
 
 }
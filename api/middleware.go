@@ -1,11 +1,15 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
 	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/CrunchyBlue/Golang-Bank/util"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -49,3 +53,58 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// requireRole rejects requests whose token wasn't issued to one of the given roles. It must run after authMiddleware.
+func requireRole(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		for _, role := range roles {
+			if payload.Role == role {
+				ctx.Next()
+				return
+			}
+		}
+
+		err := fmt.Errorf("role %q is not allowed to perform this action", payload.Role)
+		ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+	}
+}
+
+// resolveAccountParam lets a route name an account by its account number or IBAN as well as by its ID. When the param
+// holds either, it is replaced with the account's ID before the handler binds it.
+func (server *Server) resolveAccountParam(name string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value := ctx.Param(name)
+		if !isAccountNumberOrIBAN(value) {
+			ctx.Next()
+			return
+		}
+
+		account, err := server.getAccountByNumber(ctx, value)
+		if err != nil {
+			ctx.AbortWithStatusJSON(errorStatus(err), errorResponse(err))
+			return
+		}
+
+		for i := range ctx.Params {
+			if ctx.Params[i].Key == name {
+				ctx.Params[i].Value = strconv.FormatInt(account.ID, 10)
+			}
+		}
+		ctx.Next()
+	}
+}
+
+func isAccountNumberOrIBAN(value string) bool {
+	return util.ValidAccountNumber(value) || util.ValidIBAN(util.NormalizeIBAN(value))
+}
+
+// getAccountByNumber looks an account up by its account number, or by its IBAN when number isn't an account number.
+func (server *Server) getAccountByNumber(ctx *gin.Context, number string) (db.Account, error) {
+	if util.ValidAccountNumber(number) {
+		return server.store.GetAccountByNumber(ctx, number)
+	}
+
+	iban := sql.NullString{String: util.NormalizeIBAN(number), Valid: true}
+	return server.store.GetAccountByIban(ctx, iban)
+}
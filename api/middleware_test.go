@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
 	"github.com/CrunchyBlue/Golang-Bank/token"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -15,7 +16,14 @@ func addAuthorization(
 	t *testing.T, req *http.Request, tokenMaker token.Maker, authorizationType string, username string,
 	duration time.Duration,
 ) {
-	accessToken, payload, err := tokenMaker.CreateToken(username, duration)
+	addRoleAuthorization(t, req, tokenMaker, authorizationType, username, constants.RoleCustomer, duration)
+}
+
+func addRoleAuthorization(
+	t *testing.T, req *http.Request, tokenMaker token.Maker, authorizationType string, username string, role string,
+	duration time.Duration,
+) {
+	accessToken, payload, err := tokenMaker.CreateToken(username, role, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"errors"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+)
+
+type multiTransferTxResponse struct {
+	Transfers           []transferResponse `json:"transfers"`
+	SourceAccount       accountResponse    `json:"source_account"`
+	DestinationAccounts []accountResponse  `json:"destination_accounts"`
+	Entries             []entryResponse    `json:"entries"`
+	Fees                []feeResponse      `json:"fees"`
+}
+
+func newMultiTransferTxResponse(result db.MultiTransferTxResult, currencyCode string) multiTransferTxResponse {
+	res := multiTransferTxResponse{
+		Transfers:           make([]transferResponse, len(result.Transfers)),
+		SourceAccount:       newAccountResponse(result.SourceAccount),
+		DestinationAccounts: make([]accountResponse, len(result.DestinationAccounts)),
+		Entries:             make([]entryResponse, len(result.Entries)),
+		Fees:                newFeeResponses(result.Fees, currencyCode),
+	}
+	for i, transfer := range result.Transfers {
+		res.Transfers[i] = newTransferResponse(transfer, currencyCode)
+	}
+	for i, account := range result.DestinationAccounts {
+		res.DestinationAccounts[i] = newAccountResponse(account)
+	}
+	for i, entry := range result.Entries {
+		res.Entries[i] = newEntryResponse(entry, currencyCode)
+	}
+	return res
+}
+
+type transferLegRequest struct {
+	DestinationAccountID int64 `json:"destination_account_id" binding:"required_without=DestinationAccountNumber,excluded_with=DestinationAccountNumber,omitempty,min=1"`
+	// Account number or IBAN of the destination account, in place of its ID.
+	DestinationAccountNumber string `json:"destination_account_number" binding:"omitempty,account_number"`
+	Amount                   int64  `json:"amount" binding:"required,min=1"`
+	remittanceRequest
+}
+
+type createMultiTransferRequest struct {
+	SourceAccountID int64 `json:"source_account_id" binding:"required_without=SourceAccountNumber,excluded_with=SourceAccountNumber,omitempty,min=1"`
+	// Account number or IBAN of the source account, in place of its ID.
+	SourceAccountNumber string               `json:"source_account_number" binding:"omitempty,account_number"`
+	Currency            string               `json:"currency" binding:"required,currency"`
+	Legs                []transferLegRequest `json:"legs" binding:"required,min=2,max=20,dive"`
+}
+
+// createMultiTransfer splits one payment from a source account between several destinations, each with its own
+// remittance. Either every leg is transferred or none is.
+func (server *Server) createMultiTransfer(ctx *gin.Context) {
+	var req createMultiTransferRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.SourceAccountNumber != "" {
+		sourceAccount, err := server.getAccountByNumber(ctx, req.SourceAccountNumber)
+		if err != nil {
+			ctx.JSON(errorStatus(err), errorResponse(err))
+			return
+		}
+		req.SourceAccountID = sourceAccount.ID
+	}
+
+	sourceAccount, valid := server.validateAccount(ctx, req.SourceAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	var total int64
+	for _, leg := range req.Legs {
+		if leg.Amount > math.MaxInt64-total {
+			ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrMultiTransferOverflow))
+			return
+		}
+		total += leg.Amount
+	}
+
+	// Spending limits and approval thresholds apply to the payment as a whole, not to each leg
+	if !server.authorizeAccount(ctx, sourceAccount, accountActionSpend, total) ||
+		!server.authorizeWithoutApproval(ctx, sourceAccount, total) {
+		return
+	}
+
+	arg := db.MultiTransferTxParams{SourceAccountID: sourceAccount.ID}
+	// Legs to the same account count together towards its cooling-off limit, so that it can't be got around by
+	// splitting a leg in two
+	sent := make(map[int64]int64, len(req.Legs))
+	for _, leg := range req.Legs {
+		if leg.DestinationAccountNumber != "" {
+			destinationAccount, err := server.getAccountByNumber(ctx, leg.DestinationAccountNumber)
+			if err != nil {
+				ctx.JSON(errorStatus(err), errorResponse(err))
+				return
+			}
+			leg.DestinationAccountID = destinationAccount.ID
+		}
+
+		if leg.DestinationAccountID == sourceAccount.ID {
+			err := errors.New("destination account must not be the source account")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		destinationAccount, valid := server.validateAccount(ctx, leg.DestinationAccountID, req.Currency)
+		if !valid {
+			return
+		}
+		sent[destinationAccount.ID] += leg.Amount
+		if !server.authorizeDestination(ctx, destinationAccount, sent[destinationAccount.ID]) {
+			return
+		}
+
+		arg.Legs = append(
+			arg.Legs, db.TransferLeg{
+				DestinationAccountID: leg.DestinationAccountID,
+				Amount:               leg.Amount,
+				Remittance:           leg.remittance(),
+			},
+		)
+	}
+
+	result, err := server.store.MultiTransferTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newMultiTransferTxResponse(result, req.Currency))
+}
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateMultiTransferAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	otherUser, _ := generateMockUser(t)
+	source := generateMockAccounts(user.Username, 1)[0]
+	seller := generateMockAccounts(otherUser.Username, 1)[0]
+	platform := generateMockAccounts(otherUser.Username, 1)[0]
+	source.ID, seller.ID, platform.ID = 1, 2, 3
+	source.Currency, seller.Currency, platform.Currency = constants.USD, constants.USD, constants.USD
+
+	result := db.MultiTransferTxResult{
+		Transfers: []db.Transfer{
+			{ID: 1, SourceAccountID: source.ID, DestinationAccountID: seller.ID, Amount: 45},
+			{ID: 2, SourceAccountID: source.ID, DestinationAccountID: platform.ID, Amount: 5},
+		},
+		Entries: []db.Entry{
+			{ID: 1, AccountID: source.ID, Amount: -45},
+			{ID: 2, AccountID: seller.ID, Amount: 45},
+			{ID: 3, AccountID: source.ID, Amount: -5},
+			{ID: 4, AccountID: platform.ID, Amount: 5},
+		},
+		SourceAccount:       source,
+		DestinationAccounts: []db.Account{seller, platform},
+	}
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "currency": "USD", "legs": [`+
+					`{"destination_account_id": %d, "amount": 45, "memo": "Order 1001"}, `+
+					`{"destination_account_number": "%s", "amount": 5, "memo": "Commission"}]}`,
+				source.ID, seller.ID, platform.AccountNumber,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(seller.ID)).Times(1).Return(seller, nil)
+				expectClearDestination(store, seller)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(platform.AccountNumber)).Times(1).Return(
+					platform, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(platform.ID)).Times(1).Return(platform, nil)
+				expectClearDestination(store, platform)
+				store.EXPECT().MultiTransferTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.MultiTransferTxParams) (db.MultiTransferTxResult, error) {
+						require.Equal(t, source.ID, arg.SourceAccountID)
+						require.Len(t, arg.Legs, 2)
+						require.Equal(t, seller.ID, arg.Legs[0].DestinationAccountID)
+						require.Equal(t, int64(45), arg.Legs[0].Amount)
+						require.Equal(t, sql.NullString{String: "Order 1001", Valid: true}, arg.Legs[0].Memo)
+						require.Equal(t, platform.ID, arg.Legs[1].DestinationAccountID)
+						require.Equal(t, sql.NullString{String: "Commission", Valid: true}, arg.Legs[1].Memo)
+						return result, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got multiTransferTxResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got.Transfers, 2)
+				require.Len(t, got.DestinationAccounts, 2)
+				require.Len(t, got.Entries, 4)
+			},
+		},
+		{
+			name: "SourceAccountNumber",
+			body: fmt.Sprintf(
+				`{"source_account_number": "%s", "currency": "USD", "legs": [`+
+					`{"destination_account_id": %d, "amount": 45}, {"destination_account_id": %d, "amount": 5}]}`,
+				source.AccountNumber, seller.ID, platform.ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(source.AccountNumber)).Times(1).Return(
+					source, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(seller.ID)).Times(1).Return(seller, nil)
+				expectClearDestination(store, seller)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(platform.ID)).Times(1).Return(platform, nil)
+				expectClearDestination(store, platform)
+				store.EXPECT().MultiTransferTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.MultiTransferTxParams) (db.MultiTransferTxResult, error) {
+						require.Equal(t, source.ID, arg.SourceAccountID)
+						return result, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SingleLeg",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "currency": "USD", "legs": [{"destination_account_id": %d, "amount": 45}]}`,
+				source.ID, seller.ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().MultiTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "LegToSource",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "currency": "USD", "legs": [`+
+					`{"destination_account_id": %d, "amount": 45}, {"destination_account_id": %d, "amount": 5}]}`,
+				source.ID, seller.ID, source.ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(seller.ID)).Times(1).Return(seller, nil)
+				expectClearDestination(store, seller)
+				store.EXPECT().MultiTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "HeldPayeeLeg",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "currency": "USD", "legs": [`+
+					`{"destination_account_id": %d, "amount": 45}, {"destination_account_id": %d, "amount": 5}]}`,
+				source.ID, seller.ID, platform.ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				payee := generateMockPayee(user.Username, platform, time.Now().Add(-time.Hour))
+				payee.ScreeningStatus = constants.ScreeningStatusPendingReview
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(seller.ID)).Times(1).Return(seller, nil)
+				expectClearDestination(store, seller)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(platform.ID)).Times(1).Return(platform, nil)
+				expectPayeeDestination(store, platform, otherUser, payee)
+				store.EXPECT().MultiTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "TotalOverflow",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "currency": "USD", "legs": [`+
+					`{"destination_account_id": %d, "amount": %d}, {"destination_account_id": %d, "amount": %d}]}`,
+				source.ID, seller.ID, int64(math.MaxInt64), platform.ID, int64(math.MaxInt64),
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().MultiTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "CoolingOffPayeeSplitLegs",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "currency": "USD", "legs": [`+
+					`{"destination_account_id": %d, "amount": 45}, {"destination_account_id": %d, "amount": 45}]}`,
+				source.ID, seller.ID, seller.ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				payee := generateMockPayee(user.Username, seller, time.Now().Add(time.Hour))
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(seller.ID)).Times(2).Return(seller, nil)
+				expectPayeeDestination(store, seller, otherUser, payee)
+				expectPayeeDestination(store, seller, otherUser, payee)
+				store.EXPECT().MultiTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "currency": "USD", "legs": [`+
+					`{"destination_account_id": %d, "amount": 45}, {"destination_account_id": %d, "amount": 5}]}`,
+				source.ID, seller.ID, platform.ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(seller.ID)).Times(1).Return(seller, nil)
+				expectClearDestination(store, seller)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(platform.ID)).Times(1).Return(platform, nil)
+				expectClearDestination(store, platform)
+				store.EXPECT().MultiTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.MultiTransferTxResult{}, db.ErrInsufficientFunds,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "NotSourceMember",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "currency": "USD", "legs": [`+
+					`{"destination_account_id": %d, "amount": 45}, {"destination_account_id": %d, "amount": 5}]}`,
+				seller.ID, source.ID, platform.ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(seller.ID)).Times(1).Return(seller, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+				store.EXPECT().MultiTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				server.config.PayeeCoolingOffLimit = 50
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodPost, "/transfer/split", bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
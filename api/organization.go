@@ -0,0 +1,399 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"net/http"
+)
+
+var (
+	errNotOrganizationMember  = errors.New("organization doesn't include the authenticated user")
+	errOrganizationRoleDenied = errors.New("organization role doesn't allow this action")
+	errLastOrganizationAdmin  = errors.New("an organization needs at least one admin")
+	errApprovalRequired       = errors.New("amount is over the organization's approval threshold, send it as a transfer")
+)
+
+// authorizeOrganization checks that the caller is a member of the organization with one of roles, or any role if none
+// are given, writing the error response if not.
+func (server *Server) authorizeOrganization(ctx *gin.Context, organizationID int64, roles ...string) (
+	db.OrganizationMember, bool,
+) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	member, err := server.store.GetOrganizationMember(
+		ctx, db.GetOrganizationMemberParams{OrganizationID: organizationID, Username: authPayload.Username},
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errNotOrganizationMember))
+			return member, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return member, false
+	}
+
+	if len(roles) == 0 {
+		return member, true
+	}
+	for _, role := range roles {
+		if member.Role == role {
+			return member, true
+		}
+	}
+
+	ctx.JSON(http.StatusForbidden, errorResponse(errOrganizationRoleDenied))
+	return member, false
+}
+
+// getSpendingOrganization loads the organization that owns account, or returns a zero organization for a personal
+// account.
+func (server *Server) getSpendingOrganization(ctx *gin.Context, account db.Account) (db.Organization, error) {
+	if !account.OrganizationID.Valid {
+		return db.Organization{}, nil
+	}
+	return server.store.GetOrganization(ctx, account.OrganizationID.Int64)
+}
+
+// requiresApproval reports whether spending amount from one of organization's accounts needs approvers to sign off.
+func requiresApproval(organization db.Organization, amount int64) bool {
+	return organization.ID != 0 && organization.RequiredApprovals > 0 && amount > organization.ApprovalThreshold
+}
+
+// authorizeWithoutApproval rejects spends over the approval threshold from organization accounts, for the ways of
+// moving money, such as holds and scheduled transfers, that can't wait for approvers.
+func (server *Server) authorizeWithoutApproval(ctx *gin.Context, account db.Account, amount int64) bool {
+	organization, err := server.getSpendingOrganization(ctx, account)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return false
+	}
+	if requiresApproval(organization, amount) {
+		ctx.JSON(http.StatusForbidden, errorResponse(errApprovalRequired))
+		return false
+	}
+	return true
+}
+
+type createOrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	// Transfers of more than this need approval. Zero puts every transfer through approval.
+	ApprovalThreshold int64 `json:"approval_threshold" binding:"min=0"`
+	// Distinct approvers a transfer over the threshold needs. Zero turns approval off.
+	RequiredApprovals int32 `json:"required_approvals" binding:"min=0,max=10"`
+}
+
+// createOrganization creates an organization with the caller as its first admin. An organization whose name is on the
+// watchlist is held until the hits are reviewed, which stops its accounts from sending and receiving money.
+func (server *Server) createOrganization(ctx *gin.Context) {
+	var req createOrganizationRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateOrganizationParams{
+		Name:              req.Name,
+		ApprovalThreshold: req.ApprovalThreshold,
+		RequiredApprovals: req.RequiredApprovals,
+		CreatedBy:         authPayload.Username,
+	}
+
+	organization, err := server.store.CreateOrganizationTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	if matches := server.screener.Screen(organization.Name); len(matches) > 0 {
+		_, err = server.store.RecordScreeningHitsTx(
+			ctx, db.RecordScreeningHitsTxParams{
+				Username:       organization.CreatedBy,
+				OrganizationID: sql.NullInt64{Int64: organization.ID, Valid: true},
+				ScreenedName:   organization.Name,
+				Matches:        matches,
+			},
+		)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		organization.ScreeningStatus = constants.ScreeningStatusPendingReview
+	}
+
+	ctx.JSON(http.StatusOK, organization)
+}
+
+type getOrganizationsRequest struct {
+	PageNumber int32 `form:"page_number" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+// getOrganizations lists the organizations the caller is a member of.
+func (server *Server) getOrganizations(ctx *gin.Context) {
+	var req getOrganizationsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.GetOrganizationsForUserParams{
+		Username: authPayload.Username,
+		Limit:    req.PageSize,
+		Offset:   (req.PageNumber - 1) * req.PageSize,
+	}
+
+	organizations, err := server.store.GetOrganizationsForUser(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organizations)
+}
+
+type organizationRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getOrganization(ctx *gin.Context) {
+	var req organizationRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.authorizeOrganization(ctx, req.ID); !valid {
+		return
+	}
+
+	organization, err := server.store.GetOrganization(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organization)
+}
+
+type updateOrganizationApprovalRuleBody struct {
+	ApprovalThreshold int64 `json:"approval_threshold" binding:"min=0"`
+	RequiredApprovals int32 `json:"required_approvals" binding:"min=0,max=10"`
+}
+
+type updateOrganizationApprovalRuleRequest struct {
+	UriParams organizationRequest
+	Body      updateOrganizationApprovalRuleBody
+}
+
+// updateOrganizationApprovalRule changes the maker-checker rule. Transfers already pending keep the number of
+// approvals they were created with.
+func (server *Server) updateOrganizationApprovalRule(ctx *gin.Context) {
+	var req updateOrganizationApprovalRuleRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.authorizeOrganization(ctx, req.UriParams.ID, constants.OrganizationRoleAdmin); !valid {
+		return
+	}
+
+	arg := db.UpdateOrganizationApprovalRuleParams{
+		ID:                req.UriParams.ID,
+		ApprovalThreshold: req.Body.ApprovalThreshold,
+		RequiredApprovals: req.Body.RequiredApprovals,
+	}
+
+	organization, err := server.store.UpdateOrganizationApprovalRule(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organization)
+}
+
+func (server *Server) getOrganizationMembers(ctx *gin.Context) {
+	var req organizationRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.authorizeOrganization(ctx, req.ID); !valid {
+		return
+	}
+
+	members, err := server.store.GetOrganizationMembers(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, members)
+}
+
+type addOrganizationMemberBody struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Role     string `json:"role" binding:"required,oneof=admin initiator approver"`
+}
+
+type addOrganizationMemberRequest struct {
+	UriParams organizationRequest
+	Body      addOrganizationMemberBody
+}
+
+func (server *Server) addOrganizationMember(ctx *gin.Context) {
+	var req addOrganizationMemberRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.authorizeOrganization(ctx, req.UriParams.ID, constants.OrganizationRoleAdmin); !valid {
+		return
+	}
+
+	arg := db.CreateOrganizationMemberParams{
+		OrganizationID: req.UriParams.ID,
+		Username:       req.Body.Username,
+		Role:           req.Body.Role,
+	}
+
+	member, err := server.store.CreateOrganizationMember(ctx, arg)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code.Name() {
+			case constants.ForeignKeyViolation:
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			case constants.UniqueViolation:
+				ctx.JSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+type removeOrganizationMemberRequest struct {
+	ID       int64  `uri:"id" binding:"required,min=1"`
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+// removeOrganizationMember lets admins remove members, and any member leave, as long as an admin is left.
+func (server *Server) removeOrganizationMember(ctx *gin.Context) {
+	var req removeOrganizationMemberRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	var roles []string
+	if req.Username != authPayload.Username {
+		roles = []string{constants.OrganizationRoleAdmin}
+	}
+
+	if _, valid := server.authorizeOrganization(ctx, req.ID, roles...); !valid {
+		return
+	}
+
+	member, err := server.store.GetOrganizationMember(
+		ctx, db.GetOrganizationMemberParams{OrganizationID: req.ID, Username: req.Username},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	if member.Role == constants.OrganizationRoleAdmin {
+		admins, err := server.store.CountOrganizationMembersByRole(
+			ctx, db.CountOrganizationMembersByRoleParams{
+				OrganizationID: req.ID,
+				Role:           constants.OrganizationRoleAdmin,
+			},
+		)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if admins <= 1 {
+			ctx.JSON(http.StatusConflict, errorResponse(errLastOrganizationAdmin))
+			return
+		}
+	}
+
+	member, err = server.store.DeleteOrganizationMember(
+		ctx, db.DeleteOrganizationMemberParams{OrganizationID: req.ID, Username: req.Username},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+type createOrganizationAccountRequest struct {
+	UriParams organizationRequest
+	Body      createAccountRequest
+}
+
+// createOrganizationAccount opens an account owned by the organization. The admin who opens it is recorded as its
+// owner, but only their organization role gives them access, and the account doesn't count towards their own limits.
+func (server *Server) createOrganizationAccount(ctx *gin.Context) {
+	var req createOrganizationAccountRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	member, valid := server.authorizeOrganization(ctx, req.UriParams.ID, constants.OrganizationRoleAdmin)
+	if !valid {
+		return
+	}
+
+	arg, err := server.newCreateAccountParams(member.Username, req.Body)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	arg.OrganizationID = sql.NullInt64{Int64: req.UriParams.ID, Valid: true}
+
+	server.openAccount(ctx, db.CreateAccountTxParams{CreateAccountParams: arg})
+}
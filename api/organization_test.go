@@ -0,0 +1,345 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func generateMockOrganization(createdBy string) db.Organization {
+	return db.Organization{
+		ID:                util.RandomInt(1, 1000),
+		Name:              util.RandomOwner(),
+		ApprovalThreshold: 1000,
+		RequiredApprovals: 2,
+		CreatedBy:         createdBy,
+		CreatedAt:         time.Now(),
+	}
+}
+
+func generateMockOrganizationMember(organization db.Organization, username string, role string) db.OrganizationMember {
+	return db.OrganizationMember{
+		OrganizationID: organization.ID,
+		Username:       username,
+		Role:           role,
+		CreatedAt:      time.Now(),
+	}
+}
+
+func TestCreateOrganizationAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	organization := generateMockOrganization(user.Username)
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: fmt.Sprintf(
+				`{"name": "%s", "approval_threshold": 1000, "required_approvals": 2}`, organization.Name,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateOrganizationParams{
+					Name:              organization.Name,
+					ApprovalThreshold: 1000,
+					RequiredApprovals: 2,
+					CreatedBy:         user.Username,
+				}
+
+				store.EXPECT().CreateOrganizationTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(organization, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Organization
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, organization.ID, got.ID)
+				require.Equal(t, user.Username, got.CreatedBy)
+			},
+		},
+		{
+			name: "TooManyApprovals",
+			body: fmt.Sprintf(`{"name": "%s", "required_approvals": 11}`, organization.Name),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateOrganizationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeThreshold",
+			body: fmt.Sprintf(`{"name": "%s", "approval_threshold": -1}`, organization.Name),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateOrganizationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodPost, "/organizations", bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestRemoveOrganizationMemberAPI(t *testing.T) {
+	admin, _ := generateMockUser(t)
+	approver, _ := generateMockUser(t)
+	organization := generateMockOrganization(admin.Username)
+	adminMember := generateMockOrganizationMember(organization, admin.Username, constants.OrganizationRoleAdmin)
+	approverMember := generateMockOrganizationMember(organization, approver.Username, constants.OrganizationRoleApprover)
+
+	testCases := []struct {
+		name          string
+		username      string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: approver.Username,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetOrganizationMemberParams{OrganizationID: organization.ID, Username: approver.Username}
+
+				store.EXPECT().GetOrganizationMember(
+					gomock.Any(), gomock.Eq(
+						db.GetOrganizationMemberParams{OrganizationID: organization.ID, Username: admin.Username},
+					),
+				).Times(1).Return(adminMember, nil)
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Eq(arg)).Times(1).Return(approverMember, nil)
+				store.EXPECT().CountOrganizationMembersByRole(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteOrganizationMember(
+					gomock.Any(), gomock.Eq(
+						db.DeleteOrganizationMemberParams{OrganizationID: organization.ID, Username: approver.Username},
+					),
+				).Times(1).Return(approverMember, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "Leave",
+			username: approver.Username,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, approver.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(2).Return(approverMember, nil)
+				store.EXPECT().DeleteOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(approverMember, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "NotAdmin",
+			username: admin.Username,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, approver.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(approverMember, nil)
+				store.EXPECT().DeleteOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "NotMember",
+			username: approver.Username,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "outsider", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.OrganizationMember{}, sql.ErrNoRows,
+				)
+				store.EXPECT().DeleteOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "LastAdmin",
+			username: admin.Username,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CountOrganizationMembersByRoleParams{
+					OrganizationID: organization.ID,
+					Role:           constants.OrganizationRoleAdmin,
+				}
+
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(2).Return(adminMember, nil)
+				store.EXPECT().CountOrganizationMembersByRole(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(1), nil)
+				store.EXPECT().DeleteOrganizationMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/organizations/%d/members/%s", organization.ID, tc.username)
+				request, err := http.NewRequest(http.MethodDelete, url, nil)
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestCreateOrganizationAccountAPI(t *testing.T) {
+	admin, _ := generateMockUser(t)
+	initiator, _ := generateMockUser(t)
+	organization := generateMockOrganization(admin.Username)
+	account := generateMockAccounts(admin.Username, 1)[0]
+	account.Currency = constants.USD
+	account.OrganizationID = sql.NullInt64{Int64: organization.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				member := generateMockOrganizationMember(organization, admin.Username, constants.OrganizationRoleAdmin)
+
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(member, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreateAccountTxParams) (db.Account, error) {
+						require.Equal(t, admin.Username, arg.Owner)
+						require.Equal(t, account.OrganizationID, arg.OrganizationID)
+						require.Zero(t, arg.MaxAccounts)
+						require.Zero(t, arg.MaxAccountsPerCurrency)
+						return account, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got accountResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.NotNil(t, got.OrganizationID)
+				require.Equal(t, organization.ID, *got.OrganizationID)
+			},
+		},
+		{
+			name: "NotAdmin",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, initiator.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				member := generateMockOrganizationMember(
+					organization, initiator.Username, constants.OrganizationRoleInitiator,
+				)
+
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(member, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/organizations/%d/accounts", organization.ID)
+				body := []byte(`{"currency": "USD"}`)
+				request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
@@ -0,0 +1,387 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"net/http"
+	"time"
+)
+
+var (
+	errPayeeCoolingOff       = errors.New("payee was added recently, amount is over the limit for new payees")
+	errPayeeScreeningHold    = errors.New("payee is on hold pending sanctions screening review")
+	errDestinationCoolingOff = errors.New(
+		"destination hasn't been paid before the cooling-off period, amount is over the limit for new payees",
+	)
+)
+
+type payeeResponse struct {
+	ID           int64   `json:"id"`
+	Nickname     string  `json:"nickname"`
+	AccountID    int64   `json:"account_id"`
+	Currency     string  `json:"currency"`
+	ExpectedName *string `json:"expected_name"`
+	// Only set when expected_name is
+	NameCheck       *string   `json:"name_check"`
+	CoolingOffUntil time.Time `json:"cooling_off_until"`
+	ScreeningStatus string    `json:"screening_status"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func newPayeeResponse(payee db.Payee) payeeResponse {
+	return payeeResponse{
+		ID:              payee.ID,
+		Nickname:        payee.Nickname,
+		AccountID:       payee.AccountID,
+		Currency:        payee.Currency,
+		ExpectedName:    nullStringPointer(payee.ExpectedName),
+		NameCheck:       nullStringPointer(payee.NameCheck),
+		CoolingOffUntil: payee.CoolingOffUntil,
+		ScreeningStatus: payee.ScreeningStatus,
+		CreatedAt:       payee.CreatedAt,
+	}
+}
+
+// loadPayee loads a payee, checking that it belongs to the authenticated user.
+func (server *Server) loadPayee(ctx *gin.Context, id int64) (db.Payee, bool) {
+	payee, err := server.store.GetPayee(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return payee, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if payee.Owner != authPayload.Username {
+		err := errors.New("payee doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return payee, false
+	}
+
+	return payee, true
+}
+
+// checkDestination reports whether amount may be sent to destination, and the status to respond with if not. The
+// checks go by the account itself, so they apply however the destination was given: transfers are refused while the
+// account's holder, or the authenticated user's payee for the account, is held by sanctions screening, and only
+// transfers up to the configured limit are allowed while that payee is cooling off. A destination that isn't a payee
+// cools off the same way until the user has paid it for a cooling-off period, so deleting a payee, or never adding
+// one, doesn't get around the limit. The holder is the organization for an organization's account, and otherwise the
+// owner. The store checks the holder again whenever money actually moves, which covers transfers made later, such as
+// scheduled ones.
+func (server *Server) checkDestination(ctx *gin.Context, destination db.Account, amount int64) (int, error) {
+	screeningStatus, err := server.accountHolderScreeningStatus(ctx, destination)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if screeningStatus != constants.ScreeningStatusClear {
+		return http.StatusForbidden, db.ErrDestinationScreeningHold
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	payee, err := server.store.GetPayeeByAccount(
+		ctx, db.GetPayeeByAccountParams{Owner: authPayload.Username, AccountID: destination.ID},
+	)
+	if err == nil {
+		if payee.ScreeningStatus != constants.ScreeningStatusClear {
+			return http.StatusForbidden, errPayeeScreeningHold
+		}
+		if time.Now().Before(payee.CoolingOffUntil) && amount > server.config.PayeeCoolingOffLimit {
+			return http.StatusForbidden, errPayeeCoolingOff
+		}
+		return http.StatusOK, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return http.StatusInternalServerError, err
+	}
+
+	if amount <= server.config.PayeeCoolingOffLimit || destination.Owner == authPayload.Username {
+		return http.StatusOK, nil
+	}
+
+	paid, err := server.store.CountTransfersToAccountBefore(
+		ctx, db.CountTransfersToAccountBeforeParams{
+			Owner:                authPayload.Username,
+			DestinationAccountID: destination.ID,
+			CreatedAt:            time.Now().Add(-server.config.PayeeCoolingOffPeriod),
+		},
+	)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if paid == 0 {
+		return http.StatusForbidden, errDestinationCoolingOff
+	}
+
+	return http.StatusOK, nil
+}
+
+// authorizeDestination is checkDestination for handlers, writing the error response when funds may not be sent.
+func (server *Server) authorizeDestination(ctx *gin.Context, destination db.Account, amount int64) bool {
+	status, err := server.checkDestination(ctx, destination, amount)
+	if err != nil {
+		ctx.JSON(status, errorResponse(err))
+		return false
+	}
+	return true
+}
+
+// accountHolderName is the name an account is held under: its organization's for an organization account, otherwise
+// its owner's full name.
+func (server *Server) accountHolderName(ctx *gin.Context, account db.Account) (string, error) {
+	if account.OrganizationID.Valid {
+		organization, err := server.store.GetOrganization(ctx, account.OrganizationID.Int64)
+		return organization.Name, err
+	}
+
+	owner, err := server.store.GetUser(ctx, account.Owner)
+	return owner.FullName, err
+}
+
+// accountHolderScreeningStatus is the screening status of whoever an account is held by: its organization for an
+// organization account, otherwise its owner.
+func (server *Server) accountHolderScreeningStatus(ctx *gin.Context, account db.Account) (string, error) {
+	if account.OrganizationID.Valid {
+		organization, err := server.store.GetOrganization(ctx, account.OrganizationID.Int64)
+		return organization.ScreeningStatus, err
+	}
+
+	owner, err := server.store.GetUser(ctx, account.Owner)
+	return owner.ScreeningStatus, err
+}
+
+type getPayeesRequest struct {
+	PageNumber int32 `form:"page_number" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+func (server *Server) getPayees(ctx *gin.Context) {
+	var req getPayeesRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.GetPayeesParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageNumber - 1) * req.PageSize,
+	}
+
+	payees, err := server.store.GetPayees(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]payeeResponse, len(payees))
+	for i, payee := range payees {
+		res[i] = newPayeeResponse(payee)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type createPayeeRequest struct {
+	Nickname  string `json:"nickname" binding:"required,max=50,remittance_text"`
+	AccountID int64  `json:"account_id" binding:"required_without=AccountNumber,excluded_with=AccountNumber,omitempty,min=1"`
+	// Account number or IBAN of the payee's account, in place of its ID.
+	AccountNumber string `json:"account_number" binding:"omitempty,account_number"`
+	Currency      string `json:"currency" binding:"required,currency"`
+	// Checked against the name the account is held under when given.
+	ExpectedName string `json:"expected_name" binding:"omitempty,max=140,remittance_text"`
+}
+
+// createPayee saves an account to the authenticated user's payee book. Transfers to the payee are limited for the
+// configured cooling-off period, so that someone who has taken over a user's session can't add their own account and
+// drain the user's balance in to it straight away. The account holder's name is screened against the watchlist, as
+// is the expected name, and a payee that matches is held until the hits are reviewed.
+func (server *Server) createPayee(ctx *gin.Context) {
+	var req createPayeeRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var account db.Account
+	var err error
+	if req.AccountNumber != "" {
+		account, err = server.getAccountByNumber(ctx, req.AccountNumber)
+	} else {
+		account, err = server.store.GetAccount(ctx, req.AccountID)
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	if account.Currency != req.Currency {
+		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, req.Currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var holderName string
+	if req.ExpectedName != "" || !server.screener.Empty() {
+		holderName, err = server.accountHolderName(ctx, account)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	var nameCheck sql.NullString
+	if req.ExpectedName != "" {
+		nameCheck = sql.NullString{String: util.CheckName(req.ExpectedName, holderName), Valid: true}
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreatePayeeParams{
+		Owner:           authPayload.Username,
+		Nickname:        req.Nickname,
+		AccountID:       account.ID,
+		Currency:        account.Currency,
+		ExpectedName:    sql.NullString{String: req.ExpectedName, Valid: req.ExpectedName != ""},
+		NameCheck:       nameCheck,
+		CoolingOffUntil: time.Now().Add(server.config.PayeeCoolingOffPeriod),
+	}
+
+	payee, err := server.store.CreatePayee(ctx, arg)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == constants.UniqueViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	for _, name := range []string{holderName, req.ExpectedName} {
+		matches := server.screener.Screen(name)
+		if len(matches) == 0 {
+			continue
+		}
+
+		hits, err := server.store.RecordScreeningHitsTx(
+			ctx, db.RecordScreeningHitsTxParams{
+				Username:     payee.Owner,
+				PayeeID:      sql.NullInt64{Int64: payee.ID, Valid: true},
+				ScreenedName: name,
+				Matches:      matches,
+			},
+		)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if len(hits) > 0 {
+			payee.ScreeningStatus = constants.ScreeningStatusPendingReview
+		}
+	}
+
+	ctx.JSON(http.StatusOK, newPayeeResponse(payee))
+}
+
+type payeeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getPayee(ctx *gin.Context) {
+	var req payeeRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payee, valid := server.loadPayee(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPayeeResponse(payee))
+}
+
+type updatePayeeBody struct {
+	Nickname string `json:"nickname" binding:"required,max=50,remittance_text"`
+}
+
+type updatePayeeRequest struct {
+	UriParams payeeRequest
+	Body      updatePayeeBody
+}
+
+// updatePayee renames a payee. Its account can't be changed, since that would skip the cooling-off period.
+func (server *Server) updatePayee(ctx *gin.Context) {
+	var req updatePayeeRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payee, valid := server.loadPayee(ctx, req.UriParams.ID)
+	if !valid {
+		return
+	}
+
+	arg := db.UpdatePayeeNicknameParams{
+		ID:       payee.ID,
+		Nickname: req.Body.Nickname,
+	}
+
+	payee, err := server.store.UpdatePayeeNickname(ctx, arg)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == constants.UniqueViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPayeeResponse(payee))
+}
+
+func (server *Server) deletePayee(ctx *gin.Context) {
+	var req payeeRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payee, valid := server.loadPayee(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	// Keep the payee, and its hits with it, until screening is done with it
+	if payee.ScreeningStatus != constants.ScreeningStatusClear {
+		ctx.JSON(http.StatusForbidden, errorResponse(errPayeeScreeningHold))
+		return
+	}
+
+	if err := server.store.DeletePayee(ctx, payee.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
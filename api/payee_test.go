@@ -0,0 +1,568 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func generateMockPayee(owner string, account db.Account, coolingOffUntil time.Time) db.Payee {
+	return db.Payee{
+		ID:              util.RandomInt(1, 1000),
+		Owner:           owner,
+		Nickname:        util.RandomOwner(),
+		AccountID:       account.ID,
+		Currency:        account.Currency,
+		CoolingOffUntil: coolingOffUntil,
+		ScreeningStatus: constants.ScreeningStatusClear,
+		CreatedAt:       time.Now(),
+	}
+}
+
+// expectClearDestination stubs the lookups made for a transfer to destination: its owner is clear, it isn't one of
+// the caller's payees, and the caller paid it long enough ago for it not to be cooling off. That last lookup is only
+// made for transfers over the cooling-off limit to someone else's account.
+func expectClearDestination(store *mockdb.MockStore, destination db.Account) {
+	owner := db.User{Username: destination.Owner, ScreeningStatus: constants.ScreeningStatusClear}
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(destination.Owner)).Times(1).Return(owner, nil)
+	store.EXPECT().GetPayeeByAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Payee{}, sql.ErrNoRows)
+	store.EXPECT().CountTransfersToAccountBefore(gomock.Any(), gomock.Any()).AnyTimes().Return(int64(1), nil)
+}
+
+// expectPayeeDestination stubs the screening lookups made for a transfer to destination, which is held by owner and
+// is the caller's payee.
+func expectPayeeDestination(store *mockdb.MockStore, destination db.Account, owner db.User, payee db.Payee) {
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(destination.Owner)).Times(1).Return(owner, nil)
+	store.EXPECT().GetPayeeByAccount(
+		gomock.Any(), gomock.Eq(db.GetPayeeByAccountParams{Owner: payee.Owner, AccountID: destination.ID}),
+	).Times(1).Return(payee, nil)
+}
+
+func TestCreatePayeeAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	holder, _ := generateMockUser(t)
+	holder.FullName = "Jane Alice Smith"
+	account := generateMockAccounts(holder.Username, 1)[0]
+	account.Currency = constants.USD
+
+	payee := generateMockPayee(user.Username, account, time.Now().Add(time.Hour))
+	payee.Nickname = "Landlord"
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: fmt.Sprintf(
+				`{"nickname": "Landlord", "account_number": "%s", "currency": "USD", "expected_name": "Jane Smith"}`,
+				account.AccountNumber,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.AccountNumber)).Times(1).Return(
+					account, nil,
+				)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(holder.Username)).Times(1).Return(holder, nil)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreatePayeeParams) (db.Payee, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, account.ID, arg.AccountID)
+						require.Equal(t, sql.NullString{String: "Jane Smith", Valid: true}, arg.ExpectedName)
+						nameCheck := sql.NullString{String: constants.NameCheckCloseMatch, Valid: true}
+						require.Equal(t, nameCheck, arg.NameCheck)
+						require.WithinDuration(t, time.Now(), arg.CoolingOffUntil, time.Second)
+
+						payee.ExpectedName = arg.ExpectedName
+						payee.NameCheck = arg.NameCheck
+						return payee, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got payeeResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, payee.ID, got.ID)
+				require.NotNil(t, got.NameCheck)
+				require.Equal(t, constants.NameCheckCloseMatch, *got.NameCheck)
+			},
+		},
+		{
+			name: "NoNameCheck",
+			body: fmt.Sprintf(`{"nickname": "Landlord", "account_id": %d, "currency": "USD"}`, account.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreatePayeeParams) (db.Payee, error) {
+						require.False(t, arg.NameCheck.Valid)
+						return payee, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			body: fmt.Sprintf(`{"nickname": "Landlord", "account_id": %d, "currency": "EUR"}`, account.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AccountNotFound",
+			body: fmt.Sprintf(`{"nickname": "Landlord", "account_id": %d, "currency": "USD"}`, account.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					db.Account{}, sql.ErrNoRows,
+				)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateNickname",
+			body: fmt.Sprintf(`{"nickname": "Landlord", "account_id": %d, "currency": "USD"}`, account.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.Payee{}, &pq.Error{Code: "23505"},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "AccountIDAndNumber",
+			body: fmt.Sprintf(
+				`{"nickname": "Landlord", "account_id": %d, "account_number": "%s", "currency": "USD"}`,
+				account.ID, account.AccountNumber,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidNickname",
+			body: fmt.Sprintf(`{"nickname": " Landlord", "account_id": %d, "currency": "USD"}`, account.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodPost, "/payees", bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestDeletePayeeAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	other, _ := generateMockUser(t)
+	account := generateMockAccounts(other.Username, 1)[0]
+	payee := generateMockPayee(user.Username, account, time.Now())
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().DeletePayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "NotOwner",
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().DeletePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(db.Payee{}, sql.ErrNoRows)
+				store.EXPECT().DeletePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/payees/%d", payee.ID)
+				request, err := http.NewRequest(http.MethodDelete, url, nil)
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestCreateTransferToPayeeAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	other, _ := generateMockUser(t)
+	source := generateMockAccounts(user.Username, 1)[0]
+	destination := generateMockAccounts(other.Username, 1)[0]
+	source.Currency = constants.USD
+	destination.Currency = constants.USD
+	destination.ID = source.ID + 1
+	own := generateMockAccounts(user.Username, 1)[0]
+	own.Currency = constants.USD
+	own.ID = source.ID + 2
+
+	settled := generateMockPayee(user.Username, destination, time.Now().Add(-time.Hour))
+	coolingOff := generateMockPayee(user.Username, destination, time.Now().Add(time.Hour))
+	held := generateMockPayee(user.Username, destination, time.Now().Add(-time.Hour))
+	held.ScreeningStatus = constants.ScreeningStatusPendingReview
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: fmt.Sprintf(`{"payee_id": %d, "amount": 500}`, settled.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.TransferTxParams{
+					SourceAccountID:      source.ID,
+					DestinationAccountID: destination.ID,
+					Amount:               500,
+				}
+
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(settled.ID)).Times(1).Return(settled, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectPayeeDestination(store, destination, other, settled)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(arg)).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CoolingOffWithinLimit",
+			body: fmt.Sprintf(`{"payee_id": %d, "amount": 100}`, coolingOff.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(coolingOff.ID)).Times(1).Return(coolingOff, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectPayeeDestination(store, destination, other, coolingOff)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CoolingOffOverLimit",
+			body: fmt.Sprintf(`{"payee_id": %d, "amount": 101}`, coolingOff.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(coolingOff.ID)).Times(1).Return(coolingOff, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectPayeeDestination(store, destination, other, coolingOff)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "CoolingOffOverLimitByAccountNumber",
+			body: fmt.Sprintf(`{"destination_account_number": "%s", "amount": 101}`, destination.AccountNumber),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(destination.AccountNumber)).Times(1).Return(
+					destination, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectPayeeDestination(store, destination, other, coolingOff)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "PayeeScreeningHold",
+			body: fmt.Sprintf(`{"payee_id": %d, "amount": 500}`, held.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(held.ID)).Times(1).Return(held, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectPayeeDestination(store, destination, other, held)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "HeldPayeeByAccountID",
+			body: fmt.Sprintf(`{"destination_account_id": %d, "amount": 500}`, destination.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectPayeeDestination(store, destination, other, held)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "DestinationOwnerScreeningHold",
+			body: fmt.Sprintf(`{"destination_account_id": %d, "amount": 500}`, destination.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				owner := other
+				owner.ScreeningStatus = constants.ScreeningStatusPendingReview
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(other.Username)).Times(1).Return(owner, nil)
+				store.EXPECT().GetPayeeByAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "DestinationOrganizationScreeningHold",
+			body: fmt.Sprintf(`{"destination_account_id": %d, "amount": 500}`, destination.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				organization := generateMockOrganization(other.Username)
+				organization.ScreeningStatus = constants.ScreeningStatusPendingReview
+				held := destination
+				held.OrganizationID = sql.NullInt64{Int64: organization.ID, Valid: true}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(held, nil)
+				// The organization holds the account, so the owner who opened it isn't what's checked
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organization.ID)).Times(1).
+					Return(organization, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetPayeeByAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NewDestinationOverLimit",
+			body: fmt.Sprintf(`{"destination_account_id": %d, "amount": 101}`, destination.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(other.Username)).Times(1).Return(other, nil)
+				store.EXPECT().GetPayeeByAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Payee{}, sql.ErrNoRows)
+				store.EXPECT().CountTransfersToAccountBefore(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CountTransfersToAccountBeforeParams) (int64, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, destination.ID, arg.DestinationAccountID)
+						require.WithinDuration(t, time.Now(), arg.CreatedAt, time.Second)
+						return 0, nil
+					},
+				)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NewDestinationWithinLimit",
+			body: fmt.Sprintf(`{"destination_account_id": %d, "amount": 100}`, destination.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(other.Username)).Times(1).Return(other, nil)
+				store.EXPECT().GetPayeeByAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Payee{}, sql.ErrNoRows)
+				store.EXPECT().CountTransfersToAccountBefore(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "PaidDestinationOverLimit",
+			body: fmt.Sprintf(`{"destination_account_id": %d, "amount": 500}`, destination.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(other.Username)).Times(1).Return(other, nil)
+				store.EXPECT().GetPayeeByAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Payee{}, sql.ErrNoRows)
+				store.EXPECT().CountTransfersToAccountBefore(gomock.Any(), gomock.Any()).Times(1).Return(int64(2), nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OwnAccountOverLimit",
+			body: fmt.Sprintf(`{"destination_account_id": %d, "amount": 500}`, own.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(own.ID)).Times(1).Return(own, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetPayeeByAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Payee{}, sql.ErrNoRows)
+				store.EXPECT().CountTransfersToAccountBefore(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OtherUsersPayee",
+			body: fmt.Sprintf(`{"payee_id": %d, "amount": 500}`, settled.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				payee := settled
+				payee.Owner = other.Username
+
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(settled.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "PayeeAndDestination",
+			body: fmt.Sprintf(
+				`{"payee_id": %d, "destination_account_id": %d, "amount": 500}`, settled.ID, destination.ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				server.config.PayeeCoolingOffLimit = 100
+				recorder := httptest.NewRecorder()
+
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal([]byte(tc.body), &body))
+				body["source_account_id"] = source.ID
+				body["currency"] = constants.USD
+				data, err := json.Marshal(body)
+				require.NoError(t, err)
+
+				request, err := http.NewRequest(http.MethodPost, "/transfer", bytes.NewReader(data))
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
@@ -0,0 +1,442 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"net/http"
+	"time"
+)
+
+var (
+	errNotPaymentRequestParty = errors.New("payment request wasn't sent by or to the authenticated user")
+	errNotPaymentRequestPayer = errors.New("payment request wasn't sent to the authenticated user")
+)
+
+type paymentRequestResponse struct {
+	ID                   int64      `json:"id"`
+	Requester            string     `json:"requester"`
+	DestinationAccountID int64      `json:"destination_account_id"`
+	Payer                *string    `json:"payer"`
+	PayerAccountID       *int64     `json:"payer_account_id"`
+	Amount               int64      `json:"amount"`
+	Currency             string     `json:"currency"`
+	Memo                 *string    `json:"memo"`
+	Status               string     `json:"status"`
+	TransferID           *int64     `json:"transfer_id"`
+	ExpiresAt            time.Time  `json:"expires_at"`
+	RespondedAt          *time.Time `json:"responded_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
+
+func newPaymentRequestResponse(request db.PaymentRequest) paymentRequestResponse {
+	res := paymentRequestResponse{
+		ID:                   request.ID,
+		Requester:            request.Requester,
+		DestinationAccountID: request.DestinationAccountID,
+		Payer:                nullStringPointer(request.Payer),
+		Amount:               request.Amount,
+		Currency:             request.Currency,
+		Memo:                 nullStringPointer(request.Memo),
+		Status:               request.Status,
+		ExpiresAt:            request.ExpiresAt,
+		CreatedAt:            request.CreatedAt,
+	}
+	if request.PayerAccountID.Valid {
+		res.PayerAccountID = &request.PayerAccountID.Int64
+	}
+	if request.TransferID.Valid {
+		res.TransferID = &request.TransferID.Int64
+	}
+	if request.RespondedAt.Valid {
+		res.RespondedAt = &request.RespondedAt.Time
+	}
+	return res
+}
+
+func newPaymentRequestResponses(requests []db.PaymentRequest) []paymentRequestResponse {
+	res := make([]paymentRequestResponse, len(requests))
+	for i, request := range requests {
+		res[i] = newPaymentRequestResponse(request)
+	}
+	return res
+}
+
+// checkPaymentRequestPayer reports whether the caller may take action on a payment request as its payer, and the
+// status to respond with if not. A request to a user can only be answered by them; a request to an account by anyone
+// its policy lets take action on it.
+func (server *Server) checkPaymentRequestPayer(
+	ctx *gin.Context, request db.PaymentRequest, action accountAction, amount int64,
+) (int, error) {
+	if request.Payer.Valid {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		if request.Payer.String != authPayload.Username {
+			return http.StatusUnauthorized, errNotPaymentRequestPayer
+		}
+		return http.StatusOK, nil
+	}
+
+	account, err := server.store.GetAccount(ctx, request.PayerAccountID.Int64)
+	if err != nil {
+		return errorStatus(err), err
+	}
+	return server.checkAccountAccess(ctx, account, action, amount)
+}
+
+// loadPaymentRequest loads the payment request named in the URI, checking that the caller is its requester or can
+// view it as its payer.
+func (server *Server) loadPaymentRequest(ctx *gin.Context, id int64) (db.PaymentRequest, bool) {
+	request, err := server.store.GetPaymentRequest(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return request, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if request.Requester == authPayload.Username {
+		return request, true
+	}
+
+	if _, err := server.checkPaymentRequestPayer(ctx, request, accountActionView, 0); err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errNotPaymentRequestParty))
+		return request, false
+	}
+	return request, true
+}
+
+type getPaymentRequestsRequest struct {
+	Status     string `form:"status" binding:"omitempty,oneof=pending accepted declined cancelled expired"`
+	PageNumber int32  `form:"page_number" binding:"required,min=1"`
+	PageSize   int32  `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+// getIncomingPaymentRequests lists the payment requests sent to the authenticated user or to any account they have
+// access to, newest first.
+func (server *Server) getIncomingPaymentRequests(ctx *gin.Context) {
+	var req getPaymentRequestsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.GetIncomingPaymentRequestsParams{
+		Username:   authPayload.Username,
+		Status:     sql.NullString{String: req.Status, Valid: req.Status != ""},
+		PageLimit:  req.PageSize,
+		PageOffset: (req.PageNumber - 1) * req.PageSize,
+	}
+
+	requests, err := server.store.GetIncomingPaymentRequests(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPaymentRequestResponses(requests))
+}
+
+// getOutgoingPaymentRequests lists the payment requests the authenticated user has sent, newest first.
+func (server *Server) getOutgoingPaymentRequests(ctx *gin.Context) {
+	var req getPaymentRequestsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.GetOutgoingPaymentRequestsParams{
+		Requester: authPayload.Username,
+		Status:    sql.NullString{String: req.Status, Valid: req.Status != ""},
+		Limit:     req.PageSize,
+		Offset:    (req.PageNumber - 1) * req.PageSize,
+	}
+
+	requests, err := server.store.GetOutgoingPaymentRequests(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPaymentRequestResponses(requests))
+}
+
+type createPaymentRequestRequest struct {
+	// Requester's account the money is paid in to.
+	DestinationAccountID int64  `json:"destination_account_id" binding:"required,min=1"`
+	Payer                string `json:"payer" binding:"required_without_all=PayerAccountID PayerAccountNumber,excluded_with=PayerAccountID PayerAccountNumber,omitempty,alphanum"`
+	PayerAccountID       int64  `json:"payer_account_id" binding:"omitempty,excluded_with=PayerAccountNumber,min=1"`
+	// Account number or IBAN of the payer's account, in place of its ID.
+	PayerAccountNumber string `json:"payer_account_number" binding:"omitempty,account_number"`
+	Amount             int64  `json:"amount" binding:"required,min=1"`
+	Currency           string `json:"currency" binding:"required,currency"`
+	Memo               string `json:"memo" binding:"omitempty,max=140,remittance_text"`
+	// Defaults to the configured payment request lifetime from now.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// createPaymentRequest asks another user, or the holders of another account, to pay the authenticated user. Anyone
+// who can view the destination account can ask to be paid in to it.
+func (server *Server) createPaymentRequest(ctx *gin.Context) {
+	var req createPaymentRequestRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	now := time.Now()
+	if req.ExpiresAt.IsZero() {
+		req.ExpiresAt = now.Add(server.config.PaymentRequestTTL)
+	} else if !req.ExpiresAt.After(now) {
+		err := errors.New("expires_at must be in the future")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	destination, valid := server.validateAccount(ctx, req.DestinationAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	if !server.authorizeAccount(ctx, destination, accountActionView, 0) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreatePaymentRequestParams{
+		Requester:            authPayload.Username,
+		DestinationAccountID: destination.ID,
+		Payer:                sql.NullString{String: req.Payer, Valid: req.Payer != ""},
+		Amount:               req.Amount,
+		Currency:             req.Currency,
+		Memo:                 sql.NullString{String: req.Memo, Valid: req.Memo != ""},
+		ExpiresAt:            req.ExpiresAt,
+	}
+
+	if req.Payer == authPayload.Username {
+		err := errors.New("payment requests can't be sent to yourself")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.PayerAccountNumber != "" {
+		payerAccount, err := server.getAccountByNumber(ctx, req.PayerAccountNumber)
+		if err != nil {
+			ctx.JSON(errorStatus(err), errorResponse(err))
+			return
+		}
+		req.PayerAccountID = payerAccount.ID
+	}
+
+	if req.PayerAccountID != 0 {
+		if req.PayerAccountID == destination.ID {
+			err := errors.New("payer account must not be the destination account")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		if _, valid := server.validateAccount(ctx, req.PayerAccountID, req.Currency); !valid {
+			return
+		}
+		arg.PayerAccountID = sql.NullInt64{Int64: req.PayerAccountID, Valid: true}
+	}
+
+	request, err := server.store.CreatePaymentRequest(ctx, arg)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == constants.ForeignKeyViolation {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPaymentRequestResponse(request))
+}
+
+type paymentRequestRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getPaymentRequest(ctx *gin.Context) {
+	var req paymentRequestRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	request, valid := server.loadPaymentRequest(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPaymentRequestResponse(request))
+}
+
+type acceptPaymentRequestBody struct {
+	// Required for a request to a user. A request to an account is always paid from that account.
+	SourceAccountID int64 `json:"source_account_id" binding:"omitempty,min=1"`
+}
+
+type acceptPaymentRequestRequest struct {
+	UriParams paymentRequestRequest
+	Body      acceptPaymentRequestBody
+}
+
+type acceptPaymentRequestResponse struct {
+	PaymentRequest paymentRequestResponse `json:"payment_request"`
+	Transfer       transferResponse       `json:"transfer"`
+}
+
+// acceptPaymentRequest pays a payment request. Accepting it again returns the transfer that paid it rather than paying
+// twice.
+func (server *Server) acceptPaymentRequest(ctx *gin.Context) {
+	var req acceptPaymentRequestRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	request, err := server.store.GetPaymentRequest(ctx, req.UriParams.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	sourceAccountID := req.Body.SourceAccountID
+	if request.PayerAccountID.Valid {
+		if sourceAccountID != 0 && sourceAccountID != request.PayerAccountID.Int64 {
+			err := errors.New("payment request must be paid from the account it was sent to")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		sourceAccountID = request.PayerAccountID.Int64
+	} else {
+		if status, err := server.checkPaymentRequestPayer(ctx, request, accountActionSpend, 0); err != nil {
+			ctx.JSON(status, errorResponse(err))
+			return
+		}
+		if sourceAccountID == 0 {
+			err := errors.New("source_account_id is required")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	source, valid := server.validateAccount(ctx, sourceAccountID, request.Currency)
+	if !valid {
+		return
+	}
+
+	if !server.authorizeAccount(ctx, source, accountActionSpend, request.Amount) ||
+		!server.authorizeWithoutApproval(ctx, source, request.Amount) {
+		return
+	}
+
+	destination, err := server.store.GetAccount(ctx, request.DestinationAccountID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	if !server.authorizeDestination(ctx, destination, request.Amount) {
+		return
+	}
+
+	arg := db.AcceptPaymentRequestTxParams{
+		PaymentRequestID: request.ID,
+		SourceAccountID:  source.ID,
+		Now:              time.Now(),
+	}
+
+	result, err := server.store.AcceptPaymentRequestTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK, acceptPaymentRequestResponse{
+			PaymentRequest: newPaymentRequestResponse(result.PaymentRequest),
+			Transfer:       newTransferResponse(result.Transfer, result.PaymentRequest.Currency),
+		},
+	)
+}
+
+func (server *Server) declinePaymentRequest(ctx *gin.Context) {
+	var req paymentRequestRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	request, err := server.store.GetPaymentRequest(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	if status, err := server.checkPaymentRequestPayer(ctx, request, accountActionSpend, 0); err != nil {
+		ctx.JSON(status, errorResponse(err))
+		return
+	}
+
+	server.respondToPaymentRequest(ctx, server.store.DeclinePaymentRequest, request.ID)
+}
+
+func (server *Server) cancelPaymentRequest(ctx *gin.Context) {
+	var req paymentRequestRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	request, err := server.store.GetPaymentRequest(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if request.Requester != authPayload.Username {
+		err := errors.New("payment request wasn't sent by the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	server.respondToPaymentRequest(ctx, server.store.CancelPaymentRequest, request.ID)
+}
+
+// respondToPaymentRequest moves a pending payment request on with update, which only matches pending requests.
+func (server *Server) respondToPaymentRequest(
+	ctx *gin.Context, update func(ctx context.Context, id int64) (db.PaymentRequest, error), id int64,
+) {
+	request, err := update(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = db.ErrPaymentRequestNotPending
+		}
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPaymentRequestResponse(request))
+}
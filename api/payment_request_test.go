@@ -0,0 +1,567 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func generateMockPaymentRequest(requester string, destination db.Account, payer string) db.PaymentRequest {
+	return db.PaymentRequest{
+		ID:                   util.RandomInt(1, 1000),
+		Requester:            requester,
+		DestinationAccountID: destination.ID,
+		Payer:                sql.NullString{String: payer, Valid: true},
+		Amount:               util.RandomInt(1, 100),
+		Currency:             destination.Currency,
+		Status:               constants.PaymentRequestStatusPending,
+		ExpiresAt:            time.Now().Add(time.Hour),
+		CreatedAt:            time.Now(),
+	}
+}
+
+func TestCreatePaymentRequestAPI(t *testing.T) {
+	requester, _ := generateMockUser(t)
+	payer, _ := generateMockUser(t)
+	destination := generateMockAccounts(requester.Username, 1)[0]
+	payerAccount := generateMockAccounts(payer.Username, 1)[0]
+	destination.Currency = constants.USD
+	payerAccount.Currency = constants.USD
+	payerAccount.ID = destination.ID + 1
+
+	request := generateMockPaymentRequest(requester.Username, destination, payer.Username)
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "ToUser",
+			body: fmt.Sprintf(
+				`{"destination_account_id": %d, "payer": "%s", "amount": 50, "currency": "USD", "memo": "Dinner"}`,
+				destination.ID, payer.Username,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreatePaymentRequestParams) (db.PaymentRequest, error) {
+						require.Equal(t, requester.Username, arg.Requester)
+						require.Equal(t, sql.NullString{String: payer.Username, Valid: true}, arg.Payer)
+						require.False(t, arg.PayerAccountID.Valid)
+						require.Equal(t, sql.NullString{String: "Dinner", Valid: true}, arg.Memo)
+						require.WithinDuration(t, time.Now(), arg.ExpiresAt, time.Second)
+						return request, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got paymentRequestResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, request.ID, got.ID)
+				require.Equal(t, constants.PaymentRequestStatusPending, got.Status)
+			},
+		},
+		{
+			name: "ToAccount",
+			body: fmt.Sprintf(
+				`{"destination_account_id": %d, "payer_account_number": "%s", "amount": 50, "currency": "USD"}`,
+				destination.ID, payerAccount.AccountNumber,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(payerAccount.AccountNumber)).Times(1).Return(
+					payerAccount, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreatePaymentRequestParams) (db.PaymentRequest, error) {
+						require.False(t, arg.Payer.Valid)
+						require.Equal(t, sql.NullInt64{Int64: payerAccount.ID, Valid: true}, arg.PayerAccountID)
+						return request, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownPayer",
+			body: fmt.Sprintf(
+				`{"destination_account_id": %d, "payer": "nobody", "amount": 50, "currency": "USD"}`, destination.ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.PaymentRequest{}, &pq.Error{Code: "23503"},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "ToSelf",
+			body: fmt.Sprintf(
+				`{"destination_account_id": %d, "payer": "%s", "amount": 50, "currency": "USD"}`,
+				destination.ID, requester.Username,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotDestinationMember",
+			body: fmt.Sprintf(
+				`{"destination_account_id": %d, "payer": "%s", "amount": 50, "currency": "USD"}`,
+				payerAccount.ID, payer.Username,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "PayerAndPayerAccount",
+			body: fmt.Sprintf(
+				`{"destination_account_id": %d, "payer": "%s", "payer_account_id": %d, "amount": 50, "currency": "USD"}`,
+				destination.ID, payer.Username, payerAccount.ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ExpiresInPast",
+			body: fmt.Sprintf(
+				`{"destination_account_id": %d, "payer": "%s", "amount": 50, "currency": "USD", "expires_at": "%s"}`,
+				destination.ID, payer.Username, time.Now().Add(-time.Hour).Format(time.RFC3339),
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodPost, "/payment-requests", bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				addAuthorization(
+					t, request, server.tokenMaker, authorizationTypeBearer, requester.Username, time.Minute,
+				)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestAcceptPaymentRequestAPI(t *testing.T) {
+	requester, _ := generateMockUser(t)
+	payer, _ := generateMockUser(t)
+	destination := generateMockAccounts(requester.Username, 1)[0]
+	source := generateMockAccounts(payer.Username, 1)[0]
+	destination.Currency = constants.USD
+	source.Currency = constants.USD
+	source.ID = destination.ID + 1
+
+	request := generateMockPaymentRequest(requester.Username, destination, payer.Username)
+	transfer := generateMockTransfers(1, source.ID, destination.ID)[0]
+	accepted := request
+	accepted.Status = constants.PaymentRequestStatusAccepted
+	accepted.TransferID = sql.NullInt64{Int64: transfer.ID, Valid: true}
+
+	toAccount := request
+	toAccount.Payer = sql.NullString{}
+	toAccount.PayerAccountID = sql.NullInt64{Int64: source.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: payer.Username,
+			body:     fmt.Sprintf(`{"source_account_id": %d}`, source.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.AcceptPaymentRequestTxParams{PaymentRequestID: request.ID, SourceAccountID: source.ID}
+
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectClearDestination(store, destination)
+				store.EXPECT().AcceptPaymentRequestTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, got db.AcceptPaymentRequestTxParams) (db.AcceptPaymentRequestTxResult, error) {
+						require.WithinDuration(t, time.Now(), got.Now, time.Second)
+						got.Now = time.Time{}
+						require.Equal(t, arg, got)
+						return db.AcceptPaymentRequestTxResult{PaymentRequest: accepted, Transfer: transfer}, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got acceptPaymentRequestResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.PaymentRequestStatusAccepted, got.PaymentRequest.Status)
+				require.Equal(t, transfer.ID, got.Transfer.ID)
+			},
+		},
+		{
+			name:     "ToAccount",
+			username: payer.Username,
+			body:     `{}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectClearDestination(store, destination)
+				store.EXPECT().AcceptPaymentRequestTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.AcceptPaymentRequestTxParams) (db.AcceptPaymentRequestTxResult, error) {
+						require.Equal(t, source.ID, arg.SourceAccountID)
+						return db.AcceptPaymentRequestTxResult{PaymentRequest: accepted, Transfer: transfer}, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "ToAccountFromOtherAccount",
+			username: payer.Username,
+			body:     fmt.Sprintf(`{"source_account_id": %d}`, source.ID+1),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().AcceptPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "NotPayer",
+			username: requester.Username,
+			body:     fmt.Sprintf(`{"source_account_id": %d}`, destination.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().AcceptPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "MissingSourceAccount",
+			username: payer.Username,
+			body:     `{}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().AcceptPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "Expired",
+			username: payer.Username,
+			body:     fmt.Sprintf(`{"source_account_id": %d}`, source.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectClearDestination(store, destination)
+				store.EXPECT().AcceptPaymentRequestTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AcceptPaymentRequestTxResult{}, db.ErrPaymentRequestExpired,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:     "Declined",
+			username: payer.Username,
+			body:     fmt.Sprintf(`{"source_account_id": %d}`, source.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectClearDestination(store, destination)
+				store.EXPECT().AcceptPaymentRequestTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AcceptPaymentRequestTxResult{}, db.ErrPaymentRequestNotPending,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "DestinationScreeningHold",
+			username: payer.Username,
+			body:     fmt.Sprintf(`{"source_account_id": %d}`, source.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				held := requester
+				held.ScreeningStatus = constants.ScreeningStatusPendingReview
+
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(requester.Username)).Times(1).Return(held, nil)
+				store.EXPECT().AcceptPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "DestinationHeldWhilePaying",
+			username: payer.Username,
+			body:     fmt.Sprintf(`{"source_account_id": %d}`, source.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectClearDestination(store, destination)
+				store.EXPECT().AcceptPaymentRequestTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AcceptPaymentRequestTxResult{}, db.ErrDestinationScreeningHold,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/payment-requests/%d/accept", request.ID)
+				req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+				server.router.ServeHTTP(recorder, req)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestDeclineAndCancelPaymentRequestAPI(t *testing.T) {
+	requester, _ := generateMockUser(t)
+	payer, _ := generateMockUser(t)
+	destination := generateMockAccounts(requester.Username, 1)[0]
+	request := generateMockPaymentRequest(requester.Username, destination, payer.Username)
+
+	testCases := []struct {
+		name          string
+		action        string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Decline",
+			action:   "decline",
+			username: payer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				declined := request
+				declined.Status = constants.PaymentRequestStatusDeclined
+
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().DeclinePaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(declined, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got paymentRequestResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.PaymentRequestStatusDeclined, got.Status)
+			},
+		},
+		{
+			name:     "DeclineByRequester",
+			action:   "decline",
+			username: requester.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().DeclinePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "DeclineAnswered",
+			action:   "decline",
+			username: payer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().DeclinePaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(
+					db.PaymentRequest{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "Cancel",
+			action:   "cancel",
+			username: requester.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				cancelled := request
+				cancelled.Status = constants.PaymentRequestStatusCancelled
+
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().CancelPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(cancelled, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "CancelByPayer",
+			action:   "cancel",
+			username: payer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().CancelPaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/payment-requests/%d/%s", request.ID, tc.action)
+				req, err := http.NewRequest(http.MethodPost, url, nil)
+				require.NoError(t, err)
+
+				addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+				server.router.ServeHTTP(recorder, req)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestGetIncomingPaymentRequestsAPI(t *testing.T) {
+	requester, _ := generateMockUser(t)
+	payer, _ := generateMockUser(t)
+	destination := generateMockAccounts(requester.Username, 1)[0]
+	requests := []db.PaymentRequest{generateMockPaymentRequest(requester.Username, destination, payer.Username)}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	arg := db.GetIncomingPaymentRequestsParams{
+		Username:   payer.Username,
+		Status:     sql.NullString{String: constants.PaymentRequestStatusPending, Valid: true},
+		PageLimit:  10,
+		PageOffset: 10,
+	}
+	store.EXPECT().GetIncomingPaymentRequests(gomock.Any(), gomock.Eq(arg)).Times(1).Return(requests, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := "/payment-requests/incoming?status=pending&page_number=2&page_size=10"
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, payer.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []paymentRequestResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &got)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, requests[0].ID, got[0].ID)
+}
@@ -0,0 +1,292 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type pendingTransferResponse struct {
+	ID                   int64      `json:"id"`
+	OrganizationID       int64      `json:"organization_id"`
+	SourceAccountID      int64      `json:"source_account_id"`
+	DestinationAccountID int64      `json:"destination_account_id"`
+	Amount               int64      `json:"amount"`
+	InitiatedBy          string     `json:"initiated_by"`
+	RequiredApprovals    int32      `json:"required_approvals"`
+	Approvers            []string   `json:"approvers"`
+	Status               string     `json:"status"`
+	TransferID           *int64     `json:"transfer_id"`
+	RejectedBy           *string    `json:"rejected_by"`
+	RejectionReason      *string    `json:"rejection_reason"`
+	ExpiresAt            time.Time  `json:"expires_at"`
+	DecidedAt            *time.Time `json:"decided_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+	remittanceResponse
+}
+
+func newPendingTransferResponse(
+	pending db.PendingTransfer, approvals []db.TransferApproval,
+) pendingTransferResponse {
+	res := pendingTransferResponse{
+		ID:                   pending.ID,
+		OrganizationID:       pending.OrganizationID,
+		SourceAccountID:      pending.SourceAccountID,
+		DestinationAccountID: pending.DestinationAccountID,
+		Amount:               pending.Amount,
+		InitiatedBy:          pending.InitiatedBy,
+		RequiredApprovals:    pending.RequiredApprovals,
+		Approvers:            make([]string, len(approvals)),
+		Status:               pending.Status,
+		ExpiresAt:            pending.ExpiresAt,
+		CreatedAt:            pending.CreatedAt,
+		remittanceResponse: newRemittanceResponse(
+			db.Remittance{
+				Memo:          pending.Memo,
+				Reference:     pending.Reference,
+				Category:      pending.Category,
+				InvoiceNumber: pending.InvoiceNumber,
+				EndToEndID:    pending.EndToEndID,
+			},
+		),
+	}
+	for i, approval := range approvals {
+		res.Approvers[i] = approval.Approver
+	}
+	if pending.TransferID.Valid {
+		res.TransferID = &pending.TransferID.Int64
+	}
+	if pending.RejectedBy.Valid {
+		res.RejectedBy = &pending.RejectedBy.String
+	}
+	if pending.RejectionReason.Valid {
+		res.RejectionReason = &pending.RejectionReason.String
+	}
+	if pending.DecidedAt.Valid {
+		res.DecidedAt = &pending.DecidedAt.Time
+	}
+	return res
+}
+
+// createPendingTransfer holds a transfer from an organization account back until enough approvers sign off.
+func (server *Server) createPendingTransfer(
+	ctx *gin.Context, organization db.Organization, transfer db.TransferTxParams,
+) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreatePendingTransferParams{
+		OrganizationID:       organization.ID,
+		SourceAccountID:      transfer.SourceAccountID,
+		DestinationAccountID: transfer.DestinationAccountID,
+		Amount:               transfer.Amount,
+		InitiatedBy:          authPayload.Username,
+		RequiredApprovals:    organization.RequiredApprovals,
+		ExpiresAt:            time.Now().Add(server.config.PendingTransferTTL),
+		Memo:                 transfer.Memo,
+		Reference:            transfer.Reference,
+		Category:             transfer.Category,
+		InvoiceNumber:        transfer.InvoiceNumber,
+		EndToEndID:           transfer.EndToEndID,
+	}
+
+	pending, err := server.store.CreatePendingTransfer(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, newPendingTransferResponse(pending, nil))
+}
+
+type getPendingTransfersUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type getPendingTransfersQueryParams struct {
+	Status     string `form:"status" binding:"omitempty,oneof=pending executed rejected expired"`
+	PageNumber int32  `form:"page_number" binding:"required,min=1"`
+	PageSize   int32  `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+type getPendingTransfersRequest struct {
+	UriParams   getPendingTransfersUriParams
+	QueryParams getPendingTransfersQueryParams
+}
+
+// getPendingTransfers lists an organization's transfers that went through approval, newest first.
+func (server *Server) getPendingTransfers(ctx *gin.Context) {
+	var req getPendingTransfersRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.authorizeOrganization(ctx, req.UriParams.ID); !valid {
+		return
+	}
+
+	arg := db.GetPendingTransfersParams{
+		OrganizationID: req.UriParams.ID,
+		Status:         sql.NullString{String: req.QueryParams.Status, Valid: req.QueryParams.Status != ""},
+		Limit:          req.QueryParams.PageSize,
+		Offset:         (req.QueryParams.PageNumber - 1) * req.QueryParams.PageSize,
+	}
+
+	pendingTransfers, err := server.store.GetPendingTransfers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]pendingTransferResponse, len(pendingTransfers))
+	for i, pending := range pendingTransfers {
+		res[i] = newPendingTransferResponse(pending, nil)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type pendingTransferRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// loadPendingTransfer loads a pending transfer and checks that the caller has one of roles in its organization.
+func (server *Server) loadPendingTransfer(ctx *gin.Context, id int64, roles ...string) (db.PendingTransfer, bool) {
+	pending, err := server.store.GetPendingTransfer(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return pending, false
+	}
+
+	_, valid := server.authorizeOrganization(ctx, pending.OrganizationID, roles...)
+	return pending, valid
+}
+
+func (server *Server) getPendingTransfer(ctx *gin.Context) {
+	var req pendingTransferRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	pending, valid := server.loadPendingTransfer(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	approvals, err := server.store.GetTransferApprovals(ctx, pending.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPendingTransferResponse(pending, approvals))
+}
+
+type approvePendingTransferResponse struct {
+	PendingTransfer pendingTransferResponse `json:"pending_transfer"`
+	// Only set by the approval that executed the transfer
+	Transfer *transferTxResponse `json:"transfer"`
+}
+
+// approvePendingTransfer signs a transfer off as an approver or admin other than its initiator. The last approval it
+// needs executes it.
+func (server *Server) approvePendingTransfer(ctx *gin.Context) {
+	var req pendingTransferRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, valid := server.loadPendingTransfer(
+		ctx, req.ID, constants.OrganizationRoleApprover, constants.OrganizationRoleAdmin,
+	)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ApprovePendingTransferTxParams{
+		PendingTransferID: req.ID,
+		Approver:          authPayload.Username,
+		Now:               time.Now(),
+	}
+
+	result, err := server.store.ApprovePendingTransferTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	res := approvePendingTransferResponse{
+		PendingTransfer: newPendingTransferResponse(result.PendingTransfer, result.Approvals),
+	}
+	if result.Transfer != nil {
+		transfer := newTransferTxResponse(*result.Transfer, result.Transfer.SourceAccount.Currency)
+		res.Transfer = &transfer
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type rejectPendingTransferBody struct {
+	Reason string `json:"reason" binding:"omitempty,max=200"`
+}
+
+type rejectPendingTransferRequest struct {
+	UriParams pendingTransferRequest
+	Body      rejectPendingTransferBody
+}
+
+// rejectPendingTransfer stops a transfer from ever running. Any approver or admin can reject it, including one who
+// has already approved it.
+func (server *Server) rejectPendingTransfer(ctx *gin.Context) {
+	var req rejectPendingTransferRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	pending, valid := server.loadPendingTransfer(
+		ctx, req.UriParams.ID, constants.OrganizationRoleApprover, constants.OrganizationRoleAdmin,
+	)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.RejectPendingTransferParams{
+		ID:              pending.ID,
+		RejectedBy:      sql.NullString{String: authPayload.Username, Valid: true},
+		RejectionReason: sql.NullString{String: req.Body.Reason, Valid: req.Body.Reason != ""},
+	}
+
+	pending, err := server.store.RejectPendingTransfer(ctx, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = db.ErrPendingTransferNotPending
+		}
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPendingTransferResponse(pending, nil))
+}
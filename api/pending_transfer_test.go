@@ -0,0 +1,459 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func generateMockPendingTransfer(
+	organization db.Organization, source, destination db.Account, initiatedBy string,
+) db.PendingTransfer {
+	return db.PendingTransfer{
+		ID:                   util.RandomInt(1, 1000),
+		OrganizationID:       organization.ID,
+		SourceAccountID:      source.ID,
+		DestinationAccountID: destination.ID,
+		Amount:               organization.ApprovalThreshold + 1,
+		InitiatedBy:          initiatedBy,
+		RequiredApprovals:    organization.RequiredApprovals,
+		Status:               constants.PendingTransferStatusPending,
+		ExpiresAt:            time.Now().Add(time.Hour),
+		CreatedAt:            time.Now(),
+	}
+}
+
+func TestCreateOrganizationTransferAPI(t *testing.T) {
+	admin, _ := generateMockUser(t)
+	initiator, _ := generateMockUser(t)
+	approver, _ := generateMockUser(t)
+	organization := generateMockOrganization(admin.Username)
+
+	source := generateMockAccounts(admin.Username, 1)[0]
+	source.Currency = constants.USD
+	source.OrganizationID = sql.NullInt64{Int64: organization.ID, Valid: true}
+	destination := generateMockAccounts(approver.Username, 1)[0]
+	destination.ID = source.ID + 1
+	destination.Currency = constants.USD
+
+	pending := generateMockPendingTransfer(organization, source, destination, initiator.Username)
+
+	testCases := []struct {
+		name          string
+		username      string
+		role          string
+		amount        int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "UnderThreshold",
+			username: initiator.Username,
+			role:     constants.OrganizationRoleInitiator,
+			amount:   organization.ApprovalThreshold,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectClearDestination(store, destination)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organization.ID)).Times(1).Return(organization, nil)
+				store.EXPECT().CreatePendingTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "OverThreshold",
+			username: initiator.Username,
+			role:     constants.OrganizationRoleInitiator,
+			amount:   organization.ApprovalThreshold + 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectClearDestination(store, destination)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organization.ID)).Times(1).Return(organization, nil)
+				store.EXPECT().CreatePendingTransfer(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreatePendingTransferParams) (db.PendingTransfer, error) {
+						require.Equal(t, organization.ID, arg.OrganizationID)
+						require.Equal(t, source.ID, arg.SourceAccountID)
+						require.Equal(t, destination.ID, arg.DestinationAccountID)
+						require.Equal(t, initiator.Username, arg.InitiatedBy)
+						require.Equal(t, organization.RequiredApprovals, arg.RequiredApprovals)
+						return pending, nil
+					},
+				)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var got pendingTransferResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, pending.ID, got.ID)
+				require.Equal(t, constants.PendingTransferStatusPending, got.Status)
+			},
+		},
+		{
+			name:     "Approver",
+			username: approver.Username,
+			role:     constants.OrganizationRoleApprover,
+			amount:   organization.ApprovalThreshold,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				member := generateMockOrganizationMember(organization, tc.username, tc.role)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).AnyTimes().Return(destination, nil)
+				store.EXPECT().GetOrganizationMember(
+					gomock.Any(), gomock.Eq(
+						db.GetOrganizationMemberParams{OrganizationID: organization.ID, Username: tc.username},
+					),
+				).Times(1).Return(member, nil)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				body, err := json.Marshal(
+					gin.H{
+						"source_account_id":      source.ID,
+						"destination_account_id": destination.ID,
+						"amount":                 tc.amount,
+						"currency":               constants.USD,
+					},
+				)
+				require.NoError(t, err)
+
+				request, err := http.NewRequest(http.MethodPost, "/transfer", bytes.NewReader(body))
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestApprovePendingTransferAPI(t *testing.T) {
+	admin, _ := generateMockUser(t)
+	initiator, _ := generateMockUser(t)
+	approver, _ := generateMockUser(t)
+	organization := generateMockOrganization(admin.Username)
+
+	source := generateMockAccounts(admin.Username, 1)[0]
+	source.Currency = constants.USD
+	destination := generateMockAccounts(approver.Username, 1)[0]
+	destination.Currency = constants.USD
+
+	pending := generateMockPendingTransfer(organization, source, destination, initiator.Username)
+	approval := db.TransferApproval{PendingTransferID: pending.ID, Approver: approver.Username, CreatedAt: time.Now()}
+
+	transfer := generateMockTransfers(1, source.ID, destination.ID)[0]
+	executed := pending
+	executed.Status = constants.PendingTransferStatusExecuted
+	executed.TransferID = sql.NullInt64{Int64: transfer.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		username      string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Approved",
+			username: approver.Username,
+			role:     constants.OrganizationRoleApprover,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApprovePendingTransferTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.ApprovePendingTransferTxParams) (db.ApprovePendingTransferTxResult, error) {
+						require.Equal(t, pending.ID, arg.PendingTransferID)
+						require.Equal(t, approver.Username, arg.Approver)
+						return db.ApprovePendingTransferTxResult{
+							PendingTransfer: pending,
+							Approvals:       []db.TransferApproval{approval},
+						}, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got approvePendingTransferResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.PendingTransferStatusPending, got.PendingTransfer.Status)
+				require.Equal(t, []string{approver.Username}, got.PendingTransfer.Approvers)
+				require.Nil(t, got.Transfer)
+			},
+		},
+		{
+			name:     "Executed",
+			username: admin.Username,
+			role:     constants.OrganizationRoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				result := db.ApprovePendingTransferTxResult{
+					PendingTransfer: executed,
+					Approvals: []db.TransferApproval{
+						approval, {PendingTransferID: pending.ID, Approver: admin.Username},
+					},
+					Transfer: &db.TransferTxResult{
+						Transfer:           transfer,
+						SourceAccount:      source,
+						DestinationAccount: destination,
+					},
+				}
+
+				store.EXPECT().ApprovePendingTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got approvePendingTransferResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.PendingTransferStatusExecuted, got.PendingTransfer.Status)
+				require.NotNil(t, got.PendingTransfer.TransferID)
+				require.Equal(t, transfer.ID, *got.PendingTransfer.TransferID)
+				require.NotNil(t, got.Transfer)
+				require.Equal(t, transfer.ID, got.Transfer.Transfer.ID)
+			},
+		},
+		{
+			name:     "Initiator",
+			username: initiator.Username,
+			role:     constants.OrganizationRoleInitiator,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApprovePendingTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "SelfApproval",
+			username: admin.Username,
+			role:     constants.OrganizationRoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApprovePendingTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.ApprovePendingTransferTxResult{}, db.ErrSelfApproval,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "AlreadyApproved",
+			username: approver.Username,
+			role:     constants.OrganizationRoleApprover,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApprovePendingTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.ApprovePendingTransferTxResult{}, db.ErrAlreadyApproved,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "Expired",
+			username: approver.Username,
+			role:     constants.OrganizationRoleApprover,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApprovePendingTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.ApprovePendingTransferTxResult{}, db.ErrPendingTransferExpired,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:     "InsufficientFunds",
+			username: approver.Username,
+			role:     constants.OrganizationRoleApprover,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApprovePendingTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.ApprovePendingTransferTxResult{}, db.ErrInsufficientFunds,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				member := generateMockOrganizationMember(organization, tc.username, tc.role)
+				store.EXPECT().GetPendingTransfer(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(member, nil)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/pending-transfers/%d/approve", pending.ID)
+				request, err := http.NewRequest(http.MethodPost, url, nil)
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestRejectPendingTransferAPI(t *testing.T) {
+	admin, _ := generateMockUser(t)
+	initiator, _ := generateMockUser(t)
+	approver, _ := generateMockUser(t)
+	organization := generateMockOrganization(admin.Username)
+
+	source := generateMockAccounts(admin.Username, 1)[0]
+	destination := generateMockAccounts(approver.Username, 1)[0]
+	pending := generateMockPendingTransfer(organization, source, destination, initiator.Username)
+
+	rejected := pending
+	rejected.Status = constants.PendingTransferStatusRejected
+	rejected.RejectedBy = sql.NullString{String: approver.Username, Valid: true}
+	rejected.RejectionReason = sql.NullString{String: "unknown payee", Valid: true}
+
+	testCases := []struct {
+		name          string
+		body          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: `{"reason": "unknown payee"}`,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, approver.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				member := generateMockOrganizationMember(organization, approver.Username, constants.OrganizationRoleApprover)
+				arg := db.RejectPendingTransferParams{
+					ID:              pending.ID,
+					RejectedBy:      sql.NullString{String: approver.Username, Valid: true},
+					RejectionReason: sql.NullString{String: "unknown payee", Valid: true},
+				}
+
+				store.EXPECT().GetPendingTransfer(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(member, nil)
+				store.EXPECT().RejectPendingTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rejected, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got pendingTransferResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.PendingTransferStatusRejected, got.Status)
+				require.NotNil(t, got.RejectedBy)
+				require.Equal(t, approver.Username, *got.RejectedBy)
+			},
+		},
+		{
+			name: "AlreadyDecided",
+			body: `{}`,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				member := generateMockOrganizationMember(organization, admin.Username, constants.OrganizationRoleAdmin)
+
+				store.EXPECT().GetPendingTransfer(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(member, nil)
+				store.EXPECT().RejectPendingTransfer(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.PendingTransfer{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: `{}`,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, approver.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPendingTransfer(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(
+					db.PendingTransfer{}, sql.ErrNoRows,
+				)
+				store.EXPECT().RejectPendingTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/pending-transfers/%d/reject", pending.ID)
+				request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"database/sql"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type reconciliationRunResponse struct {
+	ID               int64                               `json:"id"`
+	AccountsChecked  int64                               `json:"accounts_checked"`
+	TransfersChecked int64                               `json:"transfers_checked"`
+	DiscrepancyCount int64                               `json:"discrepancy_count"`
+	StartedAt        time.Time                           `json:"started_at"`
+	CompletedAt      time.Time                           `json:"completed_at"`
+	Discrepancies    []reconciliationDiscrepancyResponse `json:"discrepancies"`
+}
+
+type reconciliationDiscrepancyResponse struct {
+	ID                   int64     `json:"id"`
+	Kind                 string    `json:"kind"`
+	AccountID            *int64    `json:"account_id"`
+	TransferID           *int64    `json:"transfer_id"`
+	EntryID              *int64    `json:"entry_id"`
+	JournalTransactionID *int64    `json:"journal_transaction_id"`
+	Expected             *int64    `json:"expected"`
+	Actual               *int64    `json:"actual"`
+	Detail               string    `json:"detail"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+func newReconciliationRunResponse(
+	run db.ReconciliationRun, discrepancies []db.ReconciliationDiscrepancy,
+) reconciliationRunResponse {
+	res := reconciliationRunResponse{
+		ID:               run.ID,
+		AccountsChecked:  run.AccountsChecked,
+		TransfersChecked: run.TransfersChecked,
+		DiscrepancyCount: run.DiscrepancyCount,
+		StartedAt:        run.StartedAt,
+		CompletedAt:      run.CompletedAt.Time,
+		Discrepancies:    make([]reconciliationDiscrepancyResponse, len(discrepancies)),
+	}
+	for i, discrepancy := range discrepancies {
+		res.Discrepancies[i] = reconciliationDiscrepancyResponse{
+			ID:                   discrepancy.ID,
+			Kind:                 discrepancy.Kind,
+			AccountID:            nullInt64Pointer(discrepancy.AccountID),
+			TransferID:           nullInt64Pointer(discrepancy.TransferID),
+			EntryID:              nullInt64Pointer(discrepancy.EntryID),
+			JournalTransactionID: nullInt64Pointer(discrepancy.JournalTransactionID),
+			Expected:             nullInt64Pointer(discrepancy.Expected),
+			Actual:               nullInt64Pointer(discrepancy.Actual),
+			Detail:               discrepancy.Detail,
+			CreatedAt:            discrepancy.CreatedAt,
+		}
+	}
+	return res
+}
+
+func nullInt64Pointer(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int64
+}
+
+type getLatestReconciliationRunRequest struct {
+	PageNumber int32 `form:"page_number" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+func (server *Server) getLatestReconciliationRun(ctx *gin.Context) {
+	var req getLatestReconciliationRunRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	run, err := server.store.GetLatestReconciliationRun(ctx)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.GetReconciliationDiscrepanciesParams{
+		RunID:  run.ID,
+		Limit:  req.PageSize,
+		Offset: (req.PageNumber - 1) * req.PageSize,
+	}
+
+	discrepancies, err := server.store.GetReconciliationDiscrepancies(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newReconciliationRunResponse(run, discrepancies))
+}
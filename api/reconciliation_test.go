@@ -0,0 +1,160 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetLatestReconciliationRunAPI(t *testing.T) {
+	admin, _ := generateMockUser(t)
+
+	run := db.ReconciliationRun{
+		ID:               util.RandomInt(1, 1000),
+		AccountsChecked:  util.RandomInt(1, 1000),
+		DiscrepancyCount: 1,
+		CompletedAt:      sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true},
+	}
+	discrepancies := []db.ReconciliationDiscrepancy{
+		{
+			ID:        util.RandomInt(1, 1000),
+			RunID:     run.ID,
+			Kind:      constants.DiscrepancyBalanceMismatch,
+			AccountID: sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true},
+			Expected:  sql.NullInt64{Int64: 500, Valid: true},
+			Actual:    sql.NullInt64{Int64: 507, Valid: true},
+			Detail:    util.RandomString(20),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?page_number=1&page_size=10",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetReconciliationDiscrepanciesParams{
+					RunID:  run.ID,
+					Limit:  10,
+					Offset: 0,
+				}
+
+				store.EXPECT().GetLatestReconciliationRun(gomock.Any()).Times(1).Return(run, nil)
+				store.EXPECT().GetReconciliationDiscrepancies(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					discrepancies, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesReconciliationRun(t, recorder.Body, run, discrepancies)
+			},
+		},
+		{
+			name:  "NoRuns",
+			query: "?page_number=1&page_size=10",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestReconciliationRun(gomock.Any()).Times(1).Return(
+					db.ReconciliationRun{}, sql.ErrNoRows,
+				)
+				store.EXPECT().GetReconciliationDiscrepancies(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "Teller",
+			query: "?page_number=1&page_size=10",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleTeller, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestReconciliationRun(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidPageSize",
+			query: "?page_number=1&page_size=100",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestReconciliationRun(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodGet, "/reconciliation/latest"+tc.query, nil)
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func requireBodyMatchesReconciliationRun(
+	t *testing.T, body *bytes.Buffer, run db.ReconciliationRun, discrepancies []db.ReconciliationDiscrepancy,
+) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var fetchedRun reconciliationRunResponse
+	err = json.Unmarshal(data, &fetchedRun)
+	require.NoError(t, err)
+	require.Equal(t, newReconciliationRunResponse(run, discrepancies), fetchedRun)
+}
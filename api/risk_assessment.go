@@ -0,0 +1,273 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/risk"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var errTransferDenied = errors.New("transfer was denied by risk checks")
+
+type riskAssessmentResponse struct {
+	ID                   int64      `json:"id"`
+	SourceAccountID      int64      `json:"source_account_id"`
+	DestinationAccountID int64      `json:"destination_account_id"`
+	Amount               int64      `json:"amount"`
+	InitiatedBy          string     `json:"initiated_by"`
+	Score                int64      `json:"score"`
+	Decision             string     `json:"decision"`
+	Reasons              []string   `json:"reasons"`
+	Status               string     `json:"status"`
+	TransferID           *int64     `json:"transfer_id"`
+	ReviewedBy           *string    `json:"reviewed_by"`
+	ReviewNote           *string    `json:"review_note"`
+	ReviewedAt           *time.Time `json:"reviewed_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+	remittanceResponse
+}
+
+func newRiskAssessmentResponse(assessment db.RiskAssessment) riskAssessmentResponse {
+	res := riskAssessmentResponse{
+		ID:                   assessment.ID,
+		SourceAccountID:      assessment.SourceAccountID,
+		DestinationAccountID: assessment.DestinationAccountID,
+		Amount:               assessment.Amount,
+		InitiatedBy:          assessment.InitiatedBy,
+		Score:                assessment.Score,
+		Decision:             assessment.Decision,
+		Reasons:              []string{},
+		Status:               assessment.Status,
+		TransferID:           nullInt64Pointer(assessment.TransferID),
+		ReviewedBy:           nullStringPointer(assessment.ReviewedBy),
+		ReviewNote:           nullStringPointer(assessment.ReviewNote),
+		CreatedAt:            assessment.CreatedAt,
+		remittanceResponse: newRemittanceResponse(
+			db.Remittance{
+				Memo:          assessment.Memo,
+				Reference:     assessment.Reference,
+				Category:      assessment.Category,
+				InvoiceNumber: assessment.InvoiceNumber,
+				EndToEndID:    assessment.EndToEndID,
+			},
+		),
+	}
+	if assessment.Reasons != "" {
+		res.Reasons = strings.Split(assessment.Reasons, ",")
+	}
+	if assessment.ReviewedAt.Valid {
+		res.ReviewedAt = &assessment.ReviewedAt.Time
+	}
+	return res
+}
+
+// createAssessedTransfer scores a transfer against the risk rules and makes it, holds it for an admin's review or
+// denies it, as the score decides.
+func (server *Server) createAssessedTransfer(
+	ctx *gin.Context, rules risk.Rules, transfer db.TransferTxParams, currencyCode string,
+) {
+	signals, err := server.riskSignals(ctx, rules, transfer)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.AssessedTransferTx(
+		ctx, db.AssessedTransferTxParams{
+			TransferTxParams: transfer,
+			InitiatedBy:      authPayload.Username,
+			Assessment:       rules.Assess(signals),
+		},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	switch result.RiskAssessment.Decision {
+	case constants.RiskDecisionAllow:
+		ctx.JSON(http.StatusOK, newTransferTxResponse(*result.Transfer, currencyCode))
+	case constants.RiskDecisionReview:
+		ctx.JSON(http.StatusAccepted, newRiskAssessmentResponse(result.RiskAssessment))
+	default:
+		ctx.JSON(
+			http.StatusForbidden, gin.H{
+				"error":           errTransferDenied.Error(),
+				"risk_assessment": newRiskAssessmentResponse(result.RiskAssessment),
+			},
+		)
+	}
+}
+
+// riskSignals looks up what the risk rules need to know about a transfer: the source account's history with the
+// destination and overall, and whether the user is on a device they haven't used before.
+func (server *Server) riskSignals(
+	ctx *gin.Context, rules risk.Rules, transfer db.TransferTxParams,
+) (risk.Signals, error) {
+	signals := risk.Signals{Amount: transfer.Amount}
+	windows := rules.WindowsAt(time.Now())
+
+	stats, err := server.store.GetTransferRiskStats(
+		ctx, db.GetTransferRiskStatsParams{
+			HistoryStart:         windows.HistoryStart,
+			RecentStart:          windows.RecentStart,
+			DestinationAccountID: transfer.DestinationAccountID,
+			SourceAccountID:      transfer.SourceAccountID,
+		},
+	)
+	if err != nil {
+		return signals, err
+	}
+	signals.NewPayee = stats.DestinationCount == 0
+	signals.AverageAmount = stats.AverageAmount
+	signals.HistoryCount = stats.HistoryCount
+	signals.RecentCount = stats.RecentCount
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	session, err := server.store.GetFirstDeviceSession(
+		ctx, db.GetFirstDeviceSessionParams{
+			Username:  authPayload.Username,
+			UserAgent: ctx.Request.UserAgent(),
+			ClientIp:  ctx.ClientIP(),
+		},
+	)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		signals.NewDevice = true
+	case err != nil:
+		return signals, err
+	default:
+		signals.NewDevice = session.CreatedAt.After(windows.NewDeviceSince)
+	}
+
+	return signals, nil
+}
+
+type getRiskAssessmentsRequest struct {
+	Status     string `form:"status" binding:"omitempty,oneof=executed pending_review rejected denied"`
+	PageNumber int32  `form:"page_number" binding:"required,min=1"`
+	PageSize   int32  `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+// getRiskAssessments lists assessments newest first. Filtering by pending_review gives the review queue.
+func (server *Server) getRiskAssessments(ctx *gin.Context) {
+	var req getRiskAssessmentsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	assessments, err := server.store.GetRiskAssessments(
+		ctx, db.GetRiskAssessmentsParams{
+			Status: sql.NullString{String: req.Status, Valid: req.Status != ""},
+			Limit:  req.PageSize,
+			Offset: (req.PageNumber - 1) * req.PageSize,
+		},
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]riskAssessmentResponse, len(assessments))
+	for i, assessment := range assessments {
+		res[i] = newRiskAssessmentResponse(assessment)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type riskAssessmentRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getRiskAssessment(ctx *gin.Context) {
+	var req riskAssessmentRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	assessment, err := server.store.GetRiskAssessment(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newRiskAssessmentResponse(assessment))
+}
+
+type reviewRiskAssessmentResponse struct {
+	RiskAssessment riskAssessmentResponse `json:"risk_assessment"`
+	Transfer       *transferTxResponse    `json:"transfer"`
+}
+
+// approveRiskAssessment makes a transfer held for review. Admins can't approve their own transfers.
+func (server *Server) approveRiskAssessment(ctx *gin.Context) {
+	var req riskAssessmentRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.reviewRiskAssessment(ctx, req.ID, true, "")
+}
+
+type rejectRiskAssessmentBody struct {
+	Reason string `json:"reason" binding:"omitempty,max=200"`
+}
+
+type rejectRiskAssessmentRequest struct {
+	UriParams riskAssessmentRequest
+	Body      rejectRiskAssessmentBody
+}
+
+func (server *Server) rejectRiskAssessment(ctx *gin.Context) {
+	var req rejectRiskAssessmentRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.reviewRiskAssessment(ctx, req.UriParams.ID, false, req.Body.Reason)
+}
+
+func (server *Server) reviewRiskAssessment(ctx *gin.Context, id int64, approve bool, note string) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.ReviewRiskAssessmentTx(
+		ctx, db.ReviewRiskAssessmentTxParams{
+			ID:       id,
+			Reviewer: authPayload.Username,
+			Approve:  approve,
+			Note:     note,
+		},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	res := reviewRiskAssessmentResponse{RiskAssessment: newRiskAssessmentResponse(result.RiskAssessment)}
+	if result.Transfer != nil {
+		transfer := newTransferTxResponse(*result.Transfer, result.Transfer.SourceAccount.Currency)
+		res.Transfer = &transfer
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
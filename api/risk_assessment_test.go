@@ -0,0 +1,363 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	"github.com/CrunchyBlue/Golang-Bank/risk"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestRiskEngine(t *testing.T, rules risk.Rules) *risk.Engine {
+	data, err := json.Marshal(rules)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "risk.json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	engine, err := risk.NewEngine(path)
+	require.NoError(t, err)
+	return engine
+}
+
+func TestCreateAssessedTransferAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	otherUser, _ := generateMockUser(t)
+	source := generateMockAccounts(user.Username, 1)[0]
+	destination := generateMockAccounts(otherUser.Username, 1)[0]
+	source.ID, destination.ID = 1, 2
+	source.Currency, destination.Currency = constants.USD, constants.USD
+
+	rules := risk.Rules{
+		ReviewScore:    50,
+		DenyScore:      90,
+		NewDeviceHours: 24,
+		Rules: []risk.Rule{
+			{Name: "new_payee", Signal: risk.SignalNewPayee, Score: 50},
+			{Name: "new_device", Signal: risk.SignalNewDevice, Score: 40},
+		},
+	}
+
+	knownDevice := db.Session{CreatedAt: time.Now().Add(-30 * 24 * time.Hour)}
+	transferResult := db.TransferTxResult{
+		Transfer:           db.Transfer{ID: 1, SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 100},
+		SourceAccount:      source,
+		DestinationAccount: destination,
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Allow",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferRiskStats(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.GetTransferRiskStatsRow{DestinationCount: 3}, nil,
+				)
+				store.EXPECT().GetFirstDeviceSession(gomock.Any(), gomock.Any()).Times(1).Return(knownDevice, nil)
+				store.EXPECT().AssessedTransferTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.AssessedTransferTxParams) (db.AssessedTransferTxResult, error) {
+						require.Equal(t, user.Username, arg.InitiatedBy)
+						require.Equal(t, constants.RiskDecisionAllow, arg.Assessment.Decision)
+						require.Zero(t, arg.Assessment.Score)
+						return db.AssessedTransferTxResult{
+							RiskAssessment: db.RiskAssessment{
+								Decision: constants.RiskDecisionAllow,
+								Status:   constants.RiskAssessmentStatusExecuted,
+							},
+							Transfer: &transferResult,
+						}, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesTransferTxResult(t, recorder.Body, transferResult, constants.USD)
+			},
+		},
+		{
+			name: "Review",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferRiskStats(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.GetTransferRiskStatsRow{}, nil,
+				)
+				store.EXPECT().GetFirstDeviceSession(gomock.Any(), gomock.Any()).Times(1).Return(knownDevice, nil)
+				store.EXPECT().AssessedTransferTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.AssessedTransferTxParams) (db.AssessedTransferTxResult, error) {
+						require.Equal(t, constants.RiskDecisionReview, arg.Assessment.Decision)
+						require.Equal(t, []string{"new_payee"}, arg.Assessment.Reasons)
+						return db.AssessedTransferTxResult{
+							RiskAssessment: db.RiskAssessment{
+								ID:       1,
+								Score:    50,
+								Decision: constants.RiskDecisionReview,
+								Reasons:  "new_payee",
+								Status:   constants.RiskAssessmentStatusPendingReview,
+							},
+						}, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var got riskAssessmentResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.RiskAssessmentStatusPendingReview, got.Status)
+				require.Equal(t, []string{"new_payee"}, got.Reasons)
+			},
+		},
+		{
+			name: "Deny",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferRiskStats(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.GetTransferRiskStatsRow{}, nil,
+				)
+				store.EXPECT().GetFirstDeviceSession(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.Session{}, sql.ErrNoRows,
+				)
+				store.EXPECT().AssessedTransferTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.AssessedTransferTxParams) (db.AssessedTransferTxResult, error) {
+						require.Equal(t, constants.RiskDecisionDeny, arg.Assessment.Decision)
+						require.Equal(t, int64(90), arg.Assessment.Score)
+						return db.AssessedTransferTxResult{
+							RiskAssessment: db.RiskAssessment{
+								ID:       1,
+								Score:    90,
+								Decision: constants.RiskDecisionDeny,
+								Reasons:  "new_payee,new_device",
+								Status:   constants.RiskAssessmentStatusDenied,
+							},
+						}, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferRiskStats(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.GetTransferRiskStatsRow{DestinationCount: 3}, nil,
+				)
+				store.EXPECT().GetFirstDeviceSession(gomock.Any(), gomock.Any()).Times(1).Return(knownDevice, nil)
+				store.EXPECT().AssessedTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AssessedTransferTxResult{}, db.ErrInsufficientFunds,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectClearDestination(store, destination)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				server.risk = newTestRiskEngine(t, rules)
+				recorder := httptest.NewRecorder()
+
+				body := fmt.Sprintf(
+					`{"source_account_id": %d, "destination_account_id": %d, "amount": 100, "currency": "USD"}`,
+					source.ID, destination.ID,
+				)
+				request, err := http.NewRequest(http.MethodPost, "/transfer", bytes.NewReader([]byte(body)))
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestReviewRiskAssessmentAPI(t *testing.T) {
+	admin, _ := generateMockUser(t)
+	user, _ := generateMockUser(t)
+	source := generateMockAccounts(user.Username, 1)[0]
+	source.Currency = constants.USD
+
+	transferResult := db.TransferTxResult{
+		Transfer:      db.Transfer{ID: 1, SourceAccountID: source.ID, Amount: 100},
+		SourceAccount: source,
+	}
+
+	testCases := []struct {
+		name          string
+		action        string
+		body          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Approve",
+			action: "approve",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReviewRiskAssessmentTxParams{ID: 1, Reviewer: admin.Username, Approve: true}
+
+				store.EXPECT().ReviewRiskAssessmentTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					db.ReviewRiskAssessmentTxResult{
+						RiskAssessment: db.RiskAssessment{
+							ID:         1,
+							Status:     constants.RiskAssessmentStatusExecuted,
+							TransferID: sql.NullInt64{Int64: 1, Valid: true},
+						},
+						Transfer: &transferResult,
+					}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got reviewRiskAssessmentResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.RiskAssessmentStatusExecuted, got.RiskAssessment.Status)
+				require.NotNil(t, got.Transfer)
+			},
+		},
+		{
+			name:   "Reject",
+			action: "reject",
+			body:   `{"reason": "Payee unknown to the customer"}`,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReviewRiskAssessmentTxParams{
+					ID:       1,
+					Reviewer: admin.Username,
+					Note:     "Payee unknown to the customer",
+				}
+
+				store.EXPECT().ReviewRiskAssessmentTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					db.ReviewRiskAssessmentTxResult{
+						RiskAssessment: db.RiskAssessment{ID: 1, Status: constants.RiskAssessmentStatusRejected},
+					}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got reviewRiskAssessmentResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.RiskAssessmentStatusRejected, got.RiskAssessment.Status)
+				require.Nil(t, got.Transfer)
+			},
+		},
+		{
+			name:   "AlreadyReviewed",
+			action: "approve",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewRiskAssessmentTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.ReviewRiskAssessmentTxResult{}, db.ErrRiskAssessmentNotPending,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "OwnTransfer",
+			action: "approve",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewRiskAssessmentTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.ReviewRiskAssessmentTxResult{}, db.ErrSelfApproval,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Customer",
+			action: "approve",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewRiskAssessmentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/risk-assessments/1/%s", tc.action)
+				request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
@@ -0,0 +1,338 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type scheduledTransferResponse struct {
+	ID                   int64      `json:"id"`
+	Owner                string     `json:"owner"`
+	SourceAccountID      int64      `json:"source_account_id"`
+	DestinationAccountID int64      `json:"destination_account_id"`
+	Amount               int64      `json:"amount"`
+	AmountDecimal        string     `json:"amount_decimal"`
+	Currency             string     `json:"currency"`
+	Frequency            string     `json:"frequency"`
+	DayOfMonth           int32      `json:"day_of_month"`
+	NextOccurrenceAt     time.Time  `json:"next_occurrence_at"`
+	NextAttemptAt        time.Time  `json:"next_attempt_at"`
+	EndAt                *time.Time `json:"end_at"`
+	RemainingRuns        *int32     `json:"remaining_runs"`
+	Status               string     `json:"status"`
+	Attempts             int32      `json:"attempts"`
+	LastError            string     `json:"last_error"`
+	LastRunAt            *time.Time `json:"last_run_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
+
+func newScheduledTransferResponse(scheduled db.ScheduledTransfer) scheduledTransferResponse {
+	res := scheduledTransferResponse{
+		ID:                   scheduled.ID,
+		Owner:                scheduled.Owner,
+		SourceAccountID:      scheduled.SourceAccountID,
+		DestinationAccountID: scheduled.DestinationAccountID,
+		Amount:               scheduled.Amount,
+		AmountDecimal:        currency.Decimal(scheduled.Currency, scheduled.Amount),
+		Currency:             scheduled.Currency,
+		Frequency:            scheduled.Frequency,
+		DayOfMonth:           scheduled.DayOfMonth,
+		NextOccurrenceAt:     scheduled.NextOccurrenceAt,
+		NextAttemptAt:        scheduled.NextAttemptAt,
+		Status:               scheduled.Status,
+		Attempts:             scheduled.Attempts,
+		LastError:            scheduled.LastError,
+		CreatedAt:            scheduled.CreatedAt,
+	}
+	if scheduled.EndAt.Valid {
+		res.EndAt = &scheduled.EndAt.Time
+	}
+	if scheduled.RemainingRuns.Valid {
+		res.RemainingRuns = &scheduled.RemainingRuns.Int32
+	}
+	if scheduled.LastRunAt.Valid {
+		res.LastRunAt = &scheduled.LastRunAt.Time
+	}
+	return res
+}
+
+type getScheduledTransfersRequest struct {
+	PageNumber int32 `form:"page_number" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+func (server *Server) getScheduledTransfers(ctx *gin.Context) {
+	var req getScheduledTransfersRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.GetScheduledTransfersParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageNumber - 1) * req.PageSize,
+	}
+
+	scheduledTransfers, err := server.store.GetScheduledTransfers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]scheduledTransferResponse, len(scheduledTransfers))
+	for i, scheduled := range scheduledTransfers {
+		res[i] = newScheduledTransferResponse(scheduled)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type createScheduledTransferRequest struct {
+	SourceAccountID int64 `json:"source_account_id" binding:"required_without=SourceAccountNumber,excluded_with=SourceAccountNumber,omitempty,min=1"`
+	// Account number or IBAN of the source account, in place of its ID.
+	SourceAccountNumber  string `json:"source_account_number" binding:"omitempty,account_number"`
+	DestinationAccountID int64  `json:"destination_account_id" binding:"required_without=DestinationAccountNumber,excluded_with=DestinationAccountNumber,omitempty,min=1"`
+	// Account number or IBAN of the destination account, in place of its ID.
+	DestinationAccountNumber string `json:"destination_account_number" binding:"omitempty,account_number"`
+	Amount                   int64  `json:"amount" binding:"required,min=1"`
+	Currency                 string `json:"currency" binding:"required,currency"`
+	Frequency                string `json:"frequency" binding:"required,oneof=once daily weekly monthly"`
+	// Date of the one-off transfer, or of the first occurrence of a recurring one.
+	StartAt time.Time `json:"start_at" binding:"required"`
+	// Day of month for monthly transfers, clamped to the last day of shorter months. Defaults to the day of start_at.
+	DayOfMonth int32 `json:"day_of_month" binding:"omitempty,min=1,max=31"`
+	// Optional end of a recurring transfer. No occurrence is made after it.
+	EndAt *time.Time `json:"end_at"`
+	// Optional number of occurrences of a recurring transfer.
+	Count int32 `json:"count" binding:"omitempty,min=1"`
+}
+
+func (server *Server) createScheduledTransfer(ctx *gin.Context) {
+	var req createScheduledTransferRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.StartAt.Before(time.Now()) {
+		err := errors.New("start_at must be in the future")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.EndAt != nil && req.EndAt.Before(req.StartAt) {
+		err := errors.New("end_at must not be before start_at")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.SourceAccountNumber != "" {
+		sourceAccount, err := server.getAccountByNumber(ctx, req.SourceAccountNumber)
+		if err != nil {
+			ctx.JSON(errorStatus(err), errorResponse(err))
+			return
+		}
+		req.SourceAccountID = sourceAccount.ID
+	}
+
+	if req.DestinationAccountNumber != "" {
+		destinationAccount, err := server.getAccountByNumber(ctx, req.DestinationAccountNumber)
+		if err != nil {
+			ctx.JSON(errorStatus(err), errorResponse(err))
+			return
+		}
+		req.DestinationAccountID = destinationAccount.ID
+	}
+
+	sourceAccount, valid := server.validateAccount(ctx, req.SourceAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	if !server.authorizeAccount(ctx, sourceAccount, accountActionSpend, req.Amount) {
+		return
+	}
+
+	if !server.authorizeWithoutApproval(ctx, sourceAccount, req.Amount) {
+		return
+	}
+
+	destinationAccount, valid := server.validateAccount(ctx, req.DestinationAccountID, req.Currency)
+	if !valid || !server.authorizeDestination(ctx, destinationAccount, req.Amount) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateScheduledTransferParams{
+		Owner:                authPayload.Username,
+		SourceAccountID:      req.SourceAccountID,
+		DestinationAccountID: req.DestinationAccountID,
+		Amount:               req.Amount,
+		Currency:             req.Currency,
+		Frequency:            req.Frequency,
+	}
+
+	if req.Frequency == constants.FrequencyMonthly {
+		arg.DayOfMonth = req.DayOfMonth
+		if arg.DayOfMonth == 0 {
+			arg.DayOfMonth = int32(req.StartAt.Day())
+		}
+	}
+
+	if req.Frequency != constants.FrequencyOnce {
+		if req.EndAt != nil {
+			arg.EndAt = sql.NullTime{Time: *req.EndAt, Valid: true}
+		}
+		if req.Count > 0 {
+			arg.RemainingRuns = sql.NullInt32{Int32: req.Count, Valid: true}
+		}
+	}
+
+	arg.NextOccurrenceAt = util.FirstOccurrence(req.Frequency, int(arg.DayOfMonth), req.StartAt)
+	arg.NextAttemptAt = arg.NextOccurrenceAt
+
+	scheduled, err := server.store.CreateScheduledTransfer(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScheduledTransferResponse(scheduled))
+}
+
+type scheduledTransferActionRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) pauseScheduledTransfer(ctx *gin.Context) {
+	scheduled, valid := server.getManagedScheduledTransfer(ctx, constants.ScheduledTransferStatusActive)
+	if !valid {
+		return
+	}
+
+	server.setScheduledTransferStatus(ctx, scheduled, constants.ScheduledTransferStatusPaused)
+}
+
+func (server *Server) cancelScheduledTransfer(ctx *gin.Context) {
+	scheduled, valid := server.getManagedScheduledTransfer(
+		ctx, constants.ScheduledTransferStatusActive, constants.ScheduledTransferStatusPaused,
+	)
+	if !valid {
+		return
+	}
+
+	server.setScheduledTransferStatus(ctx, scheduled, constants.ScheduledTransferStatusCancelled)
+}
+
+// resumeScheduledTransfer reactivates a paused transfer. Occurrences that fell due while it was paused are skipped
+// rather than paid late, except for a one-off transfer which is made straight away.
+func (server *Server) resumeScheduledTransfer(ctx *gin.Context) {
+	scheduled, valid := server.getManagedScheduledTransfer(ctx, constants.ScheduledTransferStatusPaused)
+	if !valid {
+		return
+	}
+
+	arg := db.UpdateScheduledTransferRunParams{
+		ID:               scheduled.ID,
+		NextOccurrenceAt: scheduled.NextOccurrenceAt,
+		RemainingRuns:    scheduled.RemainingRuns,
+		Status:           constants.ScheduledTransferStatusActive,
+		LastError:        scheduled.LastError,
+		LastRunAt:        scheduled.LastRunAt,
+	}
+
+	now := time.Now()
+	for arg.NextOccurrenceAt.Before(now) {
+		next, ok := util.NextOccurrence(scheduled.Frequency, int(scheduled.DayOfMonth), arg.NextOccurrenceAt)
+		if !ok {
+			break
+		}
+		arg.NextOccurrenceAt = next
+	}
+	arg.NextAttemptAt = arg.NextOccurrenceAt
+
+	if scheduled.EndAt.Valid && arg.NextOccurrenceAt.After(scheduled.EndAt.Time) {
+		arg.Status = constants.ScheduledTransferStatusCompleted
+	}
+
+	scheduled, err := server.store.UpdateScheduledTransferRun(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScheduledTransferResponse(scheduled))
+}
+
+// getManagedScheduledTransfer loads the scheduled transfer named in the URI, checking that it is in one of the given
+// statuses and that the authenticated user either set it up or may manage its source account, so that account owners
+// can stop what their members scheduled.
+func (server *Server) getManagedScheduledTransfer(ctx *gin.Context, statuses ...string) (db.ScheduledTransfer, bool) {
+	var req scheduledTransferActionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.ScheduledTransfer{}, false
+	}
+
+	scheduled, err := server.store.GetScheduledTransfer(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return scheduled, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if scheduled.Owner != authPayload.Username {
+		account, err := server.store.GetAccount(ctx, scheduled.SourceAccountID)
+		if err != nil {
+			ctx.JSON(errorStatus(err), errorResponse(err))
+			return scheduled, false
+		}
+
+		if status, err := server.checkAccountAccess(ctx, account, accountActionManage, 0); err != nil {
+			if status == http.StatusUnauthorized {
+				err = errors.New("scheduled transfer doesn't belong to the authenticated user")
+			}
+			ctx.JSON(status, errorResponse(err))
+			return scheduled, false
+		}
+	}
+
+	for _, status := range statuses {
+		if scheduled.Status == status {
+			return scheduled, true
+		}
+	}
+
+	err = fmt.Errorf("scheduled transfer is %s", scheduled.Status)
+	ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+	return scheduled, false
+}
+
+func (server *Server) setScheduledTransferStatus(ctx *gin.Context, scheduled db.ScheduledTransfer, status string) {
+	arg := db.UpdateScheduledTransferStatusParams{
+		ID:     scheduled.ID,
+		Status: status,
+	}
+
+	scheduled, err := server.store.UpdateScheduledTransferStatus(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScheduledTransferResponse(scheduled))
+}
@@ -0,0 +1,593 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetScheduledTransfersAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	account := generateMockAccounts(user.Username, 1)[0]
+	scheduledTransfers := []db.ScheduledTransfer{
+		generateMockScheduledTransfer(user.Username, account, account.ID+1),
+		generateMockScheduledTransfer(user.Username, account, account.ID+2),
+	}
+
+	testCases := []struct {
+		name          string
+		pageSize      int
+		pageNumber    int
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			pageSize:   10,
+			pageNumber: 1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetScheduledTransfersParams{
+					Owner:  user.Username,
+					Limit:  10,
+					Offset: 0,
+				}
+
+				store.EXPECT().GetScheduledTransfers(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					scheduledTransfers, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesScheduledTransfers(t, recorder.Body, scheduledTransfers)
+			},
+		},
+		{
+			name:       "InternalError",
+			pageSize:   10,
+			pageNumber: 1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfers(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.ScheduledTransfer{}, sql.ErrConnDone,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:       "BadRequest",
+			pageSize:   -1,
+			pageNumber: -1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/scheduled-transfers?page_size=%d&page_number=%d", tc.pageSize, tc.pageNumber)
+				request, err := http.NewRequest(http.MethodGet, url, nil)
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestCreateScheduledTransferAPI(t *testing.T) {
+	user1, _ := generateMockUser(t)
+	user2, _ := generateMockUser(t)
+	account1 := generateMockAccounts(user1.Username, 1)[0]
+	account2 := generateMockAccounts(user2.Username, 1)[0]
+	account2.Currency = account1.Currency
+
+	startAt := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	endAt := startAt.AddDate(1, 0, 0)
+	amount := int64(1000)
+
+	testCases := []struct {
+		name          string
+		body          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "destination_account_id": %d, "amount": %d, "currency": "%s", "frequency": "monthly", "start_at": "%s", "end_at": "%s"}`,
+				account1.ID, account2.ID, amount, account1.Currency, startAt.Format(time.RFC3339),
+				endAt.Format(time.RFC3339),
+			),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateScheduledTransferParams{
+					Owner:                user1.Username,
+					SourceAccountID:      account1.ID,
+					DestinationAccountID: account2.ID,
+					Amount:               amount,
+					Currency:             account1.Currency,
+					Frequency:            constants.FrequencyMonthly,
+					DayOfMonth:           int32(startAt.Day()),
+					NextOccurrenceAt:     startAt,
+					NextAttemptAt:        startAt,
+					EndAt:                sql.NullTime{Time: endAt, Valid: true},
+				}
+				scheduled := db.ScheduledTransfer{
+					ID:                   1,
+					Owner:                arg.Owner,
+					SourceAccountID:      arg.SourceAccountID,
+					DestinationAccountID: arg.DestinationAccountID,
+					Amount:               arg.Amount,
+					Currency:             arg.Currency,
+					Frequency:            arg.Frequency,
+					DayOfMonth:           arg.DayOfMonth,
+					NextOccurrenceAt:     arg.NextOccurrenceAt,
+					NextAttemptAt:        arg.NextAttemptAt,
+					EndAt:                arg.EndAt,
+					Status:               constants.ScheduledTransferStatusActive,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				expectClearDestination(store, account2)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(scheduled, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OnceIgnoresRecurrence",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "destination_account_id": %d, "amount": %d, "currency": "%s", "frequency": "once", "start_at": "%s", "count": 3}`,
+				account1.ID, account2.ID, amount, account1.Currency, startAt.Format(time.RFC3339),
+			),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateScheduledTransferParams{
+					Owner:                user1.Username,
+					SourceAccountID:      account1.ID,
+					DestinationAccountID: account2.ID,
+					Amount:               amount,
+					Currency:             account1.Currency,
+					Frequency:            constants.FrequencyOnce,
+					NextOccurrenceAt:     startAt,
+					NextAttemptAt:        startAt,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				expectClearDestination(store, account2)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					db.ScheduledTransfer{}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AccountNumbers",
+			body: fmt.Sprintf(
+				`{"source_account_number": "%s", "destination_account_number": "%s", "amount": %d, "currency": "%s", "frequency": "daily", "start_at": "%s"}`,
+				account1.AccountNumber, account2.AccountNumber, amount, account1.Currency, startAt.Format(time.RFC3339),
+			),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account1.AccountNumber)).Times(1).Return(
+					account1, nil,
+				)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account2.AccountNumber)).Times(1).Return(
+					account2, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				expectClearDestination(store, account2)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreateScheduledTransferParams) (db.ScheduledTransfer, error) {
+						require.Equal(t, account1.ID, arg.SourceAccountID)
+						require.Equal(t, account2.ID, arg.DestinationAccountID)
+						return db.ScheduledTransfer{
+							ID:                   1,
+							Owner:                arg.Owner,
+							SourceAccountID:      arg.SourceAccountID,
+							DestinationAccountID: arg.DestinationAccountID,
+							Amount:               arg.Amount,
+							Currency:             arg.Currency,
+							Frequency:            arg.Frequency,
+							Status:               constants.ScheduledTransferStatusActive,
+						}, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "destination_account_id": %d, "amount": %d, "currency": "%s", "frequency": "daily", "start_at": "%s"}`,
+				account1.ID, account2.ID, amount, account1.Currency, startAt.Format(time.RFC3339),
+			),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user2.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "HeldPayee",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "destination_account_id": %d, "amount": %d, "currency": "%s", "frequency": "daily", "start_at": "%s"}`,
+				account1.ID, account2.ID, amount, account1.Currency, startAt.Format(time.RFC3339),
+			),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				payee := generateMockPayee(user1.Username, account2, time.Now().Add(-time.Hour))
+				payee.ScreeningStatus = constants.ScreeningStatusBlocked
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				expectPayeeDestination(store, account2, user2, payee)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "StartInPast",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "destination_account_id": %d, "amount": %d, "currency": "%s", "frequency": "daily", "start_at": "%s"}`,
+				account1.ID, account2.ID, amount, account1.Currency,
+				time.Now().Add(-time.Hour).Format(time.RFC3339),
+			),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidFrequency",
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "destination_account_id": %d, "amount": %d, "currency": "%s", "frequency": "hourly", "start_at": "%s"}`,
+				account1.ID, account2.ID, amount, account1.Currency, startAt.Format(time.RFC3339),
+			),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(
+					http.MethodPost, "/scheduled-transfers", bytes.NewReader([]byte(tc.body)),
+				)
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestUpdateScheduledTransferStatusAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	account := generateMockAccounts(user.Username, 1)[0]
+	scheduled := generateMockScheduledTransfer(user.Username, account, account.ID+1)
+
+	paused := scheduled
+	paused.Status = constants.ScheduledTransferStatusPaused
+
+	cancelled := scheduled
+	cancelled.Status = constants.ScheduledTransferStatusCancelled
+
+	spender := db.AccountMember{
+		AccountID:     account.ID,
+		Username:      "spender",
+		Role:          constants.AccountRoleSpender,
+		TransferLimit: sql.NullInt64{Int64: 1000, Valid: true},
+	}
+	memberScheduled := generateMockScheduledTransfer(spender.Username, account, account.ID+1)
+	memberScheduled.ID = scheduled.ID
+	memberCancelled := memberScheduled
+	memberCancelled.Status = constants.ScheduledTransferStatusCancelled
+
+	testCases := []struct {
+		name          string
+		action        string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Pause",
+			action:   "pause",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateScheduledTransferStatusParams{
+					ID:     scheduled.ID,
+					Status: constants.ScheduledTransferStatusPaused,
+				}
+
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduled.ID)).Times(1).Return(
+					scheduled, nil,
+				)
+				store.EXPECT().UpdateScheduledTransferStatus(gomock.Any(), gomock.Eq(arg)).Times(1).Return(paused, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesScheduledTransfer(t, recorder.Body, paused)
+			},
+		},
+		{
+			name:     "PauseNotActive",
+			action:   "pause",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduled.ID)).Times(1).Return(
+					paused, nil,
+				)
+				store.EXPECT().UpdateScheduledTransferStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:     "Cancel",
+			action:   "cancel",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateScheduledTransferStatusParams{
+					ID:     scheduled.ID,
+					Status: constants.ScheduledTransferStatusCancelled,
+				}
+
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduled.ID)).Times(1).Return(
+					paused, nil,
+				)
+				store.EXPECT().UpdateScheduledTransferStatus(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					cancelled, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesScheduledTransfer(t, recorder.Body, cancelled)
+			},
+		},
+		{
+			name:     "Resume",
+			action:   "resume",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				missed := paused
+				missed.NextOccurrenceAt = scheduled.NextOccurrenceAt.AddDate(0, 0, -14)
+
+				arg := db.UpdateScheduledTransferRunParams{
+					ID:               scheduled.ID,
+					NextOccurrenceAt: scheduled.NextOccurrenceAt,
+					NextAttemptAt:    scheduled.NextOccurrenceAt,
+					Status:           constants.ScheduledTransferStatusActive,
+				}
+
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduled.ID)).Times(1).Return(
+					missed, nil,
+				)
+				store.EXPECT().UpdateScheduledTransferRun(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					scheduled, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesScheduledTransfer(t, recorder.Body, scheduled)
+			},
+		},
+		{
+			name:     "AccountOwnerCancelsMemberTransfer",
+			action:   "cancel",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateScheduledTransferStatusParams{
+					ID:     memberScheduled.ID,
+					Status: constants.ScheduledTransferStatusCancelled,
+				}
+
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduled.ID)).Times(1).Return(
+					memberScheduled, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdateScheduledTransferStatus(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					memberCancelled, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesScheduledTransfer(t, recorder.Body, memberCancelled)
+			},
+		},
+		{
+			name:     "SpenderCantManageOthers",
+			action:   "pause",
+			username: spender.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduled.ID)).Times(1).Return(
+					scheduled, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(spender, nil)
+				store.EXPECT().UpdateScheduledTransferStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			action:   "cancel",
+			username: "unauthorized_user",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduled.ID)).Times(1).Return(
+					scheduled, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+				store.EXPECT().UpdateScheduledTransferStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			action:   "pause",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduled.ID)).Times(1).Return(
+					db.ScheduledTransfer{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/scheduled-transfers/%d/%s", scheduled.ID, tc.action)
+				request, err := http.NewRequest(http.MethodPost, url, nil)
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func requireBodyMatchesScheduledTransfer(t *testing.T, body *bytes.Buffer, scheduled db.ScheduledTransfer) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var fetchedScheduledTransfer scheduledTransferResponse
+	err = json.Unmarshal(data, &fetchedScheduledTransfer)
+	require.NoError(t, err)
+	require.Equal(t, newScheduledTransferResponse(scheduled), fetchedScheduledTransfer)
+}
+
+func requireBodyMatchesScheduledTransfers(t *testing.T, body *bytes.Buffer, scheduledTransfers []db.ScheduledTransfer) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var fetchedScheduledTransfers []scheduledTransferResponse
+	err = json.Unmarshal(data, &fetchedScheduledTransfers)
+	require.NoError(t, err)
+
+	for i, scheduled := range scheduledTransfers {
+		require.Equal(t, newScheduledTransferResponse(scheduled), fetchedScheduledTransfers[i])
+	}
+}
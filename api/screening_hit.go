@@ -0,0 +1,156 @@
+package api
+
+import (
+	"database/sql"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type screeningHitResponse struct {
+	ID             int64      `json:"id"`
+	Username       string     `json:"username"`
+	PayeeID        *int64     `json:"payee_id"`
+	OrganizationID *int64     `json:"organization_id"`
+	ScreenedName   string     `json:"screened_name"`
+	EntryID        string     `json:"entry_id"`
+	EntryName      string     `json:"entry_name"`
+	MatchedName    string     `json:"matched_name"`
+	Programs       string     `json:"programs"`
+	Score          int32      `json:"score"`
+	Status         string     `json:"status"`
+	ReviewedBy     *string    `json:"reviewed_by"`
+	ReviewNote     *string    `json:"review_note"`
+	ReviewedAt     *time.Time `json:"reviewed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func newScreeningHitResponse(hit db.ScreeningHit) screeningHitResponse {
+	res := screeningHitResponse{
+		ID:             hit.ID,
+		Username:       hit.Username,
+		PayeeID:        nullInt64Pointer(hit.PayeeID),
+		OrganizationID: nullInt64Pointer(hit.OrganizationID),
+		ScreenedName:   hit.ScreenedName,
+		EntryID:        hit.EntryID,
+		EntryName:      hit.EntryName,
+		MatchedName:    hit.MatchedName,
+		Programs:       hit.Programs,
+		Score:          hit.Score,
+		Status:         hit.Status,
+		ReviewedBy:     nullStringPointer(hit.ReviewedBy),
+		ReviewNote:     nullStringPointer(hit.ReviewNote),
+		CreatedAt:      hit.CreatedAt,
+	}
+	if hit.ReviewedAt.Valid {
+		res.ReviewedAt = &hit.ReviewedAt.Time
+	}
+	return res
+}
+
+type getScreeningHitsRequest struct {
+	Status     string `form:"status" binding:"omitempty,oneof=pending_review confirmed cleared"`
+	PageNumber int32  `form:"page_number" binding:"required,min=1"`
+	PageSize   int32  `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+// getScreeningHits lists hits newest first. The review queue is the hits with status pending_review.
+func (server *Server) getScreeningHits(ctx *gin.Context) {
+	var req getScreeningHitsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hits, err := server.store.GetScreeningHits(
+		ctx, db.GetScreeningHitsParams{
+			Status: sql.NullString{String: req.Status, Valid: req.Status != ""},
+			Limit:  req.PageSize,
+			Offset: (req.PageNumber - 1) * req.PageSize,
+		},
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]screeningHitResponse, len(hits))
+	for i, hit := range hits {
+		res[i] = newScreeningHitResponse(hit)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type screeningHitRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getScreeningHit(ctx *gin.Context) {
+	var req screeningHitRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hit, err := server.store.GetScreeningHit(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScreeningHitResponse(hit))
+}
+
+type reviewScreeningHitBody struct {
+	Note string `json:"note" binding:"required,max=2000"`
+}
+
+type reviewScreeningHitRequest struct {
+	UriParams screeningHitRequest
+	Body      reviewScreeningHitBody
+}
+
+// clearScreeningHit marks a hit as a false match. The user or payee is released once none of its hits are pending.
+func (server *Server) clearScreeningHit(ctx *gin.Context) {
+	server.reviewScreeningHit(ctx, false)
+}
+
+// confirmScreeningHit marks a hit as a true match, which keeps the user or payee blocked.
+func (server *Server) confirmScreeningHit(ctx *gin.Context) {
+	server.reviewScreeningHit(ctx, true)
+}
+
+func (server *Server) reviewScreeningHit(ctx *gin.Context, confirm bool) {
+	var req reviewScreeningHitRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	hit, err := server.store.ReviewScreeningHitTx(
+		ctx, db.ReviewScreeningHitTxParams{
+			ID:         req.UriParams.ID,
+			ReviewedBy: authPayload.Username,
+			Confirm:    confirm,
+			Note:       req.Body.Note,
+		},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScreeningHitResponse(hit))
+}
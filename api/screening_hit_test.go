@@ -0,0 +1,350 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	"github.com/CrunchyBlue/Golang-Bank/screening"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testSanctionedName = "Hoda Salih Hamada"
+
+func newTestScreener(t *testing.T) *screening.Screener {
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	data := `2674,"HAMADA, Hoda Salih","individual","[IRAQ2] [SDGT]",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+
+	screener, err := screening.NewScreener(path, 90)
+	require.NoError(t, err)
+	return screener
+}
+
+func TestCreateUserScreeningAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		fullName      string
+		buildStubs    func(store *mockdb.MockStore, user db.User)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Hit",
+			fullName: "HODA SALIH HAMADA",
+			buildStubs: func(store *mockdb.MockStore, user db.User) {
+				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+				store.EXPECT().RecordScreeningHitsTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.RecordScreeningHitsTxParams) ([]db.ScreeningHit, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.False(t, arg.PayeeID.Valid)
+						require.Len(t, arg.Matches, 1)
+						require.Equal(t, "2674", arg.Matches[0].Entry.ID)
+						return []db.ScreeningHit{{ID: 1, Username: user.Username}}, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got userResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.ScreeningStatusPendingReview, got.ScreeningStatus)
+			},
+		},
+		{
+			name:     "Clear",
+			fullName: "Jane Alice Smith",
+			buildStubs: func(store *mockdb.MockStore, user db.User) {
+				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+				store.EXPECT().RecordScreeningHitsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got userResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.ScreeningStatusClear, got.ScreeningStatus)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				user, password := generateMockUser(t)
+				user.FullName = tc.fullName
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store, user)
+
+				server := newTestServer(t, store)
+				server.screener = newTestScreener(t)
+				recorder := httptest.NewRecorder()
+
+				body := fmt.Sprintf(
+					`{"username": "%s", "password": "%s", "full_name": "%s", "email": "%s"}`,
+					user.Username, password, user.FullName, user.Email,
+				)
+				request, err := http.NewRequest(http.MethodPost, "/user", bytes.NewReader([]byte(body)))
+				require.NoError(t, err)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestCreatePayeeScreeningAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	holder, _ := generateMockUser(t)
+	holder.FullName = testSanctionedName
+	account := generateMockAccounts(holder.Username, 1)[0]
+	account.Currency = constants.USD
+	payee := generateMockPayee(user.Username, account, time.Now().Add(time.Hour))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	// The holder's name is looked up to screen it, even without an expected name to check
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(holder.Username)).Times(1).Return(holder, nil)
+	store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(1).Return(payee, nil)
+	store.EXPECT().RecordScreeningHitsTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(_ interface{}, arg db.RecordScreeningHitsTxParams) ([]db.ScreeningHit, error) {
+			require.Equal(t, user.Username, arg.Username)
+			require.Equal(t, sql.NullInt64{Int64: payee.ID, Valid: true}, arg.PayeeID)
+			require.Equal(t, testSanctionedName, arg.ScreenedName)
+			return []db.ScreeningHit{{ID: 1, Username: user.Username, PayeeID: arg.PayeeID}}, nil
+		},
+	)
+
+	server := newTestServer(t, store)
+	server.screener = newTestScreener(t)
+	recorder := httptest.NewRecorder()
+
+	body := fmt.Sprintf(`{"nickname": "Supplier", "account_id": %d, "currency": "USD"}`, account.ID)
+	request, err := http.NewRequest(http.MethodPost, "/payees", bytes.NewReader([]byte(body)))
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got payeeResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &got)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScreeningStatusPendingReview, got.ScreeningStatus)
+}
+
+func TestCreateOrganizationScreeningAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	organization := generateMockOrganization(user.Username)
+	organization.Name = testSanctionedName
+	organization.ScreeningStatus = constants.ScreeningStatusClear
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().CreateOrganizationTx(gomock.Any(), gomock.Any()).Times(1).Return(organization, nil)
+	store.EXPECT().RecordScreeningHitsTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(_ interface{}, arg db.RecordScreeningHitsTxParams) ([]db.ScreeningHit, error) {
+			require.Equal(t, user.Username, arg.Username)
+			require.Equal(t, sql.NullInt64{Int64: organization.ID, Valid: true}, arg.OrganizationID)
+			require.False(t, arg.PayeeID.Valid)
+			require.Equal(t, testSanctionedName, arg.ScreenedName)
+			return []db.ScreeningHit{{ID: 1, Username: user.Username, OrganizationID: arg.OrganizationID}}, nil
+		},
+	)
+
+	server := newTestServer(t, store)
+	server.screener = newTestScreener(t)
+	recorder := httptest.NewRecorder()
+
+	body := fmt.Sprintf(`{"name": "%s", "approval_threshold": 1000, "required_approvals": 2}`, organization.Name)
+	request, err := http.NewRequest(http.MethodPost, "/organizations", bytes.NewReader([]byte(body)))
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got db.Organization
+	err = json.Unmarshal(recorder.Body.Bytes(), &got)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScreeningStatusPendingReview, got.ScreeningStatus)
+}
+
+func TestReviewScreeningHitAPI(t *testing.T) {
+	analyst, _ := generateMockUser(t)
+	user, _ := generateMockUser(t)
+
+	asAnalyst := func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+		addRoleAuthorization(
+			t, req, tokenMaker, authorizationTypeBearer, analyst.Username, constants.RoleAnalyst, time.Minute,
+		)
+	}
+
+	testCases := []struct {
+		name          string
+		url           string
+		body          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "Clear",
+			url:       "/screening-hits/1/clear",
+			body:      `{"note": "Different date of birth"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReviewScreeningHitTxParams{
+					ID:         1,
+					ReviewedBy: analyst.Username,
+					Note:       "Different date of birth",
+				}
+
+				store.EXPECT().ReviewScreeningHitTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					db.ScreeningHit{
+						ID:         1,
+						Username:   user.Username,
+						Status:     constants.ScreeningHitStatusCleared,
+						ReviewedBy: sql.NullString{String: analyst.Username, Valid: true},
+						ReviewedAt: sql.NullTime{Time: time.Now(), Valid: true},
+					}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got screeningHitResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.ScreeningHitStatusCleared, got.Status)
+				require.Nil(t, got.PayeeID)
+				require.NotNil(t, got.ReviewedAt)
+			},
+		},
+		{
+			name:      "Confirm",
+			url:       "/screening-hits/1/confirm",
+			body:      `{"note": "Passport matches the listing"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReviewScreeningHitTxParams{
+					ID:         1,
+					ReviewedBy: analyst.Username,
+					Confirm:    true,
+					Note:       "Passport matches the listing",
+				}
+
+				store.EXPECT().ReviewScreeningHitTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					db.ScreeningHit{ID: 1, Status: constants.ScreeningHitStatusConfirmed}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "NotPending",
+			url:       "/screening-hits/1/clear",
+			body:      `{"note": "Different date of birth"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewScreeningHitTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.ScreeningHit{}, db.ErrScreeningHitNotPending,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "OwnHit",
+			url:       "/screening-hits/1/clear",
+			body:      `{"note": "It isn't me"}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewScreeningHitTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.ScreeningHit{}, db.ErrSelfApproval,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "NoNote",
+			url:       "/screening-hits/1/clear",
+			body:      `{}`,
+			setupAuth: asAnalyst,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewScreeningHitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Customer",
+			url:  "/screening-hits/1/clear",
+			body: `{"note": "Not me"}`,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewScreeningHitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
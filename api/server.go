@@ -1,7 +1,15 @@
 package api
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/blob"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	"github.com/CrunchyBlue/Golang-Bank/limit"
+	"github.com/CrunchyBlue/Golang-Bank/risk"
+	"github.com/CrunchyBlue/Golang-Bank/screening"
 	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
 	"github.com/CrunchyBlue/Golang-Bank/token"
 	"github.com/CrunchyBlue/Golang-Bank/util"
@@ -9,25 +17,70 @@ import (
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	_ "github.com/go-playground/validator/v10"
+	"net/http"
 )
 
 type Server struct {
 	config     util.Config
 	store      db.Store
 	tokenMaker token.Maker
+	risk       *risk.Engine
+	screener   *screening.Screener
+	documents  blob.Store
 	router     *gin.Engine
 }
 
-func NewServer(store db.Store, config util.Config) (*Server, error) {
+type ServerOption func(server *Server)
+
+// WithRiskEngine makes the server assess transfers against engine's rules. A server without one assesses none.
+func WithRiskEngine(engine *risk.Engine) ServerOption {
+	return func(server *Server) {
+		server.risk = engine
+	}
+}
+
+// WithScreener makes the server screen the names of new users and payees against screener's watchlist. A server
+// without one screens none.
+func WithScreener(screener *screening.Screener) ServerOption {
+	return func(server *Server) {
+		server.screener = screener
+	}
+}
+
+// WithDocumentStore makes the server keep uploaded KYC documents in store. A server without one refuses uploads.
+func WithDocumentStore(store blob.Store) ServerOption {
+	return func(server *Server) {
+		server.documents = store
+	}
+}
+
+func NewServer(store db.Store, config util.Config, opts ...ServerOption) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.AccessTokenSymmetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	if len(config.EnabledCurrencies) > 0 {
+		if err := currency.SetEnabled(config.EnabledCurrencies); err != nil {
+			return nil, fmt.Errorf("cannot enable currencies: %w", err)
+		}
+	}
+
+	if config.IBANCountryCode != "" {
+		if _, err := util.NewIBAN(config.IBANCountryCode, config.IBANBankCode, "0"); err != nil {
+			return nil, fmt.Errorf("invalid IBAN configuration: %w", err)
+		}
+	}
+
 	server := &Server{
 		config:     config,
 		store:      store,
 		tokenMaker: tokenMaker,
+		risk:       &risk.Engine{},
+		screener:   &screening.Screener{},
+	}
+	for _, opt := range opts {
+		opt(server)
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
@@ -35,6 +88,26 @@ func NewServer(store db.Store, config util.Config) (*Server, error) {
 		if err != nil {
 			return nil, fmt.Errorf("cannot register binding validator: %w", err)
 		}
+
+		err = v.RegisterValidation("account_number", validateAccountNumber)
+		if err != nil {
+			return nil, fmt.Errorf("cannot register binding validator: %w", err)
+		}
+
+		err = v.RegisterValidation("remittance_text", validateRemittanceText)
+		if err != nil {
+			return nil, fmt.Errorf("cannot register binding validator: %w", err)
+		}
+
+		err = v.RegisterValidation("remittance_reference", validateRemittanceReference)
+		if err != nil {
+			return nil, fmt.Errorf("cannot register binding validator: %w", err)
+		}
+
+		err = v.RegisterValidation("category", validateCategory)
+		if err != nil {
+			return nil, fmt.Errorf("cannot register binding validator: %w", err)
+		}
 	}
 
 	server.mapRoutes()
@@ -55,29 +128,152 @@ func (server *Server) mapRoutes() {
 	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
 
 	// Account
+	accountParam := server.resolveAccountParam("id")
 	authRoutes.GET("/accounts", server.getAccounts)
-	authRoutes.GET("/account/:id", server.getAccount)
+	authRoutes.GET("/account/:id", accountParam, server.getAccount)
+	authRoutes.GET("/account/:id/balance", accountParam, server.getAccountBalance)
+	authRoutes.GET("/account/:id/statement", accountParam, server.getRangeStatement)
+	authRoutes.GET("/account/:id/statements", accountParam, server.getStatements)
+	authRoutes.GET("/account/:id/statements/:period", accountParam, server.getStatement)
 	authRoutes.POST("/account", server.createAccount)
-	authRoutes.PUT("/account/:id", server.updateAccount)
-	authRoutes.PUT("/account/:id/balance", server.updateAccountBalance)
-	authRoutes.DELETE("/account/:id", server.deleteAccount)
+	authRoutes.PUT("/account/:id/nickname", accountParam, server.updateAccountNickname)
+	authRoutes.POST("/account/:id/close", accountParam, server.closeAccount)
+	authRoutes.GET("/account/:id/status-changes", accountParam, server.getAccountStatusChanges)
+	authRoutes.GET("/account/:id/members", accountParam, server.getAccountMembers)
+	authRoutes.DELETE("/account/:id/members/:username", accountParam, server.removeAccountMember)
+	authRoutes.GET("/account/:id/invitations", accountParam, server.getAccountInvitations)
+	authRoutes.POST("/account/:id/invitations", accountParam, server.createAccountInvitation)
+	authRoutes.DELETE("/account/:id/invitations/:invitation_id", accountParam, server.revokeAccountInvitation)
+	authRoutes.GET("/invitations", server.getInvitations)
+	authRoutes.POST("/invitations/:id/accept", server.acceptInvitation)
+	authRoutes.POST("/invitations/:id/decline", server.declineInvitation)
+
+	authRoutes.POST(
+		"/account/:id/status", requireRole(constants.RoleTeller, constants.RoleAdmin), accountParam,
+		server.changeAccountStatus,
+	)
+
+	// Cash Transaction
+	cashRoles := requireRole(constants.RoleTeller, constants.RoleAdmin, constants.RoleRail)
+	authRoutes.POST("/account/:id/deposit", cashRoles, accountParam, server.depositToAccount)
+	authRoutes.POST("/account/:id/withdraw", cashRoles, accountParam, server.withdrawFromAccount)
+
+	// Account Product
+	authRoutes.GET("/account-products", server.getAccountProducts)
+
+	// AML Alert
+	adminRole := requireRole(constants.RoleAdmin)
+	analystRoles := requireRole(constants.RoleAnalyst, constants.RoleAdmin)
+	authRoutes.GET("/aml-alerts", analystRoles, server.getAmlAlerts)
+	authRoutes.GET("/aml-alerts/export", analystRoles, server.exportAmlAlerts)
+	authRoutes.GET("/aml-alerts/:id", analystRoles, server.getAmlAlert)
+	authRoutes.POST("/aml-alerts/:id/assign", analystRoles, server.assignAmlAlert)
+	authRoutes.POST("/aml-alerts/:id/comments", analystRoles, server.createAmlAlertComment)
+	authRoutes.POST("/aml-alerts/:id/close", analystRoles, server.closeAmlAlert)
 
 	// Entry
-	authRoutes.GET("/entries", server.getEntries)
-	authRoutes.GET("/entries/:account_id", server.getEntriesForAccount)
+	accountIDParam := server.resolveAccountParam("account_id")
+	authRoutes.GET("/entries", adminRole, server.getEntries)
+	authRoutes.GET("/entries/:account_id", accountIDParam, server.getEntriesForAccount)
 	authRoutes.GET("/entry/:id", server.getEntry)
-	authRoutes.POST("/entry", server.createEntry)
-	authRoutes.PUT("/entry/:id", server.updateEntry)
-	authRoutes.DELETE("/entry/:id", server.deleteEntry)
+
+	// Fee
+	authRoutes.GET("/fees/:account_id", accountIDParam, server.getFeesForAccount)
+
+	// Hold
+	authRoutes.GET("/holds/:account_id", accountIDParam, server.getHoldsForAccount)
+	authRoutes.GET("/hold/:id", server.getHold)
+	authRoutes.POST("/hold", server.createHold)
+	authRoutes.POST("/hold/:id/capture", server.captureHold)
+	authRoutes.POST("/hold/:id/void", server.voidHold)
+
+	// KYC
+	authRoutes.GET("/kyc", server.getKyc)
+	authRoutes.POST("/kyc/documents", server.uploadKycDocument)
+	authRoutes.GET("/kyc/documents/:id/content", server.getKycDocumentContent)
+	authRoutes.DELETE("/kyc/documents/:id", server.deleteKycDocument)
+	authRoutes.POST("/kyc/submit", server.submitKyc)
+	authRoutes.GET("/kyc/submissions", analystRoles, server.getKycSubmissions)
+	authRoutes.GET("/kyc/submissions/:id", analystRoles, server.getKycSubmission)
+	authRoutes.POST("/kyc/submissions/:id/approve", analystRoles, server.approveKycSubmission)
+	authRoutes.POST("/kyc/submissions/:id/reject", analystRoles, server.rejectKycSubmission)
+
+	// Notification
+	authRoutes.GET("/notifications", server.getNotifications)
+
+	// Organization
+	authRoutes.GET("/organizations", server.getOrganizations)
+	authRoutes.POST("/organizations", server.createOrganization)
+	authRoutes.GET("/organizations/:id", server.getOrganization)
+	authRoutes.PUT("/organizations/:id/approval-rule", server.updateOrganizationApprovalRule)
+	authRoutes.GET("/organizations/:id/members", server.getOrganizationMembers)
+	authRoutes.POST("/organizations/:id/members", server.addOrganizationMember)
+	authRoutes.DELETE("/organizations/:id/members/:username", server.removeOrganizationMember)
+	authRoutes.POST("/organizations/:id/accounts", server.createOrganizationAccount)
+	authRoutes.GET("/organizations/:id/pending-transfers", server.getPendingTransfers)
+	authRoutes.GET("/pending-transfers/:id", server.getPendingTransfer)
+	authRoutes.POST("/pending-transfers/:id/approve", server.approvePendingTransfer)
+	authRoutes.POST("/pending-transfers/:id/reject", server.rejectPendingTransfer)
+
+	// Payee
+	authRoutes.GET("/payees", server.getPayees)
+	authRoutes.POST("/payees", server.createPayee)
+	authRoutes.GET("/payees/:id", server.getPayee)
+	authRoutes.PUT("/payees/:id", server.updatePayee)
+	authRoutes.DELETE("/payees/:id", server.deletePayee)
+
+	// Payment Request
+	authRoutes.GET("/payment-requests/incoming", server.getIncomingPaymentRequests)
+	authRoutes.GET("/payment-requests/outgoing", server.getOutgoingPaymentRequests)
+	authRoutes.POST("/payment-requests", server.createPaymentRequest)
+	authRoutes.GET("/payment-requests/:id", server.getPaymentRequest)
+	authRoutes.POST("/payment-requests/:id/accept", server.acceptPaymentRequest)
+	authRoutes.POST("/payment-requests/:id/decline", server.declinePaymentRequest)
+	authRoutes.POST("/payment-requests/:id/cancel", server.cancelPaymentRequest)
+
+	// Reconciliation
+	authRoutes.GET("/reconciliation/latest", adminRole, server.getLatestReconciliationRun)
+
+	// Risk Assessment
+	authRoutes.GET("/risk-assessments", adminRole, server.getRiskAssessments)
+	authRoutes.GET("/risk-assessments/:id", adminRole, server.getRiskAssessment)
+	authRoutes.POST("/risk-assessments/:id/approve", adminRole, server.approveRiskAssessment)
+	authRoutes.POST("/risk-assessments/:id/reject", adminRole, server.rejectRiskAssessment)
+
+	// Scheduled Transfer
+	authRoutes.GET("/scheduled-transfers", server.getScheduledTransfers)
+	authRoutes.POST("/scheduled-transfers", server.createScheduledTransfer)
+	authRoutes.POST("/scheduled-transfers/:id/pause", server.pauseScheduledTransfer)
+	authRoutes.POST("/scheduled-transfers/:id/resume", server.resumeScheduledTransfer)
+	authRoutes.POST("/scheduled-transfers/:id/cancel", server.cancelScheduledTransfer)
+
+	// Screening Hit
+	authRoutes.GET("/screening-hits", analystRoles, server.getScreeningHits)
+	authRoutes.GET("/screening-hits/:id", analystRoles, server.getScreeningHit)
+	authRoutes.POST("/screening-hits/:id/clear", analystRoles, server.clearScreeningHit)
+	authRoutes.POST("/screening-hits/:id/confirm", analystRoles, server.confirmScreeningHit)
 
 	// Transfer
-	authRoutes.GET("/transfers", server.getTransfers)
-	authRoutes.GET("/transfers/:account_id/outbound", server.getOutboundTransfersForAccount)
-	authRoutes.GET("/transfers/:account_id/inbound", server.getInboundTransfersForAccount)
+	authRoutes.GET("/transfers", adminRole, server.getTransfers)
+	authRoutes.GET("/transfers/:account_id/outbound", accountIDParam, server.getOutboundTransfersForAccount)
+	authRoutes.GET("/transfers/:account_id/inbound", accountIDParam, server.getInboundTransfersForAccount)
+	authRoutes.GET("/transfers/:account_id/search", accountIDParam, server.searchTransfers)
 	authRoutes.GET("/transfer/:id", server.getTransfer)
 	authRoutes.POST("/transfer", server.createTransfer)
-	authRoutes.PUT("/transfer/:id", server.updateTransfer)
-	authRoutes.DELETE("/transfer/:id", server.deleteTransfer)
+	authRoutes.POST("/transfer/split", server.createMultiTransfer)
+
+	// Transfer Batch
+	authRoutes.POST("/transfer-batches", server.createTransferBatch)
+	authRoutes.GET("/transfer-batches/:id", server.getTransferBatch)
+	authRoutes.GET("/transfer-batches/:id/items", server.getTransferBatchItems)
+
+	// Transfer Limit
+	authRoutes.GET("/transfer-limits", server.getTransferAllowance)
+	authRoutes.PUT("/users/:username/tier", adminRole, server.updateUserTier)
+	authRoutes.PUT("/organizations/:id/tier", adminRole, server.updateOrganizationTier)
+	authRoutes.GET("/users/:username/transfer-limit-overrides", adminRole, server.getTransferLimitOverrides)
+	authRoutes.POST("/users/:username/transfer-limit-overrides", adminRole, server.createTransferLimitOverride)
+	authRoutes.DELETE("/transfer-limit-overrides/:id", adminRole, server.deleteTransferLimitOverride)
 
 	server.router = router
 }
@@ -89,3 +285,47 @@ func (server *Server) Start(address string) error {
 func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 }
+
+// errorStatus maps errors returned by the store to an HTTP status code.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.Is(err, db.ErrAccountLimitReached),
+		errors.Is(err, db.ErrSelfApproval),
+		errors.Is(err, db.ErrScreeningHold),
+		errors.Is(err, db.ErrDestinationScreeningHold),
+		errors.Is(err, limit.ErrExceeded):
+		return http.StatusForbidden
+	case errors.Is(err, db.ErrInsufficientFunds),
+		errors.Is(err, db.ErrHoldNotActive),
+		errors.Is(err, db.ErrHoldExpired),
+		errors.Is(err, db.ErrPendingTransferExpired),
+		errors.Is(err, db.ErrPaymentRequestExpired),
+		errors.Is(err, db.ErrCaptureExceedsHold),
+		errors.Is(err, db.ErrMultiTransferOverflow),
+		errors.Is(err, db.ErrAccountFrozen),
+		errors.Is(err, db.ErrAccountDormant),
+		errors.Is(err, db.ErrAccountClosed),
+		errors.Is(err, db.ErrAmlAssigneeNotAnalyst),
+		errors.Is(err, db.ErrKycDocumentsMissing):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, db.ErrIdempotencyKeyReused),
+		errors.Is(err, db.ErrInvalidStatusTransition),
+		errors.Is(err, db.ErrAccountNotEmpty),
+		errors.Is(err, db.ErrAlreadyMember),
+		errors.Is(err, db.ErrInvitationNotPending),
+		errors.Is(err, db.ErrOrganizationAccount),
+		errors.Is(err, db.ErrPendingTransferNotPending),
+		errors.Is(err, db.ErrAlreadyApproved),
+		errors.Is(err, db.ErrPaymentRequestNotPending),
+		errors.Is(err, db.ErrTransferBatchNotPending),
+		errors.Is(err, db.ErrRiskAssessmentNotPending),
+		errors.Is(err, db.ErrAmlAlertClosed),
+		errors.Is(err, db.ErrScreeningHitNotPending),
+		errors.Is(err, db.ErrKycNotEditable),
+		errors.Is(err, db.ErrKycSubmissionNotPending):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
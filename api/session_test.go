@@ -155,7 +155,7 @@ func TestRenewAccessTokenAPI(t *testing.T) {
 				recorder := httptest.NewRecorder()
 
 				refreshToken, _, err := server.tokenMaker.CreateToken(
-					user.Username, server.config.RefreshTokenDuration,
+					user.Username, user.Role, server.config.RefreshTokenDuration,
 				)
 
 				tc.buildStubs(store, refreshToken)
@@ -0,0 +1,273 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/statement"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+const (
+	statementFormatJSON = "json"
+	statementFormatCSV  = "csv"
+	statementFormatPDF  = "pdf"
+
+	contentHashHeader = "X-Content-SHA256"
+)
+
+type statementSummaryResponse struct {
+	ID             int64     `json:"id"`
+	AccountID      int64     `json:"account_id"`
+	PeriodStart    time.Time `json:"period_start"`
+	PeriodEnd      time.Time `json:"period_end"`
+	OpeningBalance int64     `json:"opening_balance"`
+	ClosingBalance int64     `json:"closing_balance"`
+	ContentHash    string    `json:"content_hash"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func newStatementSummaryResponse(s db.Statement) statementSummaryResponse {
+	return statementSummaryResponse{
+		ID:             s.ID,
+		AccountID:      s.AccountID,
+		PeriodStart:    s.PeriodStart,
+		PeriodEnd:      s.PeriodEnd,
+		OpeningBalance: s.OpeningBalance,
+		ClosingBalance: s.ClosingBalance,
+		ContentHash:    s.ContentHash,
+		CreatedAt:      s.CreatedAt,
+	}
+}
+
+type statementResponse struct {
+	// ID and CreatedAt are only set for stored statements.
+	ID          *int64          `json:"id,omitempty"`
+	CreatedAt   *time.Time      `json:"created_at,omitempty"`
+	ContentHash string          `json:"content_hash"`
+	Statement   json.RawMessage `json:"statement"`
+}
+
+type getStatementsUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type getStatementsQueryParams struct {
+	PageNumber int32 `form:"page_number" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+type getStatementsRequest struct {
+	UriParams   getStatementsUriParams
+	QueryParams getStatementsQueryParams
+}
+
+func (server *Server) getStatements(ctx *gin.Context) {
+	var req getStatementsRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.ID, accountActionView)
+	if !valid {
+		return
+	}
+
+	arg := db.GetStatementsForAccountParams{
+		AccountID: account.ID,
+		Limit:     req.QueryParams.PageSize,
+		Offset:    (req.QueryParams.PageNumber - 1) * req.QueryParams.PageSize,
+	}
+
+	statements, err := server.store.GetStatementsForAccount(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]statementSummaryResponse, len(statements))
+	for i, s := range statements {
+		res[i] = newStatementSummaryResponse(s)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type getStatementUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+	// Month of the statement, as YYYY-MM.
+	Period string `uri:"period" binding:"required,datetime=2006-01"`
+}
+
+type statementFormatQueryParams struct {
+	Format string `form:"format" binding:"omitempty,oneof=json csv pdf"`
+}
+
+type getStatementRequest struct {
+	UriParams   getStatementUriParams
+	QueryParams statementFormatQueryParams
+}
+
+func (server *Server) getStatement(ctx *gin.Context) {
+	var req getStatementRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.ID, accountActionView)
+	if !valid {
+		return
+	}
+
+	periodStart, err := time.Parse("2006-01", req.UriParams.Period)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GetStatementParams{
+		AccountID:   account.ID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodStart.AddDate(0, 1, 0),
+	}
+
+	stored, err := server.store.GetStatement(ctx, arg)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	filename := fmt.Sprintf("statement-%d-%s", account.ID, req.UriParams.Period)
+	writeStatement(
+		ctx, req.QueryParams.Format, filename, statementResponse{
+			ID:          &stored.ID,
+			CreatedAt:   &stored.CreatedAt,
+			ContentHash: stored.ContentHash,
+			Statement:   stored.Content,
+		},
+	)
+}
+
+type getRangeStatementUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type getRangeStatementQueryParams struct {
+	statementFormatQueryParams
+	From time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	// Inclusive.
+	To time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+}
+
+type getRangeStatementRequest struct {
+	UriParams   getRangeStatementUriParams
+	QueryParams getRangeStatementQueryParams
+}
+
+// getRangeStatement builds a statement for any range of days on demand. Unlike monthly statements it isn't stored.
+func (server *Server) getRangeStatement(ctx *gin.Context) {
+	var req getRangeStatementRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.QueryParams.To.Before(req.QueryParams.From) {
+		err := errors.New("to must not be before from")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.ID, accountActionView)
+	if !valid {
+		return
+	}
+
+	s, err := server.store.BuildStatement(
+		ctx, db.StatementParams{
+			AccountID:   account.ID,
+			PeriodStart: req.QueryParams.From,
+			PeriodEnd:   req.QueryParams.To.AddDate(0, 0, 1),
+		},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	content, hash, err := statement.Encode(s)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	filename := fmt.Sprintf(
+		"statement-%d-%s-%s", account.ID, req.QueryParams.From.Format("2006-01-02"),
+		req.QueryParams.To.Format("2006-01-02"),
+	)
+	writeStatement(
+		ctx, req.QueryParams.Format, filename, statementResponse{
+			ContentHash: hash,
+			Statement:   content,
+		},
+	)
+}
+
+// writeStatement responds with the statement in the requested format, JSON by default. CSV and PDF are rendered from
+// the statement content, and carry its hash in a header.
+func writeStatement(ctx *gin.Context, format string, filename string, res statementResponse) {
+	if format == "" || format == statementFormatJSON {
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
+	var s statement.Statement
+	if err := json.Unmarshal(res.Statement, &s); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var buf bytes.Buffer
+	var contentType string
+	var err error
+	switch format {
+	case statementFormatCSV:
+		contentType = "text/csv"
+		err = statement.WriteCSV(&buf, s)
+	case statementFormatPDF:
+		contentType = "application/pdf"
+		err = statement.WritePDF(&buf, s)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header(contentHashHeader, res.ContentHash)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+	ctx.Data(http.StatusOK, contentType, buf.Bytes())
+}
@@ -4,17 +4,120 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
 	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
-	"github.com/CrunchyBlue/Golang-Bank/token"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strings"
+	"time"
 )
 
+// remittanceRequest is what the payer can say about a transfer. References, invoice numbers and end-to-end IDs are
+// limited to the SWIFT character set and 35 characters so that they can be passed on to other banks unchanged.
+type remittanceRequest struct {
+	Memo          string `json:"memo" binding:"omitempty,max=140,remittance_text"`
+	Reference     string `json:"reference" binding:"omitempty,max=35,remittance_reference"`
+	Category      string `json:"category" binding:"omitempty,max=30,category"`
+	InvoiceNumber string `json:"invoice_number" binding:"omitempty,max=35,remittance_reference"`
+	EndToEndID    string `json:"end_to_end_id" binding:"omitempty,max=35,remittance_reference"`
+}
+
+func (req remittanceRequest) remittance() db.Remittance {
+	return db.Remittance{
+		Memo:          sql.NullString{String: req.Memo, Valid: req.Memo != ""},
+		Reference:     sql.NullString{String: req.Reference, Valid: req.Reference != ""},
+		Category:      sql.NullString{String: req.Category, Valid: req.Category != ""},
+		InvoiceNumber: sql.NullString{String: req.InvoiceNumber, Valid: req.InvoiceNumber != ""},
+		EndToEndID:    sql.NullString{String: req.EndToEndID, Valid: req.EndToEndID != ""},
+	}
+}
+
+type remittanceResponse struct {
+	Memo          *string `json:"memo"`
+	Reference     *string `json:"reference"`
+	Category      *string `json:"category"`
+	InvoiceNumber *string `json:"invoice_number"`
+	EndToEndID    *string `json:"end_to_end_id"`
+}
+
+func newRemittanceResponse(remittance db.Remittance) remittanceResponse {
+	return remittanceResponse{
+		Memo:          nullStringPointer(remittance.Memo),
+		Reference:     nullStringPointer(remittance.Reference),
+		Category:      nullStringPointer(remittance.Category),
+		InvoiceNumber: nullStringPointer(remittance.InvoiceNumber),
+		EndToEndID:    nullStringPointer(remittance.EndToEndID),
+	}
+}
+
+func nullStringPointer(v sql.NullString) *string {
+	if !v.Valid {
+		return nil
+	}
+	return &v.String
+}
+
+type transferResponse struct {
+	ID                   int64     `json:"id"`
+	SourceAccountID      int64     `json:"source_account_id"`
+	DestinationAccountID int64     `json:"destination_account_id"`
+	Amount               int64     `json:"amount"`
+	AmountDecimal        string    `json:"amount_decimal"`
+	JournalTransactionID *int64    `json:"journal_transaction_id"`
+	CreatedAt            time.Time `json:"created_at"`
+	remittanceResponse
+}
+
+func newTransferResponse(transfer db.Transfer, currencyCode string) transferResponse {
+	res := transferResponse{
+		ID:                   transfer.ID,
+		SourceAccountID:      transfer.SourceAccountID,
+		DestinationAccountID: transfer.DestinationAccountID,
+		Amount:               transfer.Amount,
+		AmountDecimal:        currency.Decimal(currencyCode, transfer.Amount),
+		CreatedAt:            transfer.CreatedAt,
+		remittanceResponse: newRemittanceResponse(
+			db.Remittance{
+				Memo:          transfer.Memo,
+				Reference:     transfer.Reference,
+				Category:      transfer.Category,
+				InvoiceNumber: transfer.InvoiceNumber,
+				EndToEndID:    transfer.EndToEndID,
+			},
+		),
+	}
+	if transfer.JournalTransactionID.Valid {
+		res.JournalTransactionID = &transfer.JournalTransactionID.Int64
+	}
+	return res
+}
+
+type transferTxResponse struct {
+	Transfer           transferResponse `json:"transfer"`
+	SourceAccount      accountResponse  `json:"source_account"`
+	DestinationAccount accountResponse  `json:"destination_account"`
+	FromEntry          entryResponse    `json:"from_entry"`
+	ToEntry            entryResponse    `json:"to_entry"`
+	Fees               []feeResponse    `json:"fees"`
+}
+
+func newTransferTxResponse(result db.TransferTxResult, currencyCode string) transferTxResponse {
+	return transferTxResponse{
+		Transfer:           newTransferResponse(result.Transfer, currencyCode),
+		SourceAccount:      newAccountResponse(result.SourceAccount),
+		DestinationAccount: newAccountResponse(result.DestinationAccount),
+		FromEntry:          newEntryResponse(result.FromEntry, currencyCode),
+		ToEntry:            newEntryResponse(result.ToEntry, currencyCode),
+		Fees:               newFeeResponses(result.Fees, currencyCode),
+	}
+}
+
 type getTransfersRequest struct {
 	PageNumber int32 `form:"page_number" binding:"required,min=1"`
 	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
 }
 
+// getTransfers lists every transfer in the bank, for admins.
 func (server *Server) getTransfers(ctx *gin.Context) {
 	var req getTransfersRequest
 
@@ -34,7 +137,27 @@ func (server *Server) getTransfers(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, transfers)
+	// Transfers are in their source account's currency, which the query joins in
+	res := make([]transferResponse, len(transfers))
+	for i, transfer := range transfers {
+		res[i] = newTransferResponse(
+			db.Transfer{
+				ID:                   transfer.ID,
+				SourceAccountID:      transfer.SourceAccountID,
+				DestinationAccountID: transfer.DestinationAccountID,
+				Amount:               transfer.Amount,
+				CreatedAt:            transfer.CreatedAt,
+				JournalTransactionID: transfer.JournalTransactionID,
+				Memo:                 transfer.Memo,
+				Reference:            transfer.Reference,
+				Category:             transfer.Category,
+				InvoiceNumber:        transfer.InvoiceNumber,
+				EndToEndID:           transfer.EndToEndID,
+			}, transfer.Currency,
+		)
+	}
+
+	ctx.JSON(http.StatusOK, res)
 }
 
 type getOutboundTransfersForAccountUriParams struct {
@@ -64,19 +187,29 @@ func (server *Server) getOutboundTransfersForAccount(ctx *gin.Context) {
 		return
 	}
 
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.AccountID, accountActionView)
+	if !valid {
+		return
+	}
+
 	arg := db.GetOutboundTransfersForAccountParams{
 		SourceAccountID: req.UriParams.AccountID,
 		Limit:           req.QueryParams.PageSize,
 		Offset:          (req.QueryParams.PageNumber - 1) * req.QueryParams.PageSize,
 	}
 
-	entries, err := server.store.GetOutboundTransfersForAccount(ctx, arg)
+	transfers, err := server.store.GetOutboundTransfersForAccount(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, entries)
+	res := make([]transferResponse, len(transfers))
+	for i, transfer := range transfers {
+		res[i] = newTransferResponse(transfer, account.Currency)
+	}
+
+	ctx.JSON(http.StatusOK, res)
 }
 
 type getInboundTransfersForAccountUriParams struct {
@@ -106,19 +239,97 @@ func (server *Server) getInboundTransfersForAccount(ctx *gin.Context) {
 		return
 	}
 
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.AccountID, accountActionView)
+	if !valid {
+		return
+	}
+
 	arg := db.GetInboundTransfersForAccountParams{
 		DestinationAccountID: req.UriParams.AccountID,
 		Limit:                req.QueryParams.PageSize,
 		Offset:               (req.QueryParams.PageNumber - 1) * req.QueryParams.PageSize,
 	}
 
-	entries, err := server.store.GetInboundTransfersForAccount(ctx, arg)
+	transfers, err := server.store.GetInboundTransfersForAccount(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, entries)
+	res := make([]transferResponse, len(transfers))
+	for i, transfer := range transfers {
+		res[i] = newTransferResponse(transfer, account.Currency)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type searchTransfersUriParams struct {
+	AccountID int64 `uri:"account_id" binding:"required,min=1"`
+}
+
+type searchTransfersQueryParams struct {
+	// Matched anywhere in the memo or reference, ignoring case.
+	Query         string `form:"q" binding:"omitempty,max=140"`
+	Reference     string `form:"reference" binding:"omitempty,max=35,remittance_reference"`
+	Category      string `form:"category" binding:"omitempty,max=30,category"`
+	InvoiceNumber string `form:"invoice_number" binding:"omitempty,max=35,remittance_reference"`
+	EndToEndID    string `form:"end_to_end_id" binding:"omitempty,max=35,remittance_reference"`
+	PageNumber    int32  `form:"page_number" binding:"required,min=1"`
+	PageSize      int32  `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+type searchTransfersRequest struct {
+	UriParams   searchTransfersUriParams
+	QueryParams searchTransfersQueryParams
+}
+
+// likeEscaper escapes the characters that ILIKE would otherwise treat as wildcards.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// searchTransfers finds an account's inbound and outbound transfers by their remittance information, newest first.
+func (server *Server) searchTransfers(ctx *gin.Context) {
+	var req searchTransfersRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAuthorizedAccount(ctx, req.UriParams.AccountID, accountActionView)
+	if !valid {
+		return
+	}
+
+	query := req.QueryParams
+	arg := db.SearchTransfersParams{
+		AccountID:     account.ID,
+		Search:        sql.NullString{String: likeEscaper.Replace(query.Query), Valid: query.Query != ""},
+		Reference:     sql.NullString{String: query.Reference, Valid: query.Reference != ""},
+		Category:      sql.NullString{String: query.Category, Valid: query.Category != ""},
+		InvoiceNumber: sql.NullString{String: query.InvoiceNumber, Valid: query.InvoiceNumber != ""},
+		EndToEndID:    sql.NullString{String: query.EndToEndID, Valid: query.EndToEndID != ""},
+		PageLimit:     query.PageSize,
+		PageOffset:    (query.PageNumber - 1) * query.PageSize,
+	}
+
+	transfers, err := server.store.SearchTransfers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]transferResponse, len(transfers))
+	for i, transfer := range transfers {
+		res[i] = newTransferResponse(transfer, account.Currency)
+	}
+
+	ctx.JSON(http.StatusOK, res)
 }
 
 type getTransferRequest struct {
@@ -144,14 +355,47 @@ func (server *Server) getTransfer(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, transfer)
+	account, valid := server.authorizeTransferView(ctx, transfer)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newTransferResponse(transfer, account.Currency))
+}
+
+// authorizeTransferView checks that the caller may view either of transfer's accounts, returning the one they may
+// view, and writing the error response for the destination account if they may view neither.
+func (server *Server) authorizeTransferView(ctx *gin.Context, transfer db.Transfer) (db.Account, bool) {
+	source, err := server.store.GetAccount(ctx, transfer.SourceAccountID)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return source, false
+	}
+
+	status, err := server.checkAccountAccess(ctx, source, accountActionView, 0)
+	if err == nil {
+		return source, true
+	}
+	if status == http.StatusInternalServerError {
+		ctx.JSON(status, errorResponse(err))
+		return source, false
+	}
+
+	return server.loadAuthorizedAccount(ctx, transfer.DestinationAccountID, accountActionView)
 }
 
 type createTransferRequest struct {
-	SourceAccountID      int64  `json:"source_account_id" binding:"required,min=1"`
-	DestinationAccountID int64  `json:"destination_account_id" binding:"required,min=1"`
-	Amount               int64  `json:"amount" binding:"required,min=1"`
-	Currency             string `json:"currency" binding:"required,currency"`
+	SourceAccountID int64 `json:"source_account_id" binding:"required_without=SourceAccountNumber,excluded_with=SourceAccountNumber,omitempty,min=1"`
+	// Account number or IBAN of the source account, in place of its ID.
+	SourceAccountNumber  string `json:"source_account_number" binding:"omitempty,account_number"`
+	DestinationAccountID int64  `json:"destination_account_id" binding:"required_without_all=DestinationAccountNumber PayeeID,excluded_with=DestinationAccountNumber PayeeID,omitempty,min=1"`
+	// Account number or IBAN of the destination account, in place of its ID.
+	DestinationAccountNumber string `json:"destination_account_number" binding:"omitempty,excluded_with=PayeeID,account_number"`
+	// One of the authenticated user's payees, in place of the destination account.
+	PayeeID  int64  `json:"payee_id" binding:"omitempty,min=1"`
+	Amount   int64  `json:"amount" binding:"required,min=1"`
+	Currency string `json:"currency" binding:"required,currency"`
+	remittanceRequest
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
@@ -162,20 +406,43 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
+	if req.PayeeID != 0 {
+		payee, valid := server.loadPayee(ctx, req.PayeeID)
+		if !valid {
+			return
+		}
+		req.DestinationAccountID = payee.AccountID
+	}
+
+	if req.DestinationAccountNumber != "" {
+		destinationAccount, err := server.getAccountByNumber(ctx, req.DestinationAccountNumber)
+		if err != nil {
+			ctx.JSON(errorStatus(err), errorResponse(err))
+			return
+		}
+		req.DestinationAccountID = destinationAccount.ID
+	}
+
+	if req.SourceAccountNumber != "" {
+		sourceAccount, err := server.getAccountByNumber(ctx, req.SourceAccountNumber)
+		if err != nil {
+			ctx.JSON(errorStatus(err), errorResponse(err))
+			return
+		}
+		req.SourceAccountID = sourceAccount.ID
+	}
+
 	sourceAccount, valid := server.validateAccount(ctx, req.SourceAccountID, req.Currency)
 	if !valid {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if sourceAccount.Owner != authPayload.Username {
-		err := errors.New("source account does not belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	if !server.authorizeAccount(ctx, sourceAccount, accountActionSpend, req.Amount) {
 		return
 	}
 
-	_, valid = server.validateAccount(ctx, req.DestinationAccountID, req.Currency)
-	if !valid {
+	destinationAccount, valid := server.validateAccount(ctx, req.DestinationAccountID, req.Currency)
+	if !valid || !server.authorizeDestination(ctx, destinationAccount, req.Amount) {
 		return
 	}
 
@@ -183,86 +450,31 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		SourceAccountID:      req.SourceAccountID,
 		DestinationAccountID: req.DestinationAccountID,
 		Amount:               req.Amount,
+		Remittance:           req.remittance(),
 	}
 
-	transfer, err := server.store.TransferTx(ctx, arg)
+	organization, err := server.getSpendingOrganization(ctx, sourceAccount)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	ctx.JSON(http.StatusOK, transfer)
-}
-
-type updateTransferUriParams struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
-}
-
-type updateTransferBody struct {
-	Amount int64 `json:"amount" binding:"required,min=1"`
-}
-
-type updateTransferRequest struct {
-	UriParams updateTransferUriParams
-	Body      updateTransferBody
-}
-
-func (server *Server) updateTransfer(ctx *gin.Context) {
-	var req updateTransferRequest
-
-	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
-
-	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	if requiresApproval(organization, req.Amount) {
+		server.createPendingTransfer(ctx, organization, arg)
 		return
 	}
 
-	arg := db.UpdateTransferParams{
-		ID:     req.UriParams.ID,
-		Amount: req.Body.Amount,
-	}
-
-	transfer, err := server.store.UpdateTransfer(ctx, arg)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	if rules := server.risk.Rules(); !rules.Empty() {
+		server.createAssessedTransfer(ctx, rules, arg, req.Currency)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, transfer)
-}
-
-type deleteTransferRequest struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
-}
-
-func (server *Server) deleteTransfer(ctx *gin.Context) {
-	var req deleteTransferRequest
-
-	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	err := server.store.DeleteTransfer(ctx, req.ID)
+	result, err := server.store.TransferTx(ctx, arg)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
-	ctx.Status(http.StatusOK)
+	ctx.JSON(http.StatusOK, newTransferTxResponse(result, req.Currency))
 }
 
 func (server *Server) validateAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
@@ -0,0 +1,505 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	errInvalidTransferBatchItems = errors.New("transfer batch has invalid items")
+	errTransferBatchOverflow     = errors.New("transfer batch total is too large")
+)
+
+type transferBatchProgress struct {
+	Pending   int64 `json:"pending"`
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+	Skipped   int64 `json:"skipped"`
+}
+
+type transferBatchResponse struct {
+	ID              int64  `json:"id"`
+	SourceAccountID int64  `json:"source_account_id"`
+	CreatedBy       string `json:"created_by"`
+	Mode            string `json:"mode"`
+	Currency        string `json:"currency"`
+	ItemCount       int32  `json:"item_count"`
+	TotalAmount     int64  `json:"total_amount"`
+	Status          string `json:"status"`
+	// Why an atomic batch was rolled back
+	Error       *string               `json:"error"`
+	Progress    transferBatchProgress `json:"progress"`
+	CreatedAt   time.Time             `json:"created_at"`
+	StartedAt   *time.Time            `json:"started_at"`
+	CompletedAt *time.Time            `json:"completed_at"`
+}
+
+// newTransferBatchResponse builds a batch's response, counting its items by status for the progress.
+func (server *Server) newTransferBatchResponse(ctx *gin.Context, batch db.TransferBatch) (
+	transferBatchResponse, error,
+) {
+	res := transferBatchResponse{
+		ID:              batch.ID,
+		SourceAccountID: batch.SourceAccountID,
+		CreatedBy:       batch.CreatedBy,
+		Mode:            batch.Mode,
+		Currency:        batch.Currency,
+		ItemCount:       batch.ItemCount,
+		TotalAmount:     batch.TotalAmount,
+		Status:          batch.Status,
+		Error:           nullStringPointer(batch.Error),
+		CreatedAt:       batch.CreatedAt,
+	}
+	if batch.StartedAt.Valid {
+		res.StartedAt = &batch.StartedAt.Time
+	}
+	if batch.CompletedAt.Valid {
+		res.CompletedAt = &batch.CompletedAt.Time
+	}
+
+	counts, err := server.store.CountTransferBatchItems(ctx, batch.ID)
+	if err != nil {
+		return res, err
+	}
+
+	for _, count := range counts {
+		switch count.Status {
+		case constants.TransferBatchItemStatusPending:
+			res.Progress.Pending = count.Count
+		case constants.TransferBatchItemStatusSucceeded:
+			res.Progress.Succeeded = count.Count
+		case constants.TransferBatchItemStatusFailed:
+			res.Progress.Failed = count.Count
+		case constants.TransferBatchItemStatusSkipped:
+			res.Progress.Skipped = count.Count
+		}
+	}
+
+	return res, nil
+}
+
+type transferBatchItemResponse struct {
+	ID                   int64 `json:"id"`
+	Position             int32 `json:"position"`
+	DestinationAccountID int64 `json:"destination_account_id"`
+	Amount               int64 `json:"amount"`
+	remittanceResponse
+	Status      string     `json:"status"`
+	TransferID  *int64     `json:"transfer_id"`
+	Error       *string    `json:"error"`
+	ProcessedAt *time.Time `json:"processed_at"`
+}
+
+func newTransferBatchItemResponse(item db.TransferBatchItem) transferBatchItemResponse {
+	res := transferBatchItemResponse{
+		ID:                   item.ID,
+		Position:             item.Position,
+		DestinationAccountID: item.DestinationAccountID,
+		Amount:               item.Amount,
+		remittanceResponse: newRemittanceResponse(
+			db.Remittance{
+				Memo:          item.Memo,
+				Reference:     item.Reference,
+				Category:      item.Category,
+				InvoiceNumber: item.InvoiceNumber,
+				EndToEndID:    item.EndToEndID,
+			},
+		),
+		Status: item.Status,
+		Error:  nullStringPointer(item.Error),
+	}
+	if item.TransferID.Valid {
+		res.TransferID = &item.TransferID.Int64
+	}
+	if item.ProcessedAt.Valid {
+		res.ProcessedAt = &item.ProcessedAt.Time
+	}
+	return res
+}
+
+// loadTransferBatch loads a batch, checking that the caller can view its source account.
+func (server *Server) loadTransferBatch(ctx *gin.Context, id int64) (db.TransferBatch, bool) {
+	batch, err := server.store.GetTransferBatch(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return batch, false
+	}
+
+	_, valid := server.loadAuthorizedAccount(ctx, batch.SourceAccountID, accountActionView)
+	return batch, valid
+}
+
+type transferBatchItemRequest struct {
+	DestinationAccountID int64 `json:"destination_account_id" binding:"required_without=DestinationAccountNumber,excluded_with=DestinationAccountNumber,omitempty,min=1"`
+	// Account number or IBAN of the destination account, in place of its ID.
+	DestinationAccountNumber string `json:"destination_account_number" binding:"omitempty,account_number"`
+	Amount                   int64  `json:"amount" binding:"required,min=1"`
+	remittanceRequest
+}
+
+type createTransferBatchRequest struct {
+	SourceAccountID int64 `json:"source_account_id" binding:"required_without=SourceAccountNumber,excluded_with=SourceAccountNumber,omitempty,min=1"`
+	// Account number or IBAN of the source account, in place of its ID.
+	SourceAccountNumber string `json:"source_account_number" binding:"omitempty,account_number"`
+	Currency            string `json:"currency" binding:"required,currency"`
+	// atomic transfers every item or none of them, best_effort as many as it can
+	Mode  string                     `json:"mode" binding:"required,oneof=atomic best_effort"`
+	Items []transferBatchItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// createTransferBatchForm is the multipart form a batch can be uploaded as, with its items in a CSV file.
+type createTransferBatchForm struct {
+	SourceAccountID     int64                 `form:"source_account_id"`
+	SourceAccountNumber string                `form:"source_account_number"`
+	Currency            string                `form:"currency"`
+	Mode                string                `form:"mode"`
+	File                *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+type transferBatchItemError struct {
+	Position int    `json:"position"`
+	Error    string `json:"error"`
+}
+
+// createTransferBatch transfers from one source account to many destinations. The items are sent as JSON, or as a CSV
+// file in a multipart form. Every item is checked before anything is transferred. Batches of up to the configured
+// threshold run straight away; larger ones are left pending for the transfer batch job, and 202 is returned.
+func (server *Server) createTransferBatch(ctx *gin.Context) {
+	var req createTransferBatchRequest
+
+	if ctx.ContentType() == binding.MIMEMultipartPOSTForm {
+		if err := server.bindTransferBatchForm(ctx, &req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	} else if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if len(req.Items) > server.config.TransferBatchMaxItems {
+		err := fmt.Errorf("transfer batch has more than %d items", server.config.TransferBatchMaxItems)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.SourceAccountNumber != "" {
+		sourceAccount, err := server.getAccountByNumber(ctx, req.SourceAccountNumber)
+		if err != nil {
+			ctx.JSON(errorStatus(err), errorResponse(err))
+			return
+		}
+		req.SourceAccountID = sourceAccount.ID
+	}
+
+	source, valid := server.validateAccount(ctx, req.SourceAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	// A total that wrapped around would get past the approval threshold
+	var total, largest int64
+	for _, item := range req.Items {
+		if item.Amount > math.MaxInt64-total {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errTransferBatchOverflow))
+			return
+		}
+		total += item.Amount
+		if item.Amount > largest {
+			largest = item.Amount
+		}
+	}
+
+	// Each item is a transfer of its own for the member's per-transfer limit, but the batch as a whole is what would
+	// need approval, so that splitting a payout into items can't get around it
+	if !server.authorizeAccount(ctx, source, accountActionSpend, largest) ||
+		!server.authorizeWithoutApproval(ctx, source, total) {
+		return
+	}
+
+	items, valid := server.resolveTransferBatchItems(ctx, source, req.Items)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateTransferBatchTxParams{
+		CreateTransferBatchParams: db.CreateTransferBatchParams{
+			SourceAccountID: source.ID,
+			CreatedBy:       authPayload.Username,
+			Mode:            req.Mode,
+			Currency:        req.Currency,
+			ItemCount:       int32(len(items)),
+			TotalAmount:     total,
+		},
+		Items: items,
+	}
+
+	result, err := server.store.CreateTransferBatchTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	status := http.StatusAccepted
+	batch := result.Batch
+	if len(items) <= server.config.TransferBatchAsyncThreshold {
+		status = http.StatusOK
+		batch, err = server.store.RunTransferBatch(ctx, batch.ID)
+		if err != nil {
+			ctx.JSON(errorStatus(err), errorResponse(err))
+			return
+		}
+	}
+
+	res, err := server.newTransferBatchResponse(ctx, batch)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(status, res)
+}
+
+// bindTransferBatchForm binds a batch uploaded as a multipart form, then validates it as if it had been sent as JSON.
+func (server *Server) bindTransferBatchForm(ctx *gin.Context, req *createTransferBatchRequest) error {
+	var form createTransferBatchForm
+	if err := ctx.ShouldBindWith(&form, binding.FormMultipart); err != nil {
+		return err
+	}
+
+	file, err := form.File.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	req.SourceAccountID = form.SourceAccountID
+	req.SourceAccountNumber = form.SourceAccountNumber
+	req.Currency = form.Currency
+	req.Mode = form.Mode
+	req.Items, err = parseTransferBatchCSV(file)
+	if err != nil {
+		return err
+	}
+
+	return binding.Validator.ValidateStruct(req)
+}
+
+// parseTransferBatchCSV reads batch items from CSV. The header row names the columns, which are the same as the
+// fields of a JSON item; amount is required and the others are optional.
+func parseTransferBatchCSV(r io.Reader) ([]transferBatchItemRequest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[name] = i
+	}
+	if _, ok := columns["amount"]; !ok {
+		return nil, errors.New("CSV has no amount column")
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok {
+			return record[i]
+		}
+		return ""
+	}
+
+	var items []transferBatchItemRequest
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		item := transferBatchItemRequest{
+			DestinationAccountNumber: field(record, "destination_account_number"),
+			remittanceRequest: remittanceRequest{
+				Memo:          field(record, "memo"),
+				Reference:     field(record, "reference"),
+				Category:      field(record, "category"),
+				InvoiceNumber: field(record, "invoice_number"),
+				EndToEndID:    field(record, "end_to_end_id"),
+			},
+		}
+
+		item.Amount, err = strconv.ParseInt(field(record, "amount"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount: %w", line, err)
+		}
+
+		if id := field(record, "destination_account_id"); id != "" {
+			item.DestinationAccountID, err = strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid destination_account_id: %w", line, err)
+			}
+		}
+
+		items = append(items, item)
+	}
+}
+
+// resolveTransferBatchItems looks up every item's destination account, responding with the error for each item that
+// can't be transferred to. Positions count from one.
+func (server *Server) resolveTransferBatchItems(
+	ctx *gin.Context, source db.Account, reqItems []transferBatchItemRequest,
+) ([]db.CreateTransferBatchItemParams, bool) {
+	items := make([]db.CreateTransferBatchItemParams, len(reqItems))
+	var itemErrors []transferBatchItemError
+	// Items to the same account count together towards its cooling-off limit
+	sent := make(map[int64]int64, len(reqItems))
+
+	for i, reqItem := range reqItems {
+		var destination db.Account
+		var err error
+		if reqItem.DestinationAccountNumber != "" {
+			destination, err = server.getAccountByNumber(ctx, reqItem.DestinationAccountNumber)
+		} else {
+			destination, err = server.store.GetAccount(ctx, reqItem.DestinationAccountID)
+		}
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = errors.New("destination account not found")
+		case err != nil:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return nil, false
+		case destination.ID == source.ID:
+			err = errors.New("destination account must not be the source account")
+		case destination.Currency != source.Currency:
+			err = fmt.Errorf("account [%d] currency mismatch: %s vs %s", destination.ID, destination.Currency, source.Currency)
+		default:
+			sent[destination.ID] += reqItem.Amount
+
+			var status int
+			status, err = server.checkDestination(ctx, destination, sent[destination.ID])
+			if status == http.StatusInternalServerError {
+				ctx.JSON(status, errorResponse(err))
+				return nil, false
+			}
+		}
+		if err != nil {
+			itemErrors = append(itemErrors, transferBatchItemError{Position: i + 1, Error: err.Error()})
+			continue
+		}
+
+		remittance := reqItem.remittance()
+		items[i] = db.CreateTransferBatchItemParams{
+			Position:             int32(i + 1),
+			DestinationAccountID: destination.ID,
+			Amount:               reqItem.Amount,
+			Memo:                 remittance.Memo,
+			Reference:            remittance.Reference,
+			Category:             remittance.Category,
+			InvoiceNumber:        remittance.InvoiceNumber,
+			EndToEndID:           remittance.EndToEndID,
+		}
+	}
+
+	if len(itemErrors) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": errInvalidTransferBatchItems.Error(), "items": itemErrors})
+		return nil, false
+	}
+	return items, true
+}
+
+type transferBatchRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getTransferBatch reports a batch's status and how many of its items are pending, succeeded, failed or skipped.
+func (server *Server) getTransferBatch(ctx *gin.Context) {
+	var req transferBatchRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	batch, valid := server.loadTransferBatch(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	res, err := server.newTransferBatchResponse(ctx, batch)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type getTransferBatchItemsQueryParams struct {
+	Status     string `form:"status" binding:"omitempty,oneof=pending succeeded failed skipped"`
+	PageNumber int32  `form:"page_number" binding:"required,min=1"`
+	PageSize   int32  `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+type getTransferBatchItemsRequest struct {
+	UriParams   transferBatchRequest
+	QueryParams getTransferBatchItemsQueryParams
+}
+
+// getTransferBatchItems lists a batch's items in order, optionally only those with a status, such as the failures.
+func (server *Server) getTransferBatchItems(ctx *gin.Context) {
+	var req getTransferBatchItemsRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	batch, valid := server.loadTransferBatch(ctx, req.UriParams.ID)
+	if !valid {
+		return
+	}
+
+	arg := db.GetTransferBatchItemsParams{
+		BatchID: batch.ID,
+		Status:  sql.NullString{String: req.QueryParams.Status, Valid: req.QueryParams.Status != ""},
+		Limit:   req.QueryParams.PageSize,
+		Offset:  (req.QueryParams.PageNumber - 1) * req.QueryParams.PageSize,
+	}
+
+	items, err := server.store.GetTransferBatchItems(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]transferBatchItemResponse, len(items))
+	for i, item := range items {
+		res[i] = newTransferBatchItemResponse(item)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
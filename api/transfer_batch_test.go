@@ -0,0 +1,613 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func generateMockTransferBatch(createdBy string, source db.Account, mode string) db.TransferBatch {
+	return db.TransferBatch{
+		ID:              util.RandomInt(1, 1000),
+		SourceAccountID: source.ID,
+		CreatedBy:       createdBy,
+		Mode:            mode,
+		Currency:        source.Currency,
+		ItemCount:       2,
+		TotalAmount:     util.RandomInt(2, 200),
+		Status:          constants.TransferBatchStatusPending,
+		CreatedAt:       time.Now(),
+	}
+}
+
+// transferBatchForm builds a multipart form uploading a batch with its items as CSV.
+func transferBatchForm(t *testing.T, source db.Account, mode string, items string) (string, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	require.NoError(t, writer.WriteField("source_account_id", fmt.Sprint(source.ID)))
+	require.NoError(t, writer.WriteField("currency", source.Currency))
+	require.NoError(t, writer.WriteField("mode", mode))
+
+	file, err := writer.CreateFormFile("file", "batch.csv")
+	require.NoError(t, err)
+	_, err = file.Write([]byte(items))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return body.String(), writer.FormDataContentType()
+}
+
+func TestCreateTransferBatchAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	otherUser, _ := generateMockUser(t)
+	source := generateMockAccounts(user.Username, 1)[0]
+	destinations := generateMockAccounts(otherUser.Username, 3)
+	otherSource := generateMockAccounts(otherUser.Username, 1)[0]
+	source.ID = 1
+	source.Currency = constants.USD
+	for i := range destinations {
+		destinations[i].ID = int64(i + 2)
+		destinations[i].Currency = constants.USD
+	}
+	otherSource.ID = 5
+	otherSource.Currency = constants.USD
+	organizationSource := source
+	organizationSource.ID = 6
+	organizationSource.OrganizationID = sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true}
+	organization := db.Organization{
+		ID:                organizationSource.OrganizationID.Int64,
+		ApprovalThreshold: 25,
+		RequiredApprovals: 1,
+	}
+	destinations[2].Currency = constants.EUR
+
+	batch := generateMockTransferBatch(user.Username, source, constants.TransferBatchModeAtomic)
+	completed := batch
+	completed.Status = constants.TransferBatchStatusCompleted
+
+	csvBody, csvContentType := transferBatchForm(
+		t, source, constants.TransferBatchModeBestEffort,
+		fmt.Sprintf(
+			"destination_account_id,destination_account_number,amount,memo,invoice_number\n"+
+				"%d,,10,Wages,INV-1\n,%s,20,,\n",
+			destinations[0].ID, destinations[1].AccountNumber,
+		),
+	)
+	badCSVBody, badCSVContentType := transferBatchForm(
+		t, source, constants.TransferBatchModeBestEffort,
+		fmt.Sprintf("destination_account_id,amount\n%d,ten\n", destinations[0].ID),
+	)
+
+	jsonBatch := func(sourceID int64, mode string, destinationIDs ...int64) string {
+		items := make([]string, len(destinationIDs))
+		for i, id := range destinationIDs {
+			items[i] = fmt.Sprintf(`{"destination_account_id": %d, "amount": %d}`, id, 10*(i+1))
+		}
+		return fmt.Sprintf(
+			`{"source_account_id": %d, "currency": "USD", "mode": "%s", "items": [%s]}`,
+			sourceID, mode, strings.Join(items, ", "),
+		)
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          string
+		contentType   string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Atomic",
+			username: user.Username,
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "currency": "USD", "mode": "atomic", "items": [`+
+					`{"destination_account_id": %d, "amount": 10, "memo": "Wages"}, `+
+					`{"destination_account_number": "%s", "amount": 20, "reference": "PAY-1", "category": "rent", `+
+					`"invoice_number": "INV-1", "end_to_end_id": "E2E-1"}]}`,
+				source.ID, destinations[0].ID, destinations[1].AccountNumber,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinations[0].ID)).Times(1).Return(
+					destinations[0], nil,
+				)
+				expectClearDestination(store, destinations[0])
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(destinations[1].AccountNumber)).Times(1).Return(
+					destinations[1], nil,
+				)
+				expectClearDestination(store, destinations[1])
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreateTransferBatchTxParams) (db.CreateTransferBatchTxResult, error) {
+						require.Equal(t, source.ID, arg.SourceAccountID)
+						require.Equal(t, user.Username, arg.CreatedBy)
+						require.Equal(t, constants.TransferBatchModeAtomic, arg.Mode)
+						require.Equal(t, int32(2), arg.ItemCount)
+						require.Equal(t, int64(30), arg.TotalAmount)
+						require.Len(t, arg.Items, 2)
+						require.Equal(t, int32(1), arg.Items[0].Position)
+						require.Equal(t, destinations[0].ID, arg.Items[0].DestinationAccountID)
+						require.Equal(t, sql.NullString{String: "Wages", Valid: true}, arg.Items[0].Memo)
+						require.Equal(t, int32(2), arg.Items[1].Position)
+						require.Equal(t, destinations[1].ID, arg.Items[1].DestinationAccountID)
+						require.Equal(t, sql.NullString{String: "PAY-1", Valid: true}, arg.Items[1].Reference)
+						require.Equal(t, sql.NullString{String: "rent", Valid: true}, arg.Items[1].Category)
+						require.Equal(t, sql.NullString{String: "INV-1", Valid: true}, arg.Items[1].InvoiceNumber)
+						require.Equal(t, sql.NullString{String: "E2E-1", Valid: true}, arg.Items[1].EndToEndID)
+						return db.CreateTransferBatchTxResult{Batch: batch}, nil
+					},
+				)
+				store.EXPECT().RunTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(completed, nil)
+				store.EXPECT().CountTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(
+					[]db.CountTransferBatchItemsRow{{Status: constants.TransferBatchItemStatusSucceeded, Count: 2}}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got transferBatchResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, batch.ID, got.ID)
+				require.Equal(t, constants.TransferBatchStatusCompleted, got.Status)
+				require.Equal(t, transferBatchProgress{Succeeded: 2}, got.Progress)
+			},
+		},
+		{
+			name:        "CSV",
+			username:    user.Username,
+			body:        csvBody,
+			contentType: csvContentType,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinations[0].ID)).Times(1).Return(
+					destinations[0], nil,
+				)
+				expectClearDestination(store, destinations[0])
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(destinations[1].AccountNumber)).Times(1).Return(
+					destinations[1], nil,
+				)
+				expectClearDestination(store, destinations[1])
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreateTransferBatchTxParams) (db.CreateTransferBatchTxResult, error) {
+						require.Equal(t, constants.TransferBatchModeBestEffort, arg.Mode)
+						require.Len(t, arg.Items, 2)
+						require.Equal(t, int64(10), arg.Items[0].Amount)
+						require.Equal(t, sql.NullString{String: "Wages", Valid: true}, arg.Items[0].Memo)
+						require.Equal(t, sql.NullString{String: "INV-1", Valid: true}, arg.Items[0].InvoiceNumber)
+						require.Equal(t, int64(20), arg.Items[1].Amount)
+						require.False(t, arg.Items[1].Memo.Valid)
+						require.False(t, arg.Items[1].InvoiceNumber.Valid)
+						return db.CreateTransferBatchTxResult{Batch: batch}, nil
+					},
+				)
+				store.EXPECT().RunTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(completed, nil)
+				store.EXPECT().CountTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(nil, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "Async",
+			username: user.Username,
+			body: jsonBatch(
+				source.ID, constants.TransferBatchModeBestEffort,
+				destinations[0].ID, destinations[1].ID, destinations[0].ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinations[0].ID)).Times(2).Return(
+					destinations[0], nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinations[1].ID)).Times(1).Return(
+					destinations[1], nil,
+				)
+				expectClearDestination(store, destinations[0])
+				expectClearDestination(store, destinations[1])
+				expectClearDestination(store, destinations[0])
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.CreateTransferBatchTxResult{Batch: batch}, nil,
+				)
+				store.EXPECT().RunTransferBatch(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CountTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(
+					[]db.CountTransferBatchItemsRow{{Status: constants.TransferBatchItemStatusPending, Count: 3}}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var got transferBatchResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.TransferBatchStatusPending, got.Status)
+				require.Equal(t, transferBatchProgress{Pending: 3}, got.Progress)
+			},
+		},
+		{
+			name:     "InvalidItems",
+			username: user.Username,
+			body: jsonBatch(
+				source.ID, constants.TransferBatchModeAtomic,
+				destinations[0].ID, 999, source.ID, destinations[2].ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(2).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinations[0].ID)).Times(1).Return(
+					destinations[0], nil,
+				)
+				expectClearDestination(store, destinations[0])
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(999))).Times(1).Return(
+					db.Account{}, sql.ErrNoRows,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinations[2].ID)).Times(1).Return(
+					destinations[2], nil,
+				)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				var got struct {
+					Items []transferBatchItemError `json:"items"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, 3)
+				require.Equal(t, 2, got.Items[0].Position)
+				require.Equal(t, 3, got.Items[1].Position)
+				require.Equal(t, 4, got.Items[2].Position)
+			},
+		},
+		{
+			name:     "HeldPayeeItem",
+			username: user.Username,
+			body: jsonBatch(
+				source.ID, constants.TransferBatchModeAtomic, destinations[0].ID, destinations[1].ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				payee := generateMockPayee(user.Username, destinations[1], time.Now().Add(-time.Hour))
+				payee.ScreeningStatus = constants.ScreeningStatusPendingReview
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinations[0].ID)).Times(1).Return(
+					destinations[0], nil,
+				)
+				expectClearDestination(store, destinations[0])
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinations[1].ID)).Times(1).Return(
+					destinations[1], nil,
+				)
+				expectPayeeDestination(store, destinations[1], otherUser, payee)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				var got struct {
+					Items []transferBatchItemError `json:"items"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, 1)
+				require.Equal(t, 2, got.Items[0].Position)
+				require.Equal(t, errPayeeScreeningHold.Error(), got.Items[0].Error)
+			},
+		},
+		{
+			name:     "TooManyItems",
+			username: user.Username,
+			body: jsonBatch(
+				source.ID, constants.TransferBatchModeAtomic, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "SourceAccountNumber",
+			username: user.Username,
+			body: fmt.Sprintf(
+				`{"source_account_number": "%s", "currency": "USD", "mode": "atomic", "items": [`+
+					`{"destination_account_id": %d, "amount": 10}]}`,
+				source.AccountNumber, destinations[0].ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(source.AccountNumber)).Times(1).Return(
+					source, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinations[0].ID)).Times(1).Return(
+					destinations[0], nil,
+				)
+				expectClearDestination(store, destinations[0])
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.CreateTransferBatchTxParams) (db.CreateTransferBatchTxResult, error) {
+						require.Equal(t, source.ID, arg.SourceAccountID)
+						return db.CreateTransferBatchTxResult{Batch: batch}, nil
+					},
+				)
+				store.EXPECT().RunTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(completed, nil)
+				store.EXPECT().CountTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(nil, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "TotalOverflow",
+			username: user.Username,
+			body: fmt.Sprintf(
+				`{"source_account_id": %d, "currency": "USD", "mode": "atomic", "items": [`+
+					`{"destination_account_id": %d, "amount": %d}, {"destination_account_id": %d, "amount": %d}]}`,
+				source.ID, destinations[0].ID, int64(math.MaxInt64), destinations[1].ID, int64(math.MaxInt64),
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			// Neither item is over the threshold, but together they are
+			name:     "TotalNeedsApproval",
+			username: user.Username,
+			body: jsonBatch(
+				organizationSource.ID, constants.TransferBatchModeAtomic, destinations[0].ID, destinations[1].ID,
+			),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(organizationSource.ID)).Times(1).Return(
+					organizationSource, nil,
+				)
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.OrganizationMember{
+						OrganizationID: organization.ID,
+						Username:       user.Username,
+						Role:           constants.OrganizationRoleAdmin,
+					}, nil,
+				)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organization.ID)).Times(1).Return(
+					organization, nil,
+				)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidMode",
+			username: user.Username,
+			body:     jsonBatch(source.ID, "sometimes", destinations[0].ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidCSV",
+			username:    user.Username,
+			body:        badCSVBody,
+			contentType: badCSVContentType,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "NotSourceMember",
+			username: user.Username,
+			body:     jsonBatch(otherSource.ID, constants.TransferBatchModeAtomic, destinations[0].ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(otherSource.ID)).Times(1).Return(otherSource, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				server.config.TransferBatchMaxItems = 10
+				server.config.TransferBatchAsyncThreshold = 2
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodPost, "/transfer-batches", bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+				if tc.contentType != "" {
+					request.Header.Set("Content-Type", tc.contentType)
+				}
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestGetTransferBatchAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+	otherUser, _ := generateMockUser(t)
+	source := generateMockAccounts(user.Username, 1)[0]
+
+	batch := generateMockTransferBatch(user.Username, source, constants.TransferBatchModeBestEffort)
+	batch.Status = constants.TransferBatchStatusPartiallyCompleted
+
+	failed := db.TransferBatchItem{
+		ID:                   util.RandomInt(1, 1000),
+		BatchID:              batch.ID,
+		Position:             2,
+		DestinationAccountID: util.RandomInt(1, 1000),
+		Amount:               util.RandomInt(1, 100),
+		Status:               constants.TransferBatchItemStatusFailed,
+		Error:                sql.NullString{String: db.ErrInsufficientFunds.Error(), Valid: true},
+	}
+
+	testCases := []struct {
+		name          string
+		url           string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			url:      fmt.Sprintf("/transfer-batches/%d", batch.ID),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().CountTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(
+					[]db.CountTransferBatchItemsRow{
+						{Status: constants.TransferBatchItemStatusFailed, Count: 1},
+						{Status: constants.TransferBatchItemStatusSucceeded, Count: 1},
+					}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got transferBatchResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.TransferBatchStatusPartiallyCompleted, got.Status)
+				require.Equal(t, transferBatchProgress{Succeeded: 1, Failed: 1}, got.Progress)
+			},
+		},
+		{
+			name:     "Failures",
+			url:      fmt.Sprintf("/transfer-batches/%d/items?status=failed&page_number=1&page_size=10", batch.ID),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				arg := db.GetTransferBatchItemsParams{
+					BatchID: batch.ID,
+					Status:  sql.NullString{String: constants.TransferBatchItemStatusFailed, Valid: true},
+					Limit:   10,
+					Offset:  0,
+				}
+				store.EXPECT().GetTransferBatchItems(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					[]db.TransferBatchItem{failed}, nil,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []transferBatchItemResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, 1)
+				require.Equal(t, failed.Position, got[0].Position)
+				require.Equal(t, failed.Error.String, *got[0].Error)
+				require.Nil(t, got[0].TransferID)
+			},
+		},
+		{
+			name:     "NotFound",
+			url:      fmt.Sprintf("/transfer-batches/%d", batch.ID),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(
+					db.TransferBatch{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "NotSourceMember",
+			url:      fmt.Sprintf("/transfer-batches/%d", batch.ID),
+			username: otherUser.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+				store.EXPECT().CountTransferBatchItems(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidStatus",
+			url:      fmt.Sprintf("/transfer-batches/%d/items?status=lost&page_number=1&page_size=10", batch.ID),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
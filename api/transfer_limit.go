@@ -0,0 +1,333 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/limit"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"net/http"
+	"time"
+)
+
+type transferLimitOverrideResponse struct {
+	ID          int64     `json:"id"`
+	Username    string    `json:"username"`
+	Currency    string    `json:"currency"`
+	PerTransfer *int64    `json:"per_transfer"`
+	Daily       *int64    `json:"daily"`
+	Monthly     *int64    `json:"monthly"`
+	HourlyCount *int64    `json:"hourly_count"`
+	Reason      string    `json:"reason"`
+	CreatedBy   string    `json:"created_by"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func newTransferLimitOverrideResponse(override db.TransferLimitOverride) transferLimitOverrideResponse {
+	res := transferLimitOverrideResponse{
+		ID:          override.ID,
+		Username:    override.Username,
+		Currency:    override.Currency,
+		PerTransfer: nullInt64Pointer(override.PerTransfer),
+		Daily:       nullInt64Pointer(override.Daily),
+		Monthly:     nullInt64Pointer(override.Monthly),
+		Reason:      override.Reason,
+		CreatedBy:   override.CreatedBy,
+		ExpiresAt:   override.ExpiresAt,
+		CreatedAt:   override.CreatedAt,
+	}
+	if override.HourlyCount.Valid {
+		hourlyCount := int64(override.HourlyCount.Int32)
+		res.HourlyCount = &hourlyCount
+	}
+	return res
+}
+
+// remainingLimitsResponse is what is left of each limit. Limits that aren't set are null.
+type remainingLimitsResponse struct {
+	PerTransfer *int64 `json:"per_transfer"`
+	Daily       *int64 `json:"daily"`
+	Monthly     *int64 `json:"monthly"`
+	HourlyCount *int64 `json:"hourly_count"`
+}
+
+type transferAllowanceResponse struct {
+	Tier     string `json:"tier"`
+	Currency string `json:"currency"`
+	// Zero is no limit
+	Limits    limit.Limits                   `json:"limits"`
+	Usage     limit.Usage                    `json:"usage"`
+	Remaining remainingLimitsResponse        `json:"remaining"`
+	Override  *transferLimitOverrideResponse `json:"override"`
+}
+
+func newTransferAllowanceResponse(result db.TransferAllowanceResult, currencyCode string) transferAllowanceResponse {
+	res := transferAllowanceResponse{
+		Tier:     result.Tier,
+		Currency: currencyCode,
+		Limits:   result.Limits,
+		Usage:    result.Usage,
+	}
+	res.Remaining.PerTransfer, res.Remaining.Daily, res.Remaining.Monthly, res.Remaining.HourlyCount =
+		result.Limits.Remaining(result.Usage)
+	if result.Override != nil {
+		override := newTransferLimitOverrideResponse(*result.Override)
+		res.Override = &override
+	}
+	return res
+}
+
+type getTransferAllowanceRequest struct {
+	Currency string `form:"currency" binding:"required,currency"`
+}
+
+// getTransferAllowance returns the authenticated user's transfer limits in a currency and how much of them is left.
+func (server *Server) getTransferAllowance(ctx *gin.Context) {
+	var req getTransferAllowanceRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.store.TransferAllowance(
+		ctx, db.TransferAllowanceParams{
+			Username: authPayload.Username,
+			Currency: req.Currency,
+			Now:      time.Now(),
+		},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newTransferAllowanceResponse(result, req.Currency))
+}
+
+type userUriParams struct {
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+type updateUserTierBody struct {
+	Tier string `json:"tier" binding:"required,oneof=standard premium business"`
+}
+
+type updateUserTierRequest struct {
+	UriParams userUriParams
+	Body      updateUserTierBody
+}
+
+func (server *Server) updateUserTier(ctx *gin.Context) {
+	var req updateUserTierRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.UpdateUserTier(
+		ctx, db.UpdateUserTierParams{
+			Username: req.UriParams.Username,
+			Tier:     req.Body.Tier,
+		},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
+
+type updateOrganizationTierRequest struct {
+	UriParams organizationRequest
+	Body      updateUserTierBody
+}
+
+// updateOrganizationTier sets the tier whose limits the organization's accounts have.
+func (server *Server) updateOrganizationTier(ctx *gin.Context) {
+	var req updateOrganizationTierRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	organization, err := server.store.UpdateOrganizationTier(
+		ctx, db.UpdateOrganizationTierParams{
+			ID:   req.UriParams.ID,
+			Tier: req.Body.Tier,
+		},
+	)
+	if err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organization)
+}
+
+type getTransferLimitOverridesQueryParams struct {
+	PageNumber int32 `form:"page_number" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=10,max=50"`
+}
+
+type getTransferLimitOverridesRequest struct {
+	UriParams   userUriParams
+	QueryParams getTransferLimitOverridesQueryParams
+}
+
+// getTransferLimitOverrides lists every override set for a user, expired ones included, newest first.
+func (server *Server) getTransferLimitOverrides(ctx *gin.Context) {
+	var req getTransferLimitOverridesRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req.QueryParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	overrides, err := server.store.GetTransferLimitOverrides(
+		ctx, db.GetTransferLimitOverridesParams{
+			Username: req.UriParams.Username,
+			Limit:    req.QueryParams.PageSize,
+			Offset:   (req.QueryParams.PageNumber - 1) * req.QueryParams.PageSize,
+		},
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]transferLimitOverrideResponse, len(overrides))
+	for i, override := range overrides {
+		res[i] = newTransferLimitOverrideResponse(override)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type createTransferLimitOverrideBody struct {
+	Currency    string    `json:"currency" binding:"required,currency"`
+	PerTransfer *int64    `json:"per_transfer" binding:"omitempty,min=0"`
+	Daily       *int64    `json:"daily" binding:"omitempty,min=0"`
+	Monthly     *int64    `json:"monthly" binding:"omitempty,min=0"`
+	HourlyCount *int32    `json:"hourly_count" binding:"omitempty,min=0"`
+	Reason      string    `json:"reason" binding:"max=255"`
+	ExpiresAt   time.Time `json:"expires_at" binding:"required"`
+}
+
+type createTransferLimitOverrideRequest struct {
+	UriParams userUriParams
+	Body      createTransferLimitOverrideBody
+}
+
+// createTransferLimitOverride sets limits for a user in a currency in place of their tier's until it expires. Limits
+// left out keep the tier's, and zero lifts a limit altogether.
+func (server *Server) createTransferLimitOverride(ctx *gin.Context) {
+	var req createTransferLimitOverrideRequest
+
+	if err := ctx.ShouldBindUri(&req.UriParams); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	body := req.Body
+	if body.PerTransfer == nil && body.Daily == nil && body.Monthly == nil && body.HourlyCount == nil {
+		err := errors.New("override must set at least one limit")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !body.ExpiresAt.After(time.Now()) {
+		err := errors.New("expires_at must be in the future")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.CreateTransferLimitOverrideParams{
+		Username:  req.UriParams.Username,
+		Currency:  body.Currency,
+		Reason:    body.Reason,
+		CreatedBy: authPayload.Username,
+		ExpiresAt: body.ExpiresAt,
+	}
+	if body.PerTransfer != nil {
+		arg.PerTransfer = sql.NullInt64{Int64: *body.PerTransfer, Valid: true}
+	}
+	if body.Daily != nil {
+		arg.Daily = sql.NullInt64{Int64: *body.Daily, Valid: true}
+	}
+	if body.Monthly != nil {
+		arg.Monthly = sql.NullInt64{Int64: *body.Monthly, Valid: true}
+	}
+	if body.HourlyCount != nil {
+		arg.HourlyCount = sql.NullInt32{Int32: *body.HourlyCount, Valid: true}
+	}
+
+	override, err := server.store.CreateTransferLimitOverride(ctx, arg)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == constants.ForeignKeyViolation {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newTransferLimitOverrideResponse(override))
+}
+
+type deleteTransferLimitOverrideRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteTransferLimitOverride(ctx *gin.Context) {
+	var req deleteTransferLimitOverrideRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := server.store.GetTransferLimitOverride(ctx, req.ID); err != nil {
+		ctx.JSON(errorStatus(err), errorResponse(err))
+		return
+	}
+
+	if err := server.store.DeleteTransferLimitOverride(ctx, req.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,393 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	"github.com/CrunchyBlue/Golang-Bank/limit"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/token"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetTransferAllowanceAPI(t *testing.T) {
+	user, _ := generateMockUser(t)
+
+	result := db.TransferAllowanceResult{
+		Tier:   constants.UserTierStandard,
+		Limits: limit.Limits{PerTransfer: 500, Daily: 1000, HourlyCount: 5},
+		Usage:  limit.Usage{Daily: 1200, Monthly: 1200, HourlyCount: 2},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?currency=USD",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransferAllowance(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+					func(_ interface{}, arg db.TransferAllowanceParams) (db.TransferAllowanceResult, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, constants.USD, arg.Currency)
+						return result, nil
+					},
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got transferAllowanceResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.USD, got.Currency)
+				require.Equal(t, int64(500), *got.Remaining.PerTransfer)
+				require.Zero(t, *got.Remaining.Daily)
+				require.Nil(t, got.Remaining.Monthly)
+				require.Equal(t, int64(3), *got.Remaining.HourlyCount)
+				require.Nil(t, got.Override)
+			},
+		},
+		{
+			name:  "InvalidCurrency",
+			query: "?currency=XYZ",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransferAllowance(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				request, err := http.NewRequest(http.MethodGet, "/transfer-limits"+tc.query, nil)
+				require.NoError(t, err)
+
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestCreateTransferLimitOverrideAPI(t *testing.T) {
+	admin, _ := generateMockUser(t)
+	user, _ := generateMockUser(t)
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	override := db.TransferLimitOverride{
+		ID:        1,
+		Username:  user.Username,
+		Currency:  constants.USD,
+		Daily:     sql.NullInt64{Int64: 50000, Valid: true},
+		Reason:    "Home purchase",
+		CreatedBy: admin.Username,
+		ExpiresAt: expiresAt,
+	}
+
+	testCases := []struct {
+		name          string
+		body          string
+		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: fmt.Sprintf(
+				`{"currency": "USD", "daily": 50000, "reason": "Home purchase", "expires_at": "%s"}`,
+				expiresAt.Format(time.RFC3339),
+			),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateTransferLimitOverrideParams{
+					Username:  user.Username,
+					Currency:  constants.USD,
+					Daily:     sql.NullInt64{Int64: 50000, Valid: true},
+					Reason:    "Home purchase",
+					CreatedBy: admin.Username,
+					ExpiresAt: expiresAt,
+				}
+
+				store.EXPECT().CreateTransferLimitOverride(gomock.Any(), gomock.Eq(arg)).Times(1).Return(override, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got transferLimitOverrideResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, newTransferLimitOverrideResponse(override), got)
+			},
+		},
+		{
+			name: "NoLimits",
+			body: fmt.Sprintf(`{"currency": "USD", "expires_at": "%s"}`, expiresAt.Format(time.RFC3339)),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferLimitOverride(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyExpired",
+			body: fmt.Sprintf(
+				`{"currency": "USD", "daily": 50000, "expires_at": "%s"}`,
+				time.Now().Add(-time.Hour).Format(time.RFC3339),
+			),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin, time.Minute,
+				)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferLimitOverride(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Customer",
+			body: fmt.Sprintf(
+				`{"currency": "USD", "daily": 50000, "expires_at": "%s"}`, expiresAt.Format(time.RFC3339),
+			),
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferLimitOverride(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/users/%s/transfer-limit-overrides", user.Username)
+				request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				tc.setupAuth(t, request, server.tokenMaker)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestUpdateUserTierAPI(t *testing.T) {
+	admin, _ := generateMockUser(t)
+	user, _ := generateMockUser(t)
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: `{"tier": "premium"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateUserTierParams{Username: user.Username, Tier: constants.UserTierPremium}
+				updated := user
+				updated.Tier = constants.UserTierPremium
+
+				store.EXPECT().UpdateUserTier(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got userResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.UserTierPremium, got.Tier)
+			},
+		},
+		{
+			name: "UnknownTier",
+			body: `{"tier": "gold"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserTier(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UserNotFound",
+			body: `{"tier": "business"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserTier(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/users/%s/tier", user.Username)
+				request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				addRoleAuthorization(
+					t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin,
+					time.Minute,
+				)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
+
+func TestUpdateOrganizationTierAPI(t *testing.T) {
+	admin, _ := generateMockUser(t)
+	owner, _ := generateMockUser(t)
+	organization := generateMockOrganization(owner.Username)
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: `{"tier": "business"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateOrganizationTierParams{ID: organization.ID, Tier: constants.UserTierBusiness}
+				updated := organization
+				updated.Tier = constants.UserTierBusiness
+
+				store.EXPECT().UpdateOrganizationTier(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Organization
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, constants.UserTierBusiness, got.Tier)
+			},
+		},
+		{
+			name: "UnknownTier",
+			body: `{"tier": "gold"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateOrganizationTier(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OrganizationNotFound",
+			body: `{"tier": "premium"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateOrganizationTier(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.Organization{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				server := newTestServer(t, store)
+				recorder := httptest.NewRecorder()
+
+				url := fmt.Sprintf("/organizations/%d/tier", organization.ID)
+				request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader([]byte(tc.body)))
+				require.NoError(t, err)
+
+				addRoleAuthorization(
+					t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, constants.RoleAdmin,
+					time.Minute,
+				)
+
+				server.router.ServeHTTP(recorder, request)
+				tc.checkResponse(t, recorder)
+			},
+		)
+	}
+}
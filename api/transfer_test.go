@@ -21,9 +21,13 @@ import (
 
 func TestGetTransferAPI(t *testing.T) {
 	user, _ := generateMockUser(t)
-	sourceAccountID := util.RandomInt(1, 1000)
-	destinationAccountID := util.RandomInt(1, 1000)
-	transfer := generateMockTransfers(1, sourceAccountID, destinationAccountID)[0]
+	recipient, _ := generateMockUser(t)
+	otherUser, _ := generateMockUser(t)
+	source := generateMockAccounts(user.Username, 1)[0]
+	destination := generateMockAccounts(recipient.Username, 1)[0]
+	source.ID = 1
+	destination.ID = 2
+	transfer := generateMockTransfers(1, source.ID, destination.ID)[0]
 
 	testCases := []struct {
 		name          string
@@ -40,10 +44,47 @@ func TestGetTransferAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchesTransfer(t, recorder.Body, transfer)
+				requireBodyMatchesTransfer(t, recorder.Body, transfer, source.Currency)
+			},
+		},
+		{
+			name:       "Recipient",
+			transferID: transfer.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, recipient.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "UnauthorizedUser",
+			transferID: transfer.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(2).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
 		{
@@ -123,6 +164,16 @@ func TestGetTransfersAPI(t *testing.T) {
 	sourceAccountID := util.RandomInt(1, 1000)
 	destinationAccountID := util.RandomInt(1, 1000)
 	transfers := generateMockTransfers(10, sourceAccountID, destinationAccountID)
+	rows := make([]db.GetTransfersRow, len(transfers))
+	for i, transfer := range transfers {
+		rows[i] = db.GetTransfersRow{
+			ID:                   transfer.ID,
+			SourceAccountID:      transfer.SourceAccountID,
+			DestinationAccountID: transfer.DestinationAccountID,
+			Amount:               transfer.Amount,
+			Currency:             constants.EUR,
+		}
+	}
 
 	testCases := []struct {
 		name          string
@@ -137,14 +188,16 @@ func TestGetTransfersAPI(t *testing.T) {
 			pageSize:   10,
 			pageNumber: 1,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, user.Username, constants.RoleAdmin, time.Minute,
+				)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetTransfers(gomock.Any(), gomock.Any()).Times(1).Return(transfers, nil)
+				store.EXPECT().GetTransfers(gomock.Any(), gomock.Any()).Times(1).Return(rows, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchesTransfers(t, recorder.Body, transfers)
+				requireBodyMatchesTransfers(t, recorder.Body, transfers, constants.EUR)
 			},
 		},
 		{
@@ -152,23 +205,41 @@ func TestGetTransfersAPI(t *testing.T) {
 			pageSize:   10,
 			pageNumber: 1,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, user.Username, constants.RoleAdmin, time.Minute,
+				)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTransfers(gomock.Any(), gomock.Any()).Times(1).Return(
-					[]db.Transfer{}, sql.ErrConnDone,
+					[]db.GetTransfersRow{}, sql.ErrConnDone,
 				)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:       "NotAdmin",
+			pageSize:   10,
+			pageNumber: 1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name:       "BadRequest",
 			pageSize:   -1,
 			pageNumber: -1,
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+				addRoleAuthorization(
+					t, req, tokenMaker, authorizationTypeBearer, user.Username, constants.RoleAdmin, time.Minute,
+				)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTransfers(gomock.Any(), gomock.Any()).Times(0)
@@ -208,7 +279,8 @@ func TestGetTransfersAPI(t *testing.T) {
 
 func TestGetOutboundTransfersForAccountAPI(t *testing.T) {
 	user, _ := generateMockUser(t)
-	sourceAccountID := util.RandomInt(1, 1000)
+	account := generateMockAccounts(user.Username, 1)[0]
+	sourceAccountID := account.ID
 	destinationAccountID := util.RandomInt(1, 1000)
 	transfers := generateMockTransfers(10, sourceAccountID, destinationAccountID)
 
@@ -230,13 +302,14 @@ func TestGetOutboundTransfersForAccountAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(sourceAccountID)).Times(1).Return(account, nil)
 				store.EXPECT().GetOutboundTransfersForAccount(gomock.Any(), gomock.Any()).Times(1).Return(
 					transfers, nil,
 				)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchesTransfers(t, recorder.Body, transfers)
+				requireBodyMatchesTransfers(t, recorder.Body, transfers, account.Currency)
 			},
 		},
 		{
@@ -248,6 +321,7 @@ func TestGetOutboundTransfersForAccountAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(sourceAccountID)).Times(1).Return(account, nil)
 				store.EXPECT().GetOutboundTransfersForAccount(gomock.Any(), gomock.Any()).Times(1).Return(
 					[]db.Transfer{}, sql.ErrConnDone,
 				)
@@ -256,6 +330,25 @@ func TestGetOutboundTransfersForAccountAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:       "UnauthorizedUser",
+			accountID:  sourceAccountID,
+			pageSize:   10,
+			pageNumber: 1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(sourceAccountID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+				store.EXPECT().GetOutboundTransfersForAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
 		{
 			name:       "BadRequest",
 			accountID:  sourceAccountID,
@@ -305,7 +398,8 @@ func TestGetOutboundTransfersForAccountAPI(t *testing.T) {
 func TestGetInboundTransfersForAccountAPI(t *testing.T) {
 	user, _ := generateMockUser(t)
 	sourceAccountID := util.RandomInt(1, 1000)
-	destinationAccountID := util.RandomInt(1, 1000)
+	account := generateMockAccounts(user.Username, 1)[0]
+	destinationAccountID := account.ID
 	transfers := generateMockTransfers(10, sourceAccountID, destinationAccountID)
 
 	testCases := []struct {
@@ -326,11 +420,12 @@ func TestGetInboundTransfersForAccountAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinationAccountID)).Times(1).Return(account, nil)
 				store.EXPECT().GetInboundTransfersForAccount(gomock.Any(), gomock.Any()).Times(1).Return(transfers, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchesTransfers(t, recorder.Body, transfers)
+				requireBodyMatchesTransfers(t, recorder.Body, transfers, account.Currency)
 			},
 		},
 		{
@@ -342,6 +437,7 @@ func TestGetInboundTransfersForAccountAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinationAccountID)).Times(1).Return(account, nil)
 				store.EXPECT().GetInboundTransfersForAccount(gomock.Any(), gomock.Any()).Times(1).Return(
 					[]db.Transfer{}, sql.ErrConnDone,
 				)
@@ -350,6 +446,25 @@ func TestGetInboundTransfersForAccountAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:       "UnauthorizedUser",
+			accountID:  destinationAccountID,
+			pageSize:   10,
+			pageNumber: 1,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destinationAccountID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
+				)
+				store.EXPECT().GetInboundTransfersForAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
 		{
 			name:       "BadRequest",
 			accountID:  destinationAccountID,
@@ -409,15 +524,26 @@ func TestCreateTransferAPI(t *testing.T) {
 	amount := util.RandomInt(1, 1000)
 	currency := constants.USD
 
+	transfer := generateMockTransfers(1, account1.ID, account2.ID)[0]
+	transfer.Amount = amount
+	transferResult := db.TransferTxResult{
+		Transfer:           transfer,
+		SourceAccount:      account1,
+		DestinationAccount: account2,
+		Fees:               []db.Fee{generateMockFee(account1.ID, transfer.ID)},
+	}
+
 	testCases := []struct {
-		name                 string
-		sourceAccountID      int64
-		destinationAccountID int64
-		amount               int64
-		currency             string
-		setupAuth            func(t *testing.T, req *http.Request, tokenMaker token.Maker)
-		buildStubs           func(store *mockdb.MockStore)
-		checkResponse        func(t *testing.T, recorder *httptest.ResponseRecorder)
+		name                     string
+		sourceAccountID          int64
+		sourceAccountNumber      string
+		destinationAccountID     int64
+		destinationAccountNumber string
+		amount                   int64
+		currency                 string
+		setupAuth                func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		buildStubs               func(store *mockdb.MockStore)
+		checkResponse            func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name:                 "OK",
@@ -431,12 +557,243 @@ func TestCreateTransferAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				expectClearDestination(store, account2)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:                 "WithFees",
+			sourceAccountID:      account1.ID,
+			destinationAccountID: account2.ID,
+			amount:               amount,
+			currency:             currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				expectClearDestination(store, account2)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(transferResult, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchesTransferTxResult(t, recorder.Body, transferResult, currency)
+			},
+		},
+		{
+			name:                 "SpenderWithinLimit",
+			sourceAccountID:      account1.ID,
+			destinationAccountID: account2.ID,
+			amount:               amount,
+			currency:             currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user2.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				member := db.AccountMember{
+					AccountID:     account1.ID,
+					Username:      user2.Username,
+					Role:          constants.AccountRoleSpender,
+					TransferLimit: sql.NullInt64{Int64: amount, Valid: true},
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountMember(
+					gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: account1.ID, Username: user2.Username}),
+				).Times(1).Return(member, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				expectClearDestination(store, account2)
 				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name:                 "SpenderOverLimit",
+			sourceAccountID:      account1.ID,
+			destinationAccountID: account2.ID,
+			amount:               amount,
+			currency:             currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user2.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				member := db.AccountMember{
+					AccountID:     account1.ID,
+					Username:      user2.Username,
+					Role:          constants.AccountRoleSpender,
+					TransferLimit: sql.NullInt64{Int64: amount - 1, Valid: true},
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(member, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:                 "Viewer",
+			sourceAccountID:      account1.ID,
+			destinationAccountID: account2.ID,
+			amount:               amount,
+			currency:             currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user2.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				member := db.AccountMember{
+					AccountID: account1.ID,
+					Username:  user2.Username,
+					Role:      constants.AccountRoleViewer,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(member, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:                     "DestinationAccountNumber",
+			sourceAccountID:          account1.ID,
+			destinationAccountNumber: account2.AccountNumber,
+			amount:                   amount,
+			currency:                 currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account2.AccountNumber)).Times(1).Return(
+					account2, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				expectClearDestination(store, account2)
+				store.EXPECT().TransferTx(
+					gomock.Any(), gomock.Eq(
+						db.TransferTxParams{
+							SourceAccountID:      account1.ID,
+							DestinationAccountID: account2.ID,
+							Amount:               amount,
+						},
+					),
+				).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:                 "SourceAccountNumber",
+			sourceAccountNumber:  account1.AccountNumber,
+			destinationAccountID: account2.ID,
+			amount:               amount,
+			currency:             currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account1.AccountNumber)).Times(1).Return(
+					account1, nil,
+				)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				expectClearDestination(store, account2)
+				store.EXPECT().TransferTx(
+					gomock.Any(), gomock.Eq(
+						db.TransferTxParams{
+							SourceAccountID:      account1.ID,
+							DestinationAccountID: account2.ID,
+							Amount:               amount,
+						},
+					),
+				).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:                 "SourceAccountIDAndNumber",
+			sourceAccountID:      account1.ID,
+			sourceAccountNumber:  account1.AccountNumber,
+			destinationAccountID: account2.ID,
+			amount:               amount,
+			currency:             currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:                     "DestinationAccountNumberNotFound",
+			sourceAccountID:          account1.ID,
+			destinationAccountNumber: account2.AccountNumber,
+			amount:                   amount,
+			currency:                 currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account2.AccountNumber)).Times(1).Return(
+					db.Account{}, sql.ErrNoRows,
+				)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:                     "InvalidDestinationAccountNumber",
+			sourceAccountID:          account1.ID,
+			destinationAccountNumber: "12345678901",
+			amount:                   amount,
+			currency:                 currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:                     "DestinationAccountIDAndNumber",
+			sourceAccountID:          account1.ID,
+			destinationAccountID:     account2.ID,
+			destinationAccountNumber: account2.AccountNumber,
+			amount:                   amount,
+			currency:                 currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name:                 "InternalError",
 			sourceAccountID:      account1.ID,
@@ -449,6 +806,7 @@ func TestCreateTransferAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				expectClearDestination(store, account2)
 				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(
 					db.TransferTxResult{}, sql.ErrConnDone,
 				)
@@ -457,6 +815,27 @@ func TestCreateTransferAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:                 "InsufficientFunds",
+			sourceAccountID:      account1.ID,
+			destinationAccountID: account2.ID,
+			amount:               amount,
+			currency:             currency,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				expectClearDestination(store, account2)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.TransferTxResult{}, db.ErrInsufficientFunds,
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
 		{
 			name: "BadRequest",
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
@@ -488,9 +867,9 @@ func TestCreateTransferAPI(t *testing.T) {
 				url := fmt.Sprint("/transfer")
 
 				jsonEntry := fmt.Sprintf(
-					`{"source_account_id": %d, "destination_account_id": %d, "amount": %d, "currency": "%s"}`,
-					tc.sourceAccountID,
-					tc.destinationAccountID, tc.amount, tc.currency,
+					`{"source_account_id": %d, "source_account_number": "%s", "destination_account_id": %d, "destination_account_number": "%s", "amount": %d, "currency": "%s"}`,
+					tc.sourceAccountID, tc.sourceAccountNumber,
+					tc.destinationAccountID, tc.destinationAccountNumber, tc.amount, tc.currency,
 				)
 				jsonBody := []byte(jsonEntry)
 				bodyReader := bytes.NewReader(jsonBody)
@@ -507,94 +886,78 @@ func TestCreateTransferAPI(t *testing.T) {
 	}
 }
 
-func TestUpdateTransferAPI(t *testing.T) {
+func TestCreateTransferRemittanceAPI(t *testing.T) {
 	user, _ := generateMockUser(t)
-	sourceAccountID := util.RandomInt(1, 1000)
-	destinationAccountID := util.RandomInt(1, 1000)
-	transfer := generateMockTransfers(1, sourceAccountID, destinationAccountID)[0]
-
-	amount := util.RandomInt(1, 1000)
+	accounts := generateMockAccounts(user.Username, 2)
+	source, destination := accounts[0], accounts[1]
+	source.Currency = constants.USD
+	destination.Currency = constants.USD
+	destination.ID = source.ID + 1
 
-	expectedTransfer := db.Transfer{
-		ID:                   transfer.ID,
-		SourceAccountID:      transfer.SourceAccountID,
-		DestinationAccountID: transfer.DestinationAccountID,
-		Amount:               amount,
-		CreatedAt:            transfer.CreatedAt,
-	}
+	transfer := generateMockTransfers(1, source.ID, destination.ID)[0]
+	transfer.Memo = sql.NullString{String: "March rent", Valid: true}
+	transfer.Reference = sql.NullString{String: "INV-2023/0042", Valid: true}
+	transfer.Category = sql.NullString{String: "rent", Valid: true}
 
 	testCases := []struct {
 		name          string
-		transferID    int64
-		amount        int64
-		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		remittance    string
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name:       "OK",
-			transferID: expectedTransfer.ID,
-			amount:     amount,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
+			remittance: `"memo": "March rent", "reference": "INV-2023/0042", "category": "rent"`,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateTransfer(gomock.Any(), gomock.Any()).Times(1).Return(
-					expectedTransfer, nil,
-				)
+				arg := db.TransferTxParams{
+					SourceAccountID:      source.ID,
+					DestinationAccountID: destination.ID,
+					Amount:               100,
+					Remittance: db.Remittance{
+						Memo:      transfer.Memo,
+						Reference: transfer.Reference,
+						Category:  transfer.Category,
+					},
+				}
+				result := db.TransferTxResult{Transfer: transfer, SourceAccount: source, DestinationAccount: destination}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(source.ID)).Times(1).Return(source, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(destination.ID)).Times(1).Return(destination, nil)
+				expectClearDestination(store, destination)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchesUpdatedTransfer(
-					t, recorder.Body, transfer, amount,
-				)
+
+				var got transferTxResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.NotNil(t, got.Transfer.Memo)
+				require.Equal(t, "March rent", *got.Transfer.Memo)
+				require.NotNil(t, got.Transfer.Reference)
+				require.Equal(t, "INV-2023/0042", *got.Transfer.Reference)
+				require.Nil(t, got.Transfer.EndToEndID)
 			},
 		},
 		{
-			name:       "NotFound",
-			transferID: expectedTransfer.ID,
-			amount:     amount,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateTransfer(gomock.Any(), gomock.Any()).Times(1).Return(
-					db.Transfer{}, sql.ErrNoRows,
-				)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
-			},
+			name:       "MemoTooLong",
+			remittance: fmt.Sprintf(`"memo": "%s"`, util.RandomString(141)),
 		},
 		{
-			name:       "InternalError",
-			transferID: expectedTransfer.ID,
-			amount:     amount,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateTransfer(gomock.Any(), gomock.Any()).Times(1).Return(
-					db.Transfer{}, sql.ErrConnDone,
-				)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
+			name:       "MemoControlCharacter",
+			remittance: `"memo": "two\nlines"`,
 		},
 		{
-			name:       "BadRequest",
-			transferID: 0,
-			amount:     amount,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateTransfer(gomock.Any(), gomock.Any()).Times(0)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
+			name:       "ReferenceOutsideCharacterSet",
+			remittance: `"reference": "INV_0042"`,
+		},
+		{
+			name:       "EndToEndIDTooLong",
+			remittance: fmt.Sprintf(`"end_to_end_id": "%s"`, util.RandomString(36)),
+		},
+		{
+			name:       "InvalidCategory",
+			remittance: `"category": "Office Supplies"`,
 		},
 	}
 
@@ -607,98 +970,96 @@ func TestUpdateTransferAPI(t *testing.T) {
 				defer ctrl.Finish()
 
 				store := mockdb.NewMockStore(ctrl)
-				tc.buildStubs(store)
+				if tc.buildStubs != nil {
+					tc.buildStubs(store)
+				} else {
+					store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				}
 
 				server := newTestServer(t, store)
 				recorder := httptest.NewRecorder()
 
-				url := fmt.Sprintf("/transfer/%d", tc.transferID)
-
-				jsonAccount := fmt.Sprintf(
-					`{"amount": %d}`, tc.amount,
+				body := fmt.Sprintf(
+					`{"source_account_id": %d, "destination_account_id": %d, "amount": 100, "currency": "USD", %s}`,
+					source.ID, destination.ID, tc.remittance,
 				)
-				jsonBody := []byte(jsonAccount)
-				bodyReader := bytes.NewReader(jsonBody)
-
-				request, err := http.NewRequest(http.MethodPut, url, bodyReader)
+				request, err := http.NewRequest(http.MethodPost, "/transfer", bytes.NewReader([]byte(body)))
 				require.NoError(t, err)
 
-				tc.setupAuth(t, request, server.tokenMaker)
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 
 				server.router.ServeHTTP(recorder, request)
-				tc.checkResponse(t, recorder)
+				if tc.checkResponse != nil {
+					tc.checkResponse(t, recorder)
+				} else {
+					require.Equal(t, http.StatusBadRequest, recorder.Code)
+				}
 			},
 		)
 	}
 }
 
-func TestDeleteTransferAPI(t *testing.T) {
+func TestSearchTransfersAPI(t *testing.T) {
 	user, _ := generateMockUser(t)
-	sourceAccountID := util.RandomInt(1, 1000)
-	destinationAccountID := util.RandomInt(1, 1000)
-	transfer := generateMockTransfers(1, sourceAccountID, destinationAccountID)[0]
+	account := generateMockAccounts(user.Username, 1)[0]
+	transfers := generateMockTransfers(2, account.ID, util.RandomInt(1, 1000))
 
 	testCases := []struct {
 		name          string
-		transferID    int64
-		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Maker)
+		query         string
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:       "OK",
-			transferID: transfer.ID,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
+			name:  "OK",
+			query: "q=50%25_off&category=rent&invoice_number=INV-1",
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(nil)
+				arg := db.SearchTransfersParams{
+					AccountID:     account.ID,
+					Search:        sql.NullString{String: `50\%\_off`, Valid: true},
+					Category:      sql.NullString{String: "rent", Valid: true},
+					InvoiceNumber: sql.NullString{String: "INV-1", Valid: true},
+					PageLimit:     10,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().SearchTransfers(gomock.Any(), gomock.Eq(arg)).Times(1).Return(transfers, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []transferResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Len(t, got, 2)
+				require.Equal(t, transfers[0].ID, got[0].ID)
 			},
 		},
 		{
-			name:       "NotFound",
-			transferID: transfer.ID,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
+			name:  "InvalidReference",
+			query: "reference=INV_1",
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(
-					sql.ErrNoRows,
-				)
+				store.EXPECT().SearchTransfers(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name:       "InternalError",
-			transferID: transfer.ID,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
+			name:  "UnauthorizedUser",
+			query: "q=rent",
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(
-					sql.ErrConnDone,
+				other := account
+				other.Owner = "someone"
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(other, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AccountMember{}, sql.ErrNoRows,
 				)
+				store.EXPECT().SearchTransfers(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-		{
-			name:       "BadRequest",
-			transferID: 0,
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteTransfer(gomock.Any(), gomock.Any()).Times(0)
-			},
-			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
 	}
@@ -717,11 +1078,11 @@ func TestDeleteTransferAPI(t *testing.T) {
 				server := newTestServer(t, store)
 				recorder := httptest.NewRecorder()
 
-				url := fmt.Sprintf("/transfer/%d", tc.transferID)
-				request, err := http.NewRequest(http.MethodDelete, url, nil)
+				url := fmt.Sprintf("/transfers/%d/search?page_number=1&page_size=10&%s", account.ID, tc.query)
+				request, err := http.NewRequest(http.MethodGet, url, nil)
 				require.NoError(t, err)
 
-				tc.setupAuth(t, request, server.tokenMaker)
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 
 				server.router.ServeHTTP(recorder, request)
 				tc.checkResponse(t, recorder)
@@ -730,43 +1091,39 @@ func TestDeleteTransferAPI(t *testing.T) {
 	}
 }
 
-func requireBodyMatchesTransfer(t *testing.T, body *bytes.Buffer, transfer db.Transfer) {
+func requireBodyMatchesTransfer(t *testing.T, body *bytes.Buffer, transfer db.Transfer, currencyCode string) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var fetchedTransfer db.Transfer
+	var fetchedTransfer transferResponse
 	err = json.Unmarshal(data, &fetchedTransfer)
 	require.NoError(t, err)
-	require.Equal(t, transfer, fetchedTransfer)
+	require.Equal(t, newTransferResponse(transfer, currencyCode), fetchedTransfer)
 }
 
-func requireBodyMatchesTransfers(t *testing.T, body *bytes.Buffer, transfers []db.Transfer) {
+func requireBodyMatchesTransfers(t *testing.T, body *bytes.Buffer, transfers []db.Transfer, currencyCode string) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var fetchedTransfers []db.Transfer
+	var fetchedTransfers []transferResponse
 	err = json.Unmarshal(data, &fetchedTransfers)
 	require.NoError(t, err)
+	require.Len(t, fetchedTransfers, len(transfers))
 
-	for i, _ := range transfers {
-		require.Equal(t, transfers[i], fetchedTransfers[i])
+	for i := range transfers {
+		require.Equal(t, newTransferResponse(transfers[i], currencyCode), fetchedTransfers[i])
 	}
 }
 
-func requireBodyMatchesUpdatedTransfer(
-	t *testing.T, body *bytes.Buffer, transfer db.Transfer, amount int64,
+func requireBodyMatchesTransferTxResult(
+	t *testing.T, body *bytes.Buffer, result db.TransferTxResult, currencyCode string,
 ) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var updatedTransfer db.Transfer
-	err = json.Unmarshal(data, &updatedTransfer)
+	var fetchedResult transferTxResponse
+	err = json.Unmarshal(data, &fetchedResult)
 	require.NoError(t, err)
-
-	require.Equal(t, transfer.ID, updatedTransfer.ID)
-	require.Equal(t, transfer.SourceAccountID, updatedTransfer.SourceAccountID)
-	require.Equal(t, transfer.DestinationAccountID, updatedTransfer.DestinationAccountID)
-	require.Equal(t, transfer.CreatedAt, updatedTransfer.CreatedAt)
-
-	require.Equal(t, amount, updatedTransfer.Amount)
+	require.Equal(t, newTransferTxResponse(result, currencyCode), fetchedResult)
+	require.Len(t, fetchedResult.Fees, len(result.Fees))
 }
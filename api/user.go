@@ -17,6 +17,10 @@ type userResponse struct {
 	Username          string    `json:"username"`
 	FullName          string    `json:"full_name"`
 	Email             string    `json:"email"`
+	Role              string    `json:"role"`
+	Tier              string    `json:"tier"`
+	ScreeningStatus   string    `json:"screening_status"`
+	KycStatus         string    `json:"kyc_status"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
 }
@@ -26,6 +30,10 @@ func newUserResponse(user db.User) userResponse {
 		Username:          user.Username,
 		FullName:          user.FullName,
 		Email:             user.Email,
+		Role:              user.Role,
+		Tier:              user.Tier,
+		ScreeningStatus:   user.ScreeningStatus,
+		KycStatus:         user.KycStatus,
 		PasswordChangedAt: user.PasswordChangedAt,
 		CreatedAt:         user.CreatedAt,
 	}
@@ -73,6 +81,22 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
+	// A user whose name is on the watchlist is held until the hits are reviewed
+	if matches := server.screener.Screen(user.FullName); len(matches) > 0 {
+		_, err = server.store.RecordScreeningHitsTx(
+			ctx, db.RecordScreeningHitsTxParams{
+				Username:     user.Username,
+				ScreenedName: user.FullName,
+				Matches:      matches,
+			},
+		)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		user.ScreeningStatus = constants.ScreeningStatusPendingReview
+	}
+
 	res := newUserResponse(user)
 
 	ctx.JSON(http.StatusOK, res)
@@ -116,8 +140,13 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
+	if user.ScreeningStatus != constants.ScreeningStatusClear {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrScreeningHold))
+		return
+	}
+
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
-		user.Username, server.config.AccessTokenDuration,
+		user.Username, user.Role, server.config.AccessTokenDuration,
 	)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -125,7 +154,7 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 
 	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
-		user.Username, server.config.RefreshTokenDuration,
+		user.Username, user.Role, server.config.RefreshTokenDuration,
 	)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -11,3 +11,31 @@ var validateCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool
 	}
 	return false
 }
+
+var validateAccountNumber validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if number, ok := fieldLevel.Field().Interface().(string); ok {
+		return isAccountNumberOrIBAN(number)
+	}
+	return false
+}
+
+var validateRemittanceText validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if text, ok := fieldLevel.Field().Interface().(string); ok {
+		return util.ValidRemittanceText(text)
+	}
+	return false
+}
+
+var validateRemittanceReference validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if reference, ok := fieldLevel.Field().Interface().(string); ok {
+		return util.ValidRemittanceReference(reference)
+	}
+	return false
+}
+
+var validateCategory validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if category, ok := fieldLevel.Field().Interface().(string); ok {
+		return util.ValidCategory(category)
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Store keeps files by key. Keys are slash-separated paths such as kyc/alice/<uuid>.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns ErrNotFound if nothing is stored under key.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the file under key. Deleting a key with nothing under it is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// validKey reports whether key is a relative path made of non-empty parts that can't climb out of the store.
+func validKey(key string) bool {
+	if key == "" || strings.ContainsAny(key, "\\\x00") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package blob
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+var ErrDecrypt = errors.New("cannot decrypt blob")
+
+// EncryptedStore encrypts files with AES-256-GCM before handing them to another store. Each file has its own random
+// nonce, stored in front of it, and is bound to its key so that it can't be moved under another one.
+type EncryptedStore struct {
+	store Store
+	aead  cipher.AEAD
+}
+
+// NewEncryptedStore returns a store that encrypts files into store with key, which must be 32 bytes.
+func NewEncryptedStore(store Store, key []byte) (*EncryptedStore, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedStore{store: store, aead: aead}, nil
+}
+
+func (store *EncryptedStore) Put(ctx context.Context, key string, data []byte) error {
+	nonce := make([]byte, store.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	return store.store.Put(ctx, key, store.aead.Seal(nonce, nonce, data, []byte(key)))
+}
+
+func (store *EncryptedStore) Get(ctx context.Context, key string) ([]byte, error) {
+	sealed, err := store.store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := store.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, ErrDecrypt
+	}
+
+	data, err := store.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(key))
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return data, nil
+}
+
+func (store *EncryptedStore) Delete(ctx context.Context, key string) error {
+	return store.store.Delete(ctx, key)
+}
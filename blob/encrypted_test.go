@@ -0,0 +1,43 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEncryptedStore(t *testing.T) {
+	local, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = NewEncryptedStore(local, []byte("too short"))
+	require.Error(t, err)
+
+	store, err := NewEncryptedStore(local, []byte("12345678901234567890123456789012"))
+	require.NoError(t, err)
+
+	data := []byte("%PDF-1.4 passport scan")
+	require.NoError(t, store.Put(context.Background(), "kyc/alice/1", data))
+
+	sealed, err := local.Get(context.Background(), "kyc/alice/1")
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(sealed, []byte("passport")))
+
+	opened, err := store.Get(context.Background(), "kyc/alice/1")
+	require.NoError(t, err)
+	require.Equal(t, data, opened)
+
+	// A file moved under another key, or read with another encryption key, doesn't decrypt
+	require.NoError(t, local.Put(context.Background(), "kyc/mallory/1", sealed))
+	_, err = store.Get(context.Background(), "kyc/mallory/1")
+	require.ErrorIs(t, err, ErrDecrypt)
+
+	other, err := NewEncryptedStore(local, []byte("abcdefghijklmnopqrstuvwxyz012345"))
+	require.NoError(t, err)
+	_, err = other.Get(context.Background(), "kyc/alice/1")
+	require.ErrorIs(t, err, ErrDecrypt)
+
+	_, err = store.Get(context.Background(), "kyc/alice/2")
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,44 @@
+package blob
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "documents")
+	store, err := NewLocalStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(context.Background(), "kyc/alice/1", []byte("passport")))
+
+	data, err := store.Get(context.Background(), "kyc/alice/1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("passport"), data)
+
+	// Putting again replaces the file and leaves no temporary files behind
+	require.NoError(t, store.Put(context.Background(), "kyc/alice/1", []byte("license")))
+	entries, err := os.ReadDir(filepath.Join(dir, "kyc", "alice"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.NoError(t, store.Delete(context.Background(), "kyc/alice/1"))
+	_, err = store.Get(context.Background(), "kyc/alice/1")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, store.Delete(context.Background(), "kyc/alice/1"))
+}
+
+func TestLocalStoreInvalidKey(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../secret", "kyc/../../secret", "/etc/passwd", "kyc//1", "kyc\\..\\1", "kyc/."} {
+		require.ErrorIs(t, store.Put(context.Background(), key, []byte("x")), ErrInvalidKey, key)
+		_, err := store.Get(context.Background(), key)
+		require.ErrorIs(t, err, ErrInvalidKey, key)
+		require.ErrorIs(t, store.Delete(context.Background(), key), ErrInvalidKey, key)
+	}
+}
@@ -0,0 +1,56 @@
+package constants
+
+const (
+	ProductChecking = "checking"
+	ProductSavings  = "savings"
+	ProductInternal = "internal"
+)
+
+const (
+	AccountKindChecking = "checking"
+	AccountKindSavings  = "savings"
+	AccountKindInternal = "internal"
+)
+
+// BankUsername owns the bank's internal accounts. It can never be registered since usernames must be alphanumeric.
+const BankUsername = "golang-bank"
+
+const (
+	SystemAccountCash            = "cash"
+	SystemAccountFeeRevenue      = "fee_revenue"
+	SystemAccountInterestExpense = "interest_expense"
+)
+
+const (
+	AccountStatusActive  = "active"
+	AccountStatusFrozen  = "frozen"
+	AccountStatusDormant = "dormant"
+	AccountStatusClosed  = "closed"
+)
+
+const (
+	AccountRoleOwner   = "owner"
+	AccountRoleCoOwner = "co_owner"
+	AccountRoleViewer  = "viewer"
+	AccountRoleSpender = "spender"
+)
+
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusDeclined = "declined"
+	InvitationStatusRevoked  = "revoked"
+)
+
+const (
+	OrganizationRoleAdmin     = "admin"
+	OrganizationRoleInitiator = "initiator"
+	OrganizationRoleApprover  = "approver"
+)
+
+const (
+	PendingTransferStatusPending  = "pending"
+	PendingTransferStatusExecuted = "executed"
+	PendingTransferStatusRejected = "rejected"
+	PendingTransferStatusExpired  = "expired"
+)
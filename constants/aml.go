@@ -0,0 +1,20 @@
+package constants
+
+const (
+	AmlScenarioStructuring         = "structuring"
+	AmlScenarioRapidMovement       = "rapid_movement"
+	AmlScenarioDormantReactivation = "dormant_reactivation"
+)
+
+const (
+	AmlAlertStatusOpen     = "open"
+	AmlAlertStatusAssigned = "assigned"
+	AmlAlertStatusClosed   = "closed"
+)
+
+const (
+	AmlResolutionFalsePositive = "false_positive"
+	AmlResolutionNoAction      = "no_action"
+	// AmlResolutionReported is for alerts that were reported to the regulator as suspicious activity.
+	AmlResolutionReported = "reported"
+)
@@ -0,0 +1,8 @@
+package constants
+
+const (
+	ChannelBranch = "branch"
+	ChannelATM    = "atm"
+	ChannelACH    = "ach"
+	ChannelWire   = "wire"
+)
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	FeeKindTransfer    = "transfer"
+	FeeKindMaintenance = "maintenance"
+)
@@ -0,0 +1,8 @@
+package constants
+
+const (
+	DayCountActual365    = "actual/365"
+	DayCountActual360    = "actual/360"
+	DayCountActualActual = "actual/actual"
+	DayCount30360        = "30/360"
+)
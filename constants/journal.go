@@ -0,0 +1,7 @@
+package constants
+
+const (
+	JournalKindTransfer   = "transfer"
+	JournalKindDeposit    = "deposit"
+	JournalKindWithdrawal = "withdrawal"
+)
@@ -0,0 +1,21 @@
+package constants
+
+const (
+	KycStatusUnverified = "unverified"
+	KycStatusPending    = "pending"
+	KycStatusVerified   = "verified"
+	KycStatusRejected   = "rejected"
+)
+
+const (
+	KycDocumentPassport       = "passport"
+	KycDocumentNationalID     = "national_id"
+	KycDocumentDriversLicense = "drivers_license"
+	KycDocumentProofOfAddress = "proof_of_address"
+)
+
+// KycIdentityDocuments are the documents that prove who a user is. A submission needs at least one of them.
+var KycIdentityDocuments = []string{KycDocumentPassport, KycDocumentNationalID, KycDocumentDriversLicense}
+
+// KycDocumentContentTypes are the kinds of file that can be uploaded as KYC documents.
+var KycDocumentContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
@@ -0,0 +1,7 @@
+package constants
+
+const (
+	NotificationScheduledTransferFailed  = "scheduled_transfer_failed"
+	NotificationScheduledTransferSkipped = "scheduled_transfer_skipped"
+	NotificationAccountInvitation        = "account_invitation"
+)
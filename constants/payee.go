@@ -0,0 +1,7 @@
+package constants
+
+const (
+	NameCheckMatch      = "match"
+	NameCheckCloseMatch = "close_match"
+	NameCheckNoMatch    = "no_match"
+)
@@ -0,0 +1,8 @@
+package constants
+
+const (
+	DiscrepancyBalanceMismatch        = "balance_mismatch"
+	DiscrepancyTransferMissingEntry   = "transfer_missing_entry"
+	DiscrepancyTransferEntryMismatch  = "transfer_entry_mismatch"
+	DiscrepancyJournalMissingTransfer = "journal_missing_transfer"
+)
@@ -0,0 +1,14 @@
+package constants
+
+const (
+	RiskDecisionAllow  = "allow"
+	RiskDecisionReview = "review"
+	RiskDecisionDeny   = "deny"
+)
+
+const (
+	RiskAssessmentStatusExecuted      = "executed"
+	RiskAssessmentStatusPendingReview = "pending_review"
+	RiskAssessmentStatusRejected      = "rejected"
+	RiskAssessmentStatusDenied        = "denied"
+)
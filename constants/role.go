@@ -0,0 +1,11 @@
+package constants
+
+const (
+	RoleCustomer = "customer"
+	RoleTeller   = "teller"
+	RoleAdmin    = "admin"
+	// RoleRail is held by the service accounts of external payment rails.
+	RoleRail = "rail"
+	// RoleAnalyst is held by compliance staff who work AML alerts.
+	RoleAnalyst = "analyst"
+)
@@ -0,0 +1,13 @@
+package constants
+
+const (
+	ScreeningStatusClear         = "clear"
+	ScreeningStatusPendingReview = "pending_review"
+	ScreeningStatusBlocked       = "blocked"
+)
+
+const (
+	ScreeningHitStatusPendingReview = "pending_review"
+	ScreeningHitStatusConfirmed     = "confirmed"
+	ScreeningHitStatusCleared       = "cleared"
+)
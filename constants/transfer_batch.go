@@ -0,0 +1,21 @@
+package constants
+
+const (
+	TransferBatchModeAtomic     = "atomic"
+	TransferBatchModeBestEffort = "best_effort"
+)
+
+const (
+	TransferBatchStatusPending            = "pending"
+	TransferBatchStatusProcessing         = "processing"
+	TransferBatchStatusCompleted          = "completed"
+	TransferBatchStatusPartiallyCompleted = "partially_completed"
+	TransferBatchStatusFailed             = "failed"
+)
+
+const (
+	TransferBatchItemStatusPending   = "pending"
+	TransferBatchItemStatusSucceeded = "succeeded"
+	TransferBatchItemStatusFailed    = "failed"
+	TransferBatchItemStatusSkipped   = "skipped"
+)
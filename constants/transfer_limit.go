@@ -0,0 +1,11 @@
+package constants
+
+const (
+	UserTierStandard = "standard"
+	UserTierPremium  = "premium"
+	UserTierBusiness = "business"
+	// The limits of users who haven't passed KYC, in place of their own tier's
+	UserTierUnverified = "unverified"
+)
+
+var UserTiers = []string{UserTierStandard, UserTierPremium, UserTierBusiness, UserTierUnverified}
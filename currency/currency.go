@@ -0,0 +1,164 @@
+package currency
+
+import (
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Currency struct {
+	Code       string `json:"code"`
+	Number     int    `json:"number"`
+	MinorUnits int    `json:"minor_units"`
+	Name       string `json:"name"`
+}
+
+var registry = func() map[string]Currency {
+	currencies := make(map[string]Currency, len(iso4217))
+	for _, c := range iso4217 {
+		currencies[c.Code] = c
+	}
+	return currencies
+}()
+
+var (
+	mu      sync.RWMutex
+	enabled = []string{constants.USD, constants.EUR, constants.CAD}
+)
+
+func Lookup(code string) (Currency, bool) {
+	c, ok := registry[code]
+	return c, ok
+}
+
+func All() []Currency {
+	currencies := make([]Currency, len(iso4217))
+	copy(currencies, iso4217)
+	return currencies
+}
+
+// SetEnabled replaces the set of currencies accounts and transfers may use. Every code must exist in the ISO 4217
+// registry.
+func SetEnabled(codes []string) error {
+	if len(codes) == 0 {
+		return fmt.Errorf("at least one currency must be enabled")
+	}
+
+	seen := make(map[string]bool, len(codes))
+	var next []string
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if _, ok := registry[code]; !ok {
+			return fmt.Errorf("unknown currency code: %s", code)
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		next = append(next, code)
+	}
+	sort.Strings(next)
+
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = next
+
+	return nil
+}
+
+func Enabled() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	codes := make([]string, len(enabled))
+	copy(codes, enabled)
+	return codes
+}
+
+func IsEnabled(code string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, c := range enabled {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Decimal renders an amount in minor units as a plain decimal string, e.g. 123456 USD becomes "1234.56".
+func (c Currency) Decimal(amount int64) string {
+	return c.render(amount, false)
+}
+
+// Format renders an amount in minor units for display, e.g. -123456 USD becomes "-1,234.56 USD".
+func (c Currency) Format(amount int64) string {
+	return c.render(amount, true) + " " + c.Code
+}
+
+func (c Currency) render(amount int64, grouped bool) string {
+	sign := ""
+	magnitude := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		magnitude = uint64(-(amount + 1)) + 1
+	}
+
+	digits := strconv.FormatUint(magnitude, 10)
+	if len(digits) <= c.MinorUnits {
+		digits = strings.Repeat("0", c.MinorUnits-len(digits)+1) + digits
+	}
+
+	whole := digits[:len(digits)-c.MinorUnits]
+	fraction := digits[len(digits)-c.MinorUnits:]
+
+	if grouped {
+		whole = group(whole)
+	}
+
+	if c.MinorUnits == 0 {
+		return sign + whole
+	}
+	return sign + whole + "." + fraction
+}
+
+func group(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var sb strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		sb.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
+
+// Decimal renders amount in the minor units of the given currency code. Unknown codes are rendered as an integer.
+func Decimal(code string, amount int64) string {
+	c, ok := Lookup(code)
+	if !ok {
+		return strconv.FormatInt(amount, 10)
+	}
+	return c.Decimal(amount)
+}
+
+// Format renders amount for display in the given currency code. Unknown codes are rendered as an integer.
+func Format(code string, amount int64) string {
+	c, ok := Lookup(code)
+	if !ok {
+		return strconv.FormatInt(amount, 10) + " " + code
+	}
+	return c.Format(amount)
+}
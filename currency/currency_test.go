@@ -0,0 +1,79 @@
+package currency
+
+import (
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, c := range All() {
+		require.Len(t, c.Code, 3)
+		require.NotContains(t, seen, c.Code)
+		require.True(t, c.MinorUnits >= 0 && c.MinorUnits <= 4)
+		seen[c.Code] = true
+	}
+
+	usd, ok := Lookup(constants.USD)
+	require.True(t, ok)
+	require.Equal(t, 840, usd.Number)
+	require.Equal(t, 2, usd.MinorUnits)
+
+	jpy, ok := Lookup("JPY")
+	require.True(t, ok)
+	require.Equal(t, 0, jpy.MinorUnits)
+
+	bhd, ok := Lookup("BHD")
+	require.True(t, ok)
+	require.Equal(t, 3, bhd.MinorUnits)
+
+	_, ok = Lookup("ABC")
+	require.False(t, ok)
+}
+
+func TestDecimal(t *testing.T) {
+	testCases := []struct {
+		code      string
+		amount    int64
+		decimal   string
+		formatted string
+	}{
+		{code: "USD", amount: 0, decimal: "0.00", formatted: "0.00 USD"},
+		{code: "USD", amount: 5, decimal: "0.05", formatted: "0.05 USD"},
+		{code: "USD", amount: 123456, decimal: "1234.56", formatted: "1,234.56 USD"},
+		{code: "USD", amount: -123456789, decimal: "-1234567.89", formatted: "-1,234,567.89 USD"},
+		{code: "JPY", amount: 1500, decimal: "1500", formatted: "1,500 JPY"},
+		{code: "BHD", amount: 1, decimal: "0.001", formatted: "0.001 BHD"},
+		{code: "CLF", amount: 12345, decimal: "1.2345", formatted: "1.2345 CLF"},
+		{code: "ABC", amount: 42, decimal: "42", formatted: "42 ABC"},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.decimal, Decimal(tc.code, tc.amount))
+		require.Equal(t, tc.formatted, Format(tc.code, tc.amount))
+	}
+
+	require.Equal(t, "-92233720368547758.08", Decimal(constants.USD, math.MinInt64))
+}
+
+func TestSetEnabled(t *testing.T) {
+	original := Enabled()
+	defer func() {
+		require.NoError(t, SetEnabled(original))
+	}()
+
+	err := SetEnabled([]string{"gbp", "JPY", "GBP"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"GBP", "JPY"}, Enabled())
+	require.True(t, IsEnabled("GBP"))
+	require.False(t, IsEnabled(constants.USD))
+
+	err = SetEnabled([]string{"ABC"})
+	require.Error(t, err)
+	require.Equal(t, []string{"GBP", "JPY"}, Enabled())
+
+	err = SetEnabled(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package currency
+
+// iso4217 lists every active ISO 4217 code with its numeric code and minor-unit exponent. Codes whose minor unit is
+// "N.A." in the standard (precious metals, bond market units, testing codes) are recorded with an exponent of zero.
+var iso4217 = []Currency{
+	{Code: "AED", Number: 784, MinorUnits: 2, Name: "UAE Dirham"},
+	{Code: "AFN", Number: 971, MinorUnits: 2, Name: "Afghani"},
+	{Code: "ALL", Number: 8, MinorUnits: 2, Name: "Lek"},
+	{Code: "AMD", Number: 51, MinorUnits: 2, Name: "Armenian Dram"},
+	{Code: "AOA", Number: 973, MinorUnits: 2, Name: "Kwanza"},
+	{Code: "ARS", Number: 32, MinorUnits: 2, Name: "Argentine Peso"},
+	{Code: "AUD", Number: 36, MinorUnits: 2, Name: "Australian Dollar"},
+	{Code: "AWG", Number: 533, MinorUnits: 2, Name: "Aruban Florin"},
+	{Code: "AZN", Number: 944, MinorUnits: 2, Name: "Azerbaijan Manat"},
+	{Code: "BAM", Number: 977, MinorUnits: 2, Name: "Convertible Mark"},
+	{Code: "BBD", Number: 52, MinorUnits: 2, Name: "Barbados Dollar"},
+	{Code: "BDT", Number: 50, MinorUnits: 2, Name: "Taka"},
+	{Code: "BGN", Number: 975, MinorUnits: 2, Name: "Bulgarian Lev"},
+	{Code: "BHD", Number: 48, MinorUnits: 3, Name: "Bahraini Dinar"},
+	{Code: "BIF", Number: 108, MinorUnits: 0, Name: "Burundi Franc"},
+	{Code: "BMD", Number: 60, MinorUnits: 2, Name: "Bermudian Dollar"},
+	{Code: "BND", Number: 96, MinorUnits: 2, Name: "Brunei Dollar"},
+	{Code: "BOB", Number: 68, MinorUnits: 2, Name: "Boliviano"},
+	{Code: "BOV", Number: 984, MinorUnits: 2, Name: "Mvdol"},
+	{Code: "BRL", Number: 986, MinorUnits: 2, Name: "Brazilian Real"},
+	{Code: "BSD", Number: 44, MinorUnits: 2, Name: "Bahamian Dollar"},
+	{Code: "BTN", Number: 64, MinorUnits: 2, Name: "Ngultrum"},
+	{Code: "BWP", Number: 72, MinorUnits: 2, Name: "Pula"},
+	{Code: "BYN", Number: 933, MinorUnits: 2, Name: "Belarusian Ruble"},
+	{Code: "BZD", Number: 84, MinorUnits: 2, Name: "Belize Dollar"},
+	{Code: "CAD", Number: 124, MinorUnits: 2, Name: "Canadian Dollar"},
+	{Code: "CDF", Number: 976, MinorUnits: 2, Name: "Congolese Franc"},
+	{Code: "CHE", Number: 947, MinorUnits: 2, Name: "WIR Euro"},
+	{Code: "CHF", Number: 756, MinorUnits: 2, Name: "Swiss Franc"},
+	{Code: "CHW", Number: 948, MinorUnits: 2, Name: "WIR Franc"},
+	{Code: "CLF", Number: 990, MinorUnits: 4, Name: "Unidad de Fomento"},
+	{Code: "CLP", Number: 152, MinorUnits: 0, Name: "Chilean Peso"},
+	{Code: "CNY", Number: 156, MinorUnits: 2, Name: "Yuan Renminbi"},
+	{Code: "COP", Number: 170, MinorUnits: 2, Name: "Colombian Peso"},
+	{Code: "COU", Number: 970, MinorUnits: 2, Name: "Unidad de Valor Real"},
+	{Code: "CRC", Number: 188, MinorUnits: 2, Name: "Costa Rican Colon"},
+	{Code: "CUP", Number: 192, MinorUnits: 2, Name: "Cuban Peso"},
+	{Code: "CVE", Number: 132, MinorUnits: 2, Name: "Cabo Verde Escudo"},
+	{Code: "CZK", Number: 203, MinorUnits: 2, Name: "Czech Koruna"},
+	{Code: "DJF", Number: 262, MinorUnits: 0, Name: "Djibouti Franc"},
+	{Code: "DKK", Number: 208, MinorUnits: 2, Name: "Danish Krone"},
+	{Code: "DOP", Number: 214, MinorUnits: 2, Name: "Dominican Peso"},
+	{Code: "DZD", Number: 12, MinorUnits: 2, Name: "Algerian Dinar"},
+	{Code: "EGP", Number: 818, MinorUnits: 2, Name: "Egyptian Pound"},
+	{Code: "ERN", Number: 232, MinorUnits: 2, Name: "Nakfa"},
+	{Code: "ETB", Number: 230, MinorUnits: 2, Name: "Ethiopian Birr"},
+	{Code: "EUR", Number: 978, MinorUnits: 2, Name: "Euro"},
+	{Code: "FJD", Number: 242, MinorUnits: 2, Name: "Fiji Dollar"},
+	{Code: "FKP", Number: 238, MinorUnits: 2, Name: "Falkland Islands Pound"},
+	{Code: "GBP", Number: 826, MinorUnits: 2, Name: "Pound Sterling"},
+	{Code: "GEL", Number: 981, MinorUnits: 2, Name: "Lari"},
+	{Code: "GHS", Number: 936, MinorUnits: 2, Name: "Ghana Cedi"},
+	{Code: "GIP", Number: 292, MinorUnits: 2, Name: "Gibraltar Pound"},
+	{Code: "GMD", Number: 270, MinorUnits: 2, Name: "Dalasi"},
+	{Code: "GNF", Number: 324, MinorUnits: 0, Name: "Guinean Franc"},
+	{Code: "GTQ", Number: 320, MinorUnits: 2, Name: "Quetzal"},
+	{Code: "GYD", Number: 328, MinorUnits: 2, Name: "Guyana Dollar"},
+	{Code: "HKD", Number: 344, MinorUnits: 2, Name: "Hong Kong Dollar"},
+	{Code: "HNL", Number: 340, MinorUnits: 2, Name: "Lempira"},
+	{Code: "HTG", Number: 332, MinorUnits: 2, Name: "Gourde"},
+	{Code: "HUF", Number: 348, MinorUnits: 2, Name: "Forint"},
+	{Code: "IDR", Number: 360, MinorUnits: 2, Name: "Rupiah"},
+	{Code: "ILS", Number: 376, MinorUnits: 2, Name: "New Israeli Sheqel"},
+	{Code: "INR", Number: 356, MinorUnits: 2, Name: "Indian Rupee"},
+	{Code: "IQD", Number: 368, MinorUnits: 3, Name: "Iraqi Dinar"},
+	{Code: "IRR", Number: 364, MinorUnits: 2, Name: "Iranian Rial"},
+	{Code: "ISK", Number: 352, MinorUnits: 0, Name: "Iceland Krona"},
+	{Code: "JMD", Number: 388, MinorUnits: 2, Name: "Jamaican Dollar"},
+	{Code: "JOD", Number: 400, MinorUnits: 3, Name: "Jordanian Dinar"},
+	{Code: "JPY", Number: 392, MinorUnits: 0, Name: "Yen"},
+	{Code: "KES", Number: 404, MinorUnits: 2, Name: "Kenyan Shilling"},
+	{Code: "KGS", Number: 417, MinorUnits: 2, Name: "Som"},
+	{Code: "KHR", Number: 116, MinorUnits: 2, Name: "Riel"},
+	{Code: "KMF", Number: 174, MinorUnits: 0, Name: "Comorian Franc"},
+	{Code: "KPW", Number: 408, MinorUnits: 2, Name: "North Korean Won"},
+	{Code: "KRW", Number: 410, MinorUnits: 0, Name: "Won"},
+	{Code: "KWD", Number: 414, MinorUnits: 3, Name: "Kuwaiti Dinar"},
+	{Code: "KYD", Number: 136, MinorUnits: 2, Name: "Cayman Islands Dollar"},
+	{Code: "KZT", Number: 398, MinorUnits: 2, Name: "Tenge"},
+	{Code: "LAK", Number: 418, MinorUnits: 2, Name: "Lao Kip"},
+	{Code: "LBP", Number: 422, MinorUnits: 2, Name: "Lebanese Pound"},
+	{Code: "LKR", Number: 144, MinorUnits: 2, Name: "Sri Lanka Rupee"},
+	{Code: "LRD", Number: 430, MinorUnits: 2, Name: "Liberian Dollar"},
+	{Code: "LSL", Number: 426, MinorUnits: 2, Name: "Loti"},
+	{Code: "LYD", Number: 434, MinorUnits: 3, Name: "Libyan Dinar"},
+	{Code: "MAD", Number: 504, MinorUnits: 2, Name: "Moroccan Dirham"},
+	{Code: "MDL", Number: 498, MinorUnits: 2, Name: "Moldovan Leu"},
+	{Code: "MGA", Number: 969, MinorUnits: 2, Name: "Malagasy Ariary"},
+	{Code: "MKD", Number: 807, MinorUnits: 2, Name: "Denar"},
+	{Code: "MMK", Number: 104, MinorUnits: 2, Name: "Kyat"},
+	{Code: "MNT", Number: 496, MinorUnits: 2, Name: "Tugrik"},
+	{Code: "MOP", Number: 446, MinorUnits: 2, Name: "Pataca"},
+	{Code: "MRU", Number: 929, MinorUnits: 2, Name: "Ouguiya"},
+	{Code: "MUR", Number: 480, MinorUnits: 2, Name: "Mauritius Rupee"},
+	{Code: "MVR", Number: 462, MinorUnits: 2, Name: "Rufiyaa"},
+	{Code: "MWK", Number: 454, MinorUnits: 2, Name: "Malawi Kwacha"},
+	{Code: "MXN", Number: 484, MinorUnits: 2, Name: "Mexican Peso"},
+	{Code: "MXV", Number: 979, MinorUnits: 2, Name: "Mexican Unidad de Inversion (UDI)"},
+	{Code: "MYR", Number: 458, MinorUnits: 2, Name: "Malaysian Ringgit"},
+	{Code: "MZN", Number: 943, MinorUnits: 2, Name: "Mozambique Metical"},
+	{Code: "NAD", Number: 516, MinorUnits: 2, Name: "Namibia Dollar"},
+	{Code: "NGN", Number: 566, MinorUnits: 2, Name: "Naira"},
+	{Code: "NIO", Number: 558, MinorUnits: 2, Name: "Cordoba Oro"},
+	{Code: "NOK", Number: 578, MinorUnits: 2, Name: "Norwegian Krone"},
+	{Code: "NPR", Number: 524, MinorUnits: 2, Name: "Nepalese Rupee"},
+	{Code: "NZD", Number: 554, MinorUnits: 2, Name: "New Zealand Dollar"},
+	{Code: "OMR", Number: 512, MinorUnits: 3, Name: "Rial Omani"},
+	{Code: "PAB", Number: 590, MinorUnits: 2, Name: "Balboa"},
+	{Code: "PEN", Number: 604, MinorUnits: 2, Name: "Sol"},
+	{Code: "PGK", Number: 598, MinorUnits: 2, Name: "Kina"},
+	{Code: "PHP", Number: 608, MinorUnits: 2, Name: "Philippine Peso"},
+	{Code: "PKR", Number: 586, MinorUnits: 2, Name: "Pakistan Rupee"},
+	{Code: "PLN", Number: 985, MinorUnits: 2, Name: "Zloty"},
+	{Code: "PYG", Number: 600, MinorUnits: 0, Name: "Guarani"},
+	{Code: "QAR", Number: 634, MinorUnits: 2, Name: "Qatari Rial"},
+	{Code: "RON", Number: 946, MinorUnits: 2, Name: "Romanian Leu"},
+	{Code: "RSD", Number: 941, MinorUnits: 2, Name: "Serbian Dinar"},
+	{Code: "RUB", Number: 643, MinorUnits: 2, Name: "Russian Ruble"},
+	{Code: "RWF", Number: 646, MinorUnits: 0, Name: "Rwanda Franc"},
+	{Code: "SAR", Number: 682, MinorUnits: 2, Name: "Saudi Riyal"},
+	{Code: "SBD", Number: 90, MinorUnits: 2, Name: "Solomon Islands Dollar"},
+	{Code: "SCR", Number: 690, MinorUnits: 2, Name: "Seychelles Rupee"},
+	{Code: "SDG", Number: 938, MinorUnits: 2, Name: "Sudanese Pound"},
+	{Code: "SEK", Number: 752, MinorUnits: 2, Name: "Swedish Krona"},
+	{Code: "SGD", Number: 702, MinorUnits: 2, Name: "Singapore Dollar"},
+	{Code: "SHP", Number: 654, MinorUnits: 2, Name: "Saint Helena Pound"},
+	{Code: "SLE", Number: 925, MinorUnits: 2, Name: "Leone"},
+	{Code: "SOS", Number: 706, MinorUnits: 2, Name: "Somali Shilling"},
+	{Code: "SRD", Number: 968, MinorUnits: 2, Name: "Surinam Dollar"},
+	{Code: "SSP", Number: 728, MinorUnits: 2, Name: "South Sudanese Pound"},
+	{Code: "STN", Number: 930, MinorUnits: 2, Name: "Dobra"},
+	{Code: "SVC", Number: 222, MinorUnits: 2, Name: "El Salvador Colon"},
+	{Code: "SYP", Number: 760, MinorUnits: 2, Name: "Syrian Pound"},
+	{Code: "SZL", Number: 748, MinorUnits: 2, Name: "Lilangeni"},
+	{Code: "THB", Number: 764, MinorUnits: 2, Name: "Baht"},
+	{Code: "TJS", Number: 972, MinorUnits: 2, Name: "Somoni"},
+	{Code: "TMT", Number: 934, MinorUnits: 2, Name: "Turkmenistan New Manat"},
+	{Code: "TND", Number: 788, MinorUnits: 3, Name: "Tunisian Dinar"},
+	{Code: "TOP", Number: 776, MinorUnits: 2, Name: "Pa'anga"},
+	{Code: "TRY", Number: 949, MinorUnits: 2, Name: "Turkish Lira"},
+	{Code: "TTD", Number: 780, MinorUnits: 2, Name: "Trinidad and Tobago Dollar"},
+	{Code: "TWD", Number: 901, MinorUnits: 2, Name: "New Taiwan Dollar"},
+	{Code: "TZS", Number: 834, MinorUnits: 2, Name: "Tanzanian Shilling"},
+	{Code: "UAH", Number: 980, MinorUnits: 2, Name: "Hryvnia"},
+	{Code: "UGX", Number: 800, MinorUnits: 0, Name: "Uganda Shilling"},
+	{Code: "USD", Number: 840, MinorUnits: 2, Name: "US Dollar"},
+	{Code: "USN", Number: 997, MinorUnits: 2, Name: "US Dollar (Next day)"},
+	{Code: "UYI", Number: 940, MinorUnits: 0, Name: "Uruguay Peso en Unidades Indexadas (UI)"},
+	{Code: "UYU", Number: 858, MinorUnits: 2, Name: "Peso Uruguayo"},
+	{Code: "UYW", Number: 927, MinorUnits: 4, Name: "Unidad Previsional"},
+	{Code: "UZS", Number: 860, MinorUnits: 2, Name: "Uzbekistan Sum"},
+	{Code: "VED", Number: 926, MinorUnits: 2, Name: "Bolivar Soberano"},
+	{Code: "VES", Number: 928, MinorUnits: 2, Name: "Bolivar Soberano"},
+	{Code: "VND", Number: 704, MinorUnits: 0, Name: "Dong"},
+	{Code: "VUV", Number: 548, MinorUnits: 0, Name: "Vatu"},
+	{Code: "WST", Number: 882, MinorUnits: 2, Name: "Tala"},
+	{Code: "XAF", Number: 950, MinorUnits: 0, Name: "CFA Franc BEAC"},
+	{Code: "XAG", Number: 961, MinorUnits: 0, Name: "Silver"},
+	{Code: "XAU", Number: 959, MinorUnits: 0, Name: "Gold"},
+	{Code: "XBA", Number: 955, MinorUnits: 0, Name: "Bond Markets Unit European Composite Unit (EURCO)"},
+	{Code: "XBB", Number: 956, MinorUnits: 0, Name: "Bond Markets Unit European Monetary Unit (E.M.U.-6)"},
+	{Code: "XBC", Number: 957, MinorUnits: 0, Name: "Bond Markets Unit European Unit of Account 9 (E.U.A.-9)"},
+	{Code: "XBD", Number: 958, MinorUnits: 0, Name: "Bond Markets Unit European Unit of Account 17 (E.U.A.-17)"},
+	{Code: "XCD", Number: 951, MinorUnits: 2, Name: "East Caribbean Dollar"},
+	{Code: "XCG", Number: 532, MinorUnits: 2, Name: "Caribbean Guilder"},
+	{Code: "XDR", Number: 960, MinorUnits: 0, Name: "SDR (Special Drawing Right)"},
+	{Code: "XOF", Number: 952, MinorUnits: 0, Name: "CFA Franc BCEAO"},
+	{Code: "XPD", Number: 964, MinorUnits: 0, Name: "Palladium"},
+	{Code: "XPF", Number: 953, MinorUnits: 0, Name: "CFP Franc"},
+	{Code: "XPT", Number: 962, MinorUnits: 0, Name: "Platinum"},
+	{Code: "XSU", Number: 994, MinorUnits: 0, Name: "Sucre"},
+	{Code: "XTS", Number: 963, MinorUnits: 0, Name: "Codes specifically reserved for testing purposes"},
+	{Code: "XUA", Number: 965, MinorUnits: 0, Name: "ADB Unit of Account"},
+	{Code: "XXX", Number: 999, MinorUnits: 0, Name: "No currency"},
+	{Code: "YER", Number: 886, MinorUnits: 2, Name: "Yemeni Rial"},
+	{Code: "ZAR", Number: 710, MinorUnits: 2, Name: "Rand"},
+	{Code: "ZMW", Number: 967, MinorUnits: 2, Name: "Zambian Kwacha"},
+	{Code: "ZWG", Number: 924, MinorUnits: 2, Name: "Zimbabwe Gold"},
+}
@@ -0,0 +1,4 @@
+drop table if exists hold;
+
+alter table if exists account
+    drop column if exists held_balance;
@@ -0,0 +1,30 @@
+alter table account
+    add column held_balance bigint default 0 not null;
+
+comment on column account.held_balance is 'Sum of active holds, excluded from the available balance';
+
+create table hold
+(
+    id                     bigserial
+        primary key,
+    account_id             bigint                                   not null
+        references account,
+    destination_account_id bigint                                   not null
+        references account,
+    amount                 bigint                                   not null,
+    captured_amount        bigint                   default 0        not null,
+    status                 varchar                  default 'active' not null,
+    reference              varchar                  default ''       not null,
+    transfer_id            bigint
+        references transfer,
+    expires_at             timestamp with time zone                 not null,
+    created_at             timestamp with time zone default now()   not null
+);
+
+comment on column hold.amount is 'Must be positive';
+
+create index holds_account_id_idx
+    on hold (account_id);
+
+create index holds_status_expires_at_idx
+    on hold (status, expires_at);
@@ -0,0 +1,3 @@
+drop table if exists notification;
+
+drop table if exists scheduled_transfer;
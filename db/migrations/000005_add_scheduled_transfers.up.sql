@@ -0,0 +1,50 @@
+create table scheduled_transfer
+(
+    id                     bigserial
+        primary key,
+    owner                  varchar                                  not null
+        references "user" (username),
+    source_account_id      bigint                                   not null
+        references account,
+    destination_account_id bigint                                   not null
+        references account,
+    amount                 bigint                                   not null,
+    currency               varchar                                  not null,
+    frequency              varchar                                  not null,
+    day_of_month           integer                  default 0        not null,
+    next_occurrence_at     timestamp with time zone                 not null,
+    next_attempt_at        timestamp with time zone                 not null,
+    end_at                 timestamp with time zone,
+    remaining_runs         integer,
+    status                 varchar                  default 'active' not null,
+    attempts               integer                  default 0        not null,
+    last_error             varchar                  default ''       not null,
+    last_run_at            timestamp with time zone,
+    created_at             timestamp with time zone default now()   not null
+);
+
+comment on column scheduled_transfer.next_occurrence_at is 'Date the pending run is scheduled for';
+
+comment on column scheduled_transfer.next_attempt_at is 'When the pending run is next tried, later than next_occurrence_at while retrying';
+
+comment on column scheduled_transfer.remaining_runs is 'Occurrences left, paid or skipped, before the schedule completes; unlimited when null';
+
+create index scheduled_transfers_owner_idx
+    on scheduled_transfer (owner);
+
+create index scheduled_transfers_status_next_attempt_at_idx
+    on scheduled_transfer (status, next_attempt_at);
+
+create table notification
+(
+    id         bigserial
+        primary key,
+    username   varchar                                not null
+        references "user" (username),
+    kind       varchar                                not null,
+    message    varchar                                not null,
+    created_at timestamp with time zone default now() not null
+);
+
+create index notifications_username_idx
+    on notification (username);
@@ -0,0 +1,23 @@
+drop table if exists interest_accrual;
+
+drop table if exists interest_posting;
+
+drop table if exists system_account;
+
+delete
+from account
+where product = 'internal';
+
+delete
+from "user"
+where username = 'golang-bank';
+
+drop index if exists owner_currency_key;
+
+alter table account
+    add constraint owner_currency_key unique (owner, currency);
+
+alter table account
+    drop column if exists product;
+
+drop table if exists account_product;
@@ -0,0 +1,89 @@
+create table account_product
+(
+    code                 varchar
+        primary key,
+    name                 varchar                                      not null,
+    kind                 varchar                                      not null,
+    interest_rate_bps    integer                  default 0            not null,
+    day_count_convention varchar                  default 'actual/365' not null,
+    created_at           timestamp with time zone default now()       not null
+);
+
+comment on column account_product.kind is 'checking, savings or internal';
+
+comment on column account_product.interest_rate_bps is 'Annual interest rate in basis points';
+
+insert into account_product (code, name, kind, interest_rate_bps)
+values ('checking', 'Checking', 'checking', 0),
+       ('savings', 'Savings', 'savings', 200),
+       ('internal', 'Internal', 'internal', 0);
+
+alter table account
+    add column product varchar default 'checking' not null references account_product;
+
+alter table account
+    drop constraint owner_currency_key;
+
+create unique index owner_currency_key
+    on account (owner, currency)
+    where product <> 'internal';
+
+insert into "user" (username, hashed_password, full_name, email)
+values ('golang-bank', '', 'Golang Bank', 'bank@golang-bank.internal');
+
+create table system_account
+(
+    purpose    varchar not null,
+    currency   varchar not null,
+    account_id bigint  not null
+        references account,
+    primary key (purpose, currency)
+);
+
+comment on table system_account is 'Accounts owned by the bank, one per purpose and currency';
+
+create table interest_accrual
+(
+    id                   bigserial
+        primary key,
+    account_id           bigint                                 not null
+        references account,
+    accrual_date         date                                   not null,
+    balance              bigint                                 not null,
+    interest_rate_bps    integer                                not null,
+    day_count_convention varchar                                not null,
+    amount_micros        bigint                                 not null,
+    posting_id           bigint,
+    created_at           timestamp with time zone default now() not null,
+    unique (account_id, accrual_date)
+);
+
+comment on column interest_accrual.balance is 'End-of-day balance the interest was earned on';
+
+comment on column interest_accrual.amount_micros is 'Interest in millionths of a minor unit';
+
+create table interest_posting
+(
+    id           bigserial
+        primary key,
+    account_id   bigint                                 not null
+        references account,
+    period_start date                                   not null,
+    period_end   date                                   not null,
+    amount       bigint                   default 0     not null,
+    carry_micros bigint                   default 0     not null,
+    transfer_id  bigint
+        references transfer,
+    created_at   timestamp with time zone default now() not null,
+    unique (account_id, period_start)
+);
+
+comment on column interest_posting.period_end is 'Exclusive';
+
+comment on column interest_posting.carry_micros is 'Accrued micro-units left over after posting whole minor units';
+
+alter table interest_accrual
+    add foreign key (posting_id) references interest_posting;
+
+create index interest_accruals_posting_id_idx
+    on interest_accrual (account_id, posting_id);
@@ -0,0 +1,3 @@
+drop table if exists fee_waiver;
+
+drop table if exists fee;
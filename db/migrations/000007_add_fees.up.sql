@@ -0,0 +1,48 @@
+create table fee
+(
+    id              bigserial
+        primary key,
+    account_id      bigint                                 not null
+        references account,
+    kind            varchar                                not null,
+    name            varchar                                not null,
+    amount          bigint                                 not null,
+    transfer_id     bigint
+        references transfer,
+    fee_transfer_id bigint
+        references transfer,
+    period_start    date,
+    created_at      timestamp with time zone default now() not null
+);
+
+comment on column fee.kind is 'transfer or maintenance';
+
+comment on column fee.transfer_id is 'Transfer the fee was charged on, for transfer fees';
+
+comment on column fee.fee_transfer_id is 'Transfer that moved the fee to the bank''s fee revenue account';
+
+comment on column fee.period_start is 'Month the fee was charged for, for maintenance fees';
+
+create index fee_account_id_idx
+    on fee (account_id);
+
+create unique index fee_maintenance_period_key
+    on fee (account_id, name, period_start)
+    where kind = 'maintenance';
+
+create table fee_waiver
+(
+    id         bigserial
+        primary key,
+    account_id bigint                                 not null
+        references account,
+    fee_name   varchar                  default ''    not null,
+    reason     varchar                  default ''    not null,
+    expires_at timestamp with time zone,
+    created_at timestamp with time zone default now() not null
+);
+
+comment on column fee_waiver.fee_name is 'Fee that is waived, every fee when empty';
+
+create index fee_waiver_account_id_idx
+    on fee_waiver (account_id);
@@ -0,0 +1,13 @@
+drop trigger if exists entry_journal_transaction_balanced on entry;
+
+drop function if exists check_entry_journal_transaction_balanced();
+
+drop function if exists check_journal_transaction_balanced(bigint);
+
+alter table transfer
+    drop column if exists journal_transaction_id;
+
+alter table entry
+    drop column if exists journal_transaction_id;
+
+drop table if exists journal_transaction;
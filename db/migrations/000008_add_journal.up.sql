@@ -0,0 +1,71 @@
+create table journal_transaction
+(
+    id          bigserial
+        primary key,
+    kind        varchar                                not null,
+    description varchar                  default ''    not null,
+    created_at  timestamp with time zone default now() not null
+);
+
+comment on table journal_transaction is 'Groups the entries of one movement of money, which must balance per currency';
+
+comment on column journal_transaction.kind is 'transfer, deposit or withdrawal';
+
+alter table entry
+    add column journal_transaction_id bigint
+        references journal_transaction;
+
+-- Entries written before the journal existed have no transaction; every new one must.
+alter table entry
+    add constraint entry_journal_transaction_id_check check (journal_transaction_id is not null) not valid;
+
+create index entries_journal_transaction_id_idx
+    on entry (journal_transaction_id);
+
+alter table transfer
+    add column journal_transaction_id bigint
+        references journal_transaction;
+
+create function check_journal_transaction_balanced(transaction_id bigint) returns void as
+$$
+declare
+    unbalanced_currency varchar;
+begin
+    select account.currency
+    into unbalanced_currency
+    from entry
+             join account on account.id = entry.account_id
+    where entry.journal_transaction_id = transaction_id
+    group by account.currency
+    having sum(entry.amount) <> 0
+    limit 1;
+
+    if unbalanced_currency is not null then
+        raise exception 'journal transaction % does not balance in %', transaction_id, unbalanced_currency
+            using errcode = 'check_violation';
+    end if;
+end;
+$$ language plpgsql;
+
+create function check_entry_journal_transaction_balanced() returns trigger as
+$$
+begin
+    if tg_op in ('UPDATE', 'DELETE') and old.journal_transaction_id is not null then
+        perform check_journal_transaction_balanced(old.journal_transaction_id);
+    end if;
+
+    if tg_op in ('INSERT', 'UPDATE') and new.journal_transaction_id is not null then
+        perform check_journal_transaction_balanced(new.journal_transaction_id);
+    end if;
+
+    return null;
+end;
+$$ language plpgsql;
+
+-- Deferred to commit so that a transaction can insert its entries one at a time.
+create constraint trigger entry_journal_transaction_balanced
+    after insert or update or delete
+    on entry
+    deferrable initially deferred
+    for each row
+execute function check_entry_journal_transaction_balanced();
@@ -0,0 +1,4 @@
+drop table if exists cash_transaction;
+
+alter table "user"
+    drop column if exists role;
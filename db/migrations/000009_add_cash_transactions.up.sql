@@ -0,0 +1,34 @@
+alter table "user"
+    add column role varchar default 'customer' not null;
+
+comment on column "user".role is 'customer, teller, admin or rail';
+
+create table cash_transaction
+(
+    id                     bigserial
+        primary key,
+    account_id             bigint                                 not null
+        references account,
+    kind                   varchar                                not null,
+    amount                 bigint                                 not null,
+    reference              varchar                  default ''    not null,
+    channel                varchar                                not null,
+    idempotency_key        varchar                                not null,
+    created_by             varchar                                not null
+        references "user",
+    journal_transaction_id bigint
+        references journal_transaction,
+    created_at             timestamp with time zone default now() not null,
+    unique (created_by, idempotency_key)
+);
+
+comment on table cash_transaction is 'Deposits and withdrawals against the bank''s cash clearing account';
+
+comment on column cash_transaction.kind is 'deposit or withdrawal';
+
+comment on column cash_transaction.amount is 'Must be positive';
+
+comment on column cash_transaction.channel is 'branch, atm, ach or wire';
+
+create index cash_transaction_account_id_idx
+    on cash_transaction (account_id);
@@ -0,0 +1,5 @@
+drop index if exists transfer_journal_transaction_id_idx;
+
+drop table if exists reconciliation_discrepancy;
+
+drop table if exists reconciliation_run;
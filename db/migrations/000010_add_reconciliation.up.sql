@@ -0,0 +1,42 @@
+create table reconciliation_run
+(
+    id                bigserial
+        primary key,
+    accounts_checked  bigint                   default 0     not null,
+    transfers_checked bigint                   default 0     not null,
+    discrepancy_count bigint                   default 0     not null,
+    started_at        timestamp with time zone default now() not null,
+    completed_at      timestamp with time zone
+);
+
+comment on table reconciliation_run is 'One pass of the ledger reconciliation job';
+
+comment on column reconciliation_run.completed_at is 'Null while the run is in progress or if it was interrupted';
+
+create table reconciliation_discrepancy
+(
+    id                     bigserial
+        primary key,
+    run_id                 bigint                                 not null
+        references reconciliation_run
+            on delete cascade,
+    kind                   varchar                                not null,
+    account_id             bigint,
+    transfer_id            bigint,
+    entry_id               bigint,
+    journal_transaction_id bigint,
+    expected               bigint,
+    actual                 bigint,
+    detail                 varchar                  default ''    not null,
+    created_at             timestamp with time zone default now() not null
+);
+
+comment on table reconciliation_discrepancy is 'A ledger invariant found broken by a reconciliation run';
+
+comment on column reconciliation_discrepancy.kind is 'balance_mismatch, transfer_missing_entry, transfer_entry_mismatch or journal_missing_transfer';
+
+create index reconciliation_discrepancy_run_id_idx
+    on reconciliation_discrepancy (run_id);
+
+create index transfer_journal_transaction_id_idx
+    on transfer (journal_transaction_id);
@@ -0,0 +1,11 @@
+create table balance_snapshot
+(
+    account_id bigint                                 not null
+        references account,
+    date       date                                   not null,
+    balance    bigint                                 not null,
+    created_at timestamp with time zone default now() not null,
+    primary key (account_id, date)
+);
+
+comment on table balance_snapshot is 'An account''s balance at the close of a UTC day';
@@ -0,0 +1,3 @@
+drop table if exists statement;
+
+drop function if exists reject_statement_change();
@@ -0,0 +1,37 @@
+create table statement
+(
+    id              bigserial
+        primary key,
+    account_id      bigint                                 not null
+        references account,
+    period_start    date                                   not null,
+    period_end      date                                   not null,
+    opening_balance bigint                                 not null,
+    closing_balance bigint                                 not null,
+    content         json                                   not null,
+    content_hash    varchar                                not null,
+    created_at      timestamp with time zone default now() not null,
+    unique (account_id, period_start, period_end)
+);
+
+comment on table statement is 'Generated account statements, which are never changed once written';
+
+comment on column statement.period_end is 'Exclusive';
+
+comment on column statement.content is 'The statement exactly as generated, kept as json rather than jsonb so the hash still matches';
+
+comment on column statement.content_hash is 'Hex SHA-256 of content';
+
+create function reject_statement_change() returns trigger as
+$$
+begin
+    raise exception 'statement % is immutable', old.id
+        using errcode = 'restrict_violation';
+end;
+$$ language plpgsql;
+
+create trigger statement_immutable
+    before update or delete
+    on statement
+    for each row
+execute function reject_statement_change();
@@ -0,0 +1,4 @@
+drop table if exists account_status_change;
+
+alter table account
+    drop column if exists status;
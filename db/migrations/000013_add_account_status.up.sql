@@ -0,0 +1,23 @@
+alter table account
+    add column status varchar default 'active' not null;
+
+comment on column account.status is 'active, frozen, dormant or closed';
+
+create table account_status_change
+(
+    id          bigserial
+        primary key,
+    account_id  bigint                                 not null
+        references account,
+    from_status varchar                                not null,
+    to_status   varchar                                not null,
+    reason      varchar                                not null,
+    changed_by  varchar                                not null
+        references "user",
+    created_at  timestamp with time zone default now() not null
+);
+
+comment on table account_status_change is 'Every transition of an account between lifecycle states';
+
+create index account_status_change_account_id_idx
+    on account_status_change (account_id);
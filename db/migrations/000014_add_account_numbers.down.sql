@@ -0,0 +1,3 @@
+alter table account
+    drop column if exists iban,
+    drop column if exists account_number;
@@ -0,0 +1,44 @@
+alter table account
+    add column account_number varchar unique,
+    add column iban           varchar unique;
+
+comment on column account.account_number is 'Ten random digits followed by a Luhn check digit';
+
+comment on column account.iban is 'Only set when the bank is configured with a country and bank code';
+
+create function luhn_check_digit(digits varchar) returns int
+    language plpgsql
+    immutable
+as
+$$
+declare
+    total  int     := 0;
+    d      int;
+    double boolean := true;
+begin
+    for i in reverse length(digits)..1
+        loop
+            d := substr(digits, i, 1)::int;
+            if double then
+                d := d * 2;
+                if d > 9 then
+                    d := d - 9;
+                end if;
+            end if;
+            total := total + d;
+            double := not double;
+        end loop;
+    return (10 - total % 10) % 10;
+end;
+$$;
+
+update account
+set account_number = digits || luhn_check_digit(digits)
+from (select id, lpad(floor(random() * 10000000000)::bigint::text, 10, '0') as digits
+      from account) as generated
+where account.id = generated.id;
+
+drop function luhn_check_digit(varchar);
+
+alter table account
+    alter column account_number set not null;
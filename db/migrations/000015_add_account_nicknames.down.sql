@@ -0,0 +1,8 @@
+drop index if exists owner_nickname_key;
+
+alter table account
+    drop column if exists nickname;
+
+create unique index owner_currency_key
+    on account (owner, currency)
+    where product <> 'internal';
@@ -0,0 +1,10 @@
+drop index if exists owner_currency_key;
+
+alter table account
+    add column nickname varchar;
+
+comment on column account.nickname is 'Chosen by the owner to tell accounts of the same currency apart';
+
+create unique index owner_nickname_key
+    on account (owner, nickname)
+    where nickname is not null;
@@ -0,0 +1,3 @@
+drop table if exists account_invitation;
+
+drop table if exists account_member;
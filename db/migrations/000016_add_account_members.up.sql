@@ -0,0 +1,51 @@
+create table account_member
+(
+    account_id     bigint                                 not null
+        references account,
+    username       varchar                                not null
+        references "user",
+    role           varchar                                not null,
+    transfer_limit bigint,
+    created_at     timestamp with time zone default now() not null,
+    primary key (account_id, username)
+);
+
+comment on table account_member is 'Everyone with access to an account, including its owner';
+
+comment on column account_member.role is 'owner, co_owner, viewer or spender';
+
+comment on column account_member.transfer_limit is 'Largest amount a spender can move in one transfer';
+
+create index account_member_username_idx
+    on account_member (username);
+
+insert into account_member (account_id, username, role, created_at)
+select id, owner, 'owner', created_at
+from account
+where product <> 'internal';
+
+create table account_invitation
+(
+    id             bigserial
+        primary key,
+    account_id     bigint                                       not null
+        references account,
+    invitee        varchar                                      not null
+        references "user",
+    role           varchar                                      not null,
+    transfer_limit bigint,
+    invited_by     varchar                                      not null
+        references "user",
+    status         varchar                  default 'pending'   not null,
+    created_at     timestamp with time zone default now()       not null,
+    responded_at   timestamp with time zone
+);
+
+comment on column account_invitation.status is 'pending, accepted, declined or revoked';
+
+create unique index account_invitation_pending_key
+    on account_invitation (account_id, invitee)
+    where status = 'pending';
+
+create index account_invitation_invitee_idx
+    on account_invitation (invitee);
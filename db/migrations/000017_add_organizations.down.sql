@@ -0,0 +1,10 @@
+drop table if exists transfer_approval;
+
+drop table if exists pending_transfer;
+
+alter table account
+    drop column if exists organization_id;
+
+drop table if exists organization_member;
+
+drop table if exists organization;
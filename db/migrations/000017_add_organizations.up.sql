@@ -0,0 +1,83 @@
+create table organization
+(
+    id                 bigserial
+        primary key,
+    name               varchar                                not null,
+    approval_threshold bigint                   default 0     not null,
+    required_approvals integer                  default 1     not null,
+    created_by         varchar                                not null
+        references "user",
+    created_at         timestamp with time zone default now() not null
+);
+
+comment on column organization.approval_threshold is 'Transfers of more than this need approval before they run';
+
+comment on column organization.required_approvals is 'Distinct approvers, other than the initiator, a transfer needs';
+
+create table organization_member
+(
+    organization_id bigint                                 not null
+        references organization,
+    username        varchar                                not null
+        references "user",
+    role            varchar                                not null,
+    created_at      timestamp with time zone default now() not null,
+    primary key (organization_id, username)
+);
+
+comment on column organization_member.role is 'admin, initiator or approver';
+
+create index organization_member_username_idx
+    on organization_member (username);
+
+alter table account
+    add column organization_id bigint references organization;
+
+comment on column account.organization_id is 'Set for accounts owned by an organization, whose members have access in place of the owner';
+
+create table pending_transfer
+(
+    id                     bigserial
+        primary key,
+    organization_id        bigint                                       not null
+        references organization,
+    source_account_id      bigint                                       not null
+        references account,
+    destination_account_id bigint                                       not null
+        references account,
+    amount                 bigint                                       not null,
+    initiated_by           varchar                                      not null
+        references "user",
+    required_approvals     integer                                      not null,
+    status                 varchar                  default 'pending'   not null,
+    transfer_id            bigint
+        references transfer,
+    rejected_by            varchar
+        references "user",
+    rejection_reason       varchar,
+    expires_at             timestamp with time zone                     not null,
+    decided_at             timestamp with time zone,
+    created_at             timestamp with time zone default now()       not null,
+    constraint pending_transfer_amount_check check (amount > 0)
+);
+
+comment on column pending_transfer.status is 'pending, executed, rejected or expired';
+
+comment on column pending_transfer.required_approvals is 'Copied from the organization when the transfer was initiated';
+
+create index pending_transfer_organization_id_idx
+    on pending_transfer (organization_id, status);
+
+create index pending_transfer_expires_at_idx
+    on pending_transfer (expires_at)
+    where status = 'pending';
+
+create table transfer_approval
+(
+    pending_transfer_id bigint                                 not null
+        references pending_transfer,
+    approver            varchar                                not null
+        references "user",
+    created_at          timestamp with time zone default now() not null,
+    primary key (pending_transfer_id, approver)
+);
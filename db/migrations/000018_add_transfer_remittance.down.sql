@@ -0,0 +1,26 @@
+alter table pending_transfer
+    drop column if exists memo,
+    drop column if exists reference,
+    drop column if exists category,
+    drop column if exists invoice_number,
+    drop column if exists end_to_end_id;
+
+alter table entry
+    drop column if exists memo,
+    drop column if exists reference,
+    drop column if exists category,
+    drop column if exists invoice_number,
+    drop column if exists end_to_end_id;
+
+drop index if exists transfer_end_to_end_id_idx;
+
+drop index if exists transfer_invoice_number_idx;
+
+drop index if exists transfer_reference_idx;
+
+alter table transfer
+    drop column if exists memo,
+    drop column if exists reference,
+    drop column if exists category,
+    drop column if exists invoice_number,
+    drop column if exists end_to_end_id;
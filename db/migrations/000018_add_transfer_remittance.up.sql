@@ -0,0 +1,40 @@
+alter table transfer
+    add column memo           varchar,
+    add column reference      varchar,
+    add column category       varchar,
+    add column invoice_number varchar,
+    add column end_to_end_id  varchar;
+
+comment on column transfer.memo is 'Free text from the payer';
+
+comment on column transfer.reference is 'The payer''s own reference for the transfer';
+
+comment on column transfer.end_to_end_id is 'Identifier the payer assigned to travel with the payment end to end';
+
+create index transfer_reference_idx
+    on transfer (reference)
+    where reference is not null;
+
+create index transfer_invoice_number_idx
+    on transfer (invoice_number)
+    where invoice_number is not null;
+
+create index transfer_end_to_end_id_idx
+    on transfer (end_to_end_id)
+    where end_to_end_id is not null;
+
+alter table entry
+    add column memo           varchar,
+    add column reference      varchar,
+    add column category       varchar,
+    add column invoice_number varchar,
+    add column end_to_end_id  varchar;
+
+comment on column entry.memo is 'Copied from the transfer that posted the entry';
+
+alter table pending_transfer
+    add column memo           varchar,
+    add column reference      varchar,
+    add column category       varchar,
+    add column invoice_number varchar,
+    add column end_to_end_id  varchar;
@@ -0,0 +1,29 @@
+create table payee
+(
+    id                bigserial
+        primary key,
+    owner             varchar                                not null
+        references "user",
+    nickname          varchar                                not null,
+    account_id        bigint                                 not null
+        references account,
+    currency          varchar                                not null,
+    expected_name     varchar,
+    name_check        varchar,
+    cooling_off_until timestamp with time zone               not null,
+    created_at        timestamp with time zone default now() not null
+);
+
+comment on table payee is 'Accounts a user has saved to send transfers to';
+
+comment on column payee.expected_name is 'Name the owner expects the account to be held under';
+
+comment on column payee.name_check is 'match, close_match or no_match against the real account holder, set with expected_name';
+
+comment on column payee.cooling_off_until is 'Transfers to the payee are limited until then';
+
+create unique index payee_owner_nickname_key
+    on payee (owner, nickname);
+
+create unique index payee_owner_account_id_key
+    on payee (owner, account_id);
@@ -0,0 +1,47 @@
+create table payment_request
+(
+    id                     bigserial
+        primary key,
+    requester              varchar                                      not null
+        references "user",
+    destination_account_id bigint                                       not null
+        references account,
+    payer                  varchar
+        references "user",
+    payer_account_id       bigint
+        references account,
+    amount                 bigint                                       not null,
+    currency               varchar                                      not null,
+    memo                   varchar,
+    status                 varchar                  default 'pending'   not null,
+    transfer_id            bigint
+        references transfer,
+    expires_at             timestamp with time zone                     not null,
+    responded_at           timestamp with time zone,
+    created_at             timestamp with time zone default now()       not null,
+    constraint payment_request_amount_check check (amount > 0),
+    constraint payment_request_payer_check check ((payer is null) <> (payer_account_id is null))
+);
+
+comment on table payment_request is 'Money a user has asked another user, or the holders of an account, to send them';
+
+comment on column payment_request.destination_account_id is 'Requester''s account the money is paid in to';
+
+comment on column payment_request.payer is 'Set when the request is to a user, who picks the account to pay from';
+
+comment on column payment_request.payer_account_id is 'Set when the request is to an account, which anyone who can spend from it can pay';
+
+comment on column payment_request.status is 'pending, accepted, declined, cancelled or expired';
+
+create index payment_request_requester_idx
+    on payment_request (requester);
+
+create index payment_request_payer_idx
+    on payment_request (payer);
+
+create index payment_request_payer_account_id_idx
+    on payment_request (payer_account_id);
+
+create index payment_request_expires_at_idx
+    on payment_request (expires_at)
+    where status = 'pending';
@@ -0,0 +1,3 @@
+drop table if exists transfer_batch_item;
+
+drop table if exists transfer_batch;
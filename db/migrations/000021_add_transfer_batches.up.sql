@@ -0,0 +1,57 @@
+create table transfer_batch
+(
+    id                bigserial
+        primary key,
+    source_account_id bigint                                       not null
+        references account,
+    created_by        varchar                                      not null
+        references "user",
+    mode              varchar                                      not null,
+    currency          varchar                                      not null,
+    item_count        integer                                      not null,
+    total_amount      bigint                                       not null,
+    status            varchar                  default 'pending'   not null,
+    error             varchar,
+    created_at        timestamp with time zone default now()       not null,
+    started_at        timestamp with time zone,
+    completed_at      timestamp with time zone
+);
+
+comment on table transfer_batch is 'Transfers from one source account submitted together';
+
+comment on column transfer_batch.mode is 'atomic runs every item in one transaction, best_effort each item on its own';
+
+comment on column transfer_batch.status is 'pending, processing, completed, partially_completed or failed';
+
+comment on column transfer_batch.error is 'Why an atomic batch was rolled back';
+
+create index transfer_batch_pending_idx
+    on transfer_batch (id)
+    where status = 'pending';
+
+create table transfer_batch_item
+(
+    id                     bigserial
+        primary key,
+    batch_id               bigint                                       not null
+        references transfer_batch,
+    position               integer                                      not null,
+    destination_account_id bigint                                       not null
+        references account,
+    amount                 bigint                                       not null,
+    memo                   varchar,
+    reference              varchar,
+    status                 varchar                  default 'pending'   not null,
+    transfer_id            bigint
+        references transfer,
+    error                  varchar,
+    processed_at           timestamp with time zone,
+    constraint transfer_batch_item_amount_check check (amount > 0)
+);
+
+comment on column transfer_batch_item.position is 'One-based position of the item in the submitted list or file';
+
+comment on column transfer_batch_item.status is 'pending, succeeded, failed or skipped';
+
+create unique index transfer_batch_item_batch_id_position_key
+    on transfer_batch_item (batch_id, position);
@@ -0,0 +1,6 @@
+drop index if exists transfer_source_account_id_created_at_idx;
+
+drop table if exists transfer_limit_override;
+
+alter table "user"
+    drop column if exists tier;
@@ -0,0 +1,34 @@
+alter table "user"
+    add column tier varchar default 'standard' not null;
+
+comment on column "user".tier is 'Picks the user''s transfer limits from the limit schedule';
+
+create table transfer_limit_override
+(
+    id           bigserial
+        primary key,
+    username     varchar                                not null
+        references "user",
+    currency     varchar                                not null,
+    per_transfer bigint,
+    daily        bigint,
+    monthly      bigint,
+    hourly_count integer,
+    reason       varchar                  default ''    not null,
+    created_by   varchar                                not null
+        references "user",
+    expires_at   timestamp with time zone               not null,
+    created_at   timestamp with time zone default now() not null
+);
+
+comment on table transfer_limit_override is 'Limits an admin has set for a user in place of their tier''s, until expires_at';
+
+comment on column transfer_limit_override.per_transfer is 'Null keeps the tier''s limit, as for daily, monthly and hourly_count';
+
+comment on column transfer_limit_override.hourly_count is 'Transfers allowed in any hour';
+
+create index transfer_limit_override_username_currency_idx
+    on transfer_limit_override (username, currency, expires_at);
+
+create index transfer_source_account_id_created_at_idx
+    on transfer (source_account_id, created_at);
@@ -0,0 +1,3 @@
+drop index if exists session_username_device_idx;
+
+drop table if exists risk_assessment;
@@ -0,0 +1,42 @@
+create table risk_assessment
+(
+    id                     bigserial
+        primary key,
+    source_account_id      bigint                                 not null
+        references account,
+    destination_account_id bigint                                 not null
+        references account,
+    amount                 bigint                                 not null,
+    initiated_by           varchar                                not null
+        references "user",
+    score                  bigint                                 not null,
+    decision               varchar                                not null,
+    reasons                varchar                  default ''    not null,
+    status                 varchar                                not null,
+    transfer_id            bigint
+        references transfer,
+    reviewed_by            varchar
+        references "user",
+    review_note            varchar,
+    reviewed_at            timestamp with time zone,
+    memo                   varchar,
+    reference              varchar,
+    category               varchar,
+    invoice_number         varchar,
+    end_to_end_id          varchar,
+    created_at             timestamp with time zone default now() not null
+);
+
+comment on table risk_assessment is 'The risk engine''s verdict on a transfer, and the transfer itself while it waits for review';
+
+comment on column risk_assessment.decision is 'allow, review or deny';
+
+comment on column risk_assessment.reasons is 'Comma-separated names of the rules the transfer matched';
+
+comment on column risk_assessment.status is 'executed, pending_review, rejected or denied';
+
+create index risk_assessment_status_idx
+    on risk_assessment (status, id);
+
+create index session_username_device_idx
+    on "session" (username, user_agent, client_ip, created_at);
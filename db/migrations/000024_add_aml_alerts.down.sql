@@ -0,0 +1,9 @@
+comment on column "user".role is 'customer, teller, admin or rail';
+
+drop index if exists entry_created_at_idx;
+
+drop index if exists transfer_created_at_idx;
+
+drop table if exists aml_alert_comment;
+
+drop table if exists aml_alert;
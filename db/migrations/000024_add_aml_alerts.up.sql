@@ -0,0 +1,62 @@
+create table aml_alert
+(
+    id           bigserial
+        primary key,
+    scenario     varchar                                not null,
+    account_id   bigint                                 not null
+        references account,
+    amount       bigint                                 not null,
+    event_count  bigint                                 not null,
+    details      varchar                                not null,
+    window_start timestamp with time zone               not null,
+    window_end   timestamp with time zone               not null,
+    status       varchar                  default 'open' not null,
+    assigned_to  varchar
+        references "user",
+    resolution   varchar,
+    closed_by    varchar
+        references "user",
+    closed_at    timestamp with time zone,
+    created_at   timestamp with time zone default now() not null
+);
+
+comment on table aml_alert is 'Activity on an account that matched an anti-money laundering scenario, and the case worked on it';
+
+comment on column aml_alert.scenario is 'structuring, rapid_movement or dormant_reactivation';
+
+comment on column aml_alert.amount is 'The total that matched: transferred just under the threshold, or sent out';
+
+comment on column aml_alert.event_count is 'How many transfers or entries matched';
+
+comment on column aml_alert.status is 'open, assigned or closed';
+
+comment on column aml_alert.resolution is 'false_positive, no_action or reported';
+
+create index aml_alert_account_id_scenario_idx
+    on aml_alert (account_id, scenario, window_end);
+
+create index aml_alert_status_idx
+    on aml_alert (status, id);
+
+create table aml_alert_comment
+(
+    id         bigserial
+        primary key,
+    alert_id   bigint                                 not null
+        references aml_alert,
+    author     varchar                                not null
+        references "user",
+    body       varchar                                not null,
+    created_at timestamp with time zone default now() not null
+);
+
+create index aml_alert_comment_alert_id_idx
+    on aml_alert_comment (alert_id);
+
+create index transfer_created_at_idx
+    on transfer (created_at);
+
+create index entry_created_at_idx
+    on entry (created_at);
+
+comment on column "user".role is 'customer, teller, admin, rail or analyst';
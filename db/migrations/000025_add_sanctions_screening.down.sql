@@ -0,0 +1,7 @@
+drop table if exists screening_hit;
+
+alter table payee
+    drop column if exists screening_status;
+
+alter table "user"
+    drop column if exists screening_status;
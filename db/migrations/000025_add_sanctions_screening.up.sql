@@ -0,0 +1,52 @@
+alter table "user"
+    add column screening_status varchar default 'clear' not null;
+
+comment on column "user".screening_status is 'clear, pending_review or blocked; only clear users can sign in and transfer';
+
+alter table payee
+    add column screening_status varchar default 'clear' not null;
+
+comment on column payee.screening_status is 'clear, pending_review or blocked; only clear payees can be paid';
+
+create table screening_hit
+(
+    id            bigserial
+        primary key,
+    username      varchar                                not null
+        references "user",
+    payee_id      bigint
+        references payee
+            on delete set null,
+    screened_name varchar                                not null,
+    entry_id      varchar                                not null,
+    entry_name    varchar                                not null,
+    matched_name  varchar                                not null,
+    programs      varchar                  default ''    not null,
+    score         integer                                not null,
+    status        varchar                                not null,
+    reviewed_by   varchar
+        references "user",
+    review_note   varchar,
+    reviewed_at   timestamp with time zone,
+    created_at    timestamp with time zone default now() not null
+);
+
+comment on table screening_hit is 'A user''s name, or the name behind one of their payees, that matched a watchlist entry';
+
+comment on column screening_hit.username is 'The user screened, or the owner of the payee screened';
+
+comment on column screening_hit.payee_id is 'The payee screened, if it wasn''t the user; cleared when the payee is deleted';
+
+comment on column screening_hit.matched_name is 'The entry''s name or alias that matched';
+
+comment on column screening_hit.programs is 'Comma-separated sanctions programs of the entry';
+
+comment on column screening_hit.score is 'Similarity of the names, from 0 to 100';
+
+comment on column screening_hit.status is 'pending_review, confirmed or cleared';
+
+create index screening_hit_status_idx
+    on screening_hit (status, id);
+
+create index screening_hit_username_entry_id_idx
+    on screening_hit (username, entry_id);
@@ -0,0 +1,6 @@
+drop table if exists kyc_document;
+
+drop table if exists kyc_submission;
+
+alter table "user"
+    drop column if exists kyc_status;
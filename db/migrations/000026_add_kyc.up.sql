@@ -0,0 +1,66 @@
+alter table "user"
+    add column kyc_status varchar default 'unverified' not null;
+
+comment on column "user".kyc_status is 'unverified, pending, verified or rejected; users who aren''t verified have the limits of the unverified tier';
+
+-- Users who signed up before KYC was introduced keep the limits they had
+update "user"
+set kyc_status = 'verified';
+
+create table kyc_submission
+(
+    id               bigserial
+        primary key,
+    username         varchar                                      not null
+        references "user",
+    status           varchar                  default 'pending'   not null,
+    reviewed_by      varchar
+        references "user",
+    rejection_reason varchar,
+    reviewed_at      timestamp with time zone,
+    created_at       timestamp with time zone default now()       not null
+);
+
+comment on table kyc_submission is 'A user''s documents sent for KYC review';
+
+comment on column kyc_submission.status is 'pending, verified or rejected';
+
+create index kyc_submission_status_idx
+    on kyc_submission (status, id);
+
+create index kyc_submission_username_idx
+    on kyc_submission (username);
+
+create table kyc_document
+(
+    id            bigserial
+        primary key,
+    username      varchar                                not null
+        references "user",
+    submission_id bigint
+        references kyc_submission,
+    document_type varchar                                not null,
+    file_name     varchar                                not null,
+    content_type  varchar                                not null,
+    size_bytes    bigint                                 not null,
+    sha256        varchar                                not null,
+    blob_key      varchar                                not null
+        unique,
+    created_at    timestamp with time zone default now() not null
+);
+
+comment on column kyc_document.submission_id is 'Set once the document is submitted for review, after which it can''t be deleted';
+
+comment on column kyc_document.document_type is 'passport, national_id, drivers_license or proof_of_address';
+
+comment on column kyc_document.content_type is 'Detected from the file''s content, not taken from the upload';
+
+comment on column kyc_document.sha256 is 'Hex digest of the file as uploaded, before encryption';
+
+comment on column kyc_document.blob_key is 'Where the encrypted file is kept in the document store';
+
+create index kyc_document_username_idx
+    on kyc_document (username);
+
+create index kyc_document_submission_id_idx
+    on kyc_document (submission_id);
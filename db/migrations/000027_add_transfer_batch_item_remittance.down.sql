@@ -0,0 +1,4 @@
+alter table transfer_batch_item
+    drop column if exists category,
+    drop column if exists invoice_number,
+    drop column if exists end_to_end_id;
@@ -0,0 +1,4 @@
+alter table transfer_batch_item
+    add column category       varchar,
+    add column invoice_number varchar,
+    add column end_to_end_id  varchar;
@@ -0,0 +1,8 @@
+drop index if exists screening_hit_organization_id_idx;
+
+alter table screening_hit
+    drop column if exists organization_id;
+
+alter table organization
+    drop column if exists tier,
+    drop column if exists screening_status;
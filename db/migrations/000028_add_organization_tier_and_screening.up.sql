@@ -0,0 +1,17 @@
+alter table organization
+    add column tier             varchar default 'standard' not null,
+    add column screening_status varchar default 'clear'    not null;
+
+comment on column organization.tier is 'Picks the transfer limits of the organization''s accounts from the limit schedule';
+
+comment on column organization.screening_status is 'clear, pending_review or blocked; only clear organizations'' accounts can transfer and be paid';
+
+alter table screening_hit
+    add column organization_id bigint
+        references organization;
+
+comment on column screening_hit.organization_id is 'The organization screened, if it was its name; username is then the user who created it';
+
+create index screening_hit_organization_id_idx
+    on screening_hit (organization_id)
+    where organization_id is not null;
@@ -1,8 +1,13 @@
 -- name: CreateAccount :one
 INSERT INTO account (owner,
                      balance,
-                     currency)
-VALUES ($1, $2, $3)
+                     currency,
+                     product,
+                     account_number,
+                     iban,
+                     nickname,
+                     organization_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING *;
 
 -- name: GetAccount :one
@@ -19,17 +24,14 @@ WHERE id = $1
 -- name: GetAccounts :many
 SELECT *
 FROM account
-WHERE owner = $1
+WHERE (owner = $1
+    OR id IN (SELECT account_id FROM account_member WHERE username = $1)
+    OR organization_id IN (SELECT organization_id FROM organization_member WHERE username = $1))
+  AND ($2::varchar IS NULL OR currency = $2)
+  AND ($3::varchar IS NULL OR product = $3)
+  AND ($4::varchar IS NULL OR status = $4)
 ORDER BY id
-LIMIT $2 OFFSET $3;
-
--- name: UpdateAccount :one
-UPDATE account
-SET owner    = $1,
-    balance  = $2,
-    currency = $3
-WHERE id = sqlc.arg(id)
-RETURNING *;
+LIMIT $5 OFFSET $6;
 
 -- name: UpdateAccountBalance :one
 UPDATE account
@@ -40,4 +42,87 @@ RETURNING *;
 -- name: DeleteAccount :exec
 DELETE
 FROM account
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: AddAccountHeldBalance :one
+UPDATE account
+SET held_balance = held_balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: GetInterestBearingAccounts :many
+SELECT account.*
+FROM account
+         JOIN account_product ON account_product.code = account.product
+WHERE account_product.interest_rate_bps > 0
+ORDER BY account.id
+LIMIT $1 OFFSET $2;
+
+-- name: GetAccountBalanceAt :one
+SELECT (account.balance - COALESCE(SUM(entry.amount), 0))::bigint AS balance
+FROM account
+         LEFT JOIN entry ON entry.account_id = account.id AND entry.created_at >= $2
+WHERE account.id = $1
+GROUP BY account.id;
+
+-- name: GetAccountsByProduct :many
+SELECT *
+FROM account
+WHERE product = $1
+  AND created_at < $2
+ORDER BY id
+LIMIT $3 OFFSET $4;
+
+-- name: GetAccountsOpenedBefore :many
+SELECT *
+FROM account
+WHERE created_at < $1
+ORDER BY id
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateAccountStatus :one
+UPDATE account
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: GetInactiveAccounts :many
+SELECT *
+FROM account
+WHERE status = 'active'
+  AND product <> 'internal'
+  AND created_at < $1
+  AND id > $2
+  AND NOT EXISTS (SELECT 1
+                  FROM entry
+                           LEFT JOIN transfer ON transfer.journal_transaction_id = entry.journal_transaction_id
+                  WHERE entry.account_id = account.id
+                    AND entry.created_at >= $1
+                    AND NOT EXISTS (SELECT 1 FROM fee WHERE fee.fee_transfer_id = transfer.id)
+                    AND NOT EXISTS (SELECT 1 FROM interest_posting WHERE interest_posting.transfer_id = transfer.id))
+ORDER BY id
+LIMIT $3;
+
+-- name: GetAccountByNumber :one
+SELECT *
+FROM account
+WHERE account_number = $1;
+
+-- name: GetAccountByIban :one
+SELECT *
+FROM account
+WHERE iban = $1;
+
+-- name: CountOpenAccounts :one
+SELECT count(*)
+FROM account
+WHERE owner = $1
+  AND organization_id IS NULL
+  AND status <> 'closed'
+  AND ($2::varchar IS NULL OR currency = $2);
+
+-- name: UpdateAccountNickname :one
+UPDATE account
+SET nickname = $2
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,72 @@
+-- name: CreateAccountMember :one
+INSERT INTO account_member (account_id,
+                            username,
+                            role,
+                            transfer_limit)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetAccountMember :one
+SELECT *
+FROM account_member
+WHERE account_id = $1
+  AND username = $2
+LIMIT 1;
+
+-- name: GetAccountMembers :many
+SELECT *
+FROM account_member
+WHERE account_id = $1
+ORDER BY created_at, username;
+
+-- name: DeleteAccountMember :one
+DELETE
+FROM account_member
+WHERE account_id = $1
+  AND username = $2
+RETURNING *;
+
+-- name: CreateAccountInvitation :one
+INSERT INTO account_invitation (account_id,
+                                invitee,
+                                role,
+                                transfer_limit,
+                                invited_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetAccountInvitation :one
+SELECT *
+FROM account_invitation
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetAccountInvitationForUpdate :one
+SELECT *
+FROM account_invitation
+WHERE id = $1
+LIMIT 1
+    FOR NO KEY UPDATE;
+
+-- name: GetAccountInvitations :many
+SELECT *
+FROM account_invitation
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetPendingInvitationsForUser :many
+SELECT *
+FROM account_invitation
+WHERE invitee = $1
+  AND status = 'pending'
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateAccountInvitationStatus :one
+UPDATE account_invitation
+SET status       = $2,
+    responded_at = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
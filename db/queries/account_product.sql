@@ -0,0 +1,10 @@
+-- name: GetAccountProduct :one
+SELECT *
+FROM account_product
+WHERE code = $1;
+
+-- name: GetAccountProducts :many
+SELECT *
+FROM account_product
+WHERE kind <> 'internal'
+ORDER BY code;
@@ -0,0 +1,15 @@
+-- name: CreateAccountStatusChange :one
+INSERT INTO account_status_change (account_id,
+                                   from_status,
+                                   to_status,
+                                   reason,
+                                   changed_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetAccountStatusChanges :many
+SELECT *
+FROM account_status_change
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,138 @@
+-- name: CreateAmlAlert :one
+INSERT INTO aml_alert (scenario,
+                       account_id,
+                       amount,
+                       event_count,
+                       details,
+                       window_start,
+                       window_end)
+SELECT sqlc.arg(scenario)::varchar,
+       sqlc.arg(account_id)::bigint,
+       sqlc.arg(amount)::bigint,
+       sqlc.arg(event_count)::bigint,
+       sqlc.arg(details)::varchar,
+       sqlc.arg(window_start)::timestamptz,
+       sqlc.arg(window_end)::timestamptz
+WHERE NOT EXISTS (SELECT 1
+                  FROM aml_alert AS raised
+                  WHERE raised.scenario = sqlc.arg(scenario)
+                    AND raised.account_id = sqlc.arg(account_id)
+                    AND raised.window_end > sqlc.arg(window_start))
+RETURNING *;
+
+-- name: GetAmlAlert :one
+SELECT *
+FROM aml_alert
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetAmlAlertForUpdate :one
+SELECT *
+FROM aml_alert
+WHERE id = $1
+LIMIT 1
+    FOR NO KEY UPDATE;
+
+-- name: GetAmlAlerts :many
+SELECT *
+FROM aml_alert
+WHERE ($1::varchar IS NULL OR status = $1)
+  AND ($2::varchar IS NULL OR scenario = $2)
+  AND ($3::varchar IS NULL OR assigned_to = $3)
+ORDER BY id DESC
+LIMIT $4 OFFSET $5;
+
+-- name: AssignAmlAlert :one
+UPDATE aml_alert
+SET assigned_to = $2,
+    status      = 'assigned'
+WHERE id = $1
+RETURNING *;
+
+-- name: CloseAmlAlert :one
+UPDATE aml_alert
+SET status     = 'closed',
+    resolution = $2,
+    closed_by  = $3,
+    closed_at  = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetAmlAlertsForExport :many
+SELECT aml_alert.*, account.owner, account.currency
+FROM aml_alert
+         JOIN account ON account.id = aml_alert.account_id
+WHERE aml_alert.created_at >= sqlc.arg(from_time)
+  AND aml_alert.created_at < sqlc.arg(to_time)
+  AND (sqlc.narg(resolution)::varchar IS NULL OR aml_alert.resolution = sqlc.narg(resolution))
+ORDER BY aml_alert.id;
+
+-- name: CreateAmlAlertComment :one
+INSERT INTO aml_alert_comment (alert_id,
+                               author,
+                               body)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetAmlAlertComments :many
+SELECT *
+FROM aml_alert_comment
+WHERE alert_id = $1
+ORDER BY id;
+
+-- name: GetStructuringCandidates :many
+SELECT transfer.source_account_id   AS account_id,
+       COUNT(*)                     AS transfer_count,
+       SUM(transfer.amount)::bigint AS total
+FROM transfer
+         JOIN account ON account.id = transfer.source_account_id
+WHERE account.currency = sqlc.arg(currency)
+  AND account.product <> 'internal'
+  AND transfer.amount >= sqlc.arg(min_amount)
+  AND transfer.amount < sqlc.arg(threshold)
+  AND transfer.created_at >= sqlc.arg(window_start)
+  AND transfer.created_at < sqlc.arg(window_end)
+GROUP BY transfer.source_account_id
+HAVING COUNT(*) >= sqlc.arg(min_count)
+ORDER BY transfer.source_account_id;
+
+-- name: GetRapidMovementCandidates :many
+SELECT entry.account_id,
+       COALESCE(SUM(entry.amount) FILTER (WHERE entry.amount > 0), 0)::bigint  AS inflow,
+       COALESCE(-SUM(entry.amount) FILTER (WHERE entry.amount < 0), 0)::bigint AS outflow,
+       COUNT(*)                                                                AS entry_count
+FROM entry
+         JOIN account ON account.id = entry.account_id
+WHERE account.currency = sqlc.arg(currency)
+  AND account.product <> 'internal'
+  AND entry.created_at >= sqlc.arg(window_start)
+  AND entry.created_at < sqlc.arg(window_end)
+GROUP BY entry.account_id
+HAVING SUM(entry.amount) FILTER (WHERE entry.amount > 0) >= sqlc.arg(min_amount)
+   AND -SUM(entry.amount) FILTER (WHERE entry.amount < 0) * 10000 >=
+       SUM(entry.amount) FILTER (WHERE entry.amount > 0) * sqlc.arg(outflow_bps)
+ORDER BY entry.account_id;
+
+-- name: GetDormantReactivationCandidates :many
+SELECT entry.account_id,
+       (-SUM(entry.amount))::bigint AS outflow,
+       COUNT(*)                     AS entry_count
+FROM entry
+         JOIN account ON account.id = entry.account_id
+WHERE account.currency = sqlc.arg(currency)
+  AND account.product <> 'internal'
+  AND account.created_at < sqlc.arg(dormant_since)
+  AND entry.amount < 0
+  AND entry.created_at >= sqlc.arg(window_start)
+  AND entry.created_at < sqlc.arg(window_end)
+  AND NOT EXISTS (SELECT 1
+                  FROM entry AS earlier
+                           LEFT JOIN transfer ON transfer.journal_transaction_id = earlier.journal_transaction_id
+                  WHERE earlier.account_id = entry.account_id
+                    AND earlier.created_at >= sqlc.arg(dormant_since)
+                    AND earlier.created_at < sqlc.arg(window_start)
+                    AND NOT EXISTS (SELECT 1 FROM fee WHERE fee.fee_transfer_id = transfer.id)
+                    AND NOT EXISTS (SELECT 1 FROM interest_posting WHERE interest_posting.transfer_id = transfer.id))
+GROUP BY entry.account_id
+HAVING -SUM(entry.amount) >= sqlc.arg(min_amount)
+ORDER BY entry.account_id;
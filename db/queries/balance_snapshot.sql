@@ -0,0 +1,22 @@
+-- name: CreateBalanceSnapshot :one
+INSERT INTO balance_snapshot (account_id,
+                              date,
+                              balance)
+VALUES ($1, $2, $3)
+ON CONFLICT (account_id, date) DO NOTHING
+RETURNING *;
+
+-- name: GetLatestBalanceSnapshot :one
+SELECT *
+FROM balance_snapshot
+WHERE account_id = $1
+ORDER BY date DESC
+LIMIT 1;
+
+-- name: GetBalanceSnapshotOnOrBefore :one
+SELECT *
+FROM balance_snapshot
+WHERE account_id = $1
+  AND date <= $2
+ORDER BY date DESC
+LIMIT 1;
@@ -0,0 +1,30 @@
+-- name: CreateCashTransaction :one
+INSERT INTO cash_transaction (account_id,
+                              kind,
+                              amount,
+                              reference,
+                              channel,
+                              idempotency_key,
+                              created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (created_by, idempotency_key) DO NOTHING
+RETURNING *;
+
+-- name: GetCashTransactionByIdempotencyKey :one
+SELECT *
+FROM cash_transaction
+WHERE created_by = $1
+  AND idempotency_key = $2;
+
+-- name: GetCashTransactionsForAccount :many
+SELECT *
+FROM cash_transaction
+WHERE account_id = $1
+ORDER BY id
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateCashTransactionJournal :one
+UPDATE cash_transaction
+SET journal_transaction_id = $2
+WHERE id = $1
+RETURNING *;
@@ -1,7 +1,13 @@
 -- name: CreateEntry :one
 INSERT INTO entry (account_id,
-                   amount)
-VALUES ($1, $2)
+                   amount,
+                   journal_transaction_id,
+                   memo,
+                   reference,
+                   category,
+                   invoice_number,
+                   end_to_end_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING *;
 
 -- name: GetEntry :one
@@ -22,13 +28,9 @@ WHERE account_id = $1
 ORDER BY id
     LIMIT $2 OFFSET $3;
 
--- name: UpdateEntry :one
-UPDATE entry
-SET amount = $2
-WHERE id = $1
-RETURNING *;
-
--- name: DeleteEntry :exec
-DELETE
+-- name: GetAccountEntryTotalBetween :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
 FROM entry
-WHERE id = $1;
\ No newline at end of file
+WHERE account_id = $1
+  AND created_at >= sqlc.arg(from_time)
+  AND created_at < sqlc.arg(to_time);
\ No newline at end of file
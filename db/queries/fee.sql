@@ -0,0 +1,38 @@
+-- name: CreateFee :one
+INSERT INTO fee (account_id,
+                 kind,
+                 name,
+                 amount,
+                 transfer_id,
+                 period_start)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT DO NOTHING
+RETURNING *;
+
+-- name: GetFeesForAccount :many
+SELECT *
+FROM fee
+WHERE account_id = $1
+ORDER BY id
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateFeeTransfer :one
+UPDATE fee
+SET fee_transfer_id = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateFeeWaiver :one
+INSERT INTO fee_waiver (account_id,
+                        fee_name,
+                        reason,
+                        expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetActiveFeeWaivers :many
+SELECT *
+FROM fee_waiver
+WHERE account_id = $1
+  AND (expires_at IS NULL OR expires_at > now())
+ORDER BY id;
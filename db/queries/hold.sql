@@ -0,0 +1,48 @@
+-- name: CreateHold :one
+INSERT INTO hold (account_id,
+                  destination_account_id,
+                  amount,
+                  reference,
+                  expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetHold :one
+SELECT *
+FROM hold
+WHERE id = $1;
+
+-- name: GetHoldForUpdate :one
+SELECT *
+FROM hold
+WHERE id = $1
+    FOR NO KEY UPDATE;
+
+-- name: GetHoldsForAccount :many
+SELECT *
+FROM hold
+WHERE account_id = $1
+ORDER BY id
+LIMIT $2 OFFSET $3;
+
+-- name: GetExpiredHolds :many
+SELECT *
+FROM hold
+WHERE status = 'active'
+  AND expires_at <= $1
+ORDER BY expires_at
+LIMIT $2;
+
+-- name: UpdateHoldStatus :one
+UPDATE hold
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: CaptureHold :one
+UPDATE hold
+SET status          = 'captured',
+    captured_amount = $2,
+    transfer_id     = $3
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,62 @@
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accrual (account_id,
+                              accrual_date,
+                              balance,
+                              interest_rate_bps,
+                              day_count_convention,
+                              amount_micros)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (account_id, accrual_date) DO NOTHING
+RETURNING *;
+
+-- name: GetInterestAccruals :many
+SELECT *
+FROM interest_accrual
+WHERE account_id = $1
+ORDER BY accrual_date
+LIMIT $2 OFFSET $3;
+
+-- name: GetLastInterestAccrualDate :one
+SELECT accrual_date
+FROM interest_accrual
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT 1;
+
+-- name: PostInterestAccruals :many
+UPDATE interest_accrual
+SET posting_id = $1
+WHERE account_id = $2
+  AND posting_id IS NULL
+  AND accrual_date < $3
+RETURNING amount_micros;
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_posting (account_id,
+                              period_start,
+                              period_end)
+VALUES ($1, $2, $3)
+ON CONFLICT (account_id, period_start) DO NOTHING
+RETURNING *;
+
+-- name: GetLastInterestPosting :one
+SELECT *
+FROM interest_posting
+WHERE account_id = $1
+ORDER BY period_start DESC
+LIMIT 1;
+
+-- name: GetInterestPostings :many
+SELECT *
+FROM interest_posting
+WHERE account_id = $1
+ORDER BY period_start
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateInterestPosting :one
+UPDATE interest_posting
+SET amount       = $2,
+    carry_micros = $3,
+    transfer_id  = $4
+WHERE id = $1
+RETURNING *;
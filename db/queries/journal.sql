@@ -0,0 +1,16 @@
+-- name: CreateJournalTransaction :one
+INSERT INTO journal_transaction (kind,
+                                 description)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetJournalTransaction :one
+SELECT *
+FROM journal_transaction
+WHERE id = $1;
+
+-- name: GetJournalTransactionEntries :many
+SELECT *
+FROM entry
+WHERE journal_transaction_id = $1
+ORDER BY id;
@@ -0,0 +1,82 @@
+-- name: CreateKycDocument :one
+INSERT INTO kyc_document (username,
+                          document_type,
+                          file_name,
+                          content_type,
+                          size_bytes,
+                          sha256,
+                          blob_key)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetKycDocument :one
+SELECT *
+FROM kyc_document
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetKycDocuments :many
+SELECT *
+FROM kyc_document
+WHERE username = $1
+ORDER BY id;
+
+-- name: GetUnsubmittedKycDocuments :many
+SELECT *
+FROM kyc_document
+WHERE username = $1
+  AND submission_id IS NULL
+ORDER BY id;
+
+-- name: DeleteKycDocument :one
+DELETE
+FROM kyc_document
+WHERE id = $1
+  AND submission_id IS NULL
+RETURNING *;
+
+-- name: AttachKycDocuments :exec
+UPDATE kyc_document
+SET submission_id = $1
+WHERE username = $2
+  AND submission_id IS NULL;
+
+-- name: GetKycSubmissionDocuments :many
+SELECT *
+FROM kyc_document
+WHERE submission_id = $1
+ORDER BY id;
+
+-- name: CreateKycSubmission :one
+INSERT INTO kyc_submission (username)
+VALUES ($1)
+RETURNING *;
+
+-- name: GetKycSubmission :one
+SELECT *
+FROM kyc_submission
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetKycSubmissionForUpdate :one
+SELECT *
+FROM kyc_submission
+WHERE id = $1
+LIMIT 1
+    FOR NO KEY UPDATE;
+
+-- name: GetKycSubmissions :many
+SELECT *
+FROM kyc_submission
+WHERE ($1::varchar IS NULL OR status = $1)
+ORDER BY id
+LIMIT $2 OFFSET $3;
+
+-- name: ReviewKycSubmission :one
+UPDATE kyc_submission
+SET status           = $2,
+    reviewed_by      = $3,
+    rejection_reason = $4,
+    reviewed_at      = now()
+WHERE id = $1
+RETURNING *;
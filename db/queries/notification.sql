@@ -0,0 +1,13 @@
+-- name: CreateNotification :one
+INSERT INTO notification (username,
+                          kind,
+                          message)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetNotifications :many
+SELECT *
+FROM notification
+WHERE username = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
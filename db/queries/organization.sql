@@ -0,0 +1,86 @@
+-- name: CreateOrganization :one
+INSERT INTO organization (name,
+                          approval_threshold,
+                          required_approvals,
+                          created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetOrganization :one
+SELECT *
+FROM organization
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetOrganizationForUpdate :one
+SELECT *
+FROM organization
+WHERE id = $1
+LIMIT 1
+    FOR NO KEY UPDATE;
+
+-- name: GetOrganizationsForUser :many
+SELECT organization.*
+FROM organization
+         JOIN organization_member ON organization_member.organization_id = organization.id
+WHERE organization_member.username = $1
+ORDER BY organization.id
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateOrganizationApprovalRule :one
+UPDATE organization
+SET approval_threshold = $2,
+    required_approvals = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateOrganizationTier :one
+UPDATE organization
+SET tier = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: GetOrganizationsForScreening :many
+SELECT *
+FROM organization
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: UpdateOrganizationScreeningStatus :exec
+UPDATE organization
+SET screening_status = $2
+WHERE id = $1;
+
+-- name: CreateOrganizationMember :one
+INSERT INTO organization_member (organization_id,
+                                 username,
+                                 role)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetOrganizationMember :one
+SELECT *
+FROM organization_member
+WHERE organization_id = $1
+  AND username = $2
+LIMIT 1;
+
+-- name: GetOrganizationMembers :many
+SELECT *
+FROM organization_member
+WHERE organization_id = $1
+ORDER BY created_at, username;
+
+-- name: DeleteOrganizationMember :one
+DELETE
+FROM organization_member
+WHERE organization_id = $1
+  AND username = $2
+RETURNING *;
+
+-- name: CountOrganizationMembersByRole :one
+SELECT count(*)
+FROM organization_member
+WHERE organization_id = $1
+  AND role = $2;
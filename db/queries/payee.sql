@@ -0,0 +1,67 @@
+-- name: CreatePayee :one
+INSERT INTO payee (owner,
+                   nickname,
+                   account_id,
+                   currency,
+                   expected_name,
+                   name_check,
+                   cooling_off_until)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetPayee :one
+SELECT *
+FROM payee
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetPayees :many
+SELECT *
+FROM payee
+WHERE owner = $1
+ORDER BY nickname
+LIMIT $2 OFFSET $3;
+
+-- name: GetPayeeByAccount :one
+SELECT *
+FROM payee
+WHERE owner = $1
+  AND account_id = $2
+LIMIT 1;
+
+-- name: CountTransfersToAccountBefore :one
+SELECT count(*)
+FROM transfer
+         JOIN account ON account.id = transfer.source_account_id
+WHERE account.owner = $1
+  AND transfer.destination_account_id = $2
+  AND transfer.created_at < $3;
+
+-- name: UpdatePayeeNickname :one
+UPDATE payee
+SET nickname = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeletePayee :exec
+DELETE
+FROM payee
+WHERE id = $1;
+
+-- name: GetPayeesForScreening :many
+SELECT payee.id,
+       payee.owner,
+       payee.expected_name,
+       COALESCE(organization.name, "user".full_name)::varchar AS holder_name
+FROM payee
+         JOIN account ON account.id = payee.account_id
+         JOIN "user" ON "user".username = account.owner
+         LEFT JOIN organization ON organization.id = account.organization_id
+WHERE payee.id > $1
+ORDER BY payee.id
+LIMIT $2;
+
+-- name: UpdatePayeeScreeningStatus :exec
+UPDATE payee
+SET screening_status = $2
+WHERE id = $1;
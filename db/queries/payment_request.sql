@@ -0,0 +1,84 @@
+-- name: CreatePaymentRequest :one
+INSERT INTO payment_request (requester,
+                             destination_account_id,
+                             payer,
+                             payer_account_id,
+                             amount,
+                             currency,
+                             memo,
+                             expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetPaymentRequest :one
+SELECT *
+FROM payment_request
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetPaymentRequestForUpdate :one
+SELECT *
+FROM payment_request
+WHERE id = $1
+LIMIT 1
+    FOR NO KEY UPDATE;
+
+-- name: GetOutgoingPaymentRequests :many
+SELECT *
+FROM payment_request
+WHERE requester = $1
+  AND ($2::varchar IS NULL OR status = $2)
+ORDER BY id DESC
+LIMIT $3 OFFSET $4;
+
+-- name: GetIncomingPaymentRequests :many
+SELECT *
+FROM payment_request
+WHERE (payer = sqlc.arg(username)::varchar
+    OR payer_account_id IN (SELECT id FROM account WHERE owner = sqlc.arg(username) AND organization_id IS NULL)
+    OR payer_account_id IN (SELECT account_id FROM account_member WHERE username = sqlc.arg(username))
+    OR payer_account_id IN (SELECT account.id
+                            FROM account
+                                     JOIN organization_member
+                                          ON organization_member.organization_id = account.organization_id
+                            WHERE organization_member.username = sqlc.arg(username)))
+  AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: AcceptPaymentRequest :one
+UPDATE payment_request
+SET status       = 'accepted',
+    transfer_id  = $2,
+    responded_at = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: DeclinePaymentRequest :one
+UPDATE payment_request
+SET status       = 'declined',
+    responded_at = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: CancelPaymentRequest :one
+UPDATE payment_request
+SET status       = 'cancelled',
+    responded_at = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: ExpirePaymentRequests :many
+UPDATE payment_request
+SET status       = 'expired',
+    responded_at = now()
+WHERE id IN (SELECT id
+             FROM payment_request
+             WHERE status = 'pending'
+               AND expires_at <= $1
+             ORDER BY id
+             LIMIT $2)
+RETURNING *;
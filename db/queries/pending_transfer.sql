@@ -0,0 +1,79 @@
+-- name: CreatePendingTransfer :one
+INSERT INTO pending_transfer (organization_id,
+                              source_account_id,
+                              destination_account_id,
+                              amount,
+                              initiated_by,
+                              required_approvals,
+                              expires_at,
+                              memo,
+                              reference,
+                              category,
+                              invoice_number,
+                              end_to_end_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: GetPendingTransfer :one
+SELECT *
+FROM pending_transfer
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetPendingTransferForUpdate :one
+SELECT *
+FROM pending_transfer
+WHERE id = $1
+LIMIT 1
+    FOR NO KEY UPDATE;
+
+-- name: GetPendingTransfers :many
+SELECT *
+FROM pending_transfer
+WHERE organization_id = $1
+  AND ($2::varchar IS NULL OR status = $2)
+ORDER BY id DESC
+LIMIT $3 OFFSET $4;
+
+-- name: ExecutePendingTransfer :one
+UPDATE pending_transfer
+SET status      = 'executed',
+    transfer_id = $2,
+    decided_at  = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: RejectPendingTransfer :one
+UPDATE pending_transfer
+SET status           = 'rejected',
+    rejected_by      = $2,
+    rejection_reason = $3,
+    decided_at       = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: ExpirePendingTransfers :many
+UPDATE pending_transfer
+SET status     = 'expired',
+    decided_at = now()
+WHERE id IN (SELECT id
+             FROM pending_transfer
+             WHERE status = 'pending'
+               AND expires_at <= $1
+             ORDER BY id
+             LIMIT $2)
+RETURNING *;
+
+-- name: CreateTransferApproval :one
+INSERT INTO transfer_approval (pending_transfer_id,
+                               approver)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetTransferApprovals :many
+SELECT *
+FROM transfer_approval
+WHERE pending_transfer_id = $1
+ORDER BY created_at, approver;
@@ -0,0 +1,100 @@
+-- name: CreateReconciliationRun :one
+INSERT INTO reconciliation_run DEFAULT VALUES
+RETURNING *;
+
+-- name: CompleteReconciliationRun :one
+UPDATE reconciliation_run
+SET accounts_checked  = $2,
+    transfers_checked = $3,
+    discrepancy_count = $4,
+    completed_at      = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetLatestReconciliationRun :one
+SELECT *
+FROM reconciliation_run
+WHERE completed_at IS NOT NULL
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CreateReconciliationDiscrepancy :one
+INSERT INTO reconciliation_discrepancy (run_id,
+                                        kind,
+                                        account_id,
+                                        transfer_id,
+                                        entry_id,
+                                        journal_transaction_id,
+                                        expected,
+                                        actual,
+                                        detail)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetReconciliationDiscrepancies :many
+SELECT *
+FROM reconciliation_discrepancy
+WHERE run_id = $1
+ORDER BY id
+LIMIT $2 OFFSET $3;
+
+-- name: GetAccountEntryTotals :many
+SELECT account.id,
+       account.balance,
+       COALESCE(SUM(entry.amount), 0)::bigint AS entry_total
+FROM account
+         LEFT JOIN entry ON entry.account_id = account.id
+WHERE account.id > $1
+GROUP BY account.id
+ORDER BY account.id
+LIMIT $2;
+
+-- name: GetTransferEntryMatches :many
+SELECT transfer.id,
+       transfer.source_account_id,
+       transfer.destination_account_id,
+       transfer.amount,
+       transfer.journal_transaction_id,
+       source_entry.id          AS source_entry_id,
+       source_entry.amount      AS source_entry_amount,
+       destination_entry.id     AS destination_entry_id,
+       destination_entry.amount AS destination_entry_amount
+FROM transfer
+         LEFT JOIN LATERAL (SELECT entry.id, entry.amount
+                            FROM entry
+                            WHERE entry.account_id = transfer.source_account_id
+                              AND CASE
+                                      WHEN transfer.journal_transaction_id IS NULL
+                                          THEN entry.journal_transaction_id IS NULL
+                                          AND entry.created_at = transfer.created_at
+                                          AND entry.amount = -transfer.amount
+                                      ELSE entry.journal_transaction_id = transfer.journal_transaction_id
+                                END
+                            ORDER BY entry.id
+                            LIMIT 1) source_entry ON TRUE
+         LEFT JOIN LATERAL (SELECT entry.id, entry.amount
+                            FROM entry
+                            WHERE entry.account_id = transfer.destination_account_id
+                              AND CASE
+                                      WHEN transfer.journal_transaction_id IS NULL
+                                          THEN entry.journal_transaction_id IS NULL
+                                          AND entry.created_at = transfer.created_at
+                                          AND entry.amount = transfer.amount
+                                      ELSE entry.journal_transaction_id = transfer.journal_transaction_id
+                                END
+                            ORDER BY entry.id
+                            LIMIT 1) destination_entry ON TRUE
+WHERE transfer.id > $1
+ORDER BY transfer.id
+LIMIT $2;
+
+-- name: GetJournalTransactionsMissingTransfer :many
+SELECT *
+FROM journal_transaction
+WHERE kind = 'transfer'
+  AND id > $1
+  AND NOT EXISTS(SELECT 1
+                 FROM transfer
+                 WHERE transfer.journal_transaction_id = journal_transaction.id)
+ORDER BY id
+LIMIT $2;
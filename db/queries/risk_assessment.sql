@@ -0,0 +1,57 @@
+-- name: CreateRiskAssessment :one
+INSERT INTO risk_assessment (source_account_id,
+                             destination_account_id,
+                             amount,
+                             initiated_by,
+                             score,
+                             decision,
+                             reasons,
+                             status,
+                             transfer_id,
+                             memo,
+                             reference,
+                             category,
+                             invoice_number,
+                             end_to_end_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING *;
+
+-- name: GetRiskAssessment :one
+SELECT *
+FROM risk_assessment
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetRiskAssessmentForUpdate :one
+SELECT *
+FROM risk_assessment
+WHERE id = $1
+LIMIT 1
+    FOR NO KEY UPDATE;
+
+-- name: GetRiskAssessments :many
+SELECT *
+FROM risk_assessment
+WHERE ($1::varchar IS NULL OR status = $1)
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ReviewRiskAssessment :one
+UPDATE risk_assessment
+SET status      = $2,
+    transfer_id = $3,
+    reviewed_by = $4,
+    review_note = $5,
+    reviewed_at = now()
+WHERE id = $1
+  AND status = 'pending_review'
+RETURNING *;
+
+-- name: GetTransferRiskStats :one
+SELECT COALESCE(AVG(amount) FILTER (WHERE created_at >= sqlc.arg(history_start)), 0)::bigint AS average_amount,
+       COUNT(*) FILTER (WHERE created_at >= sqlc.arg(history_start))                        AS history_count,
+       COUNT(*) FILTER (WHERE created_at >= sqlc.arg(recent_start))                         AS recent_count,
+       COUNT(*) FILTER (WHERE destination_account_id = sqlc.arg(destination_account_id))    AS destination_count
+FROM transfer
+WHERE source_account_id = sqlc.arg(source_account_id)
+  AND destination_account_id NOT IN (SELECT account_id FROM system_account);
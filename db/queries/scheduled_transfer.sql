@@ -0,0 +1,66 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfer (owner,
+                                source_account_id,
+                                destination_account_id,
+                                amount,
+                                currency,
+                                frequency,
+                                day_of_month,
+                                next_occurrence_at,
+                                next_attempt_at,
+                                end_at,
+                                remaining_runs)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT *
+FROM scheduled_transfer
+WHERE id = $1;
+
+-- name: GetScheduledTransferForUpdate :one
+SELECT *
+FROM scheduled_transfer
+WHERE id = $1
+LIMIT 1
+    FOR NO KEY UPDATE;
+
+-- name: GetScheduledTransfers :many
+SELECT *
+FROM scheduled_transfer
+WHERE owner = $1
+ORDER BY id
+LIMIT $2 OFFSET $3;
+
+-- name: GetDueScheduledTransferForUpdate :one
+SELECT *
+FROM scheduled_transfer
+WHERE status = 'active'
+  AND next_attempt_at <= $1
+ORDER BY next_attempt_at
+LIMIT 1 FOR UPDATE SKIP LOCKED;
+
+-- name: UpdateScheduledTransferStatus :one
+UPDATE scheduled_transfer
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateScheduledTransferRun :one
+UPDATE scheduled_transfer
+SET next_occurrence_at = $2,
+    next_attempt_at    = $3,
+    remaining_runs     = $4,
+    status             = $5,
+    attempts           = $6,
+    last_error         = $7,
+    last_run_at        = $8
+WHERE id = $1
+RETURNING *;
+
+-- name: CancelScheduledTransfersForAccountMember :exec
+UPDATE scheduled_transfer
+SET status = 'cancelled'
+WHERE owner = $1
+  AND source_account_id = $2
+  AND status IN ('active', 'paused');
@@ -0,0 +1,65 @@
+-- name: CreateScreeningHit :one
+INSERT INTO screening_hit (username,
+                           payee_id,
+                           organization_id,
+                           screened_name,
+                           entry_id,
+                           entry_name,
+                           matched_name,
+                           programs,
+                           score,
+                           status)
+SELECT sqlc.arg(username)::varchar,
+       sqlc.narg(payee_id)::bigint,
+       sqlc.narg(organization_id)::bigint,
+       sqlc.arg(screened_name)::varchar,
+       sqlc.arg(entry_id)::varchar,
+       sqlc.arg(entry_name)::varchar,
+       sqlc.arg(matched_name)::varchar,
+       sqlc.arg(programs)::varchar,
+       sqlc.arg(score)::integer,
+       'pending_review'
+WHERE NOT EXISTS (SELECT 1
+                  FROM screening_hit AS raised
+                  WHERE raised.username = sqlc.arg(username)
+                    AND raised.payee_id IS NOT DISTINCT FROM sqlc.narg(payee_id)
+                    AND raised.organization_id IS NOT DISTINCT FROM sqlc.narg(organization_id)
+                    AND raised.entry_id = sqlc.arg(entry_id))
+RETURNING *;
+
+-- name: GetScreeningHit :one
+SELECT *
+FROM screening_hit
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetScreeningHitForUpdate :one
+SELECT *
+FROM screening_hit
+WHERE id = $1
+LIMIT 1
+    FOR NO KEY UPDATE;
+
+-- name: GetScreeningHits :many
+SELECT *
+FROM screening_hit
+WHERE ($1::varchar IS NULL OR status = $1)
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ReviewScreeningHit :one
+UPDATE screening_hit
+SET status      = $2,
+    reviewed_by = $3,
+    review_note = $4,
+    reviewed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetScreeningHitCounts :one
+SELECT count(*) FILTER (WHERE status = 'pending_review') AS pending_count,
+       count(*) FILTER (WHERE status = 'confirmed')      AS confirmed_count
+FROM screening_hit
+WHERE username = sqlc.arg(username)
+  AND payee_id IS NOT DISTINCT FROM sqlc.narg(payee_id)
+  AND organization_id IS NOT DISTINCT FROM sqlc.narg(organization_id);
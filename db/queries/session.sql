@@ -13,4 +13,18 @@ RETURNING *;
 SELECT *
 FROM "session"
 WHERE id = $1
-LIMIT 1;
\ No newline at end of file
+LIMIT 1;
+
+-- name: GetFirstDeviceSession :one
+SELECT *
+FROM "session"
+WHERE username = $1
+  AND user_agent = $2
+  AND client_ip = $3
+ORDER BY created_at
+LIMIT 1;
+
+-- name: BlockUserSessions :exec
+UPDATE "session"
+SET is_blocked = true
+WHERE username = $1;
\ No newline at end of file
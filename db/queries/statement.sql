@@ -0,0 +1,48 @@
+-- name: CreateStatement :one
+INSERT INTO statement (account_id,
+                       period_start,
+                       period_end,
+                       opening_balance,
+                       closing_balance,
+                       content,
+                       content_hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (account_id, period_start, period_end) DO NOTHING
+RETURNING *;
+
+-- name: GetStatement :one
+SELECT *
+FROM statement
+WHERE account_id = $1
+  AND period_start = $2
+  AND period_end = $3;
+
+-- name: GetStatementsForAccount :many
+SELECT *
+FROM statement
+WHERE account_id = $1
+ORDER BY period_start DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetStatementLines :many
+SELECT entry.id,
+       entry.amount,
+       entry.created_at,
+       journal_transaction.kind,
+       journal_transaction.description,
+       transfer.id        AS transfer_id,
+       counterparty.id    AS counterparty_account_id,
+       "user".full_name   AS counterparty_name
+FROM entry
+         LEFT JOIN journal_transaction ON journal_transaction.id = entry.journal_transaction_id
+         LEFT JOIN transfer ON transfer.journal_transaction_id = entry.journal_transaction_id
+         LEFT JOIN account counterparty ON counterparty.id = CASE
+                                                                 WHEN transfer.source_account_id = entry.account_id
+                                                                     THEN transfer.destination_account_id
+                                                                 ELSE transfer.source_account_id
+    END
+         LEFT JOIN "user" ON "user".username = counterparty.owner
+WHERE entry.account_id = $1
+  AND entry.created_at >= sqlc.arg(from_time)
+  AND entry.created_at < sqlc.arg(to_time)
+ORDER BY entry.created_at, entry.id;
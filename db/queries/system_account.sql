@@ -0,0 +1,12 @@
+-- name: CreateSystemAccount :one
+INSERT INTO system_account (purpose,
+                            currency,
+                            account_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetSystemAccount :one
+SELECT *
+FROM system_account
+WHERE purpose = $1
+  AND currency = $2;
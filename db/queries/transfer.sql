@@ -1,8 +1,14 @@
 -- name: CreateTransfer :one
 INSERT INTO transfer (source_account_id,
                       destination_account_id,
-                      amount)
-VALUES ($1, $2, $3)
+                      amount,
+                      journal_transaction_id,
+                      memo,
+                      reference,
+                      category,
+                      invoice_number,
+                      end_to_end_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: GetTransfer :one
@@ -11,9 +17,10 @@ FROM transfer
 WHERE id = $1;
 
 -- name: GetTransfers :many
-SELECT *
+SELECT transfer.*, account.currency
 FROM transfer
-ORDER BY id
+         JOIN account ON account.id = transfer.source_account_id
+ORDER BY transfer.id
 LIMIT $1 OFFSET $2;
 
 -- name: GetOutboundTransfersForAccount :many
@@ -39,4 +46,18 @@ RETURNING *;
 -- name: DeleteTransfer :exec
 DELETE
 FROM transfer
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: SearchTransfers :many
+SELECT *
+FROM transfer
+WHERE (source_account_id = sqlc.arg(account_id) OR destination_account_id = sqlc.arg(account_id))
+  AND (sqlc.narg(search)::varchar IS NULL
+    OR memo ILIKE '%' || sqlc.narg(search) || '%'
+    OR reference ILIKE '%' || sqlc.narg(search) || '%')
+  AND (sqlc.narg(reference)::varchar IS NULL OR reference = sqlc.narg(reference))
+  AND (sqlc.narg(category)::varchar IS NULL OR category = sqlc.narg(category))
+  AND (sqlc.narg(invoice_number)::varchar IS NULL OR invoice_number = sqlc.narg(invoice_number))
+  AND (sqlc.narg(end_to_end_id)::varchar IS NULL OR end_to_end_id = sqlc.narg(end_to_end_id))
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
\ No newline at end of file
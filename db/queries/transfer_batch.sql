@@ -0,0 +1,104 @@
+-- name: CreateTransferBatch :one
+INSERT INTO transfer_batch (source_account_id,
+                            created_by,
+                            mode,
+                            currency,
+                            item_count,
+                            total_amount)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetTransferBatch :one
+SELECT *
+FROM transfer_batch
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetRunnableTransferBatches :many
+SELECT *
+FROM transfer_batch
+WHERE status = 'pending'
+   OR (status = 'processing' AND started_at < $1)
+ORDER BY id
+LIMIT $2;
+
+-- name: ClaimTransferBatch :one
+UPDATE transfer_batch
+SET status     = 'processing',
+    started_at = now()
+WHERE id = $1
+  AND (status = 'pending' OR (status = 'processing' AND started_at < $2))
+RETURNING *;
+
+-- name: FinishTransferBatch :one
+UPDATE transfer_batch
+SET status       = $2,
+    error        = $3,
+    completed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateTransferBatchItem :one
+INSERT INTO transfer_batch_item (batch_id,
+                                 position,
+                                 destination_account_id,
+                                 amount,
+                                 memo,
+                                 reference,
+                                 category,
+                                 invoice_number,
+                                 end_to_end_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetTransferBatchItems :many
+SELECT *
+FROM transfer_batch_item
+WHERE batch_id = $1
+  AND ($2::varchar IS NULL OR status = $2)
+ORDER BY position
+LIMIT $3 OFFSET $4;
+
+-- name: GetTransferBatchItemForUpdate :one
+SELECT *
+FROM transfer_batch_item
+WHERE id = $1
+LIMIT 1
+    FOR NO KEY UPDATE;
+
+-- name: GetPendingTransferBatchItems :many
+SELECT *
+FROM transfer_batch_item
+WHERE batch_id = $1
+  AND status = 'pending'
+ORDER BY position;
+
+-- name: CountTransferBatchItems :many
+SELECT status, count(*) AS count
+FROM transfer_batch_item
+WHERE batch_id = $1
+GROUP BY status
+ORDER BY status;
+
+-- name: CompleteTransferBatchItem :one
+UPDATE transfer_batch_item
+SET status       = 'succeeded',
+    transfer_id  = $2,
+    processed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: FailTransferBatchItem :one
+UPDATE transfer_batch_item
+SET status       = 'failed',
+    error        = $2,
+    processed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: SkipPendingTransferBatchItems :exec
+UPDATE transfer_batch_item
+SET status       = 'skipped',
+    processed_at = now()
+WHERE batch_id = $1
+  AND status = 'pending';
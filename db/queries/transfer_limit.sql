@@ -0,0 +1,62 @@
+-- name: CreateTransferLimitOverride :one
+INSERT INTO transfer_limit_override (username,
+                                     currency,
+                                     per_transfer,
+                                     daily,
+                                     monthly,
+                                     hourly_count,
+                                     reason,
+                                     created_by,
+                                     expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetTransferLimitOverride :one
+SELECT *
+FROM transfer_limit_override
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetActiveTransferLimitOverride :one
+SELECT *
+FROM transfer_limit_override
+WHERE username = $1
+  AND currency = $2
+  AND expires_at > $3
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
+
+-- name: GetTransferLimitOverrides :many
+SELECT *
+FROM transfer_limit_override
+WHERE username = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: DeleteTransferLimitOverride :exec
+DELETE
+FROM transfer_limit_override
+WHERE id = $1;
+
+-- name: GetTransferUsage :one
+SELECT COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(day_start)), 0)::bigint   AS daily,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(month_start)), 0)::bigint AS monthly,
+       COUNT(*) FILTER (WHERE t.created_at >= sqlc.arg(hour_start))                             AS hourly_count
+FROM transfer t
+         JOIN account a ON a.id = t.source_account_id
+WHERE a.owner = sqlc.arg(owner)
+  AND a.organization_id IS NULL
+  AND a.currency = sqlc.arg(currency)
+  AND t.created_at >= LEAST(sqlc.arg(month_start)::timestamptz, sqlc.arg(hour_start)::timestamptz)
+  AND t.destination_account_id NOT IN (SELECT account_id FROM system_account);
+
+-- name: GetOrganizationTransferUsage :one
+SELECT COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(day_start)), 0)::bigint   AS daily,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(month_start)), 0)::bigint AS monthly,
+       COUNT(*) FILTER (WHERE t.created_at >= sqlc.arg(hour_start))                             AS hourly_count
+FROM transfer t
+         JOIN account a ON a.id = t.source_account_id
+WHERE a.organization_id = sqlc.arg(organization_id)
+  AND a.currency = sqlc.arg(currency)
+  AND t.created_at >= LEAST(sqlc.arg(month_start)::timestamptz, sqlc.arg(hour_start)::timestamptz)
+  AND t.destination_account_id NOT IN (SELECT account_id FROM system_account);
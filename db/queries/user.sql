@@ -10,4 +10,35 @@ RETURNING *;
 SELECT *
 FROM "user"
 WHERE username = $1
-LIMIT 1;
\ No newline at end of file
+LIMIT 1;
+
+-- name: GetUserForUpdate :one
+SELECT *
+FROM "user"
+WHERE username = $1
+LIMIT 1
+    FOR NO KEY UPDATE;
+
+-- name: UpdateUserTier :one
+UPDATE "user"
+SET tier = $2
+WHERE username = $1
+RETURNING *;
+
+-- name: GetUsersForScreening :many
+SELECT *
+FROM "user"
+WHERE username > $1
+ORDER BY username
+LIMIT $2;
+
+-- name: UpdateUserScreeningStatus :exec
+UPDATE "user"
+SET screening_status = $2
+WHERE username = $1;
+
+-- name: UpdateUserKycStatus :one
+UPDATE "user"
+SET kyc_status = $2
+WHERE username = $1
+RETURNING *;
\ No newline at end of file
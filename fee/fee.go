@@ -0,0 +1,192 @@
+package fee
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	"math/big"
+	"os"
+)
+
+// TransferFee is charged to the source account of a transfer, in the source account's currency.
+type TransferFee struct {
+	Name string `json:"name"`
+	// Only charge transfers out of accounts in this currency. Required when the fee has a fixed amount.
+	Currency string `json:"currency"`
+	Flat     int64  `json:"flat"`
+	RateBps  int64  `json:"rate_bps"`
+	Min      int64  `json:"min"`
+	Max      int64  `json:"max"`
+}
+
+// MaintenanceFee is charged once a month to accounts of a product whose balance at the end of the month is below
+// MinimumBalance.
+type MaintenanceFee struct {
+	Name           string `json:"name"`
+	Product        string `json:"product"`
+	Currency       string `json:"currency"`
+	MinimumBalance int64  `json:"minimum_balance"`
+	Amount         int64  `json:"amount"`
+}
+
+type Schedule struct {
+	TransferFees    []TransferFee    `json:"transfer_fees"`
+	MaintenanceFees []MaintenanceFee `json:"maintenance_fees"`
+}
+
+// Charge is a fee owed, in minor units of the charged account's currency.
+type Charge struct {
+	Name   string `json:"name"`
+	Amount int64  `json:"amount"`
+}
+
+// LoadSchedule reads a JSON fee schedule. An empty path gives an empty schedule that charges nothing.
+func LoadSchedule(path string) (Schedule, error) {
+	var schedule Schedule
+	if path == "" {
+		return schedule, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return schedule, err
+	}
+
+	if err := json.Unmarshal(data, &schedule); err != nil {
+		return schedule, fmt.Errorf("cannot parse fee schedule: %w", err)
+	}
+
+	return schedule, schedule.Validate()
+}
+
+func (schedule Schedule) Validate() error {
+	names := map[string]bool{}
+	checkName := func(name string) error {
+		if name == "" {
+			return fmt.Errorf("fee has no name")
+		}
+		if names[name] {
+			return fmt.Errorf("fee %s is defined twice", name)
+		}
+		names[name] = true
+		return nil
+	}
+	checkCurrency := func(name string, code string) error {
+		if _, ok := currency.Lookup(code); !ok {
+			return fmt.Errorf("fee %s has unknown currency %q", name, code)
+		}
+		return nil
+	}
+
+	for _, fee := range schedule.TransferFees {
+		if err := checkName(fee.Name); err != nil {
+			return err
+		}
+		if fee.Flat < 0 || fee.Min < 0 || fee.Max < 0 || fee.RateBps < 0 || fee.RateBps > 10000 {
+			return fmt.Errorf("fee %s has a negative amount or a rate above 100%%", fee.Name)
+		}
+		if fee.Max > 0 && fee.Max < fee.Min {
+			return fmt.Errorf("fee %s has a maximum below its minimum", fee.Name)
+		}
+		if fee.Currency != "" {
+			if err := checkCurrency(fee.Name, fee.Currency); err != nil {
+				return err
+			}
+		} else if fee.Flat != 0 || fee.Min != 0 || fee.Max != 0 {
+			return fmt.Errorf("fee %s has a fixed amount but no currency", fee.Name)
+		}
+	}
+
+	for _, fee := range schedule.MaintenanceFees {
+		if err := checkName(fee.Name); err != nil {
+			return err
+		}
+		if fee.Product == "" {
+			return fmt.Errorf("fee %s has no product", fee.Name)
+		}
+		if err := checkCurrency(fee.Name, fee.Currency); err != nil {
+			return err
+		}
+		if fee.Amount <= 0 || fee.MinimumBalance < 0 {
+			return fmt.Errorf("fee %s must have a positive amount and a non-negative minimum balance", fee.Name)
+		}
+	}
+
+	return nil
+}
+
+// TransferCharges returns the fees owed on a transfer of amount out of an account in sourceCurrency.
+func (schedule Schedule) TransferCharges(sourceCurrency string, amount int64) []Charge {
+	var charges []Charge
+
+	for _, fee := range schedule.TransferFees {
+		if fee.Currency != "" && fee.Currency != sourceCurrency {
+			continue
+		}
+
+		charge := fee.Flat + percentage(amount, fee.RateBps)
+		if charge < fee.Min {
+			charge = fee.Min
+		}
+		if fee.Max > 0 && charge > fee.Max {
+			charge = fee.Max
+		}
+
+		if charge > 0 {
+			charges = append(charges, Charge{Name: fee.Name, Amount: charge})
+		}
+	}
+
+	return charges
+}
+
+// MaintenanceCharges returns the monthly fees owed by an account of product in currency that closed the month on
+// balance.
+func (schedule Schedule) MaintenanceCharges(product string, currency string, balance int64) []Charge {
+	var charges []Charge
+
+	for _, fee := range schedule.MaintenanceFees {
+		if fee.Product == product && fee.Currency == currency && balance < fee.MinimumBalance {
+			charges = append(charges, Charge{Name: fee.Name, Amount: fee.Amount})
+		}
+	}
+
+	return charges
+}
+
+// MaintenanceProducts returns the products that have at least one maintenance fee.
+func (schedule Schedule) MaintenanceProducts() []string {
+	var products []string
+	seen := map[string]bool{}
+
+	for _, fee := range schedule.MaintenanceFees {
+		if !seen[fee.Product] {
+			seen[fee.Product] = true
+			products = append(products, fee.Product)
+		}
+	}
+
+	return products
+}
+
+// Total adds up the amounts of charges.
+func Total(charges []Charge) int64 {
+	var total int64
+	for _, charge := range charges {
+		total += charge.Amount
+	}
+	return total
+}
+
+// percentage returns amount * rateBps / 10000, rounded half up.
+func percentage(amount int64, rateBps int64) int64 {
+	if amount <= 0 || rateBps <= 0 {
+		return 0
+	}
+
+	result := big.NewInt(amount)
+	result.Mul(result, big.NewInt(rateBps))
+	result.Add(result, big.NewInt(5000))
+	result.Quo(result, big.NewInt(10000))
+	return result.Int64()
+}
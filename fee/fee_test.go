@@ -0,0 +1,102 @@
+package fee
+
+import (
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testSchedule() Schedule {
+	return Schedule{
+		TransferFees: []TransferFee{
+			{Name: "percentage", RateBps: 100, Min: 50, Max: 2500, Currency: constants.USD},
+			{Name: "wire", Currency: constants.EUR, Flat: 25},
+		},
+		MaintenanceFees: []MaintenanceFee{
+			{Name: "checking_usd", Product: constants.ProductChecking, Currency: constants.USD, MinimumBalance: 150000, Amount: 500},
+		},
+	}
+}
+
+func TestTransferCharges(t *testing.T) {
+	schedule := testSchedule()
+
+	testCases := []struct {
+		name           string
+		sourceCurrency string
+		amount         int64
+		charges        []Charge
+	}{
+		{name: "Percentage", sourceCurrency: constants.USD, amount: 10050, charges: []Charge{{Name: "percentage", Amount: 101}}},
+		{name: "Minimum", sourceCurrency: constants.USD, amount: 100, charges: []Charge{{Name: "percentage", Amount: 50}}},
+		{name: "Maximum", sourceCurrency: constants.USD, amount: 1000000, charges: []Charge{{Name: "percentage", Amount: 2500}}},
+		{name: "Flat", sourceCurrency: constants.EUR, amount: 1000, charges: []Charge{{Name: "wire", Amount: 25}}},
+		{name: "OtherCurrency", sourceCurrency: constants.CAD, amount: 1000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				charges := schedule.TransferCharges(tc.sourceCurrency, tc.amount)
+				require.Equal(t, tc.charges, charges)
+			},
+		)
+	}
+}
+
+func TestMaintenanceCharges(t *testing.T) {
+	schedule := testSchedule()
+
+	require.Equal(
+		t, []Charge{{Name: "checking_usd", Amount: 500}},
+		schedule.MaintenanceCharges(constants.ProductChecking, constants.USD, 149999),
+	)
+	require.Empty(t, schedule.MaintenanceCharges(constants.ProductChecking, constants.USD, 150000))
+	require.Empty(t, schedule.MaintenanceCharges(constants.ProductChecking, constants.EUR, 0))
+	require.Empty(t, schedule.MaintenanceCharges(constants.ProductSavings, constants.USD, 0))
+	require.Equal(t, []string{constants.ProductChecking}, schedule.MaintenanceProducts())
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, testSchedule().Validate())
+	require.NoError(t, Schedule{}.Validate())
+
+	testCases := []struct {
+		name     string
+		schedule Schedule
+	}{
+		{name: "NoName", schedule: Schedule{TransferFees: []TransferFee{{RateBps: 10}}}},
+		{name: "Duplicate", schedule: Schedule{TransferFees: []TransferFee{{Name: "a", RateBps: 10}, {Name: "a", RateBps: 20}}}},
+		{name: "FlatWithoutCurrency", schedule: Schedule{TransferFees: []TransferFee{{Name: "a", Flat: 10}}}},
+		{name: "RateAbove100", schedule: Schedule{TransferFees: []TransferFee{{Name: "a", RateBps: 10001}}}},
+		{name: "MaxBelowMin", schedule: Schedule{TransferFees: []TransferFee{{Name: "a", Currency: constants.USD, Min: 10, Max: 5}}}},
+		{name: "UnknownCurrency", schedule: Schedule{MaintenanceFees: []MaintenanceFee{{Name: "a", Product: constants.ProductChecking, Currency: "XYZ", Amount: 1}}}},
+		{name: "NoProduct", schedule: Schedule{MaintenanceFees: []MaintenanceFee{{Name: "a", Currency: constants.USD, Amount: 1}}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				require.Error(t, tc.schedule.Validate())
+			},
+		)
+	}
+}
+
+func TestLoadSchedule(t *testing.T) {
+	schedule, err := LoadSchedule("")
+	require.NoError(t, err)
+	require.Empty(t, schedule.TransferFees)
+
+	schedule, err = LoadSchedule("../fees.json")
+	require.NoError(t, err)
+	require.Empty(t, schedule.TransferFees)
+	require.NotEmpty(t, schedule.MaintenanceFees)
+
+	path := filepath.Join(t.TempDir(), "fees.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"transfer_fees": [{"flat": 10}]}`), 0600))
+	_, err = LoadSchedule(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package interest
+
+import (
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"math/big"
+	"time"
+)
+
+// MicrosPerUnit is the number of accrual micro-units in one minor currency unit. Daily accruals are kept in micro-units
+// so that the fractions of a cent earned each day add up to the right amount when interest is posted.
+const MicrosPerUnit = 1000000
+
+// IsDayCountConvention reports whether convention is one DailyAccrual understands.
+func IsDayCountConvention(convention string) bool {
+	switch convention {
+	case constants.DayCountActual365, constants.DayCountActual360, constants.DayCountActualActual,
+		constants.DayCount30360:
+		return true
+	}
+	return false
+}
+
+// DailyAccrual returns the interest, in micro-units, earned by an end-of-day balance on day at an annual rate in basis
+// points. Fractions of a micro-unit are truncated. Zero and negative balances earn nothing.
+func DailyAccrual(balance int64, rateBps int32, convention string, day time.Time) (int64, error) {
+	days, yearDays, err := dayFraction(convention, day)
+	if err != nil {
+		return 0, err
+	}
+
+	if balance <= 0 || rateBps <= 0 || days == 0 {
+		return 0, nil
+	}
+
+	// balance * rate / 10000 * days / yearDays * MicrosPerUnit
+	accrual := big.NewInt(balance)
+	accrual.Mul(accrual, big.NewInt(int64(rateBps)))
+	accrual.Mul(accrual, big.NewInt(days*MicrosPerUnit))
+	accrual.Quo(accrual, big.NewInt(10000*yearDays))
+	if !accrual.IsInt64() {
+		return 0, fmt.Errorf("interest accrual on %d overflows", balance)
+	}
+
+	return accrual.Int64(), nil
+}
+
+// dayFraction returns the share of a year that day counts for, as days over yearDays, under the convention.
+func dayFraction(convention string, day time.Time) (days int64, yearDays int64, err error) {
+	switch convention {
+	case constants.DayCountActual365:
+		return 1, 365, nil
+	case constants.DayCountActual360:
+		return 1, 360, nil
+	case constants.DayCountActualActual:
+		if isLeapYear(day.Year()) {
+			return 1, 366, nil
+		}
+		return 1, 365, nil
+	case constants.DayCount30360:
+		// Every month counts as 30 days: the 31st counts for nothing and the end of February makes up the shortfall.
+		lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		switch {
+		case day.Day() == 31:
+			return 0, 360, nil
+		case day.Day() == lastDay:
+			return int64(31 - lastDay), 360, nil
+		}
+		return 1, 360, nil
+	}
+	return 0, 0, fmt.Errorf("unknown day count convention %q", convention)
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// Split divides accrued micro-units into the whole minor units that can be posted and the remainder carried over to
+// the next posting.
+func Split(micros int64) (amount int64, carry int64) {
+	return micros / MicrosPerUnit, micros % MicrosPerUnit
+}
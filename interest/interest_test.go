@@ -0,0 +1,65 @@
+package interest
+
+import (
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDailyAccrual(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name       string
+		balance    int64
+		rateBps    int32
+		convention string
+		day        time.Time
+		accrual    int64
+	}{
+		{name: "Actual365", balance: 365000, rateBps: 100, convention: constants.DayCountActual365, day: date(2023, 3, 1), accrual: 10000000},
+		{name: "Actual360", balance: 360000, rateBps: 100, convention: constants.DayCountActual360, day: date(2023, 3, 1), accrual: 10000000},
+		{name: "ActualActualLeapYear", balance: 366000, rateBps: 100, convention: constants.DayCountActualActual, day: date(2024, 3, 1), accrual: 10000000},
+		{name: "ActualActual", balance: 365000, rateBps: 100, convention: constants.DayCountActualActual, day: date(2023, 3, 1), accrual: 10000000},
+		{name: "Truncated", balance: 1000, rateBps: 250, convention: constants.DayCountActual365, day: date(2023, 3, 1), accrual: 68493},
+		{name: "30360Day31", balance: 360000, rateBps: 100, convention: constants.DayCount30360, day: date(2023, 1, 31), accrual: 0},
+		{name: "30360EndOfFebruary", balance: 360000, rateBps: 100, convention: constants.DayCount30360, day: date(2023, 2, 28), accrual: 30000000},
+		{name: "30360EndOfFebruaryLeapYear", balance: 360000, rateBps: 100, convention: constants.DayCount30360, day: date(2024, 2, 29), accrual: 20000000},
+		{name: "NegativeBalance", balance: -5000, rateBps: 100, convention: constants.DayCountActual365, day: date(2023, 3, 1), accrual: 0},
+		{name: "ZeroRate", balance: 5000, rateBps: 0, convention: constants.DayCountActual365, day: date(2023, 3, 1), accrual: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				accrual, err := DailyAccrual(tc.balance, tc.rateBps, tc.convention, tc.day)
+				require.NoError(t, err)
+				require.Equal(t, tc.accrual, accrual)
+			},
+		)
+	}
+
+	_, err := DailyAccrual(1000, 100, "bogus", date(2023, 3, 1))
+	require.Error(t, err)
+}
+
+func TestDailyAccrualMonthTotal(t *testing.T) {
+	for _, month := range []time.Month{time.January, time.February, time.April} {
+		var total int64
+		for day := time.Date(2023, month, 1, 0, 0, 0, 0, time.UTC); day.Month() == month; day = day.AddDate(0, 0, 1) {
+			accrual, err := DailyAccrual(360000, 100, constants.DayCount30360, day)
+			require.NoError(t, err)
+			total += accrual
+		}
+		require.Equal(t, int64(300000000), total, month.String())
+	}
+}
+
+func TestSplit(t *testing.T) {
+	amount, carry := Split(12345678)
+	require.Equal(t, int64(12), amount)
+	require.Equal(t, int64(345678), carry)
+}
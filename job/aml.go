@@ -0,0 +1,151 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/aml"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"log"
+)
+
+// MonitorTransactions checks recent transfers and entries against every AML scenario and raises an alert for each
+// account that matches one. An account doesn't raise another alert for the same scenario until the window of its last
+// one has passed.
+func MonitorTransactions(store db.Store, scenarios aml.Scenarios, clock util.Clock) Func {
+	return func(ctx context.Context) error {
+		now := clock.Now()
+		raised := 0
+		raise := func(arg db.CreateAmlAlertParams) error {
+			_, err := store.CreateAmlAlert(ctx, arg)
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return nil
+			case err != nil:
+				return fmt.Errorf("cannot raise %s alert for account %d: %w", arg.Scenario, arg.AccountID, err)
+			}
+			raised++
+			return nil
+		}
+
+		for _, s := range scenarios.Structuring {
+			windowStart := now.Add(-s.Window())
+			candidates, err := store.GetStructuringCandidates(
+				ctx, db.GetStructuringCandidatesParams{
+					Currency:    s.Currency,
+					MinAmount:   s.MinAmount(),
+					Threshold:   s.Threshold,
+					WindowStart: windowStart,
+					WindowEnd:   now,
+					MinCount:    s.MinCount,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, candidate := range candidates {
+				err := raise(
+					db.CreateAmlAlertParams{
+						Scenario:   constants.AmlScenarioStructuring,
+						AccountID:  candidate.AccountID,
+						Amount:     candidate.Total,
+						EventCount: candidate.TransferCount,
+						Details: fmt.Sprintf(
+							"%d transfers between %s and %s %s in %d hours",
+							candidate.TransferCount, currency.Decimal(s.Currency, s.MinAmount()),
+							currency.Decimal(s.Currency, s.Threshold), s.Currency, s.WindowHours,
+						),
+						WindowStart: windowStart,
+						WindowEnd:   now,
+					},
+				)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, s := range scenarios.RapidMovement {
+			windowStart := now.Add(-s.Window())
+			candidates, err := store.GetRapidMovementCandidates(
+				ctx, db.GetRapidMovementCandidatesParams{
+					Currency:    s.Currency,
+					WindowStart: windowStart,
+					WindowEnd:   now,
+					MinAmount:   s.MinAmount,
+					OutflowBps:  s.OutflowBps,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, candidate := range candidates {
+				err := raise(
+					db.CreateAmlAlertParams{
+						Scenario:   constants.AmlScenarioRapidMovement,
+						AccountID:  candidate.AccountID,
+						Amount:     candidate.Outflow,
+						EventCount: candidate.EntryCount,
+						Details: fmt.Sprintf(
+							"received %s %s and sent %s %s out in %d hours",
+							currency.Decimal(s.Currency, candidate.Inflow), s.Currency,
+							currency.Decimal(s.Currency, candidate.Outflow), s.Currency, s.WindowHours,
+						),
+						WindowStart: windowStart,
+						WindowEnd:   now,
+					},
+				)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, s := range scenarios.DormantReactivation {
+			windowStart := now.Add(-s.Window())
+			candidates, err := store.GetDormantReactivationCandidates(
+				ctx, db.GetDormantReactivationCandidatesParams{
+					Currency:     s.Currency,
+					DormantSince: s.DormantSince(windowStart),
+					WindowStart:  windowStart,
+					WindowEnd:    now,
+					MinAmount:    s.MinAmount,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, candidate := range candidates {
+				err := raise(
+					db.CreateAmlAlertParams{
+						Scenario:   constants.AmlScenarioDormantReactivation,
+						AccountID:  candidate.AccountID,
+						Amount:     candidate.Outflow,
+						EventCount: candidate.EntryCount,
+						Details: fmt.Sprintf(
+							"sent %s %s out after %d days without activity",
+							currency.Decimal(s.Currency, candidate.Outflow), s.Currency, s.DormantDays,
+						),
+						WindowStart: windowStart,
+						WindowEnd:   now,
+					},
+				)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if raised > 0 {
+			log.Printf("raised %d AML alerts", raised)
+		}
+		return nil
+	}
+}
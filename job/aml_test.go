@@ -0,0 +1,172 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/aml"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMonitorTransactions(t *testing.T) {
+	now := time.Date(2023, time.March, 15, 12, 0, 0, 0, time.UTC)
+	scenarios := aml.Scenarios{
+		Structuring: []aml.Structuring{
+			{Currency: constants.USD, Threshold: 1000000, MarginBps: 1000, MinCount: 3, WindowHours: 72},
+		},
+		RapidMovement: []aml.RapidMovement{
+			{Currency: constants.USD, MinAmount: 500000, OutflowBps: 9000, WindowHours: 24},
+		},
+		DormantReactivation: []aml.DormantReactivation{
+			{Currency: constants.USD, DormantDays: 180, MinAmount: 100000, WindowHours: 24},
+		},
+	}
+	dayAgo := now.Add(-24 * time.Hour)
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStructuringCandidates(
+					gomock.Any(), gomock.Eq(
+						db.GetStructuringCandidatesParams{
+							Currency:    constants.USD,
+							MinAmount:   900000,
+							Threshold:   1000000,
+							WindowStart: now.Add(-72 * time.Hour),
+							WindowEnd:   now,
+							MinCount:    3,
+						},
+					),
+				).Times(1).Return(
+					[]db.GetStructuringCandidatesRow{{AccountID: 1, TransferCount: 4, Total: 3900000}}, nil,
+				)
+				store.EXPECT().GetRapidMovementCandidates(
+					gomock.Any(), gomock.Eq(
+						db.GetRapidMovementCandidatesParams{
+							Currency:    constants.USD,
+							WindowStart: dayAgo,
+							WindowEnd:   now,
+							MinAmount:   500000,
+							OutflowBps:  9000,
+						},
+					),
+				).Times(1).Return(
+					[]db.GetRapidMovementCandidatesRow{{AccountID: 2, Inflow: 600000, Outflow: 590000, EntryCount: 3}}, nil,
+				)
+				store.EXPECT().GetDormantReactivationCandidates(
+					gomock.Any(), gomock.Eq(
+						db.GetDormantReactivationCandidatesParams{
+							Currency:     constants.USD,
+							DormantSince: dayAgo.AddDate(0, 0, -180),
+							WindowStart:  dayAgo,
+							WindowEnd:    now,
+							MinAmount:    100000,
+						},
+					),
+				).Times(1).Return(
+					[]db.GetDormantReactivationCandidatesRow{{AccountID: 3, Outflow: 250000, EntryCount: 1}}, nil,
+				)
+
+				store.EXPECT().CreateAmlAlert(
+					gomock.Any(), gomock.Eq(
+						db.CreateAmlAlertParams{
+							Scenario:    constants.AmlScenarioStructuring,
+							AccountID:   1,
+							Amount:      3900000,
+							EventCount:  4,
+							Details:     "4 transfers between 9000.00 and 10000.00 USD in 72 hours",
+							WindowStart: now.Add(-72 * time.Hour),
+							WindowEnd:   now,
+						},
+					),
+				).Times(1).Return(db.AmlAlert{ID: 1}, nil)
+				store.EXPECT().CreateAmlAlert(
+					gomock.Any(), gomock.Eq(
+						db.CreateAmlAlertParams{
+							Scenario:    constants.AmlScenarioRapidMovement,
+							AccountID:   2,
+							Amount:      590000,
+							EventCount:  3,
+							Details:     "received 6000.00 USD and sent 5900.00 USD out in 24 hours",
+							WindowStart: dayAgo,
+							WindowEnd:   now,
+						},
+					),
+				).Times(1).Return(db.AmlAlert{}, sql.ErrNoRows)
+				store.EXPECT().CreateAmlAlert(
+					gomock.Any(), gomock.Eq(
+						db.CreateAmlAlertParams{
+							Scenario:    constants.AmlScenarioDormantReactivation,
+							AccountID:   3,
+							Amount:      250000,
+							EventCount:  1,
+							Details:     "sent 2500.00 USD out after 180 days without activity",
+							WindowStart: dayAgo,
+							WindowEnd:   now,
+						},
+					),
+				).Times(1).Return(db.AmlAlert{ID: 2}, nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "CandidatesError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStructuringCandidates(gomock.Any(), gomock.Any()).Times(1).Return(
+					nil, errors.New("boom"),
+				)
+				store.EXPECT().GetRapidMovementCandidates(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateAmlAlert(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "AlertError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStructuringCandidates(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.GetStructuringCandidatesRow{{AccountID: 1, TransferCount: 4, Total: 3900000}}, nil,
+				)
+				store.EXPECT().CreateAmlAlert(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.AmlAlert{}, sql.ErrConnDone,
+				)
+				store.EXPECT().GetRapidMovementCandidates(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				err := MonitorTransactions(store, scenarios, util.NewFakeClock(now))(context.Background())
+				tc.checkError(t, err)
+			},
+		)
+	}
+}
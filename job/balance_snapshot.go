@@ -0,0 +1,111 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"time"
+)
+
+const (
+	accountBatchSize = 100
+	// dayCloseDelay leaves time for transactions that started before midnight to commit before the day they were
+	// dated is treated as closed.
+	dayCloseDelay = time.Hour
+)
+
+// SnapshotBalances snapshots every account's balance for each closed day that has not been snapshotted yet, starting
+// from the day after its latest snapshot, or the day it was opened.
+func SnapshotBalances(store db.Store, clock util.Clock) Func {
+	return func(ctx context.Context) error {
+		today := util.StartOfDay(clock.Now().Add(-dayCloseDelay))
+
+		return forEachAccountOpenedBefore(
+			ctx, store, today, func(account db.Account) error {
+				day := util.StartOfDay(account.CreatedAt)
+
+				snapshot, err := store.GetLatestBalanceSnapshot(ctx, account.ID)
+				switch {
+				case err == nil:
+					day = util.StartOfDay(snapshot.Date).AddDate(0, 0, 1)
+				case !errors.Is(err, sql.ErrNoRows):
+					return err
+				}
+
+				return snapshotBalances(ctx, store, account.ID, day, today)
+			},
+		)
+	}
+}
+
+// BackfillBalanceSnapshots snapshots every account's balance for each day from from through to that it was open,
+// skipping days that already have a snapshot. Days that haven't closed yet are left for SnapshotBalances.
+func BackfillBalanceSnapshots(
+	ctx context.Context, store db.Store, clock util.Clock, from time.Time, to time.Time,
+) error {
+	from = util.StartOfDay(from)
+	end := util.StartOfDay(to).AddDate(0, 0, 1)
+	if today := util.StartOfDay(clock.Now().Add(-dayCloseDelay)); end.After(today) {
+		end = today
+	}
+
+	return forEachAccountOpenedBefore(
+		ctx, store, end, func(account db.Account) error {
+			day := util.StartOfDay(account.CreatedAt)
+			if day.Before(from) {
+				day = from
+			}
+
+			return snapshotBalances(ctx, store, account.ID, day, end)
+		},
+	)
+}
+
+func forEachAccountOpenedBefore(
+	ctx context.Context, store db.Store, before time.Time, fn func(account db.Account) error,
+) error {
+	for offset := int32(0); ; offset += accountBatchSize {
+		accounts, err := store.GetAccountsOpenedBefore(
+			ctx, db.GetAccountsOpenedBeforeParams{
+				CreatedAt: before,
+				Limit:     accountBatchSize,
+				Offset:    offset,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, account := range accounts {
+			if err := fn(account); err != nil {
+				return err
+			}
+		}
+
+		if len(accounts) < accountBatchSize {
+			return nil
+		}
+	}
+}
+
+// snapshotBalances snapshots each day from day up to, but not including, end.
+func snapshotBalances(ctx context.Context, store db.Store, accountID int64, day time.Time, end time.Time) error {
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		_, err := store.SnapshotBalanceTx(
+			ctx, db.SnapshotBalanceTxParams{
+				AccountID: accountID,
+				Date:      day,
+			},
+		)
+		if err != nil && !errors.Is(err, db.ErrBalanceSnapshotExists) {
+			return fmt.Errorf(
+				"cannot snapshot balance of account %d for %s: %w", accountID, day.Format("2006-01-02"), err,
+			)
+		}
+	}
+
+	return nil
+}
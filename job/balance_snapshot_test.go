@@ -0,0 +1,176 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSnapshotBalances(t *testing.T) {
+	date := func(day int) time.Time {
+		return time.Date(2023, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	account := db.Account{
+		ID:        util.RandomInt(1, 1000),
+		CreatedAt: date(10).Add(15 * time.Hour),
+	}
+
+	expectSnapshot := func(store *mockdb.MockStore, day time.Time, err error) *gomock.Call {
+		arg := db.SnapshotBalanceTxParams{
+			AccountID: account.ID,
+			Date:      day,
+		}
+		return store.EXPECT().SnapshotBalanceTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.BalanceSnapshot{}, err)
+	}
+
+	testCases := []struct {
+		name       string
+		now        time.Time
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "FirstRun",
+			now:  date(13).Add(2 * time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetAccountsOpenedBeforeParams{
+					CreatedAt: date(13),
+					Limit:     accountBatchSize,
+				}
+				store.EXPECT().GetAccountsOpenedBefore(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					[]db.Account{account}, nil,
+				)
+				store.EXPECT().GetLatestBalanceSnapshot(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					db.BalanceSnapshot{}, sql.ErrNoRows,
+				)
+				gomock.InOrder(
+					expectSnapshot(store, date(10), nil),
+					expectSnapshot(store, date(11), nil),
+					expectSnapshot(store, date(12), nil),
+				)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "BeforeDayHasSettled",
+			now:  date(13).Add(30 * time.Minute),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountsOpenedBefore(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.Account{account}, nil,
+				)
+				store.EXPECT().GetLatestBalanceSnapshot(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					db.BalanceSnapshot{AccountID: account.ID, Date: date(11)}, nil,
+				)
+				store.EXPECT().SnapshotBalanceTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "ConcurrentSnapshot",
+			now:  date(13).Add(2 * time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountsOpenedBefore(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.Account{account}, nil,
+				)
+				store.EXPECT().GetLatestBalanceSnapshot(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					db.BalanceSnapshot{AccountID: account.ID, Date: date(11)}, nil,
+				)
+				expectSnapshot(store, date(12), db.ErrBalanceSnapshotExists)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InternalError",
+			now:  date(13).Add(2 * time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountsOpenedBefore(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.Account{account}, nil,
+				)
+				store.EXPECT().GetLatestBalanceSnapshot(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					db.BalanceSnapshot{}, sql.ErrConnDone,
+				)
+				store.EXPECT().SnapshotBalanceTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				clock := util.NewFakeClock(tc.now)
+				err := SnapshotBalances(store, clock)(context.Background())
+				tc.checkError(t, err)
+			},
+		)
+	}
+}
+
+func TestBackfillBalanceSnapshots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	date := func(day int) time.Time {
+		return time.Date(2023, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	store := mockdb.NewMockStore(ctrl)
+	clock := util.NewFakeClock(date(20).Add(2 * time.Hour))
+	oldAccount := db.Account{ID: 1, CreatedAt: date(1)}
+	newAccount := db.Account{ID: 2, CreatedAt: date(18).Add(9 * time.Hour)}
+
+	arg := db.GetAccountsOpenedBeforeParams{
+		CreatedAt: date(20),
+		Limit:     accountBatchSize,
+	}
+	store.EXPECT().GetAccountsOpenedBefore(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+		[]db.Account{oldAccount, newAccount}, nil,
+	)
+
+	var snapshotted []db.SnapshotBalanceTxParams
+	store.EXPECT().SnapshotBalanceTx(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, arg db.SnapshotBalanceTxParams) (db.BalanceSnapshot, error) {
+			snapshotted = append(snapshotted, arg)
+			if arg.AccountID == oldAccount.ID && arg.Date.Equal(date(17)) {
+				return db.BalanceSnapshot{}, db.ErrBalanceSnapshotExists
+			}
+			return db.BalanceSnapshot{}, nil
+		},
+	)
+
+	err := BackfillBalanceSnapshots(context.Background(), store, clock, date(16), date(25))
+	require.NoError(t, err)
+	require.Equal(
+		t, []db.SnapshotBalanceTxParams{
+			{AccountID: oldAccount.ID, Date: date(16)},
+			{AccountID: oldAccount.ID, Date: date(17)},
+			{AccountID: oldAccount.ID, Date: date(18)},
+			{AccountID: oldAccount.ID, Date: date(19)},
+			{AccountID: newAccount.ID, Date: date(18)},
+			{AccountID: newAccount.ID, Date: date(19)},
+		}, snapshotted,
+	)
+}
@@ -0,0 +1,52 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+)
+
+// MarkDormantAccounts marks every active customer account that has had no activity for months as dormant. Fees and
+// interest postings don't count as activity.
+func MarkDormantAccounts(store db.Store, clock util.Clock, months int) Func {
+	return func(ctx context.Context) error {
+		cutoff := clock.Now().AddDate(0, -months, 0)
+		reason := fmt.Sprintf("no activity for %d months", months)
+
+		var afterID int64
+		for {
+			accounts, err := store.GetInactiveAccounts(
+				ctx, db.GetInactiveAccountsParams{
+					CreatedAt: cutoff,
+					ID:        afterID,
+					Limit:     accountBatchSize,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, account := range accounts {
+				_, err := store.ChangeAccountStatusTx(
+					ctx, db.ChangeAccountStatusTxParams{
+						AccountID: account.ID,
+						Status:    constants.AccountStatusDormant,
+						Reason:    reason,
+						ChangedBy: constants.BankUsername,
+					},
+				)
+				if err != nil && !errors.Is(err, db.ErrInvalidStatusTransition) {
+					return fmt.Errorf("cannot mark account %d dormant: %w", account.ID, err)
+				}
+				afterID = account.ID
+			}
+
+			if len(accounts) < accountBatchSize {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMarkDormantAccounts(t *testing.T) {
+	now := time.Date(2023, time.March, 15, 12, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2022, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	accounts := make([]db.Account, accountBatchSize)
+	for i := range accounts {
+		accounts[i] = db.Account{ID: int64(i + 1), Status: constants.AccountStatusActive}
+	}
+	last := db.Account{ID: accountBatchSize + 1, Status: constants.AccountStatusActive}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().GetInactiveAccounts(
+						gomock.Any(), gomock.Eq(
+							db.GetInactiveAccountsParams{
+								CreatedAt: cutoff,
+								Limit:     accountBatchSize,
+							},
+						),
+					).Times(1).Return(accounts, nil),
+					store.EXPECT().GetInactiveAccounts(
+						gomock.Any(), gomock.Eq(
+							db.GetInactiveAccountsParams{
+								CreatedAt: cutoff,
+								ID:        accountBatchSize,
+								Limit:     accountBatchSize,
+							},
+						),
+					).Times(1).Return([]db.Account{last}, nil),
+				)
+
+				store.EXPECT().ChangeAccountStatusTx(gomock.Any(), gomock.Any()).Times(accountBatchSize).Return(
+					db.ChangeAccountStatusTxResult{}, nil,
+				)
+				arg := db.ChangeAccountStatusTxParams{
+					AccountID: last.ID,
+					Status:    constants.AccountStatusDormant,
+					Reason:    "no activity for 12 months",
+					ChangedBy: constants.BankUsername,
+				}
+				store.EXPECT().ChangeAccountStatusTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					db.ChangeAccountStatusTxResult{}, db.ErrInvalidStatusTransition,
+				)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetInactiveAccounts(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.Account{last}, nil,
+				)
+				store.EXPECT().ChangeAccountStatusTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.ChangeAccountStatusTxResult{}, errors.New("boom"),
+				)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				err := MarkDormantAccounts(store, util.NewFakeClock(now), 12)(context.Background())
+				tc.checkError(t, err)
+			},
+		)
+	}
+}
@@ -0,0 +1,39 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"time"
+)
+
+const expireHoldsBatchSize = 100
+
+// ExpireHolds releases every active hold whose expiry has passed.
+func ExpireHolds(store db.Store) Func {
+	return func(ctx context.Context) error {
+		for {
+			holds, err := store.GetExpiredHolds(
+				ctx, db.GetExpiredHoldsParams{
+					ExpiresAt: time.Now(),
+					Limit:     expireHoldsBatchSize,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, hold := range holds {
+				_, err := store.ExpireHoldTx(ctx, hold.ID)
+				if err != nil && !errors.Is(err, db.ErrHoldNotActive) {
+					return fmt.Errorf("cannot expire hold %d: %w", hold.ID, err)
+				}
+			}
+
+			if len(holds) < expireHoldsBatchSize {
+				return nil
+			}
+		}
+	}
+}
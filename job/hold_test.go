@@ -0,0 +1,79 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExpireHolds(t *testing.T) {
+	holds := []db.Hold{
+		{ID: util.RandomInt(1, 1000)},
+		{ID: util.RandomInt(1001, 2000)},
+	}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExpiredHolds(gomock.Any(), gomock.Any()).Times(1).Return(holds, nil)
+				store.EXPECT().ExpireHoldTx(gomock.Any(), gomock.Eq(holds[0].ID)).Times(1).Return(holds[0], nil)
+				store.EXPECT().ExpireHoldTx(gomock.Any(), gomock.Eq(holds[1].ID)).Times(1).Return(holds[1], nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "AlreadyReleased",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExpiredHolds(gomock.Any(), gomock.Any()).Times(1).Return(holds, nil)
+				store.EXPECT().ExpireHoldTx(gomock.Any(), gomock.Eq(holds[0].ID)).Times(1).Return(
+					db.Hold{}, db.ErrHoldNotActive,
+				)
+				store.EXPECT().ExpireHoldTx(gomock.Any(), gomock.Eq(holds[1].ID)).Times(1).Return(holds[1], nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExpiredHolds(gomock.Any(), gomock.Any()).Times(1).Return(holds, nil)
+				store.EXPECT().ExpireHoldTx(gomock.Any(), gomock.Eq(holds[0].ID)).Times(1).Return(
+					db.Hold{}, sql.ErrConnDone,
+				)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				err := ExpireHolds(store)(context.Background())
+				tc.checkError(t, err)
+			},
+		)
+	}
+}
@@ -0,0 +1,93 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"time"
+)
+
+const interestBatchSize = 100
+
+// AccrueAndPostInterest accrues interest on every interest-bearing account for each day up to yesterday that has not
+// been accrued yet, then posts last month's interest once the month is over. Running it again, on the same day or
+// after missing some, only does the work that is still outstanding. Closed accounts are skipped.
+func AccrueAndPostInterest(store db.Store, clock util.Clock) Func {
+	return func(ctx context.Context) error {
+		today := util.StartOfDay(clock.Now())
+		periodEnd := util.StartOfMonth(today)
+		periodStart := periodEnd.AddDate(0, -1, 0)
+
+		for offset := int32(0); ; offset += interestBatchSize {
+			accounts, err := store.GetInterestBearingAccounts(
+				ctx, db.GetInterestBearingAccountsParams{
+					Limit:  interestBatchSize,
+					Offset: offset,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, account := range accounts {
+				if account.Status == constants.AccountStatusClosed {
+					continue
+				}
+
+				if err := accrueInterest(ctx, store, account, today); err != nil {
+					return err
+				}
+
+				_, err := store.PostInterestTx(
+					ctx, db.PostInterestTxParams{
+						AccountID:   account.ID,
+						PeriodStart: periodStart,
+						PeriodEnd:   periodEnd,
+					},
+				)
+				if err != nil && !errors.Is(err, db.ErrInterestAlreadyPosted) &&
+					!errors.Is(err, db.ErrNoInterestAccrued) {
+					return fmt.Errorf("cannot post interest on account %d: %w", account.ID, err)
+				}
+			}
+
+			if len(accounts) < interestBatchSize {
+				return nil
+			}
+		}
+	}
+}
+
+// accrueInterest accrues each day from the one after the account's last accrual, or the day it was opened, up to the
+// day before today.
+func accrueInterest(ctx context.Context, store db.Store, account db.Account, today time.Time) error {
+	day := util.StartOfDay(account.CreatedAt)
+
+	lastAccrualDate, err := store.GetLastInterestAccrualDate(ctx, account.ID)
+	switch {
+	case err == nil:
+		day = util.StartOfDay(lastAccrualDate).AddDate(0, 0, 1)
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+		_, err := store.AccrueInterestTx(
+			ctx, db.AccrueInterestTxParams{
+				AccountID: account.ID,
+				Date:      day,
+			},
+		)
+		if err != nil && !errors.Is(err, db.ErrInterestAlreadyAccrued) {
+			return fmt.Errorf(
+				"cannot accrue interest on account %d for %s: %w", account.ID, day.Format("2006-01-02"), err,
+			)
+		}
+	}
+
+	return nil
+}
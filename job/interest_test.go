@@ -0,0 +1,183 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAccrueAndPostInterest(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2023, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	account := db.Account{
+		ID:        util.RandomInt(1, 1000),
+		CreatedAt: date(time.February, 26).Add(10 * time.Hour),
+	}
+
+	expectAccrual := func(store *mockdb.MockStore, day time.Time, err error) *gomock.Call {
+		arg := db.AccrueInterestTxParams{
+			AccountID: account.ID,
+			Date:      day,
+		}
+		return store.EXPECT().AccrueInterestTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.InterestAccrual{}, err)
+	}
+
+	expectPosting := func(store *mockdb.MockStore, err error) *gomock.Call {
+		arg := db.PostInterestTxParams{
+			AccountID:   account.ID,
+			PeriodStart: date(time.February, 1),
+			PeriodEnd:   date(time.March, 1),
+		}
+		return store.EXPECT().PostInterestTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+			db.PostInterestTxResult{}, err,
+		)
+	}
+
+	testCases := []struct {
+		name       string
+		now        time.Time
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "FirstRun",
+			now:  date(time.March, 1).Add(2 * time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetInterestBearingAccounts(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.Account{account}, nil,
+				)
+				store.EXPECT().GetLastInterestAccrualDate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					time.Time{}, sql.ErrNoRows,
+				)
+				gomock.InOrder(
+					expectAccrual(store, date(time.February, 26), nil),
+					expectAccrual(store, date(time.February, 27), nil),
+					expectAccrual(store, date(time.February, 28), nil),
+					expectPosting(store, nil),
+				)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Rerun",
+			now:  date(time.March, 1).Add(3 * time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetInterestBearingAccounts(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.Account{account}, nil,
+				)
+				store.EXPECT().GetLastInterestAccrualDate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					date(time.February, 28), nil,
+				)
+				store.EXPECT().AccrueInterestTx(gomock.Any(), gomock.Any()).Times(0)
+				expectPosting(store, db.ErrInterestAlreadyPosted)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "ConcurrentAccrual",
+			now:  date(time.March, 1).Add(3 * time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetInterestBearingAccounts(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.Account{account}, nil,
+				)
+				store.EXPECT().GetLastInterestAccrualDate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					date(time.February, 27), nil,
+				)
+				expectAccrual(store, date(time.February, 28), db.ErrInterestAlreadyAccrued)
+				expectPosting(store, db.ErrNoInterestAccrued)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InternalError",
+			now:  date(time.March, 1).Add(2 * time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetInterestBearingAccounts(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.Account{account}, nil,
+				)
+				store.EXPECT().GetLastInterestAccrualDate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+					date(time.February, 27), nil,
+				)
+				expectAccrual(store, date(time.February, 28), sql.ErrConnDone)
+				store.EXPECT().PostInterestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				clock := util.NewFakeClock(tc.now)
+				err := AccrueAndPostInterest(store, clock)(context.Background())
+				tc.checkError(t, err)
+			},
+		)
+	}
+}
+
+func TestAccrueAndPostInterestMidMonth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	clock := util.NewFakeClock(time.Date(2023, time.March, 15, 1, 0, 0, 0, time.UTC))
+	account := db.Account{ID: util.RandomInt(1, 1000)}
+
+	store.EXPECT().GetInterestBearingAccounts(gomock.Any(), gomock.Any()).Times(2).Return([]db.Account{account}, nil)
+	store.EXPECT().GetLastInterestAccrualDate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+		time.Date(2023, time.March, 13, 0, 0, 0, 0, time.UTC), nil,
+	)
+	store.EXPECT().GetLastInterestAccrualDate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(
+		time.Date(2023, time.March, 14, 0, 0, 0, 0, time.UTC), nil,
+	)
+	store.EXPECT().AccrueInterestTx(
+		gomock.Any(), gomock.Eq(
+			db.AccrueInterestTxParams{
+				AccountID: account.ID,
+				Date:      time.Date(2023, time.March, 14, 0, 0, 0, 0, time.UTC),
+			},
+		),
+	).Times(1).Return(db.InterestAccrual{}, nil)
+	store.EXPECT().AccrueInterestTx(
+		gomock.Any(), gomock.Eq(
+			db.AccrueInterestTxParams{
+				AccountID: account.ID,
+				Date:      time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC),
+			},
+		),
+	).Times(1).Return(db.InterestAccrual{}, nil)
+	store.EXPECT().PostInterestTx(gomock.Any(), gomock.Any()).Times(2).Return(
+		db.PostInterestTxResult{}, db.ErrInterestAlreadyPosted,
+	)
+
+	job := AccrueAndPostInterest(store, clock)
+	require.NoError(t, job(context.Background()))
+
+	clock.Advance(24 * time.Hour)
+	require.NoError(t, job(context.Background()))
+}
@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/fee"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+)
+
+const maintenanceFeeBatchSize = 100
+
+// ChargeMaintenanceFees charges last month's maintenance fees to every account, opened before the month ended, of a
+// product that schedule has maintenance fees for. Accounts that were already charged for the month, or that aren't
+// active, are skipped.
+func ChargeMaintenanceFees(store db.Store, schedule fee.Schedule, clock util.Clock) Func {
+	return func(ctx context.Context) error {
+		periodEnd := util.StartOfMonth(clock.Now())
+		periodStart := periodEnd.AddDate(0, -1, 0)
+
+		for _, product := range schedule.MaintenanceProducts() {
+			for offset := int32(0); ; offset += maintenanceFeeBatchSize {
+				accounts, err := store.GetAccountsByProduct(
+					ctx, db.GetAccountsByProductParams{
+						Product:   product,
+						CreatedAt: periodEnd,
+						Limit:     maintenanceFeeBatchSize,
+						Offset:    offset,
+					},
+				)
+				if err != nil {
+					return err
+				}
+
+				for _, account := range accounts {
+					if account.Status != constants.AccountStatusActive {
+						continue
+					}
+
+					_, err := store.ChargeMaintenanceFeeTx(
+						ctx, db.ChargeMaintenanceFeeTxParams{
+							AccountID:   account.ID,
+							PeriodStart: periodStart,
+							PeriodEnd:   periodEnd,
+						},
+					)
+					if err != nil && !errors.Is(err, db.ErrFeeAlreadyCharged) {
+						return fmt.Errorf("cannot charge maintenance fees on account %d: %w", account.ID, err)
+					}
+				}
+
+				if len(accounts) < maintenanceFeeBatchSize {
+					break
+				}
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,134 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	"github.com/CrunchyBlue/Golang-Bank/fee"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestChargeMaintenanceFees(t *testing.T) {
+	periodStart := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	schedule := fee.Schedule{
+		MaintenanceFees: []fee.MaintenanceFee{
+			{
+				Name:           "checking_maintenance_usd",
+				Product:        constants.ProductChecking,
+				Currency:       constants.USD,
+				MinimumBalance: 150000,
+				Amount:         500,
+			},
+		},
+	}
+
+	accounts := []db.Account{
+		{ID: util.RandomInt(1, 1000), Product: constants.ProductChecking, Status: constants.AccountStatusActive},
+		{ID: util.RandomInt(1001, 2000), Product: constants.ProductChecking, Status: constants.AccountStatusActive},
+		// Not charged while frozen.
+		{ID: util.RandomInt(2001, 3000), Product: constants.ProductChecking, Status: constants.AccountStatusFrozen},
+	}
+
+	expectAccounts := func(store *mockdb.MockStore) {
+		arg := db.GetAccountsByProductParams{
+			Product:   constants.ProductChecking,
+			CreatedAt: periodEnd,
+			Limit:     maintenanceFeeBatchSize,
+			Offset:    0,
+		}
+		store.EXPECT().GetAccountsByProduct(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
+	}
+
+	expectCharge := func(store *mockdb.MockStore, account db.Account, err error) *gomock.Call {
+		arg := db.ChargeMaintenanceFeeTxParams{
+			AccountID:   account.ID,
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+		}
+		return store.EXPECT().ChargeMaintenanceFeeTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil, err)
+	}
+
+	testCases := []struct {
+		name       string
+		schedule   fee.Schedule
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name:     "OK",
+			schedule: schedule,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectAccounts(store)
+				gomock.InOrder(
+					expectCharge(store, accounts[0], nil),
+					expectCharge(store, accounts[1], nil),
+				)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:     "AlreadyCharged",
+			schedule: schedule,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectAccounts(store)
+				gomock.InOrder(
+					expectCharge(store, accounts[0], db.ErrFeeAlreadyCharged),
+					expectCharge(store, accounts[1], nil),
+				)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:     "InternalError",
+			schedule: schedule,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectAccounts(store)
+				expectCharge(store, accounts[0], sql.ErrConnDone)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+		{
+			name:     "NoMaintenanceFees",
+			schedule: fee.Schedule{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountsByProduct(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ChargeMaintenanceFeeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				clock := util.NewFakeClock(periodEnd.Add(26 * time.Hour))
+				err := ChargeMaintenanceFees(store, tc.schedule, clock)(context.Background())
+				tc.checkError(t, err)
+			},
+		)
+	}
+}
@@ -0,0 +1,30 @@
+package job
+
+import (
+	"context"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+)
+
+const expirePaymentRequestsBatchSize = 100
+
+// ExpirePaymentRequests marks every pending payment request whose expiry has passed as expired.
+func ExpirePaymentRequests(store db.Store, clock util.Clock) Func {
+	return func(ctx context.Context) error {
+		for {
+			expired, err := store.ExpirePaymentRequests(
+				ctx, db.ExpirePaymentRequestsParams{
+					ExpiresAt: clock.Now(),
+					Limit:     expirePaymentRequestsBatchSize,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			if len(expired) < expirePaymentRequestsBatchSize {
+				return nil
+			}
+		}
+	}
+}
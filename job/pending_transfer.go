@@ -0,0 +1,30 @@
+package job
+
+import (
+	"context"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+)
+
+const expirePendingTransfersBatchSize = 100
+
+// ExpirePendingTransfers marks every pending organization transfer whose approval window has passed as expired.
+func ExpirePendingTransfers(store db.Store, clock util.Clock) Func {
+	return func(ctx context.Context) error {
+		for {
+			expired, err := store.ExpirePendingTransfers(
+				ctx, db.ExpirePendingTransfersParams{
+					ExpiresAt: clock.Now(),
+					Limit:     expirePendingTransfersBatchSize,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			if len(expired) < expirePendingTransfersBatchSize {
+				return nil
+			}
+		}
+	}
+}
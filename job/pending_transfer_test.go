@@ -0,0 +1,69 @@
+package job
+
+import (
+	"context"
+	"errors"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestExpirePendingTransfers(t *testing.T) {
+	now := time.Date(2023, time.March, 15, 12, 0, 0, 0, time.UTC)
+	arg := db.ExpirePendingTransfersParams{ExpiresAt: now, Limit: expirePendingTransfersBatchSize}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().ExpirePendingTransfers(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+						make([]db.PendingTransfer, expirePendingTransfersBatchSize), nil,
+					),
+					store.EXPECT().ExpirePendingTransfers(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+						[]db.PendingTransfer{{ID: 1}}, nil,
+					),
+				)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExpirePendingTransfers(gomock.Any(), gomock.Any()).Times(1).Return(
+					nil, errors.New("boom"),
+				)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				err := ExpirePendingTransfers(store, util.NewFakeClock(now))(context.Background())
+				tc.checkError(t, err)
+			},
+		)
+	}
+}
@@ -0,0 +1,234 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+)
+
+const reconciliationBatchSize = 500
+
+// Reconcile runs ReconcileLedger and fails if it found any discrepancies, so that the scheduler logs them.
+func Reconcile(store db.Store) Func {
+	return func(ctx context.Context) error {
+		run, err := ReconcileLedger(ctx, store)
+		if err != nil {
+			return err
+		}
+
+		if run.DiscrepancyCount > 0 {
+			return fmt.Errorf("reconciliation run %d found %d discrepancies", run.ID, run.DiscrepancyCount)
+		}
+		return nil
+	}
+}
+
+// ReconcileLedger checks that every account's balance equals the sum of its entries, that every transfer has a
+// matching debit and credit entry, and that no transfer journal transaction has lost its transfer. Each broken
+// invariant is written to the run's report rather than stopping the scan.
+func ReconcileLedger(ctx context.Context, store db.Store) (db.ReconciliationRun, error) {
+	run, err := store.CreateReconciliationRun(ctx)
+	if err != nil {
+		return run, err
+	}
+
+	r := &reconciler{store: store, runID: run.ID}
+	for _, check := range []func(context.Context) error{r.checkBalances, r.checkTransfers, r.checkJournals} {
+		if err := check(ctx); err != nil {
+			return run, fmt.Errorf("reconciliation run %d: %w", run.ID, err)
+		}
+	}
+
+	return store.CompleteReconciliationRun(
+		ctx, db.CompleteReconciliationRunParams{
+			ID:               run.ID,
+			AccountsChecked:  r.accountsChecked,
+			TransfersChecked: r.transfersChecked,
+			DiscrepancyCount: r.discrepancyCount,
+		},
+	)
+}
+
+type reconciler struct {
+	store            db.Store
+	runID            int64
+	accountsChecked  int64
+	transfersChecked int64
+	discrepancyCount int64
+}
+
+func (r *reconciler) report(ctx context.Context, arg db.CreateReconciliationDiscrepancyParams) error {
+	arg.RunID = r.runID
+	if _, err := r.store.CreateReconciliationDiscrepancy(ctx, arg); err != nil {
+		return err
+	}
+
+	r.discrepancyCount++
+	return nil
+}
+
+func (r *reconciler) checkBalances(ctx context.Context) error {
+	for afterID := int64(0); ; {
+		totals, err := r.store.GetAccountEntryTotals(
+			ctx, db.GetAccountEntryTotalsParams{
+				ID:    afterID,
+				Limit: reconciliationBatchSize,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, total := range totals {
+			if total.Balance != total.EntryTotal {
+				err := r.report(
+					ctx, db.CreateReconciliationDiscrepancyParams{
+						Kind:      constants.DiscrepancyBalanceMismatch,
+						AccountID: validInt64(total.ID),
+						Expected:  validInt64(total.EntryTotal),
+						Actual:    validInt64(total.Balance),
+						Detail: fmt.Sprintf(
+							"account %d has a balance of %d but its entries sum to %d",
+							total.ID, total.Balance, total.EntryTotal,
+						),
+					},
+				)
+				if err != nil {
+					return err
+				}
+			}
+			afterID = total.ID
+		}
+		r.accountsChecked += int64(len(totals))
+
+		if len(totals) < reconciliationBatchSize {
+			return nil
+		}
+	}
+}
+
+func (r *reconciler) checkTransfers(ctx context.Context) error {
+	for afterID := int64(0); ; {
+		matches, err := r.store.GetTransferEntryMatches(
+			ctx, db.GetTransferEntryMatchesParams{
+				ID:    afterID,
+				Limit: reconciliationBatchSize,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, match := range matches {
+			err := r.checkTransferEntry(
+				ctx, match, match.SourceAccountID, match.SourceEntryID, match.SourceEntryAmount, -match.Amount,
+			)
+			if err != nil {
+				return err
+			}
+
+			err = r.checkTransferEntry(
+				ctx, match, match.DestinationAccountID, match.DestinationEntryID, match.DestinationEntryAmount,
+				match.Amount,
+			)
+			if err != nil {
+				return err
+			}
+			afterID = match.ID
+		}
+		r.transfersChecked += int64(len(matches))
+
+		if len(matches) < reconciliationBatchSize {
+			return nil
+		}
+	}
+}
+
+func (r *reconciler) checkTransferEntry(
+	ctx context.Context, match db.GetTransferEntryMatchesRow, accountID int64, entryID sql.NullInt64,
+	entryAmount sql.NullInt64, expected int64,
+) error {
+	if !entryID.Valid {
+		return r.report(
+			ctx, db.CreateReconciliationDiscrepancyParams{
+				Kind:                 constants.DiscrepancyTransferMissingEntry,
+				AccountID:            validInt64(accountID),
+				TransferID:           validInt64(match.ID),
+				JournalTransactionID: match.JournalTransactionID,
+				Expected:             validInt64(expected),
+				Detail:               fmt.Sprintf("transfer %d has no entry of %d on account %d", match.ID, expected, accountID),
+			},
+		)
+	}
+
+	if entryAmount.Int64 != expected {
+		return r.report(
+			ctx, db.CreateReconciliationDiscrepancyParams{
+				Kind:                 constants.DiscrepancyTransferEntryMismatch,
+				AccountID:            validInt64(accountID),
+				TransferID:           validInt64(match.ID),
+				EntryID:              entryID,
+				JournalTransactionID: match.JournalTransactionID,
+				Expected:             validInt64(expected),
+				Actual:               entryAmount,
+				Detail: fmt.Sprintf(
+					"transfer %d expects %d on account %d but entry %d is for %d",
+					match.ID, expected, accountID, entryID.Int64, entryAmount.Int64,
+				),
+			},
+		)
+	}
+
+	return nil
+}
+
+func (r *reconciler) checkJournals(ctx context.Context) error {
+	for afterID := int64(0); ; {
+		journals, err := r.store.GetJournalTransactionsMissingTransfer(
+			ctx, db.GetJournalTransactionsMissingTransferParams{
+				ID:    afterID,
+				Limit: reconciliationBatchSize,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, journal := range journals {
+			entries, err := r.store.GetJournalTransactionEntries(ctx, validInt64(journal.ID))
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range entries {
+				err := r.report(
+					ctx, db.CreateReconciliationDiscrepancyParams{
+						Kind:                 constants.DiscrepancyJournalMissingTransfer,
+						AccountID:            validInt64(entry.AccountID),
+						EntryID:              validInt64(entry.ID),
+						JournalTransactionID: validInt64(journal.ID),
+						Actual:               validInt64(entry.Amount),
+						Detail: fmt.Sprintf(
+							"entry %d belongs to transfer journal transaction %d, which has no transfer",
+							entry.ID, journal.ID,
+						),
+					},
+				)
+				if err != nil {
+					return err
+				}
+			}
+			afterID = journal.ID
+		}
+
+		if len(journals) < reconciliationBatchSize {
+			return nil
+		}
+	}
+}
+
+func validInt64(v int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: v, Valid: true}
+}
@@ -0,0 +1,149 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReconcileLedger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	run := db.ReconciliationRun{ID: 7}
+
+	store.EXPECT().CreateReconciliationRun(gomock.Any()).Times(1).Return(run, nil)
+
+	store.EXPECT().GetAccountEntryTotals(
+		gomock.Any(), gomock.Eq(db.GetAccountEntryTotalsParams{ID: 0, Limit: reconciliationBatchSize}),
+	).Times(1).Return(
+		[]db.GetAccountEntryTotalsRow{
+			{ID: 1, Balance: 500, EntryTotal: 500},
+			{ID: 2, Balance: 507, EntryTotal: 500},
+		}, nil,
+	)
+
+	store.EXPECT().GetTransferEntryMatches(
+		gomock.Any(), gomock.Eq(db.GetTransferEntryMatchesParams{ID: 0, Limit: reconciliationBatchSize}),
+	).Times(1).Return(
+		[]db.GetTransferEntryMatchesRow{
+			{
+				ID:                     10,
+				SourceAccountID:        1,
+				DestinationAccountID:   2,
+				Amount:                 100,
+				JournalTransactionID:   validInt64(20),
+				SourceEntryID:          validInt64(30),
+				SourceEntryAmount:      validInt64(-100),
+				DestinationEntryID:     validInt64(31),
+				DestinationEntryAmount: validInt64(100),
+			},
+			{
+				ID:                     11,
+				SourceAccountID:        1,
+				DestinationAccountID:   2,
+				Amount:                 150,
+				JournalTransactionID:   validInt64(21),
+				SourceEntryID:          validInt64(32),
+				SourceEntryAmount:      validInt64(-100),
+				DestinationEntryID:     validInt64(33),
+				DestinationEntryAmount: validInt64(100),
+			},
+			{
+				ID:                   12,
+				SourceAccountID:      1,
+				DestinationAccountID: 2,
+				Amount:               50,
+			},
+		}, nil,
+	)
+
+	store.EXPECT().GetJournalTransactionsMissingTransfer(
+		gomock.Any(), gomock.Eq(db.GetJournalTransactionsMissingTransferParams{ID: 0, Limit: reconciliationBatchSize}),
+	).Times(1).Return([]db.JournalTransaction{{ID: 22, Kind: constants.JournalKindTransfer}}, nil)
+	store.EXPECT().GetJournalTransactionEntries(gomock.Any(), gomock.Eq(validInt64(22))).Times(1).Return(
+		[]db.Entry{
+			{ID: 34, AccountID: 1, Amount: -80},
+			{ID: 35, AccountID: 2, Amount: 80},
+		}, nil,
+	)
+
+	var reported []db.CreateReconciliationDiscrepancyParams
+	store.EXPECT().CreateReconciliationDiscrepancy(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, arg db.CreateReconciliationDiscrepancyParams) (db.ReconciliationDiscrepancy, error) {
+			reported = append(reported, arg)
+			return db.ReconciliationDiscrepancy{}, nil
+		},
+	)
+
+	completed := db.CompleteReconciliationRunParams{
+		ID:               run.ID,
+		AccountsChecked:  2,
+		TransfersChecked: 3,
+		DiscrepancyCount: 7,
+	}
+	store.EXPECT().CompleteReconciliationRun(gomock.Any(), gomock.Eq(completed)).Times(1).Return(
+		db.ReconciliationRun{ID: run.ID, DiscrepancyCount: 7}, nil,
+	)
+
+	result, err := ReconcileLedger(context.Background(), store)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), result.DiscrepancyCount)
+
+	type found struct {
+		kind       string
+		accountID  sql.NullInt64
+		transferID sql.NullInt64
+		entryID    sql.NullInt64
+	}
+	var got []found
+	for _, arg := range reported {
+		require.Equal(t, run.ID, arg.RunID)
+		got = append(got, found{arg.Kind, arg.AccountID, arg.TransferID, arg.EntryID})
+	}
+
+	require.Equal(
+		t, []found{
+			{constants.DiscrepancyBalanceMismatch, validInt64(2), sql.NullInt64{}, sql.NullInt64{}},
+			{constants.DiscrepancyTransferEntryMismatch, validInt64(1), validInt64(11), validInt64(32)},
+			{constants.DiscrepancyTransferEntryMismatch, validInt64(2), validInt64(11), validInt64(33)},
+			{constants.DiscrepancyTransferMissingEntry, validInt64(1), validInt64(12), sql.NullInt64{}},
+			{constants.DiscrepancyTransferMissingEntry, validInt64(2), validInt64(12), sql.NullInt64{}},
+			{constants.DiscrepancyJournalMissingTransfer, validInt64(1), sql.NullInt64{}, validInt64(34)},
+			{constants.DiscrepancyJournalMissingTransfer, validInt64(2), sql.NullInt64{}, validInt64(35)},
+		}, got,
+	)
+	require.Equal(t, validInt64(500), reported[0].Expected)
+	require.Equal(t, validInt64(507), reported[0].Actual)
+}
+
+func TestReconcile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().CreateReconciliationRun(gomock.Any()).Times(2).Return(db.ReconciliationRun{ID: 1}, nil)
+	store.EXPECT().GetAccountEntryTotals(gomock.Any(), gomock.Any()).Times(2).Return(
+		[]db.GetAccountEntryTotalsRow{{ID: 1, Balance: 10, EntryTotal: 10}}, nil,
+	)
+	store.EXPECT().GetTransferEntryMatches(gomock.Any(), gomock.Any()).Times(2).Return(nil, nil)
+	store.EXPECT().GetJournalTransactionsMissingTransfer(gomock.Any(), gomock.Any()).Times(2).Return(nil, nil)
+	gomock.InOrder(
+		store.EXPECT().CompleteReconciliationRun(gomock.Any(), gomock.Any()).Times(1).Return(
+			db.ReconciliationRun{ID: 1}, nil,
+		),
+		store.EXPECT().CompleteReconciliationRun(gomock.Any(), gomock.Any()).Times(1).Return(
+			db.ReconciliationRun{ID: 1, DiscrepancyCount: 1}, nil,
+		),
+	)
+
+	job := Reconcile(store)
+	require.NoError(t, job(context.Background()))
+	require.Error(t, job(context.Background()))
+}
@@ -0,0 +1,19 @@
+package job
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/risk"
+	"log"
+)
+
+// ReloadRiskRules picks up changes to the risk rules file. Rules that fail to load are reported and the engine keeps
+// the ones it had.
+func ReloadRiskRules(engine *risk.Engine) Func {
+	return func(ctx context.Context) error {
+		reloaded, err := engine.Reload()
+		if reloaded {
+			log.Print("reloaded risk rules")
+		}
+		return err
+	}
+}
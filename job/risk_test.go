@@ -0,0 +1,32 @@
+package job
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/risk"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReloadRiskRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "risk.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": [{"name": "a", "signal": "new_payee"}]}`), 0600))
+
+	engine, err := risk.NewEngine(path)
+	require.NoError(t, err)
+
+	reload := ReloadRiskRules(engine)
+	require.NoError(t, reload(context.Background()))
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": []}`), 0600))
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	require.NoError(t, reload(context.Background()))
+	require.True(t, engine.Rules().Empty())
+
+	require.NoError(t, os.Remove(path))
+	require.Error(t, reload(context.Background()))
+}
@@ -0,0 +1,35 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"time"
+)
+
+const scheduledTransfersBatchSize = 100
+
+// ExecuteScheduledTransfers runs the scheduled transfers that are due, at most one batch per call. Each run locks its
+// row with SKIP LOCKED, so replicas running this job at the same time never fire the same transfer twice.
+func ExecuteScheduledTransfers(store db.Store, retryInterval time.Duration, maxAttempts int32) Func {
+	return func(ctx context.Context) error {
+		for i := 0; i < scheduledTransfersBatchSize; i++ {
+			_, err := store.RunDueScheduledTransferTx(
+				ctx, db.RunDueScheduledTransferTxParams{
+					Now:           time.Now(),
+					RetryInterval: retryInterval,
+					MaxAttempts:   maxAttempts,
+				},
+			)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,76 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestExecuteScheduledTransfers(t *testing.T) {
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().RunDueScheduledTransferTx(gomock.Any(), gomock.Any()).Times(2).Return(
+						db.RunDueScheduledTransferTxResult{}, nil,
+					),
+					store.EXPECT().RunDueScheduledTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(
+						db.RunDueScheduledTransferTxResult{}, sql.ErrNoRows,
+					),
+				)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "FullBatch",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RunDueScheduledTransferTx(gomock.Any(), gomock.Any()).
+					Times(scheduledTransfersBatchSize).
+					Return(db.RunDueScheduledTransferTxResult{}, nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RunDueScheduledTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(
+					db.RunDueScheduledTransferTxResult{}, sql.ErrConnDone,
+				)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				err := ExecuteScheduledTransfers(store, time.Hour, 3)(context.Background())
+				tc.checkError(t, err)
+			},
+		)
+	}
+}
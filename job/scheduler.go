@@ -0,0 +1,61 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type Func func(ctx context.Context) error
+
+type Scheduler struct {
+	jobs []scheduledJob
+}
+
+type scheduledJob struct {
+	name     string
+	interval time.Duration
+	fn       Func
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every registers fn to run once per interval. A non-positive interval leaves the job disabled.
+func (scheduler *Scheduler) Every(name string, interval time.Duration, fn Func) {
+	if interval <= 0 {
+		return
+	}
+
+	scheduler.jobs = append(
+		scheduler.jobs, scheduledJob{
+			name:     name,
+			interval: interval,
+			fn:       fn,
+		},
+	)
+}
+
+// Start runs each registered job in its own goroutine until ctx is cancelled.
+func (scheduler *Scheduler) Start(ctx context.Context) {
+	for _, j := range scheduler.jobs {
+		go j.run(ctx)
+	}
+}
+
+func (j scheduledJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.fn(ctx); err != nil {
+				log.Printf("job %s failed: %v", j.name, err)
+			}
+		}
+	}
+}
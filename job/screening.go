@@ -0,0 +1,176 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/screening"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"log"
+)
+
+const screeningBatchSize = 100
+
+// ReloadSanctionsList picks up changes to the watchlist file and screens every user, organization and payee again
+// against the new list. A list that fails to load is reported and the screener keeps the one it had; a rescreen that
+// fails part way is run again from the start next time.
+func ReloadSanctionsList(store db.Store, screener *screening.Screener) Func {
+	var rescreenPending bool
+
+	return func(ctx context.Context) error {
+		reloaded, err := screener.Reload()
+		if err != nil {
+			return err
+		}
+		if reloaded {
+			log.Print("reloaded sanctions list")
+			rescreenPending = true
+		}
+		if !rescreenPending {
+			return nil
+		}
+
+		hits, err := rescreenUsers(ctx, store, screener)
+		if err != nil {
+			return err
+		}
+		organizationHits, err := rescreenOrganizations(ctx, store, screener)
+		if err != nil {
+			return err
+		}
+		payeeHits, err := rescreenPayees(ctx, store, screener)
+		if err != nil {
+			return err
+		}
+
+		rescreenPending = false
+		if total := hits + organizationHits + payeeHits; total > 0 {
+			log.Printf("raised %d screening hits", total)
+		}
+		return nil
+	}
+}
+
+func rescreenUsers(ctx context.Context, store db.Store, screener *screening.Screener) (int, error) {
+	var hits int
+	var afterUsername string
+	for {
+		users, err := store.GetUsersForScreening(
+			ctx, db.GetUsersForScreeningParams{
+				Username: afterUsername,
+				Limit:    screeningBatchSize,
+			},
+		)
+		if err != nil {
+			return hits, err
+		}
+
+		for _, user := range users {
+			if matches := screener.Screen(user.FullName); len(matches) > 0 {
+				recorded, err := store.RecordScreeningHitsTx(
+					ctx, db.RecordScreeningHitsTxParams{
+						Username:     user.Username,
+						ScreenedName: user.FullName,
+						Matches:      matches,
+					},
+				)
+				if err != nil {
+					return hits, fmt.Errorf("cannot record screening hits for user %s: %w", user.Username, err)
+				}
+				hits += len(recorded)
+			}
+			afterUsername = user.Username
+		}
+
+		if len(users) < screeningBatchSize {
+			return hits, nil
+		}
+	}
+}
+
+// rescreenOrganizations screens the name each organization holds its accounts under. Hits are raised against the user
+// who created it.
+func rescreenOrganizations(ctx context.Context, store db.Store, screener *screening.Screener) (int, error) {
+	var hits int
+	var afterID int64
+	for {
+		organizations, err := store.GetOrganizationsForScreening(
+			ctx, db.GetOrganizationsForScreeningParams{
+				ID:    afterID,
+				Limit: screeningBatchSize,
+			},
+		)
+		if err != nil {
+			return hits, err
+		}
+
+		for _, organization := range organizations {
+			if matches := screener.Screen(organization.Name); len(matches) > 0 {
+				recorded, err := store.RecordScreeningHitsTx(
+					ctx, db.RecordScreeningHitsTxParams{
+						Username:       organization.CreatedBy,
+						OrganizationID: sql.NullInt64{Int64: organization.ID, Valid: true},
+						ScreenedName:   organization.Name,
+						Matches:        matches,
+					},
+				)
+				if err != nil {
+					return hits, fmt.Errorf(
+						"cannot record screening hits for organization %d: %w", organization.ID, err,
+					)
+				}
+				hits += len(recorded)
+			}
+			afterID = organization.ID
+		}
+
+		if len(organizations) < screeningBatchSize {
+			return hits, nil
+		}
+	}
+}
+
+// rescreenPayees screens the name each payee's account is held under, and the name its owner expected if they gave
+// one.
+func rescreenPayees(ctx context.Context, store db.Store, screener *screening.Screener) (int, error) {
+	var hits int
+	var afterID int64
+	for {
+		payees, err := store.GetPayeesForScreening(
+			ctx, db.GetPayeesForScreeningParams{
+				ID:    afterID,
+				Limit: screeningBatchSize,
+			},
+		)
+		if err != nil {
+			return hits, err
+		}
+
+		for _, payee := range payees {
+			for _, name := range []string{payee.HolderName, payee.ExpectedName.String} {
+				matches := screener.Screen(name)
+				if len(matches) == 0 {
+					continue
+				}
+
+				recorded, err := store.RecordScreeningHitsTx(
+					ctx, db.RecordScreeningHitsTxParams{
+						Username:     payee.Owner,
+						PayeeID:      sql.NullInt64{Int64: payee.ID, Valid: true},
+						ScreenedName: name,
+						Matches:      matches,
+					},
+				)
+				if err != nil {
+					return hits, fmt.Errorf("cannot record screening hits for payee %d: %w", payee.ID, err)
+				}
+				hits += len(recorded)
+			}
+			afterID = payee.ID
+		}
+
+		if len(payees) < screeningBatchSize {
+			return hits, nil
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	"github.com/CrunchyBlue/Golang-Bank/screening"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReloadSanctionsList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	require.NoError(t, os.WriteFile(path, []byte(`1,"SMITH, John","individual","[SDGT]"`), 0600))
+
+	screener, err := screening.NewScreener(path, 90)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	reload := ReloadSanctionsList(store, screener)
+
+	// Nothing to do until the list changes
+	store.EXPECT().GetUsersForScreening(gomock.Any(), gomock.Any()).Times(0)
+	require.NoError(t, reload(context.Background()))
+
+	later := time.Now().Add(time.Minute)
+	data := `1,"SMITH, John","individual","[SDGT]"` + "\n" + `2,"HAMADA, Hoda Salih","individual","[SDGT]"`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	users := []db.User{{Username: "hoda", FullName: "Hoda Salih Hamada"}, {Username: "jane", FullName: "Jane Doe"}}
+	organizations := []db.Organization{{ID: 3, Name: "Hoda Salih Hamada", CreatedBy: "jane"}}
+	payees := []db.GetPayeesForScreeningRow{
+		{
+			ID:           7,
+			Owner:        "jane",
+			ExpectedName: sql.NullString{String: "J. Smith", Valid: true},
+			HolderName:   "John Smith",
+		},
+	}
+
+	gomock.InOrder(
+		store.EXPECT().GetUsersForScreening(
+			gomock.Any(), gomock.Eq(db.GetUsersForScreeningParams{Limit: screeningBatchSize}),
+		).Times(1).Return(users, nil),
+		store.EXPECT().RecordScreeningHitsTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(_ interface{}, arg db.RecordScreeningHitsTxParams) ([]db.ScreeningHit, error) {
+				require.Equal(t, "hoda", arg.Username)
+				require.False(t, arg.PayeeID.Valid)
+				require.Equal(t, "2", arg.Matches[0].Entry.ID)
+				return []db.ScreeningHit{{ID: 1}}, nil
+			},
+		),
+		store.EXPECT().GetOrganizationsForScreening(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil),
+		// The failure leaves the rescreen to be run again, even though the list won't have changed
+		store.EXPECT().GetPayeesForScreening(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone),
+		store.EXPECT().GetUsersForScreening(gomock.Any(), gomock.Any()).Times(1).Return(users, nil),
+		store.EXPECT().RecordScreeningHitsTx(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil),
+		store.EXPECT().GetOrganizationsForScreening(
+			gomock.Any(), gomock.Eq(db.GetOrganizationsForScreeningParams{Limit: screeningBatchSize}),
+		).Times(1).Return(organizations, nil),
+		// The organization's hits are raised against the user who created it
+		store.EXPECT().RecordScreeningHitsTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(_ interface{}, arg db.RecordScreeningHitsTxParams) ([]db.ScreeningHit, error) {
+				require.Equal(t, "jane", arg.Username)
+				require.Equal(t, sql.NullInt64{Int64: 3, Valid: true}, arg.OrganizationID)
+				require.False(t, arg.PayeeID.Valid)
+				require.Equal(t, "Hoda Salih Hamada", arg.ScreenedName)
+				return []db.ScreeningHit{{ID: 2}}, nil
+			},
+		),
+		store.EXPECT().GetPayeesForScreening(
+			gomock.Any(), gomock.Eq(db.GetPayeesForScreeningParams{Limit: screeningBatchSize}),
+		).Times(1).Return(payees, nil),
+		// Only the holder's name matches; the expected name is too far from it
+		store.EXPECT().RecordScreeningHitsTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(_ interface{}, arg db.RecordScreeningHitsTxParams) ([]db.ScreeningHit, error) {
+				require.Equal(t, "jane", arg.Username)
+				require.Equal(t, sql.NullInt64{Int64: 7, Valid: true}, arg.PayeeID)
+				require.Equal(t, "John Smith", arg.ScreenedName)
+				return []db.ScreeningHit{{ID: 3}}, nil
+			},
+		),
+	)
+
+	require.ErrorIs(t, reload(context.Background()), sql.ErrConnDone)
+	require.NoError(t, reload(context.Background()))
+	require.NoError(t, reload(context.Background()))
+}
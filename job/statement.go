@@ -0,0 +1,42 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+)
+
+// GenerateMonthlyStatements stores last month's statement for every customer account opened before the month ended.
+// Accounts that already have one are skipped.
+func GenerateMonthlyStatements(store db.Store, clock util.Clock) Func {
+	return func(ctx context.Context) error {
+		periodEnd := util.StartOfMonth(clock.Now().Add(-dayCloseDelay))
+		periodStart := periodEnd.AddDate(0, -1, 0)
+
+		return forEachAccountOpenedBefore(
+			ctx, store, periodEnd, func(account db.Account) error {
+				if account.Product == constants.ProductInternal {
+					return nil
+				}
+
+				_, err := store.CreateStatementTx(
+					ctx, db.StatementParams{
+						AccountID:   account.ID,
+						PeriodStart: periodStart,
+						PeriodEnd:   periodEnd,
+					},
+				)
+				if err != nil && !errors.Is(err, db.ErrStatementExists) {
+					return fmt.Errorf(
+						"cannot generate %s statement for account %d: %w", periodStart.Format("2006-01"), account.ID,
+						err,
+					)
+				}
+				return nil
+			},
+		)
+	}
+}
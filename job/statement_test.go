@@ -0,0 +1,104 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	mockdb "github.com/CrunchyBlue/Golang-Bank/db/mock"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestGenerateMonthlyStatements(t *testing.T) {
+	periodStart := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	checking := db.Account{ID: 1, Product: constants.ProductChecking}
+	savings := db.Account{ID: 2, Product: constants.ProductSavings}
+	internal := db.Account{ID: 3, Product: constants.ProductInternal}
+
+	expectStatement := func(store *mockdb.MockStore, account db.Account, err error) *gomock.Call {
+		arg := db.StatementParams{
+			AccountID:   account.ID,
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+		}
+		return store.EXPECT().CreateStatementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Statement{}, err)
+	}
+
+	testCases := []struct {
+		name       string
+		now        time.Time
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			now:  periodEnd.Add(2 * time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetAccountsOpenedBeforeParams{
+					CreatedAt: periodEnd,
+					Limit:     accountBatchSize,
+				}
+				store.EXPECT().GetAccountsOpenedBefore(gomock.Any(), gomock.Eq(arg)).Times(1).Return(
+					[]db.Account{checking, savings, internal}, nil,
+				)
+				expectStatement(store, checking, nil)
+				expectStatement(store, savings, db.ErrStatementExists)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "BeforeMonthHasClosed",
+			now:  periodEnd.Add(30 * time.Minute),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetAccountsOpenedBeforeParams{
+					CreatedAt: periodStart,
+					Limit:     accountBatchSize,
+				}
+				store.EXPECT().GetAccountsOpenedBefore(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil, nil)
+				store.EXPECT().CreateStatementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InternalError",
+			now:  periodEnd.Add(2 * time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountsOpenedBefore(gomock.Any(), gomock.Any()).Times(1).Return(
+					[]db.Account{checking, savings}, nil,
+				)
+				expectStatement(store, checking, sql.ErrConnDone)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(
+			tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				store := mockdb.NewMockStore(ctrl)
+				tc.buildStubs(store)
+
+				clock := util.NewFakeClock(tc.now)
+				err := GenerateMonthlyStatements(store, clock)(context.Background())
+				tc.checkError(t, err)
+			},
+		)
+	}
+}
@@ -0,0 +1,42 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
+	"time"
+)
+
+const processTransferBatchesBatchSize = 10
+
+// ProcessTransferBatches runs every pending transfer batch, oldest first. Batches too large to run while the caller
+// waits are left pending by the API for this job to pick up. A batch whose run was interrupted is run again once its
+// claim has timed out.
+func ProcessTransferBatches(store db.Store) Func {
+	return func(ctx context.Context) error {
+		for {
+			batches, err := store.GetRunnableTransferBatches(
+				ctx, db.GetRunnableTransferBatchesParams{
+					StartedAt: sql.NullTime{Time: time.Now().Add(-db.TransferBatchClaimTimeout), Valid: true},
+					Limit:     processTransferBatchesBatchSize,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, batch := range batches {
+				_, err := store.RunTransferBatch(ctx, batch.ID)
+				if err != nil && !errors.Is(err, db.ErrTransferBatchNotPending) {
+					return fmt.Errorf("cannot run transfer batch %d: %w", batch.ID, err)
+				}
+			}
+
+			if len(batches) < processTransferBatchesBatchSize {
+				return nil
+			}
+		}
+	}
+}
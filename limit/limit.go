@@ -0,0 +1,160 @@
+package limit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	"os"
+	"time"
+)
+
+var ErrExceeded = errors.New("transfer limit exceeded")
+
+// Limits caps what a user can transfer out of their accounts in one currency. A zero limit is no limit.
+type Limits struct {
+	PerTransfer int64 `json:"per_transfer"`
+	// Per calendar day, in UTC
+	Daily int64 `json:"daily"`
+	// Per calendar month, in UTC
+	Monthly int64 `json:"monthly"`
+	// Transfers in any hour
+	HourlyCount int64 `json:"hourly_count"`
+}
+
+// TierLimits are the limits of every user in a tier, for transfers in one currency.
+type TierLimits struct {
+	Tier     string `json:"tier"`
+	Currency string `json:"currency"`
+	Limits
+}
+
+type Schedule struct {
+	Tiers []TierLimits `json:"tiers"`
+}
+
+// Usage is what a user has already transferred in a currency, in the windows the limits cover.
+type Usage struct {
+	Daily       int64 `json:"daily"`
+	Monthly     int64 `json:"monthly"`
+	HourlyCount int64 `json:"hourly_count"`
+}
+
+// Windows are the starts of the periods that usage is counted over at a point in time.
+type Windows struct {
+	HourStart  time.Time
+	DayStart   time.Time
+	MonthStart time.Time
+}
+
+// LoadSchedule reads a JSON limit schedule. An empty path gives an empty schedule that limits nothing.
+func LoadSchedule(path string) (Schedule, error) {
+	var schedule Schedule
+	if path == "" {
+		return schedule, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return schedule, err
+	}
+
+	if err := json.Unmarshal(data, &schedule); err != nil {
+		return schedule, fmt.Errorf("cannot parse limit schedule: %w", err)
+	}
+
+	return schedule, schedule.Validate()
+}
+
+func (schedule Schedule) Validate() error {
+	seen := map[string]bool{}
+
+	for _, tier := range schedule.Tiers {
+		if !validTier(tier.Tier) {
+			return fmt.Errorf("limits have unknown tier %q", tier.Tier)
+		}
+		if _, ok := currency.Lookup(tier.Currency); !ok {
+			return fmt.Errorf("limits for tier %s have unknown currency %q", tier.Tier, tier.Currency)
+		}
+
+		key := tier.Tier + "/" + tier.Currency
+		if seen[key] {
+			return fmt.Errorf("limits for tier %s in %s are defined twice", tier.Tier, tier.Currency)
+		}
+		seen[key] = true
+
+		if tier.PerTransfer < 0 || tier.Daily < 0 || tier.Monthly < 0 || tier.HourlyCount < 0 {
+			return fmt.Errorf("limits for tier %s in %s are negative", tier.Tier, tier.Currency)
+		}
+	}
+
+	return nil
+}
+
+func validTier(tier string) bool {
+	for _, known := range constants.UserTiers {
+		if tier == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup returns the limits of a tier in a currency. A tier without limits in the currency is unlimited.
+func (schedule Schedule) Lookup(tier string, currencyCode string) Limits {
+	for _, limits := range schedule.Tiers {
+		if limits.Tier == tier && limits.Currency == currencyCode {
+			return limits.Limits
+		}
+	}
+	return Limits{}
+}
+
+// Unlimited reports whether none of the limits are set, so that usage needn't be looked up.
+func (limits Limits) Unlimited() bool {
+	return limits == Limits{}
+}
+
+// Check returns ErrExceeded, saying which limit, if transferring amount as count transfers on top of usage would go
+// over any of the limits.
+func (limits Limits) Check(usage Usage, amount int64, count int64) error {
+	switch {
+	case limits.PerTransfer > 0 && amount > limits.PerTransfer:
+		return fmt.Errorf("%w: %d per transfer", ErrExceeded, limits.PerTransfer)
+	case limits.Daily > 0 && usage.Daily+amount > limits.Daily:
+		return fmt.Errorf("%w: %d per day", ErrExceeded, limits.Daily)
+	case limits.Monthly > 0 && usage.Monthly+amount > limits.Monthly:
+		return fmt.Errorf("%w: %d per month", ErrExceeded, limits.Monthly)
+	case limits.HourlyCount > 0 && usage.HourlyCount+count > limits.HourlyCount:
+		return fmt.Errorf("%w: %d transfers per hour", ErrExceeded, limits.HourlyCount)
+	}
+	return nil
+}
+
+// Remaining returns what is left of each limit after usage, or nil for limits that aren't set.
+func (limits Limits) Remaining(usage Usage) (perTransfer, daily, monthly, hourlyCount *int64) {
+	remaining := func(limit int64, used int64) *int64 {
+		if limit == 0 {
+			return nil
+		}
+		left := limit - used
+		if left < 0 {
+			left = 0
+		}
+		return &left
+	}
+
+	return remaining(limits.PerTransfer, 0), remaining(limits.Daily, usage.Daily),
+		remaining(limits.Monthly, usage.Monthly), remaining(limits.HourlyCount, usage.HourlyCount)
+}
+
+// WindowsAt returns the windows usage is counted over at now: the last hour, and the calendar day and month in UTC.
+func WindowsAt(now time.Time) Windows {
+	utc := now.UTC()
+	return Windows{
+		HourStart:  now.Add(-time.Hour),
+		DayStart:   time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC),
+		MonthStart: time.Date(utc.Year(), utc.Month(), 1, 0, 0, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,120 @@
+package limit
+
+import (
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testSchedule() Schedule {
+	return Schedule{
+		Tiers: []TierLimits{
+			{Tier: constants.UserTierStandard, Currency: constants.USD, Limits: Limits{PerTransfer: 500, Daily: 1000, Monthly: 5000, HourlyCount: 3}},
+			{Tier: constants.UserTierPremium, Currency: constants.USD, Limits: Limits{Daily: 10000}},
+		},
+	}
+}
+
+func TestLookup(t *testing.T) {
+	schedule := testSchedule()
+
+	require.Equal(t, Limits{PerTransfer: 500, Daily: 1000, Monthly: 5000, HourlyCount: 3}, schedule.Lookup(constants.UserTierStandard, constants.USD))
+	require.Equal(t, Limits{Daily: 10000}, schedule.Lookup(constants.UserTierPremium, constants.USD))
+	require.True(t, schedule.Lookup(constants.UserTierStandard, constants.EUR).Unlimited())
+	require.True(t, schedule.Lookup(constants.UserTierBusiness, constants.USD).Unlimited())
+}
+
+func TestCheck(t *testing.T) {
+	limits := testSchedule().Lookup(constants.UserTierStandard, constants.USD)
+
+	testCases := []struct {
+		name     string
+		usage    Usage
+		amount   int64
+		count    int64
+		exceeded bool
+	}{
+		{name: "WithinLimits", usage: Usage{Daily: 400, Monthly: 4000, HourlyCount: 2}, amount: 500, count: 1},
+		{name: "PerTransfer", amount: 501, count: 1, exceeded: true},
+		{name: "Daily", usage: Usage{Daily: 600, Monthly: 600}, amount: 401, count: 1, exceeded: true},
+		{name: "Monthly", usage: Usage{Monthly: 4800}, amount: 201, count: 1, exceeded: true},
+		{name: "HourlyCount", usage: Usage{HourlyCount: 3}, amount: 1, count: 1, exceeded: true},
+		{name: "HourlyCountOfSeveral", usage: Usage{HourlyCount: 1}, amount: 10, count: 3, exceeded: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				err := limits.Check(tc.usage, tc.amount, tc.count)
+				if tc.exceeded {
+					require.True(t, errors.Is(err, ErrExceeded))
+				} else {
+					require.NoError(t, err)
+				}
+			},
+		)
+	}
+
+	require.NoError(t, Limits{}.Check(Usage{Daily: 1 << 40, HourlyCount: 1000}, 1<<40, 1))
+}
+
+func TestRemaining(t *testing.T) {
+	limits := Limits{PerTransfer: 500, Daily: 1000, HourlyCount: 3}
+
+	perTransfer, daily, monthly, hourlyCount := limits.Remaining(Usage{Daily: 1200, Monthly: 1200, HourlyCount: 1})
+	require.Equal(t, int64(500), *perTransfer)
+	require.Zero(t, *daily)
+	require.Nil(t, monthly)
+	require.Equal(t, int64(2), *hourlyCount)
+}
+
+func TestWindowsAt(t *testing.T) {
+	now := time.Date(2023, time.March, 15, 10, 30, 0, 0, time.UTC)
+
+	windows := WindowsAt(now)
+	require.Equal(t, time.Date(2023, time.March, 15, 9, 30, 0, 0, time.UTC), windows.HourStart)
+	require.Equal(t, time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC), windows.DayStart)
+	require.Equal(t, time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), windows.MonthStart)
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, testSchedule().Validate())
+	require.NoError(t, Schedule{}.Validate())
+
+	testCases := []struct {
+		name     string
+		schedule Schedule
+	}{
+		{name: "UnknownTier", schedule: Schedule{Tiers: []TierLimits{{Tier: "gold", Currency: constants.USD}}}},
+		{name: "UnknownCurrency", schedule: Schedule{Tiers: []TierLimits{{Tier: constants.UserTierStandard, Currency: "XYZ"}}}},
+		{name: "Duplicate", schedule: Schedule{Tiers: []TierLimits{{Tier: constants.UserTierStandard, Currency: constants.USD}, {Tier: constants.UserTierStandard, Currency: constants.USD}}}},
+		{name: "Negative", schedule: Schedule{Tiers: []TierLimits{{Tier: constants.UserTierStandard, Currency: constants.USD, Limits: Limits{Daily: -1}}}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				require.Error(t, tc.schedule.Validate())
+			},
+		)
+	}
+}
+
+func TestLoadSchedule(t *testing.T) {
+	schedule, err := LoadSchedule("")
+	require.NoError(t, err)
+	require.Empty(t, schedule.Tiers)
+
+	schedule, err = LoadSchedule("../limits.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, schedule.Tiers)
+
+	path := filepath.Join(t.TempDir(), "limits.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"tiers": [{"tier": "gold", "currency": "USD"}]}`), 0600))
+	_, err = LoadSchedule(path)
+	require.Error(t, err)
+}
@@ -1,11 +1,21 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/aml"
 	"github.com/CrunchyBlue/Golang-Bank/api"
+	"github.com/CrunchyBlue/Golang-Bank/blob"
+	"github.com/CrunchyBlue/Golang-Bank/fee"
+	"github.com/CrunchyBlue/Golang-Bank/job"
+	"github.com/CrunchyBlue/Golang-Bank/limit"
+	"github.com/CrunchyBlue/Golang-Bank/risk"
+	"github.com/CrunchyBlue/Golang-Bank/screening"
 	db "github.com/CrunchyBlue/Golang-Bank/sqlc"
 	"github.com/CrunchyBlue/Golang-Bank/util"
 	"log"
+	"os"
+	"time"
 
 	_ "github.com/lib/pq"
 )
@@ -17,14 +27,144 @@ func main() {
 		log.Fatal("Cannot connect to db:", err)
 	}
 
-	store := db.NewStore(conn)
-	server, err := api.NewServer(store, config)
+	fees, err := fee.LoadSchedule(config.FeeSchedulePath)
+	if err != nil {
+		log.Fatal("cannot load fee schedule:", err)
+	}
+
+	limits, err := limit.LoadSchedule(config.TransferLimitSchedulePath)
+	if err != nil {
+		log.Fatal("cannot load transfer limit schedule:", err)
+	}
+
+	store := db.NewStore(conn, db.WithFeeSchedule(fees), db.WithLimitSchedule(limits))
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "reconcile":
+			reconcile(store)
+			return
+		case "backfill-snapshots":
+			backfillSnapshots(store, os.Args[2:])
+			return
+		}
+	}
+
+	scenarios, err := aml.LoadScenarios(config.AMLScenariosPath)
+	if err != nil {
+		log.Fatal("cannot load AML scenarios:", err)
+	}
+
+	riskEngine, err := risk.NewEngine(config.RiskRulesPath)
+	if err != nil {
+		log.Fatal("cannot load risk rules:", err)
+	}
+
+	screener, err := screening.NewScreener(config.SanctionsListPath, config.SanctionsMatchThreshold)
+	if err != nil {
+		log.Fatal("cannot load sanctions list:", err)
+	}
+
+	localDocuments, err := blob.NewLocalStore(config.KYCDocumentDir)
+	if err != nil {
+		log.Fatal("cannot open KYC document store:", err)
+	}
+	documents, err := blob.NewEncryptedStore(localDocuments, []byte(config.KYCEncryptionKey))
+	if err != nil {
+		log.Fatal("cannot open KYC document store:", err)
+	}
+
+	server, err := api.NewServer(
+		store, config, api.WithRiskEngine(riskEngine), api.WithScreener(screener), api.WithDocumentStore(documents),
+	)
 	if err != nil {
 		log.Fatal("cannot create server:", err)
 	}
 
+	scheduler := job.NewScheduler()
+	scheduler.Every("expire holds", config.HoldExpiryInterval, job.ExpireHolds(store))
+	scheduler.Every(
+		"execute scheduled transfers", config.ScheduledTransferInterval,
+		job.ExecuteScheduledTransfers(store, config.ScheduledTransferRetryInterval, config.ScheduledTransferAttempts),
+	)
+	scheduler.Every(
+		"accrue and post interest", config.InterestInterval, job.AccrueAndPostInterest(store, util.SystemClock{}),
+	)
+	scheduler.Every(
+		"charge maintenance fees", config.MaintenanceFeeInterval,
+		job.ChargeMaintenanceFees(store, fees, util.SystemClock{}),
+	)
+	scheduler.Every(
+		"snapshot balances", config.BalanceSnapshotInterval, job.SnapshotBalances(store, util.SystemClock{}),
+	)
+	scheduler.Every(
+		"generate monthly statements", config.StatementInterval,
+		job.GenerateMonthlyStatements(store, util.SystemClock{}),
+	)
+	scheduler.Every(
+		"mark dormant accounts", config.DormancyInterval,
+		job.MarkDormantAccounts(store, util.SystemClock{}, config.DormancyMonths),
+	)
+	scheduler.Every(
+		"expire pending transfers", config.PendingTransferExpiryInterval,
+		job.ExpirePendingTransfers(store, util.SystemClock{}),
+	)
+	scheduler.Every(
+		"expire payment requests", config.PaymentRequestExpiryInterval,
+		job.ExpirePaymentRequests(store, util.SystemClock{}),
+	)
+	scheduler.Every("process transfer batches", config.TransferBatchInterval, job.ProcessTransferBatches(store))
+	scheduler.Every("reconcile ledger", config.ReconciliationInterval, job.Reconcile(store))
+	scheduler.Every("reload risk rules", config.RiskRulesReloadInterval, job.ReloadRiskRules(riskEngine))
+	scheduler.Every(
+		"monitor transactions", config.AMLMonitoringInterval,
+		job.MonitorTransactions(store, scenarios, util.SystemClock{}),
+	)
+	scheduler.Every(
+		"reload sanctions list", config.SanctionsReloadInterval, job.ReloadSanctionsList(store, screener),
+	)
+	scheduler.Start(context.Background())
+
 	err = server.Start(config.ServerAddress)
 	if err != nil {
 		log.Fatal("Cannot start server:", err)
 	}
 }
+
+// reconcile runs a single ledger reconciliation and exits non-zero if it found any discrepancies.
+func reconcile(store db.Store) {
+	run, err := job.ReconcileLedger(context.Background(), store)
+	if err != nil {
+		log.Fatal("cannot reconcile ledger:", err)
+	}
+
+	log.Printf(
+		"reconciliation run %d checked %d accounts and %d transfers, found %d discrepancies",
+		run.ID, run.AccountsChecked, run.TransfersChecked, run.DiscrepancyCount,
+	)
+	if run.DiscrepancyCount > 0 {
+		os.Exit(1)
+	}
+}
+
+// backfillSnapshots snapshots balances for every day between the two dates, given as YYYY-MM-DD, inclusive.
+func backfillSnapshots(store db.Store, args []string) {
+	if len(args) != 2 {
+		log.Fatal("usage: backfill-snapshots FROM TO")
+	}
+
+	from, err := time.Parse("2006-01-02", args[0])
+	if err != nil {
+		log.Fatal("invalid FROM date:", err)
+	}
+
+	to, err := time.Parse("2006-01-02", args[1])
+	if err != nil {
+		log.Fatal("invalid TO date:", err)
+	}
+
+	err = job.BackfillBalanceSnapshots(context.Background(), store, util.SystemClock{}, from, to)
+	if err != nil {
+		log.Fatal("cannot backfill balance snapshots:", err)
+	}
+}
@@ -0,0 +1,258 @@
+package risk
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SignalNewPayee matches transfers to a destination the source account has never transferred to.
+	SignalNewPayee = "new_payee"
+	// SignalNewDevice matches transfers made from a user agent and IP the user first signed in from recently.
+	SignalNewDevice = "new_device"
+	// SignalAmountOverAverage matches transfers over Multiplier times the source account's average transfer.
+	SignalAmountOverAverage = "amount_over_average"
+	// SignalRapidSuccession matches the Count-th transfer from the source account within the rapid window, and every
+	// one after it.
+	SignalRapidSuccession = "rapid_succession"
+	// SignalRoundAmount matches amounts that are a multiple of Multiple.
+	SignalRoundAmount = "round_amount"
+)
+
+// Rule adds Score to the risk of every transfer that shows its signal.
+type Rule struct {
+	Name   string `json:"name"`
+	Signal string `json:"signal"`
+	Score  int64  `json:"score"`
+	// Only match transfers of at least this amount.
+	MinAmount int64 `json:"min_amount"`
+	// amount_over_average only. Accounts with fewer transfers in the history window never match.
+	MinHistory int64 `json:"min_history"`
+	Multiplier int64 `json:"multiplier"`
+	// rapid_succession only. Counts the transfer being assessed.
+	Count int64 `json:"count"`
+	// round_amount only, in minor units.
+	Multiple int64 `json:"multiple"`
+}
+
+type Rules struct {
+	// Transfers scoring at least this are held for review. Zero never holds any.
+	ReviewScore int64 `json:"review_score"`
+	// Transfers scoring at least this are denied. Zero never denies any.
+	DenyScore int64 `json:"deny_score"`
+	// How far back the average transfer amount is taken over.
+	HistoryDays int `json:"history_days"`
+	// The window rapid succession counts transfers in.
+	RapidWindowMinutes int `json:"rapid_window_minutes"`
+	// How long after a user first signs in from a device it is still new.
+	NewDeviceHours int    `json:"new_device_hours"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Signals are what is known about a transfer when it is assessed.
+type Signals struct {
+	Amount        int64
+	NewPayee      bool
+	NewDevice     bool
+	AverageAmount int64
+	// Transfers from the source account in the history window
+	HistoryCount int64
+	// Transfers from the source account in the rapid window, not counting the one being assessed
+	RecentCount int64
+}
+
+// Windows are the starts of the periods signals are taken over at a point in time.
+type Windows struct {
+	HistoryStart time.Time
+	RecentStart  time.Time
+	// Devices first seen after this are new
+	NewDeviceSince time.Time
+}
+
+type Assessment struct {
+	Score    int64    `json:"score"`
+	Decision string   `json:"decision"`
+	Reasons  []string `json:"reasons"`
+}
+
+// LoadRules reads JSON risk rules. An empty path gives no rules, which allow every transfer.
+func LoadRules(path string) (Rules, error) {
+	var rules Rules
+	if path == "" {
+		return rules, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rules, err
+	}
+
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return rules, fmt.Errorf("cannot parse risk rules: %w", err)
+	}
+
+	return rules, rules.Validate()
+}
+
+func (rules Rules) Validate() error {
+	if rules.ReviewScore < 0 || rules.DenyScore < 0 {
+		return fmt.Errorf("risk scores must not be negative")
+	}
+	if rules.ReviewScore > 0 && rules.DenyScore > 0 && rules.DenyScore < rules.ReviewScore {
+		return fmt.Errorf("deny score must not be below review score")
+	}
+	if rules.HistoryDays < 0 || rules.RapidWindowMinutes < 0 || rules.NewDeviceHours < 0 {
+		return fmt.Errorf("risk windows must not be negative")
+	}
+
+	names := map[string]bool{}
+	for _, rule := range rules.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("risk rule has no name")
+		}
+		if strings.Contains(rule.Name, ",") {
+			return fmt.Errorf("risk rule name %q must not contain commas", rule.Name)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("risk rule %s is defined twice", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if rule.Score < 0 {
+			return fmt.Errorf("risk rule %s has a negative score", rule.Name)
+		}
+
+		switch rule.Signal {
+		case SignalNewPayee, SignalNewDevice:
+		case SignalAmountOverAverage:
+			if rule.Multiplier < 1 {
+				return fmt.Errorf("risk rule %s needs a multiplier", rule.Name)
+			}
+		case SignalRapidSuccession:
+			if rule.Count < 1 {
+				return fmt.Errorf("risk rule %s needs a count", rule.Name)
+			}
+		case SignalRoundAmount:
+			if rule.Multiple < 1 {
+				return fmt.Errorf("risk rule %s needs a multiple", rule.Name)
+			}
+		default:
+			return fmt.Errorf("risk rule %s has unknown signal %q", rule.Name, rule.Signal)
+		}
+	}
+
+	return nil
+}
+
+// Empty reports whether there are no rules, so that signals needn't be looked up.
+func (rules Rules) Empty() bool {
+	return len(rules.Rules) == 0
+}
+
+func (rules Rules) WindowsAt(now time.Time) Windows {
+	return Windows{
+		HistoryStart:   now.AddDate(0, 0, -rules.HistoryDays),
+		RecentStart:    now.Add(-time.Duration(rules.RapidWindowMinutes) * time.Minute),
+		NewDeviceSince: now.Add(-time.Duration(rules.NewDeviceHours) * time.Hour),
+	}
+}
+
+// Assess scores a transfer by the rules it matches and decides whether to allow it, hold it for review or deny it.
+func (rules Rules) Assess(signals Signals) Assessment {
+	assessment := Assessment{Decision: constants.RiskDecisionAllow, Reasons: []string{}}
+
+	for _, rule := range rules.Rules {
+		if rule.matches(signals) {
+			assessment.Score += rule.Score
+			assessment.Reasons = append(assessment.Reasons, rule.Name)
+		}
+	}
+
+	switch {
+	case rules.DenyScore > 0 && assessment.Score >= rules.DenyScore:
+		assessment.Decision = constants.RiskDecisionDeny
+	case rules.ReviewScore > 0 && assessment.Score >= rules.ReviewScore:
+		assessment.Decision = constants.RiskDecisionReview
+	}
+
+	return assessment
+}
+
+func (rule Rule) matches(signals Signals) bool {
+	if signals.Amount < rule.MinAmount {
+		return false
+	}
+
+	switch rule.Signal {
+	case SignalNewPayee:
+		return signals.NewPayee
+	case SignalNewDevice:
+		return signals.NewDevice
+	case SignalAmountOverAverage:
+		return signals.HistoryCount >= rule.MinHistory && signals.AverageAmount > 0 &&
+			signals.Amount > signals.AverageAmount*rule.Multiplier
+	case SignalRapidSuccession:
+		return signals.RecentCount+1 >= rule.Count
+	case SignalRoundAmount:
+		return signals.Amount%rule.Multiple == 0
+	}
+	return false
+}
+
+// Engine holds the rules read from a file, and reads them again when the file changes, so that they can be tuned
+// without a restart. The zero Engine has no rules.
+type Engine struct {
+	path    string
+	mu      sync.RWMutex
+	rules   Rules
+	modTime time.Time
+}
+
+func NewEngine(path string) (*Engine, error) {
+	engine := &Engine{path: path}
+	_, err := engine.Reload()
+	return engine, err
+}
+
+// Reload reads the rules again if the file has changed since they were last read, and reports whether it did. Rules
+// that fail to load are rejected and the engine keeps the ones it had.
+func (engine *Engine) Reload() (bool, error) {
+	if engine.path == "" {
+		return false, nil
+	}
+
+	info, err := os.Stat(engine.path)
+	if err != nil {
+		return false, err
+	}
+
+	engine.mu.RLock()
+	unchanged := info.ModTime().Equal(engine.modTime)
+	engine.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	rules, err := LoadRules(engine.path)
+	if err != nil {
+		return false, err
+	}
+
+	engine.mu.Lock()
+	engine.rules = rules
+	engine.modTime = info.ModTime()
+	engine.mu.Unlock()
+
+	return true, nil
+}
+
+func (engine *Engine) Rules() Rules {
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	return engine.rules
+}
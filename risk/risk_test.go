@@ -0,0 +1,176 @@
+package risk
+
+import (
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testRules() Rules {
+	return Rules{
+		ReviewScore: 50,
+		DenyScore:   90,
+		Rules: []Rule{
+			{Name: "new_payee", Signal: SignalNewPayee, Score: 20},
+			{Name: "new_device", Signal: SignalNewDevice, Score: 30},
+			{Name: "large", Signal: SignalAmountOverAverage, Multiplier: 5, MinHistory: 3, Score: 40},
+			{Name: "rapid", Signal: SignalRapidSuccession, Count: 3, Score: 30},
+			{Name: "round", Signal: SignalRoundAmount, Multiple: 10000, MinAmount: 10000, Score: 10},
+		},
+	}
+}
+
+func TestAssess(t *testing.T) {
+	rules := testRules()
+
+	testCases := []struct {
+		name     string
+		signals  Signals
+		score    int64
+		decision string
+		reasons  []string
+	}{
+		{
+			name:     "NoSignals",
+			signals:  Signals{Amount: 1234, AverageAmount: 1000, HistoryCount: 10},
+			decision: constants.RiskDecisionAllow,
+			reasons:  []string{},
+		},
+		{
+			name:     "Review",
+			signals:  Signals{Amount: 1234, NewPayee: true, NewDevice: true},
+			score:    50,
+			decision: constants.RiskDecisionReview,
+			reasons:  []string{"new_payee", "new_device"},
+		},
+		{
+			name: "Deny",
+			signals: Signals{
+				Amount: 50000, NewPayee: true, NewDevice: true, AverageAmount: 1000, HistoryCount: 3,
+			},
+			score:    100,
+			decision: constants.RiskDecisionDeny,
+			reasons:  []string{"new_payee", "new_device", "large", "round"},
+		},
+		{
+			name:     "ShortHistory",
+			signals:  Signals{Amount: 50001, AverageAmount: 1000, HistoryCount: 2},
+			decision: constants.RiskDecisionAllow,
+			reasons:  []string{},
+		},
+		{
+			name:     "RapidSuccession",
+			signals:  Signals{Amount: 1234, RecentCount: 2},
+			score:    30,
+			decision: constants.RiskDecisionAllow,
+			reasons:  []string{"rapid"},
+		},
+		{
+			name:     "RoundBelowMinimum",
+			signals:  Signals{Amount: 5000},
+			decision: constants.RiskDecisionAllow,
+			reasons:  []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				assessment := rules.Assess(tc.signals)
+				require.Equal(t, tc.score, assessment.Score)
+				require.Equal(t, tc.decision, assessment.Decision)
+				require.Equal(t, tc.reasons, assessment.Reasons)
+			},
+		)
+	}
+}
+
+func TestWindowsAt(t *testing.T) {
+	rules := Rules{HistoryDays: 90, RapidWindowMinutes: 10, NewDeviceHours: 24}
+	now := time.Date(2023, time.March, 15, 10, 30, 0, 0, time.UTC)
+
+	windows := rules.WindowsAt(now)
+	require.Equal(t, time.Date(2022, time.December, 15, 10, 30, 0, 0, time.UTC), windows.HistoryStart)
+	require.Equal(t, time.Date(2023, time.March, 15, 10, 20, 0, 0, time.UTC), windows.RecentStart)
+	require.Equal(t, time.Date(2023, time.March, 14, 10, 30, 0, 0, time.UTC), windows.NewDeviceSince)
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, testRules().Validate())
+	require.NoError(t, Rules{}.Validate())
+
+	testCases := []struct {
+		name  string
+		rules Rules
+	}{
+		{name: "DenyBelowReview", rules: Rules{ReviewScore: 50, DenyScore: 40}},
+		{name: "NoName", rules: Rules{Rules: []Rule{{Signal: SignalNewPayee}}}},
+		{name: "Comma", rules: Rules{Rules: []Rule{{Name: "a,b", Signal: SignalNewPayee}}}},
+		{name: "Duplicate", rules: Rules{Rules: []Rule{{Name: "a", Signal: SignalNewPayee}, {Name: "a", Signal: SignalNewDevice}}}},
+		{name: "UnknownSignal", rules: Rules{Rules: []Rule{{Name: "a", Signal: "night_time"}}}},
+		{name: "NoMultiplier", rules: Rules{Rules: []Rule{{Name: "a", Signal: SignalAmountOverAverage}}}},
+		{name: "NoCount", rules: Rules{Rules: []Rule{{Name: "a", Signal: SignalRapidSuccession}}}},
+		{name: "NoMultiple", rules: Rules{Rules: []Rule{{Name: "a", Signal: SignalRoundAmount}}}},
+		{name: "NegativeScore", rules: Rules{Rules: []Rule{{Name: "a", Signal: SignalNewPayee, Score: -1}}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				require.Error(t, tc.rules.Validate())
+			},
+		)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	rules, err := LoadRules("")
+	require.NoError(t, err)
+	require.True(t, rules.Empty())
+
+	rules, err = LoadRules("../risk.json")
+	require.NoError(t, err)
+	require.False(t, rules.Empty())
+}
+
+func TestEngineReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "risk.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": [{"name": "a", "signal": "new_payee"}]}`), 0600))
+
+	engine, err := NewEngine(path)
+	require.NoError(t, err)
+	require.Len(t, engine.Rules().Rules, 1)
+
+	reloaded, err := engine.Reload()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(
+		t, os.WriteFile(
+			path, []byte(`{"rules": [{"name": "a", "signal": "new_payee"}, {"name": "b", "signal": "new_device"}]}`),
+			0600,
+		),
+	)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	reloaded, err = engine.Reload()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+	require.Len(t, engine.Rules().Rules, 2)
+
+	// Broken rules are rejected, leaving the engine with the ones it had
+	later = later.Add(time.Minute)
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": [{"name": "a", "signal": "unknown"}]}`), 0600))
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	reloaded, err = engine.Reload()
+	require.Error(t, err)
+	require.False(t, reloaded)
+	require.Len(t, engine.Rules().Rules, 2)
+
+	require.True(t, (&Engine{}).Rules().Empty())
+}
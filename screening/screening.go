@@ -0,0 +1,309 @@
+package screening
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// transliterations spell letters with diacritics, and Cyrillic letters, the way they are usually romanized, so that
+// names match however they were written.
+var transliterations = func() map[rune]string {
+	groups := []struct {
+		latin   string
+		letters string
+	}{
+		{"a", "àáâãäåāăą"}, {"c", "çćĉċč"}, {"d", "ďđð"}, {"e", "èéêëēĕėęě"}, {"g", "ĝğġģ"},
+		{"h", "ĥħ"}, {"i", "ìíîïĩīĭįı"}, {"j", "ĵ"}, {"k", "ķ"}, {"l", "ĺļľŀł"}, {"n", "ñńņňŉ"},
+		{"o", "òóôõöøōŏő"}, {"r", "ŕŗř"}, {"s", "śŝşšș"}, {"t", "ţťŧț"}, {"u", "ùúûüũūŭůűų"},
+		{"w", "ŵ"}, {"y", "ýÿŷ"}, {"z", "źżž"},
+		{"ae", "æ"}, {"oe", "œ"}, {"ss", "ß"}, {"th", "þ"},
+		{"a", "а"}, {"b", "б"}, {"v", "в"}, {"g", "гґ"}, {"d", "д"}, {"e", "еёэ"}, {"zh", "ж"}, {"z", "з"},
+		{"i", "иі"}, {"y", "йы"}, {"k", "к"}, {"l", "л"}, {"m", "м"}, {"n", "н"}, {"o", "о"}, {"p", "п"},
+		{"r", "р"}, {"s", "с"}, {"t", "т"}, {"u", "у"}, {"f", "ф"}, {"kh", "х"}, {"ts", "ц"}, {"ch", "ч"},
+		{"sh", "ш"}, {"shch", "щ"}, {"yu", "ю"}, {"ya", "я"}, {"yi", "ї"}, {"ye", "є"}, {"", "ъь'’"},
+	}
+
+	table := map[rune]string{}
+	for _, group := range groups {
+		for _, letter := range group.letters {
+			table[letter] = group.latin
+		}
+	}
+	return table
+}()
+
+// Normalize reduces a name to the form names are compared in: lowercase, transliterated to Latin letters without
+// diacritics, without punctuation, and with its words sorted, so that "SMITH, John" and "John Smith" are the same.
+func Normalize(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if latin, ok := transliterations[r]; ok {
+			b.WriteString(latin)
+			continue
+		}
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteByte(' ')
+		}
+	}
+
+	tokens := strings.Fields(b.String())
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// Similarity scores how alike two names are from 0 to 100, by the edit distance between their normalized forms.
+func Similarity(a, b string) int {
+	x, y := []rune(Normalize(a)), []rune(Normalize(b))
+	longest := len(x)
+	if len(y) > longest {
+		longest = len(y)
+	}
+	if longest == 0 {
+		return 0
+	}
+
+	distance := distanceWithin(x, y, longest, newRows(longest))
+	return 100 * (longest - distance) / longest
+}
+
+// rows are the two rows of the edit distance table, kept between comparisons so that screening a name against a
+// whole list doesn't allocate for each one.
+type rows struct {
+	prev []int
+	cur  []int
+}
+
+func newRows(length int) *rows {
+	return &rows{prev: make([]int, length+1), cur: make([]int, length+1)}
+}
+
+// distanceWithin returns the Levenshtein distance between a and b if it is at most limit, otherwise limit+1. Only the
+// band of the table within limit of its diagonal is computed, and it gives up as soon as a row is all over the
+// limit, so names that are far apart are cheap to reject.
+func distanceWithin(a, b []rune, limit int, r *rows) int {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	over := limit + 1
+	if len(b)-len(a) > limit {
+		return over
+	}
+	if len(r.prev) <= len(b) {
+		*r = *newRows(len(b))
+	}
+
+	prev, cur := r.prev, r.cur
+	for j := 0; j <= len(b) && j <= over; j++ {
+		prev[j] = j
+	}
+	if len(b) > limit {
+		prev[over] = over
+	}
+
+	for i := 1; i <= len(a); i++ {
+		lo, hi := i-limit, i+limit
+		if lo < 1 {
+			lo = 1
+		}
+		if hi > len(b) {
+			hi = len(b)
+		}
+
+		cur[lo-1] = over
+		if lo == 1 && i < over {
+			cur[0] = i
+		}
+		rowMin := cur[lo-1]
+		for j := lo; j <= hi; j++ {
+			v := prev[j-1]
+			if a[i-1] != b[j-1] {
+				v++
+			}
+			if prev[j]+1 < v {
+				v = prev[j] + 1
+			}
+			if cur[j-1]+1 < v {
+				v = cur[j-1] + 1
+			}
+			if v > over {
+				v = over
+			}
+			cur[j] = v
+			if v < rowMin {
+				rowMin = v
+			}
+		}
+		if hi < len(b) {
+			cur[hi+1] = over
+		}
+		if rowMin > limit {
+			return over
+		}
+		prev, cur = cur, prev
+	}
+
+	if prev[len(b)] > limit {
+		return over
+	}
+	return prev[len(b)]
+}
+
+// Match is a watchlist entry that a screened name is like.
+type Match struct {
+	Entry Entry `json:"entry"`
+	// The entry's name or alias that matched
+	MatchedName string `json:"matched_name"`
+	Score       int    `json:"score"`
+}
+
+type indexedName struct {
+	entry      int
+	name       string
+	normalized []rune
+}
+
+// List is a watchlist with its names normalized for screening.
+type List struct {
+	entries []Entry
+	names   []indexedName
+}
+
+func NewList(entries []Entry) *List {
+	list := &List{entries: entries}
+	for i, entry := range entries {
+		for _, name := range entry.Names {
+			normalized := []rune(Normalize(name))
+			if len(normalized) > 0 {
+				list.names = append(list.names, indexedName{entry: i, name: name, normalized: normalized})
+			}
+		}
+	}
+	return list
+}
+
+func (list *List) Len() int {
+	return len(list.entries)
+}
+
+// Screen returns the entries with a name or alias that scores at least threshold against name, best first. Each entry
+// is matched once, by its most similar name.
+func (list *List) Screen(name string, threshold int) []Match {
+	query := []rune(Normalize(name))
+	if len(query) == 0 {
+		return nil
+	}
+
+	best := map[int]Match{}
+	r := newRows(len(query) * 2)
+	for _, candidate := range list.names {
+		longest := len(query)
+		if len(candidate.normalized) > longest {
+			longest = len(candidate.normalized)
+		}
+		limit := longest * (100 - threshold) / 100
+
+		distance := distanceWithin(query, candidate.normalized, limit, r)
+		if distance > limit {
+			continue
+		}
+		score := 100 * (longest - distance) / longest
+		if score < threshold || score <= best[candidate.entry].Score {
+			continue
+		}
+		best[candidate.entry] = Match{Entry: list.entries[candidate.entry], MatchedName: candidate.name, Score: score}
+	}
+
+	matches := make([]Match, 0, len(best))
+	for _, match := range best {
+		matches = append(matches, match)
+	}
+	sort.Slice(
+		matches, func(i, j int) bool {
+			if matches[i].Score != matches[j].Score {
+				return matches[i].Score > matches[j].Score
+			}
+			return matches[i].Entry.ID < matches[j].Entry.ID
+		},
+	)
+	return matches
+}
+
+// Screener screens names against a watchlist file, and picks up new versions of the file when it is reloaded.
+type Screener struct {
+	path      string
+	threshold int
+	mu        sync.RWMutex
+	list      *List
+	modTime   time.Time
+}
+
+// NewScreener loads the watchlist at path and matches names that score at least threshold against it. A screener
+// without a path, like the zero Screener, matches nothing.
+func NewScreener(path string, threshold int) (*Screener, error) {
+	if path != "" && (threshold < 1 || threshold > 100) {
+		return nil, fmt.Errorf("screening threshold must be between 1 and 100, got %d", threshold)
+	}
+
+	screener := &Screener{path: path, threshold: threshold}
+	_, err := screener.Reload()
+	return screener, err
+}
+
+// Reload reads the watchlist again if the file has changed since it was last read, and reports whether it did. A
+// list that fails to load is rejected and the screener keeps the one it had.
+func (screener *Screener) Reload() (bool, error) {
+	if screener.path == "" {
+		return false, nil
+	}
+
+	info, err := os.Stat(screener.path)
+	if err != nil {
+		return false, err
+	}
+
+	screener.mu.RLock()
+	unchanged := info.ModTime().Equal(screener.modTime)
+	screener.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	list, err := LoadList(screener.path)
+	if err != nil {
+		return false, err
+	}
+
+	screener.mu.Lock()
+	screener.list = list
+	screener.modTime = info.ModTime()
+	screener.mu.Unlock()
+
+	return true, nil
+}
+
+// Empty reports whether there is no watchlist to screen against.
+func (screener *Screener) Empty() bool {
+	screener.mu.RLock()
+	defer screener.mu.RUnlock()
+	return screener.list == nil || screener.list.Len() == 0
+}
+
+func (screener *Screener) Screen(name string) []Match {
+	screener.mu.RLock()
+	list := screener.list
+	screener.mu.RUnlock()
+
+	if list == nil {
+		return nil
+	}
+	return list.Screen(name, screener.threshold)
+}
@@ -0,0 +1,199 @@
+package screening
+
+import (
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		name       string
+		normalized string
+	}{
+		{name: "HAMADA, Hoda Salih", normalized: "hamada hoda salih"},
+		{name: "Hoda Salih Hamada", normalized: "hamada hoda salih"},
+		{name: "  José   María O'Brien-Núñez ", normalized: "jose maria nunez obrien"},
+		{name: "Müller Straße", normalized: "muller strasse"},
+		{name: "José", normalized: "jose"},
+		{name: "Łukasz Żółć", normalized: "lukasz zolc"},
+		{name: "Владимир Щукин", normalized: "shchukin vladimir"},
+		{name: "AL-QAIDA", normalized: "al qaida"},
+		{name: "--", normalized: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				require.Equal(t, tc.normalized, Normalize(tc.name))
+			},
+		)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	require.Equal(t, 100, Similarity("SMITH, John", "john smith"))
+	require.Equal(t, 100, Similarity("Łukasz Żółć", "Lukasz Zolc"))
+	// One letter off in seventeen
+	require.Equal(t, 94, Similarity("Hoda Salih Hamada", "Huda Salih Hamada"))
+	require.Less(t, Similarity("John Smith", "Jane Doe"), 50)
+	require.Equal(t, 0, Similarity("", ""))
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func TestDistanceWithin(t *testing.T) {
+	r := newRows(4)
+	for i := 0; i < 2000; i++ {
+		a := []rune(util.RandomString(rand.Intn(12)))
+		b := []rune(util.RandomString(rand.Intn(12)))
+		// Mostly similar pairs, so that the band is exercised and not just the length check
+		if i%2 == 0 && len(a) > 0 {
+			b = append(append([]rune{}, a[:rand.Intn(len(a))]...), b[:len(b)/3]...)
+		}
+		limit := rand.Intn(6)
+
+		want := levenshtein(a, b)
+		if want > limit {
+			want = limit + 1
+		}
+		require.Equal(t, want, distanceWithin(a, b, limit, r), "%q %q within %d", string(a), string(b), limit)
+	}
+}
+
+func TestScreen(t *testing.T) {
+	list := NewList(
+		[]Entry{
+			{ID: "2674", Type: EntryTypeIndividual, Names: []string{"HAMADA, Hoda Salih", "AMMASH, Huda Salih Mahdi"}},
+			{ID: "306", Type: EntryTypeEntity, Names: []string{"BANCO NACIONAL DE CUBA", "BNC"}},
+			{ID: "9999", Type: EntryTypeIndividual, Names: []string{"HAMADA, Huda Salih"}},
+		},
+	)
+
+	matches := list.Screen("Hoda Salih Hamada", 90)
+	require.Len(t, matches, 2)
+	require.Equal(t, "2674", matches[0].Entry.ID)
+	require.Equal(t, "HAMADA, Hoda Salih", matches[0].MatchedName)
+	require.Equal(t, 100, matches[0].Score)
+	require.Equal(t, "9999", matches[1].Entry.ID)
+	require.Equal(t, 94, matches[1].Score)
+
+	// Aliases match too
+	matches = list.Screen("Huda Salih Mahdi Ammash", 90)
+	require.Len(t, matches, 1)
+	require.Equal(t, "AMMASH, Huda Salih Mahdi", matches[0].MatchedName)
+
+	require.Len(t, list.Screen("Banco Nacional de Cuba", 90), 1)
+	require.Empty(t, list.Screen("John Smith", 90))
+	require.Empty(t, list.Screen("", 90))
+	require.Empty(t, list.Screen("Hoda Salih Hamada", 101))
+}
+
+func TestScreener(t *testing.T) {
+	var zero Screener
+	require.True(t, zero.Empty())
+	require.Empty(t, zero.Screen("Hoda Salih Hamada"))
+
+	_, err := NewScreener(filepath.Join(t.TempDir(), "missing.csv"), 90)
+	require.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	require.NoError(t, os.WriteFile(path, []byte(testSDNCSV), 0o600))
+
+	_, err = NewScreener(path, 0)
+	require.Error(t, err)
+
+	screener, err := NewScreener(path, 90)
+	require.NoError(t, err)
+	require.False(t, screener.Empty())
+	require.Len(t, screener.Screen("Hoda Salih Hamada"), 1)
+
+	reloaded, err := screener.Reload()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+
+	require.NoError(t, os.WriteFile(path, []byte(`1,-0- ,-0- ,"CUBA"`), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	_, err = screener.Reload()
+	require.Error(t, err)
+	require.Len(t, screener.Screen("Hoda Salih Hamada"), 1)
+
+	require.NoError(t, os.WriteFile(path, []byte(`1,"SMITH, John","individual","[SDGT]"`), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute)))
+	reloaded, err = screener.Reload()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+	require.Empty(t, screener.Screen("Hoda Salih Hamada"))
+	require.Len(t, screener.Screen("John Smith"), 1)
+}
+
+func benchmarkList(size int) *List {
+	rng := rand.New(rand.NewSource(1))
+	word := func() string {
+		const letters = "abcdefghijklmnopqrstuvwxyz"
+		b := make([]byte, 3+rng.Intn(7))
+		for i := range b {
+			b[i] = letters[rng.Intn(len(letters))]
+		}
+		return string(b)
+	}
+
+	entries := make([]Entry, size)
+	for i := range entries {
+		entries[i] = Entry{
+			ID:    fmt.Sprint(i),
+			Type:  EntryTypeIndividual,
+			Names: []string{word() + ", " + word() + " " + word(), word() + " " + word()},
+		}
+	}
+	return NewList(entries)
+}
+
+func BenchmarkScreen(b *testing.B) {
+	for _, size := range []int{1000, 10000, 50000} {
+		list := benchmarkList(size)
+		b.Run(
+			fmt.Sprint(size), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					list.Screen("Hoda Salih Hamada", 90)
+				}
+			},
+		)
+	}
+}
+
+func BenchmarkNewList(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchmarkList(50000)
+	}
+}
@@ -0,0 +1,168 @@
+package screening
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	EntryTypeIndividual = "individual"
+	EntryTypeEntity     = "entity"
+)
+
+// Entry is a sanctioned individual or entity.
+type Entry struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	// The primary name first, then the aliases
+	Names    []string `json:"names"`
+	Programs []string `json:"programs"`
+}
+
+// LoadList reads a watchlist in the layout of OFAC's SDN list, either SDN.CSV or SDN.XML by the file's extension.
+// Vessels and aircraft are left out, since only people and organizations are screened.
+func LoadList(path string) (*List, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Entry
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		entries, err = ParseCSV(file)
+	case ".xml":
+		entries, err = ParseXML(file)
+	default:
+		err = fmt.Errorf("unsupported watchlist format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot load watchlist %s: %w", path, err)
+	}
+
+	return NewList(entries), nil
+}
+
+// csvNull is what the SDN CSV files have in place of an empty field.
+const csvNull = "-0-"
+
+var akaPattern = regexp.MustCompile(`a\.k\.a\. '([^']+)'`)
+
+// ParseCSV reads entries from SDN.CSV, which has no header and one row per entry: the entry number, name, type
+// (empty for entities), programs in brackets, and the remarks, which list the aliases, in the twelfth column.
+func ParseCSV(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// The file ends with a line holding just an end of file character
+		if len(record) < 4 {
+			continue
+		}
+
+		field := func(i int) string {
+			if i >= len(record) || strings.TrimSpace(record[i]) == csvNull {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		entryType, ok := entryType(field(2))
+		if !ok {
+			continue
+		}
+		if field(0) == "" || field(1) == "" {
+			return nil, fmt.Errorf("entry %q has no number or name", strings.Join(record, ","))
+		}
+
+		entry := Entry{ID: field(0), Type: entryType, Names: []string{field(1)}}
+		for _, program := range strings.Split(field(3), "] [") {
+			if program = strings.Trim(program, "[] "); program != "" {
+				entry.Programs = append(entry.Programs, program)
+			}
+		}
+		for _, aka := range akaPattern.FindAllStringSubmatch(field(11), -1) {
+			entry.Names = append(entry.Names, aka[1])
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+type sdnName struct {
+	FirstName string `xml:"firstName"`
+	LastName  string `xml:"lastName"`
+}
+
+func (name sdnName) String() string {
+	return strings.TrimSpace(name.FirstName + " " + name.LastName)
+}
+
+type sdnList struct {
+	Entries []struct {
+		UID string `xml:"uid"`
+		sdnName
+		Type     string    `xml:"sdnType"`
+		Programs []string  `xml:"programList>program"`
+		Akas     []sdnName `xml:"akaList>aka"`
+	} `xml:"sdnEntry"`
+}
+
+// ParseXML reads entries from SDN.XML.
+func ParseXML(r io.Reader) ([]Entry, error) {
+	var list sdnList
+	if err := xml.NewDecoder(r).Decode(&list); err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, sdn := range list.Entries {
+		entryType, ok := entryType(sdn.Type)
+		if !ok {
+			continue
+		}
+		if sdn.UID == "" || sdn.String() == "" {
+			return nil, fmt.Errorf("entry %q has no uid or name", sdn.UID)
+		}
+
+		entry := Entry{ID: sdn.UID, Type: entryType, Names: []string{sdn.String()}, Programs: sdn.Programs}
+		for _, aka := range sdn.Akas {
+			if aka.String() != "" {
+				entry.Names = append(entry.Names, aka.String())
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// entryType maps the SDN type of an entry to the one it is screened as, and reports whether it is screened at all.
+func entryType(sdnType string) (string, bool) {
+	switch strings.ToLower(sdnType) {
+	case "", EntryTypeEntity:
+		return EntryTypeEntity, true
+	case EntryTypeIndividual:
+		return EntryTypeIndividual, true
+	default:
+		return "", false
+	}
+}
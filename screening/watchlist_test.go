@@ -0,0 +1,117 @@
+package screening
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSDNCSV = `36,"AEROCARIBBEAN AIRLINES",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"a.k.a. 'AERO-CARIBBEAN'."
+173,"ANGLO-CARIBBEAN CO., LTD.",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+306,"BANCO NACIONAL DE CUBA",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"a.k.a. 'BNC'; a.k.a. 'NATIONAL BANK OF CUBA'."
+2674,"HAMADA, Hoda Salih","individual","[IRAQ2] [SDGT]",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"DOB 1953; a.k.a. 'AMMASH, Huda Salih Mahdi'."
+15036,"ARCTIC SUNRISE","vessel","[SDGT]",-0- ,"IMO 9000000",-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+` + "\x1a\n"
+
+const testSDNXML = `<?xml version="1.0" standalone="yes"?>
+<sdnList xmlns="https://sanctionslistservice.ofac.treas.gov/api/PublicationPreview/exports/XML">
+  <publshInformation><Publish_Date>03/01/2023</Publish_Date></publshInformation>
+  <sdnEntry>
+    <uid>2674</uid>
+    <firstName>Hoda Salih</firstName>
+    <lastName>HAMADA</lastName>
+    <sdnType>Individual</sdnType>
+    <programList><program>IRAQ2</program><program>SDGT</program></programList>
+    <akaList>
+      <aka><uid>2001</uid><type>a.k.a.</type><category>strong</category><lastName>AMMASH</lastName><firstName>Huda Salih Mahdi</firstName></aka>
+    </akaList>
+  </sdnEntry>
+  <sdnEntry>
+    <uid>306</uid>
+    <lastName>BANCO NACIONAL DE CUBA</lastName>
+    <sdnType>Entity</sdnType>
+    <programList><program>CUBA</program></programList>
+  </sdnEntry>
+  <sdnEntry>
+    <uid>15036</uid>
+    <lastName>ARCTIC SUNRISE</lastName>
+    <sdnType>Vessel</sdnType>
+  </sdnEntry>
+</sdnList>`
+
+func TestParseCSV(t *testing.T) {
+	entries, err := ParseCSV(strings.NewReader(testSDNCSV))
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	require.Equal(
+		t, Entry{
+			ID:       "36",
+			Type:     EntryTypeEntity,
+			Names:    []string{"AEROCARIBBEAN AIRLINES", "AERO-CARIBBEAN"},
+			Programs: []string{"CUBA"},
+		}, entries[0],
+	)
+	require.Equal(t, []string{"ANGLO-CARIBBEAN CO., LTD."}, entries[1].Names)
+	require.Equal(t, []string{"BANCO NACIONAL DE CUBA", "BNC", "NATIONAL BANK OF CUBA"}, entries[2].Names)
+	require.Equal(
+		t, Entry{
+			ID:       "2674",
+			Type:     EntryTypeIndividual,
+			Names:    []string{"HAMADA, Hoda Salih", "AMMASH, Huda Salih Mahdi"},
+			Programs: []string{"IRAQ2", "SDGT"},
+		}, entries[3],
+	)
+
+	_, err = ParseCSV(strings.NewReader(`-0- ,"NO NUMBER",-0- ,"CUBA"`))
+	require.Error(t, err)
+}
+
+func TestParseXML(t *testing.T) {
+	entries, err := ParseXML(strings.NewReader(testSDNXML))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	require.Equal(
+		t, Entry{
+			ID:       "2674",
+			Type:     EntryTypeIndividual,
+			Names:    []string{"Hoda Salih HAMADA", "Huda Salih Mahdi AMMASH"},
+			Programs: []string{"IRAQ2", "SDGT"},
+		}, entries[0],
+	)
+	require.Equal(
+		t, Entry{
+			ID:       "306",
+			Type:     EntryTypeEntity,
+			Names:    []string{"BANCO NACIONAL DE CUBA"},
+			Programs: []string{"CUBA"},
+		}, entries[1],
+	)
+
+	_, err = ParseXML(strings.NewReader("<sdnList><sdnEntry>"))
+	require.Error(t, err)
+}
+
+func TestLoadList(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"sdn.csv": testSDNCSV, "sdn.xml": testSDNXML, "sdn.txt": ""} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	list, err := LoadList(filepath.Join(dir, "sdn.csv"))
+	require.NoError(t, err)
+	require.Equal(t, 4, list.Len())
+
+	list, err = LoadList(filepath.Join(dir, "sdn.xml"))
+	require.NoError(t, err)
+	require.Equal(t, 2, list.Len())
+
+	_, err = LoadList(filepath.Join(dir, "sdn.txt"))
+	require.Error(t, err)
+
+	_, err = LoadList(filepath.Join(dir, "missing.csv"))
+	require.Error(t, err)
+}
@@ -7,24 +7,98 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
+const addAccountHeldBalance = `-- name: AddAccountHeldBalance :one
+UPDATE account
+SET held_balance = held_balance + $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
+`
+
+type AddAccountHeldBalanceParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddAccountHeldBalance(ctx context.Context, arg AddAccountHeldBalanceParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, addAccountHeldBalance, arg.Amount, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.Product,
+		&i.Status,
+		&i.AccountNumber,
+		&i.Iban,
+		&i.Nickname,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const countOpenAccounts = `-- name: CountOpenAccounts :one
+SELECT count(*)
+FROM account
+WHERE owner = $1
+  AND organization_id IS NULL
+  AND status <> 'closed'
+  AND ($2::varchar IS NULL OR currency = $2)
+`
+
+type CountOpenAccountsParams struct {
+	Owner    string         `json:"owner"`
+	Currency sql.NullString `json:"currency"`
+}
+
+func (q *Queries) CountOpenAccounts(ctx context.Context, arg CountOpenAccountsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOpenAccounts, arg.Owner, arg.Currency)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO account (owner,
                      balance,
-                     currency)
-VALUES ($1, $2, $3)
-RETURNING id, owner, balance, currency, created_at
+                     currency,
+                     product,
+                     account_number,
+                     iban,
+                     nickname,
+                     organization_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
 `
 
 type CreateAccountParams struct {
-	Owner    string `json:"owner"`
-	Balance  int64  `json:"balance"`
-	Currency string `json:"currency"`
+	Owner          string         `json:"owner"`
+	Balance        int64          `json:"balance"`
+	Currency       string         `json:"currency"`
+	Product        string         `json:"product"`
+	AccountNumber  string         `json:"account_number"`
+	Iban           sql.NullString `json:"iban"`
+	Nickname       sql.NullString `json:"nickname"`
+	OrganizationID sql.NullInt64  `json:"organization_id"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRowContext(ctx, createAccount,
+		arg.Owner,
+		arg.Balance,
+		arg.Currency,
+		arg.Product,
+		arg.AccountNumber,
+		arg.Iban,
+		arg.Nickname,
+		arg.OrganizationID,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -32,6 +106,13 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.Product,
+		&i.Status,
+		&i.AccountNumber,
+		&i.Iban,
+		&i.Nickname,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -48,7 +129,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
 FROM account
 WHERE id = $1
 `
@@ -62,12 +143,91 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.Product,
+		&i.Status,
+		&i.AccountNumber,
+		&i.Iban,
+		&i.Nickname,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const getAccountBalanceAt = `-- name: GetAccountBalanceAt :one
+SELECT (account.balance - COALESCE(SUM(entry.amount), 0))::bigint AS balance
+FROM account
+         LEFT JOIN entry ON entry.account_id = account.id AND entry.created_at >= $2
+WHERE account.id = $1
+GROUP BY account.id
+`
+
+type GetAccountBalanceAtParams struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getAccountBalanceAt, arg.ID, arg.CreatedAt)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const getAccountByIban = `-- name: GetAccountByIban :one
+SELECT id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
+FROM account
+WHERE iban = $1
+`
+
+func (q *Queries) GetAccountByIban(ctx context.Context, iban sql.NullString) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByIban, iban)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.Product,
+		&i.Status,
+		&i.AccountNumber,
+		&i.Iban,
+		&i.Nickname,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const getAccountByNumber = `-- name: GetAccountByNumber :one
+SELECT id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
+FROM account
+WHERE account_number = $1
+`
+
+func (q *Queries) GetAccountByNumber(ctx context.Context, accountNumber string) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByNumber, accountNumber)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.Product,
+		&i.Status,
+		&i.AccountNumber,
+		&i.Iban,
+		&i.Nickname,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
 FROM account
 WHERE id = $1
     FOR NO KEY UPDATE
@@ -82,26 +242,265 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.Product,
+		&i.Status,
+		&i.AccountNumber,
+		&i.Iban,
+		&i.Nickname,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
 const getAccounts = `-- name: GetAccounts :many
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
 FROM account
-WHERE owner = $1
+WHERE (owner = $1
+    OR id IN (SELECT account_id FROM account_member WHERE username = $1)
+    OR organization_id IN (SELECT organization_id FROM organization_member WHERE username = $1))
+  AND ($2::varchar IS NULL OR currency = $2)
+  AND ($3::varchar IS NULL OR product = $3)
+  AND ($4::varchar IS NULL OR status = $4)
 ORDER BY id
-LIMIT $2 OFFSET $3
+LIMIT $5 OFFSET $6
 `
 
 type GetAccountsParams struct {
-	Owner  string `json:"owner"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
+	Owner    string         `json:"owner"`
+	Currency sql.NullString `json:"currency"`
+	Product  sql.NullString `json:"product"`
+	Status   sql.NullString `json:"status"`
+	Limit    int32          `json:"limit"`
+	Offset   int32          `json:"offset"`
 }
 
 func (q *Queries) GetAccounts(ctx context.Context, arg GetAccountsParams) ([]Account, error) {
-	rows, err := q.db.QueryContext(ctx, getAccounts, arg.Owner, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getAccounts,
+		arg.Owner,
+		arg.Currency,
+		arg.Product,
+		arg.Status,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.HeldBalance,
+			&i.Product,
+			&i.Status,
+			&i.AccountNumber,
+			&i.Iban,
+			&i.Nickname,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAccountsByProduct = `-- name: GetAccountsByProduct :many
+SELECT id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
+FROM account
+WHERE product = $1
+  AND created_at < $2
+ORDER BY id
+LIMIT $3 OFFSET $4
+`
+
+type GetAccountsByProductParams struct {
+	Product   string    `json:"product"`
+	CreatedAt time.Time `json:"created_at"`
+	Limit     int32     `json:"limit"`
+	Offset    int32     `json:"offset"`
+}
+
+func (q *Queries) GetAccountsByProduct(ctx context.Context, arg GetAccountsByProductParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, getAccountsByProduct,
+		arg.Product,
+		arg.CreatedAt,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.HeldBalance,
+			&i.Product,
+			&i.Status,
+			&i.AccountNumber,
+			&i.Iban,
+			&i.Nickname,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAccountsOpenedBefore = `-- name: GetAccountsOpenedBefore :many
+SELECT id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
+FROM account
+WHERE created_at < $1
+ORDER BY id
+LIMIT $2 OFFSET $3
+`
+
+type GetAccountsOpenedBeforeParams struct {
+	CreatedAt time.Time `json:"created_at"`
+	Limit     int32     `json:"limit"`
+	Offset    int32     `json:"offset"`
+}
+
+func (q *Queries) GetAccountsOpenedBefore(ctx context.Context, arg GetAccountsOpenedBeforeParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, getAccountsOpenedBefore, arg.CreatedAt, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.HeldBalance,
+			&i.Product,
+			&i.Status,
+			&i.AccountNumber,
+			&i.Iban,
+			&i.Nickname,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInactiveAccounts = `-- name: GetInactiveAccounts :many
+SELECT id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
+FROM account
+WHERE status = 'active'
+  AND product <> 'internal'
+  AND created_at < $1
+  AND id > $2
+  AND NOT EXISTS (SELECT 1
+                  FROM entry
+                           LEFT JOIN transfer ON transfer.journal_transaction_id = entry.journal_transaction_id
+                  WHERE entry.account_id = account.id
+                    AND entry.created_at >= $1
+                    AND NOT EXISTS (SELECT 1 FROM fee WHERE fee.fee_transfer_id = transfer.id)
+                    AND NOT EXISTS (SELECT 1 FROM interest_posting WHERE interest_posting.transfer_id = transfer.id))
+ORDER BY id
+LIMIT $3
+`
+
+type GetInactiveAccountsParams struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int64     `json:"id"`
+	Limit     int32     `json:"limit"`
+}
+
+func (q *Queries) GetInactiveAccounts(ctx context.Context, arg GetInactiveAccountsParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, getInactiveAccounts, arg.CreatedAt, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.HeldBalance,
+			&i.Product,
+			&i.Status,
+			&i.AccountNumber,
+			&i.Iban,
+			&i.Nickname,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInterestBearingAccounts = `-- name: GetInterestBearingAccounts :many
+SELECT account.id, account.owner, account.balance, account.currency, account.created_at, account.held_balance, account.product, account.status, account.account_number, account.iban, account.nickname, account.organization_id
+FROM account
+         JOIN account_product ON account_product.code = account.product
+WHERE account_product.interest_rate_bps > 0
+ORDER BY account.id
+LIMIT $1 OFFSET $2
+`
+
+type GetInterestBearingAccountsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) GetInterestBearingAccounts(ctx context.Context, arg GetInterestBearingAccountsParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, getInterestBearingAccounts, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -115,6 +514,13 @@ func (q *Queries) GetAccounts(ctx context.Context, arg GetAccountsParams) ([]Acc
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.HeldBalance,
+			&i.Product,
+			&i.Status,
+			&i.AccountNumber,
+			&i.Iban,
+			&i.Nickname,
+			&i.OrganizationID,
 		); err != nil {
 			return nil, err
 		}
@@ -129,29 +535,52 @@ func (q *Queries) GetAccounts(ctx context.Context, arg GetAccountsParams) ([]Acc
 	return items, nil
 }
 
-const updateAccount = `-- name: UpdateAccount :one
+const updateAccountBalance = `-- name: UpdateAccountBalance :one
 UPDATE account
-SET owner    = $1,
-    balance  = $2,
-    currency = $3
-WHERE id = $4
-RETURNING id, owner, balance, currency, created_at
+SET balance = balance + $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
 `
 
-type UpdateAccountParams struct {
-	Owner    string `json:"owner"`
-	Balance  int64  `json:"balance"`
-	Currency string `json:"currency"`
-	ID       int64  `json:"id"`
+type UpdateAccountBalanceParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
 }
 
-func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, updateAccount,
-		arg.Owner,
-		arg.Balance,
-		arg.Currency,
-		arg.ID,
+func (q *Queries) UpdateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountBalance, arg.Amount, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.Product,
+		&i.Status,
+		&i.AccountNumber,
+		&i.Iban,
+		&i.Nickname,
+		&i.OrganizationID,
 	)
+	return i, err
+}
+
+const updateAccountNickname = `-- name: UpdateAccountNickname :one
+UPDATE account
+SET nickname = $2
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
+`
+
+type UpdateAccountNicknameParams struct {
+	ID       int64          `json:"id"`
+	Nickname sql.NullString `json:"nickname"`
+}
+
+func (q *Queries) UpdateAccountNickname(ctx context.Context, arg UpdateAccountNicknameParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountNickname, arg.ID, arg.Nickname)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -159,24 +588,31 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.Product,
+		&i.Status,
+		&i.AccountNumber,
+		&i.Iban,
+		&i.Nickname,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
-const updateAccountBalance = `-- name: UpdateAccountBalance :one
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
 UPDATE account
-SET balance = balance + $1
-WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+SET status = $2
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, held_balance, product, status, account_number, iban, nickname, organization_id
 `
 
-type UpdateAccountBalanceParams struct {
-	Amount int64 `json:"amount"`
-	ID     int64 `json:"id"`
+type UpdateAccountStatusParams struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
 }
 
-func (q *Queries) UpdateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, updateAccountBalance, arg.Amount, arg.ID)
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountStatus, arg.ID, arg.Status)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -184,6 +620,13 @@ func (q *Queries) UpdateAccountBalance(ctx context.Context, arg UpdateAccountBal
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.Product,
+		&i.Status,
+		&i.AccountNumber,
+		&i.Iban,
+		&i.Nickname,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -0,0 +1,206 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+)
+
+var (
+	ErrAlreadyMember        = errors.New("user is already a member of the account")
+	ErrInvitationNotPending = errors.New("invitation has already been answered or revoked")
+	ErrOrganizationAccount  = errors.New("organization accounts are shared through their organization")
+	ErrNotAccountSpender    = errors.New("user may no longer spend from the account")
+)
+
+type InviteAccountMemberTxParams struct {
+	AccountID     int64         `json:"account_id"`
+	Invitee       string        `json:"invitee"`
+	Role          string        `json:"role"`
+	TransferLimit sql.NullInt64 `json:"transfer_limit"`
+	InvitedBy     string        `json:"invited_by"`
+}
+
+// InviteAccountMemberTx invites a user to join an account and notifies them. Closed accounts can't take new members,
+// and organization accounts don't take members of their own.
+func (store *SQLStore) InviteAccountMemberTx(ctx context.Context, arg InviteAccountMemberTxParams) (
+	AccountInvitation, error,
+) {
+	var invitation AccountInvitation
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+			if account.Status == constants.AccountStatusClosed {
+				return ErrAccountClosed
+			}
+			if account.OrganizationID.Valid {
+				return ErrOrganizationAccount
+			}
+
+			_, err = q.GetAccountMember(ctx, GetAccountMemberParams{AccountID: arg.AccountID, Username: arg.Invitee})
+			switch {
+			case err == nil:
+				return ErrAlreadyMember
+			case !errors.Is(err, sql.ErrNoRows):
+				return err
+			}
+
+			invitation, err = q.CreateAccountInvitation(
+				ctx, CreateAccountInvitationParams{
+					AccountID:     arg.AccountID,
+					Invitee:       arg.Invitee,
+					Role:          arg.Role,
+					TransferLimit: arg.TransferLimit,
+					InvitedBy:     arg.InvitedBy,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreateNotification(
+				ctx, CreateNotificationParams{
+					Username: arg.Invitee,
+					Kind:     constants.NotificationAccountInvitation,
+					Message: fmt.Sprintf(
+						"%s invited you to account %s as %s, see invitation %d", arg.InvitedBy,
+						account.AccountNumber, arg.Role, invitation.ID,
+					),
+				},
+			)
+			return err
+		},
+	)
+
+	return invitation, err
+}
+
+type AcceptAccountInvitationTxResult struct {
+	Invitation AccountInvitation `json:"invitation"`
+	Member     AccountMember     `json:"member"`
+}
+
+// AcceptAccountInvitationTx makes the invitee a member of the account with the role they were invited to.
+func (store *SQLStore) AcceptAccountInvitationTx(ctx context.Context, invitationID int64) (
+	AcceptAccountInvitationTxResult, error,
+) {
+	var result AcceptAccountInvitationTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			invitation, err := q.GetAccountInvitationForUpdate(ctx, invitationID)
+			if err != nil {
+				return err
+			}
+			if invitation.Status != constants.InvitationStatusPending {
+				return ErrInvitationNotPending
+			}
+
+			account, err := q.GetAccountForUpdate(ctx, invitation.AccountID)
+			if err != nil {
+				return err
+			}
+			if account.Status == constants.AccountStatusClosed {
+				return ErrAccountClosed
+			}
+
+			result.Member, err = q.CreateAccountMember(
+				ctx, CreateAccountMemberParams{
+					AccountID:     invitation.AccountID,
+					Username:      invitation.Invitee,
+					Role:          invitation.Role,
+					TransferLimit: invitation.TransferLimit,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			result.Invitation, err = q.UpdateAccountInvitationStatus(
+				ctx, UpdateAccountInvitationStatusParams{
+					ID:     invitation.ID,
+					Status: constants.InvitationStatusAccepted,
+				},
+			)
+			return err
+		},
+	)
+
+	return result, err
+}
+
+// RemoveAccountMemberTx removes a member from an account and cancels the scheduled transfers they set up from it, so
+// that nothing keeps spending on their behalf once they have lost access.
+func (store *SQLStore) RemoveAccountMemberTx(ctx context.Context, arg DeleteAccountMemberParams) (
+	AccountMember, error,
+) {
+	var member AccountMember
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			var err error
+			member, err = q.DeleteAccountMember(ctx, arg)
+			if err != nil {
+				return err
+			}
+
+			return q.CancelScheduledTransfersForAccountMember(
+				ctx, CancelScheduledTransfersForAccountMemberParams{
+					Owner:           arg.Username,
+					SourceAccountID: arg.AccountID,
+				},
+			)
+		},
+	)
+
+	return member, err
+}
+
+// checkAccountSpender returns ErrNotAccountSpender unless username may move amount out of account: as its owner, as
+// a co-owner, as a spender within their per-transfer limit, or, on an organization account, as an admin or initiator
+// of the organization. It mirrors the spending rules the API applies when a transfer is set up, for transfers made
+// later on that user's behalf.
+func checkAccountSpender(ctx context.Context, q *Queries, account Account, username string, amount int64) error {
+	if account.OrganizationID.Valid {
+		member, err := q.GetOrganizationMember(
+			ctx, GetOrganizationMemberParams{OrganizationID: account.OrganizationID.Int64, Username: username},
+		)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrNotAccountSpender
+		case err != nil:
+			return err
+		case member.Role == constants.OrganizationRoleAdmin, member.Role == constants.OrganizationRoleInitiator:
+			return nil
+		}
+		return ErrNotAccountSpender
+	}
+
+	if username == account.Owner {
+		return nil
+	}
+
+	member, err := q.GetAccountMember(ctx, GetAccountMemberParams{AccountID: account.ID, Username: username})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrNotAccountSpender
+	case err != nil:
+		return err
+	}
+
+	switch member.Role {
+	case constants.AccountRoleOwner, constants.AccountRoleCoOwner:
+		return nil
+	case constants.AccountRoleSpender:
+		if member.TransferLimit.Valid && amount <= member.TransferLimit.Int64 {
+			return nil
+		}
+	}
+	return ErrNotAccountSpender
+}
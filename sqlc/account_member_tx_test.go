@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAccountInvitationFlow(t *testing.T) {
+	store := NewStore(testDB)
+	owner, _, err := createRandomUser()
+	require.NoError(t, err)
+	invitee, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	account, err := createAccountTx(
+		t, store, CreateAccountTxParams{
+			CreateAccountParams: CreateAccountParams{Owner: owner.Username, Currency: constants.USD},
+		},
+	)
+	require.NoError(t, err)
+
+	members, err := testQueries.GetAccountMembers(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	require.Equal(t, owner.Username, members[0].Username)
+	require.Equal(t, constants.AccountRoleOwner, members[0].Role)
+
+	limit := sql.NullInt64{Int64: 250, Valid: true}
+	invitation, err := store.InviteAccountMemberTx(
+		context.Background(), InviteAccountMemberTxParams{
+			AccountID:     account.ID,
+			Invitee:       invitee.Username,
+			Role:          constants.AccountRoleSpender,
+			TransferLimit: limit,
+			InvitedBy:     owner.Username,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.InvitationStatusPending, invitation.Status)
+	require.False(t, invitation.RespondedAt.Valid)
+
+	notifications, err := testQueries.GetNotifications(
+		context.Background(), GetNotificationsParams{Username: invitee.Username, Limit: 5},
+	)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, constants.NotificationAccountInvitation, notifications[0].Kind)
+
+	pending, err := testQueries.GetPendingInvitationsForUser(
+		context.Background(), GetPendingInvitationsForUserParams{Invitee: invitee.Username, Limit: 5},
+	)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, invitation.ID, pending[0].ID)
+
+	result, err := store.AcceptAccountInvitationTx(context.Background(), invitation.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.InvitationStatusAccepted, result.Invitation.Status)
+	require.True(t, result.Invitation.RespondedAt.Valid)
+	require.Equal(t, constants.AccountRoleSpender, result.Member.Role)
+	require.Equal(t, limit, result.Member.TransferLimit)
+
+	_, err = store.AcceptAccountInvitationTx(context.Background(), invitation.ID)
+	require.ErrorIs(t, err, ErrInvitationNotPending)
+
+	_, err = store.InviteAccountMemberTx(
+		context.Background(), InviteAccountMemberTxParams{
+			AccountID: account.ID,
+			Invitee:   invitee.Username,
+			Role:      constants.AccountRoleViewer,
+			InvitedBy: owner.Username,
+		},
+	)
+	require.ErrorIs(t, err, ErrAlreadyMember)
+
+	accounts, err := testQueries.GetAccounts(
+		context.Background(), GetAccountsParams{Owner: invitee.Username, Limit: 5},
+	)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	removed, err := testQueries.DeleteAccountMember(
+		context.Background(), DeleteAccountMemberParams{AccountID: account.ID, Username: invitee.Username},
+	)
+	require.NoError(t, err)
+	require.Equal(t, invitee.Username, removed.Username)
+
+	accounts, err = testQueries.GetAccounts(
+		context.Background(), GetAccountsParams{Owner: invitee.Username, Limit: 5},
+	)
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+}
+
+func TestDeclineAccountInvitation(t *testing.T) {
+	store := NewStore(testDB)
+	account, _, err := createRandomAccount()
+	require.NoError(t, err)
+	invitee, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	invitation, err := store.InviteAccountMemberTx(
+		context.Background(), InviteAccountMemberTxParams{
+			AccountID: account.ID,
+			Invitee:   invitee.Username,
+			Role:      constants.AccountRoleViewer,
+			InvitedBy: account.Owner,
+		},
+	)
+	require.NoError(t, err)
+
+	declined, err := testQueries.UpdateAccountInvitationStatus(
+		context.Background(), UpdateAccountInvitationStatusParams{
+			ID:     invitation.ID,
+			Status: constants.InvitationStatusDeclined,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.InvitationStatusDeclined, declined.Status)
+
+	_, err = store.AcceptAccountInvitationTx(context.Background(), invitation.ID)
+	require.ErrorIs(t, err, ErrInvitationNotPending)
+
+	_, err = testQueries.GetAccountMember(
+		context.Background(), GetAccountMemberParams{AccountID: account.ID, Username: invitee.Username},
+	)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestRemoveAccountMemberTx(t *testing.T) {
+	store := NewStore(testDB)
+	account, err := createFundedAccount(100)
+	require.NoError(t, err)
+	destination, _, err := createRandomAccount()
+	require.NoError(t, err)
+	spender, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateAccountMember(
+		context.Background(), CreateAccountMemberParams{
+			AccountID:     account.ID,
+			Username:      spender.Username,
+			Role:          constants.AccountRoleSpender,
+			TransferLimit: sql.NullInt64{Int64: 50, Valid: true},
+		},
+	)
+	require.NoError(t, err)
+
+	arg := CreateScheduledTransferParams{
+		Owner:                spender.Username,
+		SourceAccountID:      account.ID,
+		DestinationAccountID: destination.ID,
+		Amount:               10,
+		Currency:             account.Currency,
+		Frequency:            constants.FrequencyWeekly,
+		NextOccurrenceAt:     time.Now().Add(time.Hour),
+		NextAttemptAt:        time.Now().Add(time.Hour),
+	}
+	spenderScheduled, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+
+	arg.Owner = account.Owner
+	ownerScheduled, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+
+	removed, err := store.RemoveAccountMemberTx(
+		context.Background(), DeleteAccountMemberParams{AccountID: account.ID, Username: spender.Username},
+	)
+	require.NoError(t, err)
+	require.Equal(t, spender.Username, removed.Username)
+
+	spenderScheduled, err = testQueries.GetScheduledTransfer(context.Background(), spenderScheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScheduledTransferStatusCancelled, spenderScheduled.Status)
+
+	ownerScheduled, err = testQueries.GetScheduledTransfer(context.Background(), ownerScheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScheduledTransferStatusActive, ownerScheduled.Status)
+
+	_, err = store.RemoveAccountMemberTx(
+		context.Background(), DeleteAccountMemberParams{AccountID: account.ID, Username: spender.Username},
+	)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,27 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetAccountProduct(t *testing.T) {
+	product, err := testQueries.GetAccountProduct(context.Background(), constants.ProductSavings)
+	require.NoError(t, err)
+	require.Equal(t, constants.ProductSavings, product.Code)
+	require.Equal(t, constants.AccountKindSavings, product.Kind)
+	require.Positive(t, product.InterestRateBps)
+	require.Equal(t, constants.DayCountActual365, product.DayCountConvention)
+}
+
+func TestGetAccountProducts(t *testing.T) {
+	products, err := testQueries.GetAccountProducts(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, products)
+
+	for _, product := range products {
+		require.NotEqual(t, constants.AccountKindInternal, product.Kind)
+	}
+}
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+)
+
+var (
+	ErrAccountFrozen           = errors.New("account is frozen")
+	ErrAccountDormant          = errors.New("account is dormant")
+	ErrAccountClosed           = errors.New("account is closed")
+	ErrInvalidStatusTransition = errors.New("account can't move to that status from its current one")
+	ErrAccountNotEmpty         = errors.New("account must have a zero balance and no active holds to be closed")
+)
+
+// accountStatusTransitions lists the statuses an account can move to from each status. Closed is final.
+var accountStatusTransitions = map[string][]string{
+	constants.AccountStatusActive: {
+		constants.AccountStatusFrozen, constants.AccountStatusDormant, constants.AccountStatusClosed,
+	},
+	constants.AccountStatusFrozen:  {constants.AccountStatusActive, constants.AccountStatusClosed},
+	constants.AccountStatusDormant: {constants.AccountStatusActive, constants.AccountStatusClosed},
+}
+
+func canTransition(from string, to string) bool {
+	for _, status := range accountStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAccountPosting reports why an account can't take a posting of amount. Frozen and dormant accounts still
+// accept credits but reject debits; closed accounts accept neither.
+func checkAccountPosting(account Account, amount int64) error {
+	switch account.Status {
+	case constants.AccountStatusClosed:
+		return ErrAccountClosed
+	case constants.AccountStatusFrozen:
+		if amount < 0 {
+			return ErrAccountFrozen
+		}
+	case constants.AccountStatusDormant:
+		if amount < 0 {
+			return ErrAccountDormant
+		}
+	}
+	return nil
+}
+
+type ChangeAccountStatusTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+	ChangedBy string `json:"changed_by"`
+}
+
+type ChangeAccountStatusTxResult struct {
+	Account      Account             `json:"account"`
+	StatusChange AccountStatusChange `json:"status_change"`
+}
+
+// ChangeAccountStatusTx moves an account to another lifecycle status and records who did it and why. Closing an
+// account requires it to be empty; its entries and transfers are kept.
+func (store *SQLStore) ChangeAccountStatusTx(
+	ctx context.Context, arg ChangeAccountStatusTxParams,
+) (ChangeAccountStatusTxResult, error) {
+	var result ChangeAccountStatusTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+
+			if !canTransition(account.Status, arg.Status) {
+				return ErrInvalidStatusTransition
+			}
+
+			if arg.Status == constants.AccountStatusClosed && (account.Balance != 0 || account.HeldBalance != 0) {
+				return ErrAccountNotEmpty
+			}
+
+			result.Account, err = q.UpdateAccountStatus(
+				ctx, UpdateAccountStatusParams{
+					ID:     account.ID,
+					Status: arg.Status,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			result.StatusChange, err = q.CreateAccountStatusChange(
+				ctx, CreateAccountStatusChangeParams{
+					AccountID:  account.ID,
+					FromStatus: account.Status,
+					ToStatus:   arg.Status,
+					Reason:     arg.Reason,
+					ChangedBy:  arg.ChangedBy,
+				},
+			)
+			return err
+		},
+	)
+
+	return result, err
+}
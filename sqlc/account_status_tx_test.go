@@ -0,0 +1,203 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func changeAccountStatus(t *testing.T, store Store, accountID int64, status string) ChangeAccountStatusTxResult {
+	staff, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	result, err := store.ChangeAccountStatusTx(
+		context.Background(), ChangeAccountStatusTxParams{
+			AccountID: accountID,
+			Status:    status,
+			Reason:    util.RandomString(12),
+			ChangedBy: staff.Username,
+		},
+	)
+	require.NoError(t, err)
+	return result
+}
+
+func TestChangeAccountStatusTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createReconciledAccount(t, store, 0)
+	require.Equal(t, constants.AccountStatusActive, account.Status)
+
+	result := changeAccountStatus(t, store, account.ID, constants.AccountStatusFrozen)
+	require.Equal(t, constants.AccountStatusFrozen, result.Account.Status)
+	require.Equal(t, constants.AccountStatusActive, result.StatusChange.FromStatus)
+	require.Equal(t, constants.AccountStatusFrozen, result.StatusChange.ToStatus)
+	require.NotEmpty(t, result.StatusChange.Reason)
+
+	_, err := store.ChangeAccountStatusTx(
+		context.Background(), ChangeAccountStatusTxParams{
+			AccountID: account.ID,
+			Status:    constants.AccountStatusDormant,
+			Reason:    "no activity",
+			ChangedBy: result.StatusChange.ChangedBy,
+		},
+	)
+	require.ErrorIs(t, err, ErrInvalidStatusTransition)
+
+	changeAccountStatus(t, store, account.ID, constants.AccountStatusClosed)
+
+	_, err = store.ChangeAccountStatusTx(
+		context.Background(), ChangeAccountStatusTxParams{
+			AccountID: account.ID,
+			Status:    constants.AccountStatusActive,
+			Reason:    "reopen",
+			ChangedBy: result.StatusChange.ChangedBy,
+		},
+	)
+	require.ErrorIs(t, err, ErrInvalidStatusTransition)
+
+	changes, err := testQueries.GetAccountStatusChanges(
+		context.Background(), GetAccountStatusChangesParams{
+			AccountID: account.ID,
+			Limit:     10,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, constants.AccountStatusClosed, changes[0].ToStatus)
+	require.Equal(t, constants.AccountStatusFrozen, changes[1].ToStatus)
+}
+
+func TestChangeAccountStatusTxCloseRequiresZeroBalance(t *testing.T) {
+	store := NewStore(testDB)
+	account := createReconciledAccount(t, store, 100)
+
+	staff, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	_, err = store.ChangeAccountStatusTx(
+		context.Background(), ChangeAccountStatusTxParams{
+			AccountID: account.ID,
+			Status:    constants.AccountStatusClosed,
+			Reason:    "customer request",
+			ChangedBy: staff.Username,
+		},
+	)
+	require.ErrorIs(t, err, ErrAccountNotEmpty)
+
+	fetched, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.AccountStatusActive, fetched.Status)
+}
+
+func TestTransferTxEnforcesAccountStatus(t *testing.T) {
+	store := NewStore(testDB)
+	frozen := createReconciledAccount(t, store, 500)
+	other := createReconciledAccount(t, store, 500)
+	changeAccountStatus(t, store, frozen.ID, constants.AccountStatusFrozen)
+
+	_, err := store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      frozen.ID,
+			DestinationAccountID: other.ID,
+			Amount:               10,
+		},
+	)
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      other.ID,
+			DestinationAccountID: frozen.ID,
+			Amount:               10,
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = store.PlaceHoldTx(
+		context.Background(), PlaceHoldTxParams{
+			AccountID:            frozen.ID,
+			DestinationAccountID: other.ID,
+			Amount:               10,
+			ExpiresAt:            time.Now().Add(time.Hour),
+		},
+	)
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	closed := createReconciledAccount(t, store, 0)
+	changeAccountStatus(t, store, closed.ID, constants.AccountStatusClosed)
+
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      other.ID,
+			DestinationAccountID: closed.ID,
+			Amount:               10,
+		},
+	)
+	require.ErrorIs(t, err, ErrAccountClosed)
+}
+
+func TestWithdrawTxRejectsDormantAccount(t *testing.T) {
+	store := NewStore(testDB)
+	account := createReconciledAccount(t, store, 500)
+	changeAccountStatus(t, store, account.ID, constants.AccountStatusDormant)
+
+	teller, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	_, err = store.WithdrawTx(
+		context.Background(), CashTxParams{
+			AccountID:      account.ID,
+			Amount:         100,
+			Channel:        constants.ChannelBranch,
+			IdempotencyKey: util.RandomString(16),
+			CreatedBy:      teller.Username,
+		},
+	)
+	require.ErrorIs(t, err, ErrAccountDormant)
+
+	_, err = store.DepositTx(
+		context.Background(), CashTxParams{
+			AccountID:      account.ID,
+			Amount:         100,
+			Channel:        constants.ChannelBranch,
+			IdempotencyKey: util.RandomString(16),
+			CreatedBy:      teller.Username,
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestGetInactiveAccounts(t *testing.T) {
+	store := NewStore(testDB)
+	inactive, _, err := createRandomAccount()
+	require.NoError(t, err)
+	active := createReconciledAccount(t, store, 100)
+
+	cutoff := time.Now().Add(time.Minute)
+
+	accounts, err := testQueries.GetInactiveAccounts(
+		context.Background(), GetInactiveAccountsParams{
+			CreatedAt: cutoff,
+			ID:        inactive.ID - 1,
+			Limit:     1,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, inactive.ID, accounts[0].ID)
+
+	accounts, err = testQueries.GetInactiveAccounts(
+		context.Background(), GetInactiveAccountsParams{
+			CreatedAt: cutoff,
+			ID:        active.ID - 1,
+			Limit:     1,
+		},
+	)
+	require.NoError(t, err)
+	for _, account := range accounts {
+		require.NotEqual(t, active.ID, account.ID)
+	}
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
 	"github.com/CrunchyBlue/Golang-Bank/util"
 	"testing"
 	"time"
@@ -19,6 +20,8 @@ func TestCreateAccount(t *testing.T) {
 	require.Equal(t, arg.Owner, account.Owner)
 	require.Equal(t, arg.Balance, account.Balance)
 	require.Equal(t, arg.Currency, account.Currency)
+	require.Equal(t, arg.AccountNumber, account.AccountNumber)
+	require.False(t, account.Iban.Valid)
 
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
@@ -37,37 +40,53 @@ func TestGetAccount(t *testing.T) {
 	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
 }
 
-func TestGetAccountForUpdate(t *testing.T) {
+func TestGetAccountByNumber(t *testing.T) {
 	account1, _, _ := createRandomAccount()
-	account2, err := testQueries.GetAccountForUpdate(context.Background(), account1.ID)
+	account2, err := testQueries.GetAccountByNumber(context.Background(), account1.AccountNumber)
 	require.NoError(t, err)
-	require.NotEmpty(t, account2)
-
 	require.Equal(t, account1.ID, account2.ID)
-	require.Equal(t, account1.Owner, account2.Owner)
-	require.Equal(t, account1.Balance, account2.Balance)
-	require.Equal(t, account1.Currency, account2.Currency)
-	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
+
+	_, err = testQueries.GetAccountByNumber(context.Background(), "00000000000")
+	require.ErrorIs(t, err, sql.ErrNoRows)
 }
 
-func TestUpdateAccount(t *testing.T) {
-	account1, _, _ := createRandomAccount()
+func TestGetAccountByIban(t *testing.T) {
+	user, _, err := createRandomUser()
+	require.NoError(t, err)
 
-	arg := UpdateAccountParams{
-		ID:       account1.ID,
-		Owner:    account1.Owner,
-		Balance:  util.RandomInt(0, 1000),
-		Currency: util.RandomCurrency(),
-	}
+	accountNumber, err := util.NewAccountNumber()
+	require.NoError(t, err)
 
-	account2, err := testQueries.UpdateAccount(context.Background(), arg)
+	iban, err := util.NewIBAN("DE", "GOLB", accountNumber)
+	require.NoError(t, err)
+
+	account1, err := testQueries.CreateAccount(
+		context.Background(), CreateAccountParams{
+			Owner:         user.Username,
+			Currency:      constants.USD,
+			Product:       constants.ProductChecking,
+			AccountNumber: accountNumber,
+			Iban:          sql.NullString{String: iban, Valid: true},
+		},
+	)
+	require.NoError(t, err)
+
+	account2, err := testQueries.GetAccountByIban(context.Background(), sql.NullString{String: iban, Valid: true})
+	require.NoError(t, err)
+	require.Equal(t, account1.ID, account2.ID)
+	require.Equal(t, accountNumber, account2.AccountNumber)
+}
+
+func TestGetAccountForUpdate(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, err := testQueries.GetAccountForUpdate(context.Background(), account1.ID)
 	require.NoError(t, err)
 	require.NotEmpty(t, account2)
 
 	require.Equal(t, account1.ID, account2.ID)
-	require.Equal(t, arg.Owner, account2.Owner)
-	require.Equal(t, arg.Balance, account2.Balance)
-	require.Equal(t, arg.Currency, account2.Currency)
+	require.Equal(t, account1.Owner, account2.Owner)
+	require.Equal(t, account1.Balance, account2.Balance)
+	require.Equal(t, account1.Currency, account2.Currency)
 	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
 }
 
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+)
+
+var ErrAccountLimitReached = errors.New("account limit reached")
+
+type CreateAccountTxParams struct {
+	CreateAccountParams
+	// Most accounts the owner can have open at once. Zero means no limit.
+	MaxAccounts int64 `json:"max_accounts"`
+	// Most accounts the owner can have open at once in the new account's currency. Zero means no limit.
+	MaxAccountsPerCurrency int64 `json:"max_accounts_per_currency"`
+}
+
+// CreateAccountTx opens an account, with its owner as its first member, unless it would take the owner over either
+// limit. Closed accounts and organization accounts don't count towards them, and organization accounts get no member
+// rows since access comes from the organization. The owner is locked so that concurrent requests can't both slip
+// under a limit.
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (Account, error) {
+	var account Account
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			_, err := q.GetUserForUpdate(ctx, arg.Owner)
+			if err != nil {
+				return err
+			}
+
+			if arg.MaxAccounts > 0 {
+				count, err := q.CountOpenAccounts(ctx, CountOpenAccountsParams{Owner: arg.Owner})
+				if err != nil {
+					return err
+				}
+				if count >= arg.MaxAccounts {
+					return fmt.Errorf("%w: at most %d open accounts are allowed", ErrAccountLimitReached, arg.MaxAccounts)
+				}
+			}
+
+			if arg.MaxAccountsPerCurrency > 0 {
+				count, err := q.CountOpenAccounts(
+					ctx, CountOpenAccountsParams{
+						Owner:    arg.Owner,
+						Currency: sql.NullString{String: arg.Currency, Valid: true},
+					},
+				)
+				if err != nil {
+					return err
+				}
+				if count >= arg.MaxAccountsPerCurrency {
+					return fmt.Errorf(
+						"%w: at most %d open %s accounts are allowed", ErrAccountLimitReached,
+						arg.MaxAccountsPerCurrency, arg.Currency,
+					)
+				}
+			}
+
+			account, err = q.CreateAccount(ctx, arg.CreateAccountParams)
+			if err != nil {
+				return err
+			}
+			if account.OrganizationID.Valid {
+				return nil
+			}
+
+			_, err = q.CreateAccountMember(
+				ctx, CreateAccountMemberParams{
+					AccountID: account.ID,
+					Username:  account.Owner,
+					Role:      constants.AccountRoleOwner,
+				},
+			)
+			return err
+		},
+	)
+
+	return account, err
+}
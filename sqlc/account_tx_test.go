@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createAccountTx(t *testing.T, store Store, arg CreateAccountTxParams) (Account, error) {
+	accountNumber, err := util.NewAccountNumber()
+	require.NoError(t, err)
+
+	arg.AccountNumber = accountNumber
+	if arg.Product == "" {
+		arg.Product = constants.ProductChecking
+	}
+	return store.CreateAccountTx(context.Background(), arg)
+}
+
+func TestCreateAccountTxSameCurrency(t *testing.T) {
+	store := NewStore(testDB)
+	user, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	checking, err := createAccountTx(
+		t, store, CreateAccountTxParams{
+			CreateAccountParams: CreateAccountParams{Owner: user.Username, Currency: constants.USD},
+		},
+	)
+	require.NoError(t, err)
+	require.False(t, checking.Nickname.Valid)
+
+	for _, nickname := range []string{"Holiday fund", "Rainy day"} {
+		savings, err := createAccountTx(
+			t, store, CreateAccountTxParams{
+				CreateAccountParams: CreateAccountParams{
+					Owner:    user.Username,
+					Currency: constants.USD,
+					Product:  constants.ProductSavings,
+					Nickname: sql.NullString{String: nickname, Valid: true},
+				},
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, nickname, savings.Nickname.String)
+		require.Equal(t, constants.ProductSavings, savings.Product)
+	}
+
+	_, err = createAccountTx(
+		t, store, CreateAccountTxParams{
+			CreateAccountParams: CreateAccountParams{
+				Owner:    user.Username,
+				Currency: constants.EUR,
+				Nickname: sql.NullString{String: "Rainy day", Valid: true},
+			},
+		},
+	)
+	require.ErrorContains(t, err, "owner_nickname_key")
+}
+
+func TestCreateAccountTxLimits(t *testing.T) {
+	store := NewStore(testDB)
+	user, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	arg := CreateAccountTxParams{
+		CreateAccountParams:    CreateAccountParams{Owner: user.Username, Currency: constants.USD},
+		MaxAccounts:            3,
+		MaxAccountsPerCurrency: 2,
+	}
+
+	first, err := createAccountTx(t, store, arg)
+	require.NoError(t, err)
+	_, err = createAccountTx(t, store, arg)
+	require.NoError(t, err)
+
+	_, err = createAccountTx(t, store, arg)
+	require.ErrorIs(t, err, ErrAccountLimitReached)
+
+	arg.Currency = constants.EUR
+	_, err = createAccountTx(t, store, arg)
+	require.NoError(t, err)
+
+	_, err = createAccountTx(t, store, arg)
+	require.ErrorIs(t, err, ErrAccountLimitReached)
+
+	changeAccountStatus(t, store, first.ID, constants.AccountStatusClosed)
+
+	arg.Currency = constants.USD
+	_, err = createAccountTx(t, store, arg)
+	require.NoError(t, err)
+}
+
+func TestGetAccountsFiltered(t *testing.T) {
+	store := NewStore(testDB)
+	user, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	for _, arg := range []CreateAccountParams{
+		{Owner: user.Username, Currency: constants.USD},
+		{Owner: user.Username, Currency: constants.USD, Product: constants.ProductSavings},
+		{Owner: user.Username, Currency: constants.EUR, Product: constants.ProductSavings},
+	} {
+		_, err := createAccountTx(t, store, CreateAccountTxParams{CreateAccountParams: arg})
+		require.NoError(t, err)
+	}
+
+	accounts, err := testQueries.GetAccounts(
+		context.Background(), GetAccountsParams{
+			Owner:    user.Username,
+			Currency: sql.NullString{String: constants.USD, Valid: true},
+			Limit:    10,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+
+	accounts, err = testQueries.GetAccounts(
+		context.Background(), GetAccountsParams{
+			Owner:   user.Username,
+			Product: sql.NullString{String: constants.ProductSavings, Valid: true},
+			Status:  sql.NullString{String: constants.AccountStatusActive, Valid: true},
+			Limit:   10,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	for _, account := range accounts {
+		require.Equal(t, constants.ProductSavings, account.Product)
+	}
+
+	accounts, err = testQueries.GetAccounts(
+		context.Background(), GetAccountsParams{
+			Owner:  user.Username,
+			Status: sql.NullString{String: constants.AccountStatusClosed, Valid: true},
+			Limit:  10,
+		},
+	)
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+}
+
+func TestUpdateAccountNickname(t *testing.T) {
+	account, _, err := createRandomAccount()
+	require.NoError(t, err)
+
+	nickname := sql.NullString{String: util.RandomString(8), Valid: true}
+	updatedAccount, err := testQueries.UpdateAccountNickname(
+		context.Background(), UpdateAccountNicknameParams{ID: account.ID, Nickname: nickname},
+	)
+	require.NoError(t, err)
+	require.Equal(t, nickname, updatedAccount.Nickname)
+
+	updatedAccount, err = testQueries.UpdateAccountNickname(
+		context.Background(), UpdateAccountNicknameParams{ID: account.ID},
+	)
+	require.NoError(t, err)
+	require.False(t, updatedAccount.Nickname.Valid)
+}
@@ -0,0 +1,284 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func makeTestTransfer(t *testing.T, store Store, source Account, destination Account, amount int64) {
+	_, err := store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               amount,
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestGetStructuringCandidates(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(100000)
+	require.NoError(t, err)
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		makeTestTransfer(t, store, source, destination, 9500)
+	}
+	// Well under the threshold, so not part of the pattern
+	makeTestTransfer(t, store, source, destination, 5000)
+
+	arg := GetStructuringCandidatesParams{
+		Currency:    source.Currency,
+		MinAmount:   9000,
+		Threshold:   10000,
+		WindowStart: time.Now().Add(-time.Hour),
+		WindowEnd:   time.Now().Add(time.Hour),
+		MinCount:    3,
+	}
+	candidates, err := testQueries.GetStructuringCandidates(context.Background(), arg)
+	require.NoError(t, err)
+
+	var found *GetStructuringCandidatesRow
+	for i := range candidates {
+		require.NotEqual(t, destination.ID, candidates[i].AccountID)
+		if candidates[i].AccountID == source.ID {
+			found = &candidates[i]
+		}
+	}
+	require.NotNil(t, found)
+	require.Equal(t, int64(3), found.TransferCount)
+	require.Equal(t, int64(28500), found.Total)
+
+	arg.MinCount = 4
+	candidates, err = testQueries.GetStructuringCandidates(context.Background(), arg)
+	require.NoError(t, err)
+	for _, candidate := range candidates {
+		require.NotEqual(t, source.ID, candidate.AccountID)
+	}
+}
+
+func TestGetRapidMovementCandidates(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(100000)
+	require.NoError(t, err)
+	passThrough, err := createFundedAccount(0)
+	require.NoError(t, err)
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	makeTestTransfer(t, store, source, passThrough, 60000)
+	makeTestTransfer(t, store, passThrough, destination, 57000)
+
+	candidates, err := testQueries.GetRapidMovementCandidates(
+		context.Background(), GetRapidMovementCandidatesParams{
+			Currency:    passThrough.Currency,
+			WindowStart: time.Now().Add(-time.Hour),
+			WindowEnd:   time.Now().Add(time.Hour),
+			MinAmount:   50000,
+			OutflowBps:  9000,
+		},
+	)
+	require.NoError(t, err)
+
+	var found *GetRapidMovementCandidatesRow
+	for i := range candidates {
+		// The source only sent money and the destination only received it
+		require.NotEqual(t, source.ID, candidates[i].AccountID)
+		require.NotEqual(t, destination.ID, candidates[i].AccountID)
+		if candidates[i].AccountID == passThrough.ID {
+			found = &candidates[i]
+		}
+	}
+	require.NotNil(t, found)
+	require.Equal(t, int64(60000), found.Inflow)
+	require.Equal(t, int64(57000), found.Outflow)
+	require.Equal(t, int64(2), found.EntryCount)
+}
+
+func TestGetDormantReactivationCandidates(t *testing.T) {
+	store := NewStore(testDB)
+
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	// Both accounts are old, but only one of them has been quiet for more than 180 days
+	createOldAccount := func(lastActive time.Duration) Account {
+		account, err := createFundedAccount(100000)
+		require.NoError(t, err)
+		_, err = testDB.Exec(
+			"UPDATE account SET created_at = now() - interval '400 days' WHERE id = $1", account.ID,
+		)
+		require.NoError(t, err)
+
+		entry, err := testQueries.CreateEntry(
+			context.Background(), CreateEntryParams{AccountID: account.ID, Amount: 100000},
+		)
+		require.NoError(t, err)
+		_, err = testDB.Exec("UPDATE entry SET created_at = $1 WHERE id = $2", time.Now().Add(-lastActive), entry.ID)
+		require.NoError(t, err)
+
+		makeTestTransfer(t, store, account, destination, 20000)
+		return account
+	}
+	dormant := createOldAccount(300 * 24 * time.Hour)
+	active := createOldAccount(10 * 24 * time.Hour)
+
+	windowStart := time.Now().Add(-time.Hour)
+	candidates, err := testQueries.GetDormantReactivationCandidates(
+		context.Background(), GetDormantReactivationCandidatesParams{
+			Currency:     dormant.Currency,
+			DormantSince: windowStart.AddDate(0, 0, -180),
+			WindowStart:  windowStart,
+			WindowEnd:    time.Now().Add(time.Hour),
+			MinAmount:    10000,
+		},
+	)
+	require.NoError(t, err)
+
+	var found *GetDormantReactivationCandidatesRow
+	for i := range candidates {
+		require.NotEqual(t, active.ID, candidates[i].AccountID)
+		// New accounts aren't dormant
+		require.NotEqual(t, destination.ID, candidates[i].AccountID)
+		if candidates[i].AccountID == dormant.ID {
+			found = &candidates[i]
+		}
+	}
+	require.NotNil(t, found)
+	require.Equal(t, int64(20000), found.Outflow)
+	require.Equal(t, int64(1), found.EntryCount)
+}
+
+func TestCreateAmlAlertDeduplicates(t *testing.T) {
+	account, _, err := createRandomAccount()
+	require.NoError(t, err)
+
+	windowEnd := time.Now().Truncate(time.Second)
+	arg := CreateAmlAlertParams{
+		Scenario:    constants.AmlScenarioStructuring,
+		AccountID:   account.ID,
+		Amount:      28500,
+		EventCount:  3,
+		Details:     "3 transfers between 90.00 and 100.00 USD in 24 hours",
+		WindowStart: windowEnd.Add(-24 * time.Hour),
+		WindowEnd:   windowEnd,
+	}
+	alert, err := testQueries.CreateAmlAlert(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, constants.AmlAlertStatusOpen, alert.Status)
+	require.Equal(t, arg.Details, alert.Details)
+	require.False(t, alert.AssignedTo.Valid)
+
+	// The next run sees the same activity in an overlapping window
+	arg.WindowStart = arg.WindowStart.Add(time.Hour)
+	arg.WindowEnd = arg.WindowEnd.Add(time.Hour)
+	_, err = testQueries.CreateAmlAlert(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.Scenario = constants.AmlScenarioRapidMovement
+	_, err = testQueries.CreateAmlAlert(context.Background(), arg)
+	require.NoError(t, err)
+
+	arg.Scenario = constants.AmlScenarioStructuring
+	arg.WindowStart = windowEnd
+	arg.WindowEnd = windowEnd.Add(24 * time.Hour)
+	_, err = testQueries.CreateAmlAlert(context.Background(), arg)
+	require.NoError(t, err)
+}
+
+func TestAmlAlertCase(t *testing.T) {
+	store := NewStore(testDB)
+
+	account, _, err := createRandomAccount()
+	require.NoError(t, err)
+	analyst, _, err := createRandomUser()
+	require.NoError(t, err)
+	_, err = testDB.Exec(`UPDATE "user" SET role = $1 WHERE username = $2`, constants.RoleAnalyst, analyst.Username)
+	require.NoError(t, err)
+
+	alert, err := testQueries.CreateAmlAlert(
+		context.Background(), CreateAmlAlertParams{
+			Scenario:    constants.AmlScenarioDormantReactivation,
+			AccountID:   account.ID,
+			Amount:      20000,
+			EventCount:  1,
+			Details:     "sent 200.00 USD out after 180 days without activity",
+			WindowStart: time.Now().Add(-24 * time.Hour),
+			WindowEnd:   time.Now(),
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = store.AssignAmlAlertTx(
+		context.Background(), AssignAmlAlertTxParams{ID: alert.ID, Assignee: account.Owner},
+	)
+	require.ErrorIs(t, err, ErrAmlAssigneeNotAnalyst)
+
+	assigned, err := store.AssignAmlAlertTx(
+		context.Background(), AssignAmlAlertTxParams{ID: alert.ID, Assignee: analyst.Username},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.AmlAlertStatusAssigned, assigned.Status)
+	require.Equal(t, analyst.Username, assigned.AssignedTo.String)
+
+	result, err := store.CloseAmlAlertTx(
+		context.Background(), CloseAmlAlertTxParams{
+			ID:         alert.ID,
+			ClosedBy:   analyst.Username,
+			Resolution: constants.AmlResolutionReported,
+			Comment:    "Filed with the regulator",
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.AmlAlertStatusClosed, result.Alert.Status)
+	require.Equal(t, constants.AmlResolutionReported, result.Alert.Resolution.String)
+	require.Equal(t, analyst.Username, result.Alert.ClosedBy.String)
+	require.True(t, result.Alert.ClosedAt.Valid)
+	require.NotNil(t, result.Comment)
+
+	comments, err := testQueries.GetAmlAlertComments(context.Background(), alert.ID)
+	require.NoError(t, err)
+	require.Equal(t, []AmlAlertComment{*result.Comment}, comments)
+
+	_, err = store.CloseAmlAlertTx(
+		context.Background(), CloseAmlAlertTxParams{
+			ID:         alert.ID,
+			ClosedBy:   analyst.Username,
+			Resolution: constants.AmlResolutionNoAction,
+		},
+	)
+	require.ErrorIs(t, err, ErrAmlAlertClosed)
+
+	_, err = store.AssignAmlAlertTx(
+		context.Background(), AssignAmlAlertTxParams{ID: alert.ID, Assignee: analyst.Username},
+	)
+	require.ErrorIs(t, err, ErrAmlAlertClosed)
+
+	exported, err := testQueries.GetAmlAlertsForExport(
+		context.Background(), GetAmlAlertsForExportParams{
+			FromTime:   alert.CreatedAt,
+			ToTime:     alert.CreatedAt.Add(time.Second),
+			Resolution: sql.NullString{String: constants.AmlResolutionReported, Valid: true},
+		},
+	)
+	require.NoError(t, err)
+
+	var found bool
+	for _, row := range exported {
+		require.Equal(t, constants.AmlResolutionReported, row.Resolution.String)
+		if row.ID == alert.ID {
+			found = true
+			require.Equal(t, account.Owner, row.Owner)
+			require.Equal(t, account.Currency, row.Currency)
+		}
+	}
+	require.True(t, found)
+}
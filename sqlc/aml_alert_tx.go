@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+)
+
+var (
+	ErrAmlAlertClosed        = errors.New("alert is already closed")
+	ErrAmlAssigneeNotAnalyst = errors.New("alerts can only be assigned to analysts and admins")
+)
+
+type AssignAmlAlertTxParams struct {
+	ID       int64  `json:"id"`
+	Assignee string `json:"assignee"`
+}
+
+// AssignAmlAlertTx hands an open alert to an analyst, or moves an assigned one to another.
+func (store *SQLStore) AssignAmlAlertTx(ctx context.Context, arg AssignAmlAlertTxParams) (AmlAlert, error) {
+	var result AmlAlert
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			alert, err := q.GetAmlAlertForUpdate(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+			if alert.Status == constants.AmlAlertStatusClosed {
+				return ErrAmlAlertClosed
+			}
+
+			assignee, err := q.GetUser(ctx, arg.Assignee)
+			if err != nil {
+				return err
+			}
+			if assignee.Role != constants.RoleAnalyst && assignee.Role != constants.RoleAdmin {
+				return ErrAmlAssigneeNotAnalyst
+			}
+
+			result, err = q.AssignAmlAlert(
+				ctx, AssignAmlAlertParams{
+					ID:         alert.ID,
+					AssignedTo: sql.NullString{String: assignee.Username, Valid: true},
+				},
+			)
+			return err
+		},
+	)
+
+	return result, err
+}
+
+type CloseAmlAlertTxParams struct {
+	ID         int64  `json:"id"`
+	ClosedBy   string `json:"closed_by"`
+	Resolution string `json:"resolution"`
+	// Left on the alert as a comment, if there is one
+	Comment string `json:"comment"`
+}
+
+type CloseAmlAlertTxResult struct {
+	Alert   AmlAlert         `json:"alert"`
+	Comment *AmlAlertComment `json:"comment"`
+}
+
+// CloseAmlAlertTx closes an alert with its resolution, together with the comment explaining it.
+func (store *SQLStore) CloseAmlAlertTx(ctx context.Context, arg CloseAmlAlertTxParams) (CloseAmlAlertTxResult, error) {
+	var result CloseAmlAlertTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			alert, err := q.GetAmlAlertForUpdate(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+			if alert.Status == constants.AmlAlertStatusClosed {
+				return ErrAmlAlertClosed
+			}
+
+			result.Alert, err = q.CloseAmlAlert(
+				ctx, CloseAmlAlertParams{
+					ID:         alert.ID,
+					Resolution: sql.NullString{String: arg.Resolution, Valid: true},
+					ClosedBy:   sql.NullString{String: arg.ClosedBy, Valid: true},
+				},
+			)
+			if err != nil || arg.Comment == "" {
+				return err
+			}
+
+			comment, err := q.CreateAmlAlertComment(
+				ctx, CreateAmlAlertCommentParams{
+					AlertID: alert.ID,
+					Author:  arg.ClosedBy,
+					Body:    arg.Comment,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			result.Comment = &comment
+			return nil
+		},
+	)
+
+	return result, err
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSnapshotBalanceTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createReconciledAccount(t, store, 500)
+
+	today := util.StartOfDay(time.Now())
+	yesterday := today.AddDate(0, 0, -1)
+
+	snapshot, err := store.SnapshotBalanceTx(
+		context.Background(), SnapshotBalanceTxParams{
+			AccountID: account.ID,
+			Date:      yesterday,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, snapshot.AccountID)
+	require.True(t, yesterday.Equal(snapshot.Date))
+	require.Zero(t, snapshot.Balance)
+
+	_, err = store.SnapshotBalanceTx(
+		context.Background(), SnapshotBalanceTxParams{
+			AccountID: account.ID,
+			Date:      yesterday,
+		},
+	)
+	require.ErrorIs(t, err, ErrBalanceSnapshotExists)
+
+	snapshot, err = store.SnapshotBalanceTx(
+		context.Background(), SnapshotBalanceTxParams{
+			AccountID: account.ID,
+			Date:      today,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(500), snapshot.Balance)
+
+	latest, err := testQueries.GetLatestBalanceSnapshot(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.True(t, today.Equal(latest.Date))
+}
+
+func TestBalanceAsOf(t *testing.T) {
+	store := NewStore(testDB)
+	account := createReconciledAccount(t, store, 500)
+	afterDeposit := time.Now().Add(time.Second)
+
+	result, err := store.BalanceAsOf(context.Background(), account.ID, afterDeposit)
+	require.NoError(t, err)
+	require.Equal(t, int64(500), result.Balance)
+	require.False(t, result.SnapshotDate.Valid)
+
+	result, err = store.BalanceAsOf(context.Background(), account.ID, account.CreatedAt)
+	require.NoError(t, err)
+	require.Zero(t, result.Balance)
+
+	yesterday := util.StartOfDay(time.Now()).AddDate(0, 0, -1)
+	_, err = store.SnapshotBalanceTx(
+		context.Background(), SnapshotBalanceTxParams{
+			AccountID: account.ID,
+			Date:      yesterday,
+		},
+	)
+	require.NoError(t, err)
+
+	result, err = store.BalanceAsOf(context.Background(), account.ID, afterDeposit)
+	require.NoError(t, err)
+	require.Equal(t, int64(500), result.Balance)
+	require.True(t, result.SnapshotDate.Valid)
+	require.True(t, yesterday.Equal(result.SnapshotDate.Time))
+}
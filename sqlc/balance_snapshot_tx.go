@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var ErrBalanceSnapshotExists = errors.New("balance already snapshotted for this day")
+
+type SnapshotBalanceTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Midnight UTC of the day to snapshot. The snapshot holds the balance at the close of that day.
+	Date time.Time `json:"date"`
+}
+
+// SnapshotBalanceTx records an account's balance at the close of a day, which may be any day since the account was
+// opened. Snapshotting the same day twice returns ErrBalanceSnapshotExists and changes nothing.
+func (store *SQLStore) SnapshotBalanceTx(ctx context.Context, arg SnapshotBalanceTxParams) (BalanceSnapshot, error) {
+	var snapshot BalanceSnapshot
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			balance, err := q.GetAccountBalanceAt(
+				ctx, GetAccountBalanceAtParams{
+					ID:        arg.AccountID,
+					CreatedAt: arg.Date.AddDate(0, 0, 1),
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			snapshot, err = q.CreateBalanceSnapshot(
+				ctx, CreateBalanceSnapshotParams{
+					AccountID: arg.AccountID,
+					Date:      arg.Date,
+					Balance:   balance,
+				},
+			)
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrBalanceSnapshotExists
+			}
+			return err
+		},
+	)
+
+	return snapshot, err
+}
+
+type BalanceAsOfResult struct {
+	Balance int64 `json:"balance"`
+	// SnapshotDate is the day of the snapshot the balance was built from, if there was one.
+	SnapshotDate sql.NullTime `json:"snapshot_date"`
+}
+
+// BalanceAsOf returns an account's balance at asOf, counting entries made strictly before it. It starts from the
+// latest snapshot taken at or before asOf and adds the entries made since, falling back to working back from the
+// current balance when the account has no such snapshot.
+func (store *SQLStore) BalanceAsOf(ctx context.Context, accountID int64, asOf time.Time) (BalanceAsOfResult, error) {
+	var result BalanceAsOfResult
+
+	snapshot, err := store.GetBalanceSnapshotOnOrBefore(
+		ctx, GetBalanceSnapshotOnOrBeforeParams{
+			AccountID: accountID,
+			Date:      asOf.UTC().AddDate(0, 0, -1),
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		result.Balance, err = store.GetAccountBalanceAt(
+			ctx, GetAccountBalanceAtParams{
+				ID:        accountID,
+				CreatedAt: asOf,
+			},
+		)
+		return result, err
+	}
+	if err != nil {
+		return result, err
+	}
+
+	total, err := store.GetAccountEntryTotalBetween(
+		ctx, GetAccountEntryTotalBetweenParams{
+			AccountID: accountID,
+			FromTime:  snapshot.Date.AddDate(0, 0, 1),
+			ToTime:    asOf,
+		},
+	)
+	if err != nil {
+		return result, err
+	}
+
+	result.Balance = snapshot.Balance + total
+	result.SnapshotDate = sql.NullTime{Time: snapshot.Date, Valid: true}
+	return result, nil
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createRandomCashTransaction(t *testing.T, account Account) CashTransaction {
+	user, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	arg := CreateCashTransactionParams{
+		AccountID:      account.ID,
+		Kind:           constants.JournalKindDeposit,
+		Amount:         util.RandomInt(1, 1000),
+		Reference:      util.RandomString(12),
+		Channel:        constants.ChannelBranch,
+		IdempotencyKey: util.RandomString(16),
+		CreatedBy:      user.Username,
+	}
+
+	cashTransaction, err := testQueries.CreateCashTransaction(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.AccountID, cashTransaction.AccountID)
+	require.Equal(t, arg.Kind, cashTransaction.Kind)
+	require.Equal(t, arg.Amount, cashTransaction.Amount)
+	require.Equal(t, arg.Reference, cashTransaction.Reference)
+	require.Equal(t, arg.Channel, cashTransaction.Channel)
+	require.Equal(t, arg.IdempotencyKey, cashTransaction.IdempotencyKey)
+	require.Equal(t, arg.CreatedBy, cashTransaction.CreatedBy)
+	require.False(t, cashTransaction.JournalTransactionID.Valid)
+	require.NotZero(t, cashTransaction.ID)
+	require.NotZero(t, cashTransaction.CreatedAt)
+
+	return cashTransaction
+}
+
+func TestCreateCashTransaction(t *testing.T) {
+	account, _, _ := createRandomAccount()
+	createRandomCashTransaction(t, account)
+}
+
+func TestCreateCashTransactionDuplicateKey(t *testing.T) {
+	account, _, _ := createRandomAccount()
+	cashTransaction := createRandomCashTransaction(t, account)
+
+	_, err := testQueries.CreateCashTransaction(
+		context.Background(), CreateCashTransactionParams{
+			AccountID:      account.ID,
+			Kind:           constants.JournalKindWithdrawal,
+			Amount:         cashTransaction.Amount,
+			Channel:        constants.ChannelATM,
+			IdempotencyKey: cashTransaction.IdempotencyKey,
+			CreatedBy:      cashTransaction.CreatedBy,
+		},
+	)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	existing, err := testQueries.GetCashTransactionByIdempotencyKey(
+		context.Background(), GetCashTransactionByIdempotencyKeyParams{
+			CreatedBy:      cashTransaction.CreatedBy,
+			IdempotencyKey: cashTransaction.IdempotencyKey,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, cashTransaction, existing)
+}
+
+func TestGetCashTransactionsForAccount(t *testing.T) {
+	account, _, _ := createRandomAccount()
+	for i := 0; i < 10; i++ {
+		createRandomCashTransaction(t, account)
+	}
+
+	arg := GetCashTransactionsForAccountParams{
+		AccountID: account.ID,
+		Limit:     5,
+		Offset:    5,
+	}
+
+	cashTransactions, err := testQueries.GetCashTransactionsForAccount(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, cashTransactions, 5)
+
+	for _, cashTransaction := range cashTransactions {
+		require.Equal(t, account.ID, cashTransaction.AccountID)
+	}
+}
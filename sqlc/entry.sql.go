@@ -7,45 +7,84 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entry (account_id,
-                   amount)
-VALUES ($1, $2)
-RETURNING id, account_id, amount, created_at
+                   amount,
+                   journal_transaction_id,
+                   memo,
+                   reference,
+                   category,
+                   invoice_number,
+                   end_to_end_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, account_id, amount, created_at, journal_transaction_id, memo, reference, category, invoice_number, end_to_end_id
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID            int64          `json:"account_id"`
+	Amount               int64          `json:"amount"`
+	JournalTransactionID sql.NullInt64  `json:"journal_transaction_id"`
+	Memo                 sql.NullString `json:"memo"`
+	Reference            sql.NullString `json:"reference"`
+	Category             sql.NullString `json:"category"`
+	InvoiceNumber        sql.NullString `json:"invoice_number"`
+	EndToEndID           sql.NullString `json:"end_to_end_id"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.JournalTransactionID,
+		arg.Memo,
+		arg.Reference,
+		arg.Category,
+		arg.InvoiceNumber,
+		arg.EndToEndID,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.JournalTransactionID,
+		&i.Memo,
+		&i.Reference,
+		&i.Category,
+		&i.InvoiceNumber,
+		&i.EndToEndID,
 	)
 	return i, err
 }
 
-const deleteEntry = `-- name: DeleteEntry :exec
-DELETE
+const getAccountEntryTotalBetween = `-- name: GetAccountEntryTotalBetween :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
 FROM entry
-WHERE id = $1
+WHERE account_id = $1
+  AND created_at >= $2
+  AND created_at < $3
 `
 
-func (q *Queries) DeleteEntry(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteEntry, id)
-	return err
+type GetAccountEntryTotalBetweenParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+func (q *Queries) GetAccountEntryTotalBetween(ctx context.Context, arg GetAccountEntryTotalBetweenParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getAccountEntryTotalBetween, arg.AccountID, arg.FromTime, arg.ToTime)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
 }
 
 const getEntries = `-- name: GetEntries :many
-SELECT id, account_id, amount, created_at
+SELECT id, account_id, amount, created_at, journal_transaction_id, memo, reference, category, invoice_number, end_to_end_id
 FROM entry
 ORDER BY id
 LIMIT $1 OFFSET $2
@@ -70,6 +109,12 @@ func (q *Queries) GetEntries(ctx context.Context, arg GetEntriesParams) ([]Entry
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.JournalTransactionID,
+			&i.Memo,
+			&i.Reference,
+			&i.Category,
+			&i.InvoiceNumber,
+			&i.EndToEndID,
 		); err != nil {
 			return nil, err
 		}
@@ -85,7 +130,7 @@ func (q *Queries) GetEntries(ctx context.Context, arg GetEntriesParams) ([]Entry
 }
 
 const getEntriesForAccount = `-- name: GetEntriesForAccount :many
-SELECT id, account_id, amount, created_at
+SELECT id, account_id, amount, created_at, journal_transaction_id, memo, reference, category, invoice_number, end_to_end_id
 FROM entry
 WHERE account_id = $1
 ORDER BY id
@@ -112,6 +157,12 @@ func (q *Queries) GetEntriesForAccount(ctx context.Context, arg GetEntriesForAcc
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.JournalTransactionID,
+			&i.Memo,
+			&i.Reference,
+			&i.Category,
+			&i.InvoiceNumber,
+			&i.EndToEndID,
 		); err != nil {
 			return nil, err
 		}
@@ -127,7 +178,7 @@ func (q *Queries) GetEntriesForAccount(ctx context.Context, arg GetEntriesForAcc
 }
 
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at
+SELECT id, account_id, amount, created_at, journal_transaction_id, memo, reference, category, invoice_number, end_to_end_id
 FROM entry
 WHERE id = $1
 `
@@ -140,30 +191,12 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
-	)
-	return i, err
-}
-
-const updateEntry = `-- name: UpdateEntry :one
-UPDATE entry
-SET amount = $2
-WHERE id = $1
-RETURNING id, account_id, amount, created_at
-`
-
-type UpdateEntryParams struct {
-	ID     int64 `json:"id"`
-	Amount int64 `json:"amount"`
-}
-
-func (q *Queries) UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, updateEntry, arg.ID, arg.Amount)
-	var i Entry
-	err := row.Scan(
-		&i.ID,
-		&i.AccountID,
-		&i.Amount,
-		&i.CreatedAt,
+		&i.JournalTransactionID,
+		&i.Memo,
+		&i.Reference,
+		&i.Category,
+		&i.InvoiceNumber,
+		&i.EndToEndID,
 	)
 	return i, err
 }
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCreateMaintenanceFeeOncePerPeriod(t *testing.T) {
+	account, _, err := createRandomAccount()
+	require.NoError(t, err)
+
+	arg := CreateFeeParams{
+		AccountID:   account.ID,
+		Kind:        constants.FeeKindMaintenance,
+		Name:        util.RandomString(10),
+		Amount:      util.RandomInt(1, 1000),
+		PeriodStart: sql.NullTime{Time: time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	fee, err := testQueries.CreateFee(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.AccountID, fee.AccountID)
+	require.Equal(t, arg.Kind, fee.Kind)
+	require.Equal(t, arg.Name, fee.Name)
+	require.Equal(t, arg.Amount, fee.Amount)
+	require.False(t, fee.FeeTransferID.Valid)
+	require.NotZero(t, fee.ID)
+
+	_, err = testQueries.CreateFee(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	fees, err := testQueries.GetFeesForAccount(
+		context.Background(), GetFeesForAccountParams{
+			AccountID: account.ID,
+			Limit:     10,
+			Offset:    0,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, fees, 1)
+	require.Equal(t, fee.ID, fees[0].ID)
+}
+
+func TestGetActiveFeeWaivers(t *testing.T) {
+	account, _, err := createRandomAccount()
+	require.NoError(t, err)
+
+	active, err := testQueries.CreateFeeWaiver(
+		context.Background(), CreateFeeWaiverParams{
+			AccountID: account.ID,
+			FeeName:   util.RandomString(10),
+			Reason:    util.RandomString(20),
+			ExpiresAt: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateFeeWaiver(
+		context.Background(), CreateFeeWaiverParams{
+			AccountID: account.ID,
+			ExpiresAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+		},
+	)
+	require.NoError(t, err)
+
+	waivers, err := testQueries.GetActiveFeeWaivers(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, waivers, 1)
+	require.Equal(t, active.ID, waivers[0].ID)
+}
@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/fee"
+	"time"
+)
+
+var ErrFeeAlreadyCharged = errors.New("fee already charged for this period")
+
+type ChargeMaintenanceFeeTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	PeriodStart time.Time `json:"period_start"`
+	// Exclusive. The fees are decided on the balance at the end of the period.
+	PeriodEnd time.Time `json:"period_end"`
+}
+
+// ChargeMaintenanceFeeTx charges an account the monthly maintenance fees it owes for a period under the store's fee
+// schedule. A fee never takes more than the available balance, so an account is never overdrawn by maintenance
+// fees; a fee the account cannot pay at all is still recorded, at zero, so that it isn't charged later. Charging the
+// same period twice returns ErrFeeAlreadyCharged and changes nothing.
+func (store *SQLStore) ChargeMaintenanceFeeTx(ctx context.Context, arg ChargeMaintenanceFeeTxParams) ([]Fee, error) {
+	var fees []Fee
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			account, err := q.GetAccount(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+
+			balance, err := q.GetAccountBalanceAt(
+				ctx, GetAccountBalanceAtParams{
+					ID:        account.ID,
+					CreatedAt: arg.PeriodEnd,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			charges, err := waiveFees(
+				ctx, q, account.ID, store.fees.MaintenanceCharges(account.Product, account.Currency, balance),
+			)
+			if err != nil || len(charges) == 0 {
+				return err
+			}
+
+			revenueAccount, err := systemAccount(ctx, q, constants.SystemAccountFeeRevenue, account.Currency)
+			if err != nil {
+				return err
+			}
+
+			accounts, err := lockAccounts(ctx, q, account.ID, revenueAccount.ID)
+			if err != nil {
+				return err
+			}
+
+			available := availableBalance(accounts[account.ID])
+			for _, charge := range charges {
+				amount := charge.Amount
+				if amount > available {
+					amount = available
+				}
+				if amount < 0 {
+					amount = 0
+				}
+
+				charged, _, err := chargeFee(
+					ctx, q, CreateFeeParams{
+						AccountID:   account.ID,
+						Kind:        constants.FeeKindMaintenance,
+						Name:        charge.Name,
+						Amount:      amount,
+						PeriodStart: sql.NullTime{Time: arg.PeriodStart, Valid: true},
+					}, revenueAccount.ID,
+				)
+				if err != nil {
+					return err
+				}
+
+				fees = append(fees, charged)
+				available -= amount
+			}
+
+			return nil
+		},
+	)
+
+	return fees, err
+}
+
+// chargeFee records a fee and moves it from the charged account to the bank's fee revenue account. A zero fee is
+// only recorded. Callers must already hold the locks on both accounts and have checked the charged account's funds.
+func chargeFee(
+	ctx context.Context, q *Queries, arg CreateFeeParams, revenueAccountID int64,
+) (Fee, TransferTxResult, error) {
+	var feeTransfer TransferTxResult
+
+	charged, err := q.CreateFee(ctx, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		return charged, feeTransfer, ErrFeeAlreadyCharged
+	}
+	if err != nil || arg.Amount == 0 {
+		return charged, feeTransfer, err
+	}
+
+	feeTransfer, err = moveFunds(
+		ctx, q, TransferTxParams{
+			SourceAccountID:      arg.AccountID,
+			DestinationAccountID: revenueAccountID,
+			Amount:               arg.Amount,
+		}, 0,
+	)
+	if err != nil {
+		return charged, feeTransfer, err
+	}
+
+	charged, err = q.UpdateFeeTransfer(
+		ctx, UpdateFeeTransferParams{
+			ID:            charged.ID,
+			FeeTransferID: sql.NullInt64{Int64: feeTransfer.Transfer.ID, Valid: true},
+		},
+	)
+	return charged, feeTransfer, err
+}
+
+// waiveFees drops the charges that an active waiver on the account covers.
+func waiveFees(ctx context.Context, q *Queries, accountID int64, charges []fee.Charge) ([]fee.Charge, error) {
+	if len(charges) == 0 {
+		return charges, nil
+	}
+
+	waivers, err := q.GetActiveFeeWaivers(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	waived := make(map[string]bool, len(waivers))
+	for _, waiver := range waivers {
+		if waiver.FeeName == "" {
+			return nil, nil
+		}
+		waived[waiver.FeeName] = true
+	}
+
+	var remaining []fee.Charge
+	for _, charge := range charges {
+		if !waived[charge.Name] {
+			remaining = append(remaining, charge)
+		}
+	}
+	return remaining, nil
+}
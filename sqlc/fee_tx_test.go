@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/fee"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func feeRevenueBalance(t *testing.T, currency string) int64 {
+	account, err := systemAccount(context.Background(), testQueries, constants.SystemAccountFeeRevenue, currency)
+	require.NoError(t, err)
+	return account.Balance
+}
+
+func TestTransferTxFees(t *testing.T) {
+	feeName := util.RandomString(12)
+	store := NewStore(
+		testDB, WithFeeSchedule(fee.Schedule{TransferFees: []fee.TransferFee{{Name: feeName, RateBps: 100}}}),
+	)
+
+	source, err := createFundedAccount(10000)
+	require.NoError(t, err)
+	destination, _, _ := createRandomAccount()
+	revenueBalance := feeRevenueBalance(t, source.Currency)
+
+	result, err := store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               5000,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(5000), result.Transfer.Amount)
+	require.Equal(t, int64(4950), result.SourceAccount.Balance)
+	require.Equal(t, destination.Balance+5000, result.DestinationAccount.Balance)
+
+	require.Len(t, result.Fees, 1)
+	require.Equal(t, feeName, result.Fees[0].Name)
+	require.Equal(t, constants.FeeKindTransfer, result.Fees[0].Kind)
+	require.Equal(t, int64(50), result.Fees[0].Amount)
+	require.Equal(t, result.Transfer.ID, result.Fees[0].TransferID.Int64)
+	require.True(t, result.Fees[0].FeeTransferID.Valid)
+	require.Equal(t, revenueBalance+50, feeRevenueBalance(t, source.Currency))
+
+	// The fee must be covered as well as the amount.
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               4950,
+		},
+	)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = testQueries.CreateFeeWaiver(
+		context.Background(), CreateFeeWaiverParams{
+			AccountID: source.ID,
+			FeeName:   feeName,
+			Reason:    "promotion",
+		},
+	)
+	require.NoError(t, err)
+
+	result, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               4950,
+		},
+	)
+	require.NoError(t, err)
+	require.Empty(t, result.Fees)
+	require.Zero(t, result.SourceAccount.Balance)
+}
+
+func TestChargeMaintenanceFeeTx(t *testing.T) {
+	account, err := createFundedAccount(300)
+	require.NoError(t, err)
+
+	feeName := util.RandomString(12)
+	store := NewStore(
+		testDB, WithFeeSchedule(
+			fee.Schedule{
+				MaintenanceFees: []fee.MaintenanceFee{
+					{
+						Name:           feeName,
+						Product:        constants.ProductChecking,
+						Currency:       account.Currency,
+						MinimumBalance: 1000,
+						Amount:         500,
+					},
+				},
+			},
+		),
+	)
+
+	arg := ChargeMaintenanceFeeTxParams{
+		AccountID:   account.ID,
+		PeriodStart: util.StartOfMonth(time.Now()),
+		PeriodEnd:   time.Now().Add(time.Hour),
+	}
+	revenueBalance := feeRevenueBalance(t, account.Currency)
+
+	// The fee is capped at what the account can pay.
+	fees, err := store.ChargeMaintenanceFeeTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, fees, 1)
+	require.Equal(t, feeName, fees[0].Name)
+	require.Equal(t, constants.FeeKindMaintenance, fees[0].Kind)
+	require.Equal(t, int64(300), fees[0].Amount)
+	require.True(t, fees[0].FeeTransferID.Valid)
+	require.Equal(t, revenueBalance+300, feeRevenueBalance(t, account.Currency))
+
+	account, err = testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Zero(t, account.Balance)
+
+	_, err = store.ChargeMaintenanceFeeTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrFeeAlreadyCharged)
+}
+
+func TestChargeMaintenanceFeeTxAboveMinimum(t *testing.T) {
+	account, err := createFundedAccount(1000)
+	require.NoError(t, err)
+
+	store := NewStore(
+		testDB, WithFeeSchedule(
+			fee.Schedule{
+				MaintenanceFees: []fee.MaintenanceFee{
+					{
+						Name:           util.RandomString(12),
+						Product:        constants.ProductChecking,
+						Currency:       account.Currency,
+						MinimumBalance: 1000,
+						Amount:         500,
+					},
+				},
+			},
+		),
+	)
+
+	fees, err := store.ChargeMaintenanceFeeTx(
+		context.Background(), ChargeMaintenanceFeeTxParams{
+			AccountID:   account.ID,
+			PeriodStart: util.StartOfMonth(time.Now()),
+			PeriodEnd:   time.Now().Add(time.Hour),
+		},
+	)
+	require.NoError(t, err)
+	require.Empty(t, fees)
+
+	recorded, err := testQueries.GetFeesForAccount(
+		context.Background(), GetFeesForAccountParams{
+			AccountID: account.ID,
+			Limit:     10,
+			Offset:    0,
+		},
+	)
+	require.NoError(t, err)
+	require.Empty(t, recorded)
+}
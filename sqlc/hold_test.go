@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCreateHold(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, _, _ := createRandomAccount()
+
+	hold, arg, err := createRandomHold(account1.ID, account2.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, hold)
+
+	require.Equal(t, arg.AccountID, hold.AccountID)
+	require.Equal(t, arg.DestinationAccountID, hold.DestinationAccountID)
+	require.Equal(t, arg.Amount, hold.Amount)
+	require.Equal(t, arg.Reference, hold.Reference)
+	require.Equal(t, constants.HoldStatusActive, hold.Status)
+	require.Zero(t, hold.CapturedAmount)
+	require.False(t, hold.TransferID.Valid)
+	require.WithinDuration(t, arg.ExpiresAt, hold.ExpiresAt, time.Second)
+
+	require.NotZero(t, hold.ID)
+	require.NotZero(t, hold.CreatedAt)
+}
+
+func TestGetHold(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, _, _ := createRandomAccount()
+
+	hold1, _, _ := createRandomHold(account1.ID, account2.ID)
+	hold2, err := testQueries.GetHold(context.Background(), hold1.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, hold2)
+
+	require.Equal(t, hold1.ID, hold2.ID)
+	require.Equal(t, hold1.AccountID, hold2.AccountID)
+	require.Equal(t, hold1.Amount, hold2.Amount)
+	require.Equal(t, hold1.Status, hold2.Status)
+	require.WithinDuration(t, hold1.CreatedAt, hold2.CreatedAt, time.Second)
+}
+
+func TestGetHoldsForAccount(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, _, _ := createRandomAccount()
+
+	for i := 0; i < 10; i++ {
+		_, _, err := createRandomHold(account1.ID, account2.ID)
+		require.NoError(t, err)
+	}
+
+	arg := GetHoldsForAccountParams{
+		AccountID: account1.ID,
+		Limit:     5,
+		Offset:    5,
+	}
+
+	holds, err := testQueries.GetHoldsForAccount(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, holds, 5)
+
+	for _, hold := range holds {
+		require.NotEmpty(t, hold)
+		require.Equal(t, account1.ID, hold.AccountID)
+	}
+}
+
+func TestGetExpiredHolds(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, _, _ := createRandomAccount()
+
+	expiredHold, err := testQueries.CreateHold(
+		context.Background(), CreateHoldParams{
+			AccountID:            account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               10,
+			ExpiresAt:            time.Now().Add(-time.Minute),
+		},
+	)
+	require.NoError(t, err)
+
+	activeHold, _, err := createRandomHold(account1.ID, account2.ID)
+	require.NoError(t, err)
+
+	holds, err := testQueries.GetExpiredHolds(
+		context.Background(), GetExpiredHoldsParams{
+			ExpiresAt: time.Now(),
+			Limit:     1000,
+		},
+	)
+	require.NoError(t, err)
+
+	ids := make(map[int64]bool)
+	for _, hold := range holds {
+		require.Equal(t, constants.HoldStatusActive, hold.Status)
+		ids[hold.ID] = true
+	}
+	require.Contains(t, ids, expiredHold.ID)
+	require.NotContains(t, ids, activeHold.ID)
+}
+
+func TestUpdateHoldStatus(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, _, _ := createRandomAccount()
+
+	hold1, _, _ := createRandomHold(account1.ID, account2.ID)
+	hold2, err := testQueries.UpdateHoldStatus(
+		context.Background(), UpdateHoldStatusParams{
+			ID:     hold1.ID,
+			Status: constants.HoldStatusVoided,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, hold1.ID, hold2.ID)
+	require.Equal(t, constants.HoldStatusVoided, hold2.Status)
+}
+
+func TestCaptureHold(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, _, _ := createRandomAccount()
+
+	hold1, _, _ := createRandomHold(account1.ID, account2.ID)
+	transfer, _, _ := createRandomTransfer(account1.ID, account2.ID)
+
+	hold2, err := testQueries.CaptureHold(
+		context.Background(), CaptureHoldParams{
+			ID:             hold1.ID,
+			CapturedAmount: hold1.Amount,
+			TransferID:     sql.NullInt64{Int64: transfer.ID, Valid: true},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.HoldStatusCaptured, hold2.Status)
+	require.Equal(t, hold1.Amount, hold2.CapturedAmount)
+	require.Equal(t, transfer.ID, hold2.TransferID.Int64)
+}
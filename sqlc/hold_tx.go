@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"time"
+)
+
+var (
+	ErrHoldNotActive      = errors.New("hold is not active")
+	ErrHoldExpired        = errors.New("hold has expired")
+	ErrCaptureExceedsHold = errors.New("capture amount exceeds held amount")
+)
+
+type PlaceHoldTxParams struct {
+	AccountID            int64     `json:"account_id"`
+	DestinationAccountID int64     `json:"destination_account_id"`
+	Amount               int64     `json:"amount"`
+	Reference            string    `json:"reference"`
+	ExpiresAt            time.Time `json:"expires_at"`
+}
+
+func (store *SQLStore) PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (Hold, error) {
+	var hold Hold
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+
+			if err := checkAccountPosting(account, -arg.Amount); err != nil {
+				return err
+			}
+
+			if availableBalance(account) < arg.Amount {
+				return ErrInsufficientFunds
+			}
+
+			_, err = q.AddAccountHeldBalance(
+				ctx, AddAccountHeldBalanceParams{
+					ID:     arg.AccountID,
+					Amount: arg.Amount,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			hold, err = q.CreateHold(
+				ctx, CreateHoldParams{
+					AccountID:            arg.AccountID,
+					DestinationAccountID: arg.DestinationAccountID,
+					Amount:               arg.Amount,
+					Reference:            arg.Reference,
+					ExpiresAt:            arg.ExpiresAt,
+				},
+			)
+			return err
+		},
+	)
+
+	return hold, err
+}
+
+type CaptureHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	// Amount to settle, up to the held amount. Any remainder of the hold is released.
+	Amount int64 `json:"amount"`
+}
+
+type CaptureHoldTxResult struct {
+	Hold     Hold             `json:"hold"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// CaptureHoldTx settles a hold as a regular transfer to its destination account. A partial capture releases the
+// rest of the hold.
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			hold, err := lockActiveHold(ctx, q, arg.HoldID)
+			if err != nil {
+				return err
+			}
+
+			if arg.Amount > hold.Amount {
+				return ErrCaptureExceedsHold
+			}
+
+			result.Transfer, err = transferTx(
+				ctx, q, TransferTxParams{
+					SourceAccountID:      hold.AccountID,
+					DestinationAccountID: hold.DestinationAccountID,
+					Amount:               arg.Amount,
+				}, hold.Amount, store.fees, store.limits,
+			)
+			if err != nil {
+				return err
+			}
+
+			result.Hold, err = q.CaptureHold(
+				ctx, CaptureHoldParams{
+					ID:             hold.ID,
+					CapturedAmount: arg.Amount,
+					TransferID:     sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+				},
+			)
+			return err
+		},
+	)
+
+	return result, err
+}
+
+func (store *SQLStore) VoidHoldTx(ctx context.Context, holdID int64) (Hold, error) {
+	return store.releaseHoldTx(ctx, holdID, constants.HoldStatusVoided)
+}
+
+// ExpireHoldTx releases a hold whose expiry has passed. It returns ErrHoldNotActive if the hold was settled or
+// released in the meantime.
+func (store *SQLStore) ExpireHoldTx(ctx context.Context, holdID int64) (Hold, error) {
+	return store.releaseHoldTx(ctx, holdID, constants.HoldStatusExpired)
+}
+
+func (store *SQLStore) releaseHoldTx(ctx context.Context, holdID int64, status string) (Hold, error) {
+	var hold Hold
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			var err error
+
+			hold, err = q.GetHoldForUpdate(ctx, holdID)
+			if err != nil {
+				return err
+			}
+
+			if hold.Status != constants.HoldStatusActive {
+				return ErrHoldNotActive
+			}
+
+			if status == constants.HoldStatusExpired && time.Now().Before(hold.ExpiresAt) {
+				return ErrHoldNotActive
+			}
+
+			_, err = q.AddAccountHeldBalance(
+				ctx, AddAccountHeldBalanceParams{
+					ID:     hold.AccountID,
+					Amount: -hold.Amount,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			hold, err = q.UpdateHoldStatus(
+				ctx, UpdateHoldStatusParams{
+					ID:     hold.ID,
+					Status: status,
+				},
+			)
+			return err
+		},
+	)
+
+	return hold, err
+}
+
+func lockActiveHold(ctx context.Context, q *Queries, holdID int64) (Hold, error) {
+	hold, err := q.GetHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return hold, err
+	}
+
+	if hold.Status != constants.HoldStatusActive {
+		return hold, ErrHoldNotActive
+	}
+
+	if !time.Now().Before(hold.ExpiresAt) {
+		return hold, ErrHoldExpired
+	}
+
+	return hold, nil
+}
@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func placeTestHold(t *testing.T, store Store, source Account, destination Account, amount int64) Hold {
+	hold, err := store.PlaceHoldTx(
+		context.Background(), PlaceHoldTxParams{
+			AccountID:            source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               amount,
+			ExpiresAt:            time.Now().Add(time.Hour),
+		},
+	)
+	require.NoError(t, err)
+	return hold
+}
+
+func TestPlaceHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	hold := placeTestHold(t, store, account1, account2, 60)
+	require.Equal(t, constants.HoldStatusActive, hold.Status)
+	require.Equal(t, int64(60), hold.Amount)
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), updatedAccount1.Balance)
+	require.Equal(t, int64(60), updatedAccount1.HeldBalance)
+
+	_, err = store.PlaceHoldTx(
+		context.Background(), PlaceHoldTxParams{
+			AccountID:            account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               41,
+			ExpiresAt:            time.Now().Add(time.Hour),
+		},
+	)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               41,
+		},
+	)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCaptureHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	hold := placeTestHold(t, store, account1, account2, 100)
+
+	result, err := store.CaptureHoldTx(
+		context.Background(), CaptureHoldTxParams{
+			HoldID: hold.ID,
+			Amount: 70,
+		},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, constants.HoldStatusCaptured, result.Hold.Status)
+	require.Equal(t, int64(70), result.Hold.CapturedAmount)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Hold.TransferID.Int64)
+
+	require.Equal(t, int64(70), result.Transfer.Transfer.Amount)
+	require.Equal(t, int64(30), result.Transfer.SourceAccount.Balance)
+	require.Zero(t, result.Transfer.SourceAccount.HeldBalance)
+	require.Equal(t, account2.Balance+70, result.Transfer.DestinationAccount.Balance)
+
+	_, err = store.CaptureHoldTx(
+		context.Background(), CaptureHoldTxParams{
+			HoldID: hold.ID,
+			Amount: 10,
+		},
+	)
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestCaptureHoldTxExceedsHold(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	hold := placeTestHold(t, store, account1, account2, 50)
+
+	_, err = store.CaptureHoldTx(
+		context.Background(), CaptureHoldTxParams{
+			HoldID: hold.ID,
+			Amount: 51,
+		},
+	)
+	require.ErrorIs(t, err, ErrCaptureExceedsHold)
+}
+
+func TestVoidHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	hold := placeTestHold(t, store, account1, account2, 100)
+
+	voidedHold, err := store.VoidHoldTx(context.Background(), hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.HoldStatusVoided, voidedHold.Status)
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), updatedAccount1.Balance)
+	require.Zero(t, updatedAccount1.HeldBalance)
+
+	_, err = store.VoidHoldTx(context.Background(), hold.ID)
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestExpireHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	hold := placeTestHold(t, store, account1, account2, 100)
+
+	_, err = store.ExpireHoldTx(context.Background(), hold.ID)
+	require.ErrorIs(t, err, ErrHoldNotActive)
+
+	_, err = testDB.Exec("UPDATE hold SET expires_at = now() - interval '1 minute' WHERE id = $1", hold.ID)
+	require.NoError(t, err)
+
+	expiredHold, err := store.ExpireHoldTx(context.Background(), hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.HoldStatusExpired, expiredHold.Status)
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Zero(t, updatedAccount1.HeldBalance)
+}
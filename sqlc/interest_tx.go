@@ -0,0 +1,186 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/interest"
+	"time"
+)
+
+var (
+	ErrInterestAlreadyAccrued = errors.New("interest already accrued for this day")
+	ErrInterestAlreadyPosted  = errors.New("interest already posted for this period")
+	ErrNoInterestAccrued      = errors.New("no interest accrued in this period")
+)
+
+type AccrueInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Midnight UTC of the day to accrue for. Interest is earned on the balance at the end of that day.
+	Date time.Time `json:"date"`
+}
+
+// AccrueInterestTx records one day of interest on an account at its product's rate. Accruing the same day twice
+// returns ErrInterestAlreadyAccrued and changes nothing.
+func (store *SQLStore) AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (InterestAccrual, error) {
+	var accrual InterestAccrual
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			account, err := q.GetAccount(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+
+			product, err := q.GetAccountProduct(ctx, account.Product)
+			if err != nil {
+				return err
+			}
+
+			balance, err := q.GetAccountBalanceAt(
+				ctx, GetAccountBalanceAtParams{
+					ID:        account.ID,
+					CreatedAt: arg.Date.AddDate(0, 0, 1),
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			amountMicros, err := interest.DailyAccrual(
+				balance, product.InterestRateBps, product.DayCountConvention, arg.Date,
+			)
+			if err != nil {
+				return err
+			}
+
+			accrual, err = q.CreateInterestAccrual(
+				ctx, CreateInterestAccrualParams{
+					AccountID:          account.ID,
+					AccrualDate:        arg.Date,
+					Balance:            balance,
+					InterestRateBps:    product.InterestRateBps,
+					DayCountConvention: product.DayCountConvention,
+					AmountMicros:       amountMicros,
+				},
+			)
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInterestAlreadyAccrued
+			}
+			return err
+		},
+	)
+
+	return accrual, err
+}
+
+type PostInterestTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	PeriodStart time.Time `json:"period_start"`
+	// Accruals before this date that have not been posted yet are included.
+	PeriodEnd time.Time `json:"period_end"`
+}
+
+type PostInterestTxResult struct {
+	Posting InterestPosting `json:"posting"`
+	// Empty when the accruals added up to less than one minor unit.
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// PostInterestTx pays the interest accrued on an account as a transfer from the bank's interest expense account in
+// the same currency. Only whole minor units are paid; the remainder is carried into the next period. Posting the
+// same period twice returns ErrInterestAlreadyPosted.
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			account, err := q.GetAccount(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+
+			var carryMicros int64
+			lastPosting, err := q.GetLastInterestPosting(ctx, account.ID)
+			switch {
+			case err == nil:
+				carryMicros = lastPosting.CarryMicros
+			case !errors.Is(err, sql.ErrNoRows):
+				return err
+			}
+
+			result.Posting, err = q.CreateInterestPosting(
+				ctx, CreateInterestPostingParams{
+					AccountID:   account.ID,
+					PeriodStart: arg.PeriodStart,
+					PeriodEnd:   arg.PeriodEnd,
+				},
+			)
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInterestAlreadyPosted
+			}
+			if err != nil {
+				return err
+			}
+
+			accruals, err := q.PostInterestAccruals(
+				ctx, PostInterestAccrualsParams{
+					PostingID:   sql.NullInt64{Int64: result.Posting.ID, Valid: true},
+					AccountID:   account.ID,
+					AccrualDate: arg.PeriodEnd,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			if len(accruals) == 0 {
+				return ErrNoInterestAccrued
+			}
+
+			totalMicros := carryMicros
+			for _, amountMicros := range accruals {
+				totalMicros += amountMicros
+			}
+			amount, carryMicros := interest.Split(totalMicros)
+
+			update := UpdateInterestPostingParams{
+				ID:          result.Posting.ID,
+				Amount:      amount,
+				CarryMicros: carryMicros,
+			}
+
+			if amount > 0 {
+				expenseAccount, err := systemAccount(
+					ctx, q, constants.SystemAccountInterestExpense, account.Currency,
+				)
+				if err != nil {
+					return err
+				}
+
+				_, err = lockAccounts(ctx, q, expenseAccount.ID, account.ID)
+				if err != nil {
+					return err
+				}
+
+				result.Transfer, err = moveFunds(
+					ctx, q, TransferTxParams{
+						SourceAccountID:      expenseAccount.ID,
+						DestinationAccountID: account.ID,
+						Amount:               amount,
+					}, 0,
+				)
+				if err != nil {
+					return err
+				}
+
+				update.TransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+			}
+
+			result.Posting, err = q.UpdateInterestPosting(ctx, update)
+			return err
+		},
+	)
+
+	return result, err
+}
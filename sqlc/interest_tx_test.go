@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAccrueInterestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createSavingsAccount(365000)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	// Today's transfer doesn't count towards yesterday's end-of-day balance.
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               182500,
+		},
+	)
+	require.NoError(t, err)
+
+	accrual, err := store.AccrueInterestTx(
+		context.Background(), AccrueInterestTxParams{
+			AccountID: account1.ID,
+			Date:      today.AddDate(0, 0, -1),
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, account1.ID, accrual.AccountID)
+	require.Equal(t, int64(365000), accrual.Balance)
+	require.Equal(t, int32(200), accrual.InterestRateBps)
+	require.Equal(t, constants.DayCountActual365, accrual.DayCountConvention)
+	require.Equal(t, int64(20000000), accrual.AmountMicros)
+	require.False(t, accrual.PostingID.Valid)
+
+	accrual, err = store.AccrueInterestTx(
+		context.Background(), AccrueInterestTxParams{
+			AccountID: account1.ID,
+			Date:      today,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(182500), accrual.Balance)
+	require.Equal(t, int64(10000000), accrual.AmountMicros)
+
+	_, err = store.AccrueInterestTx(
+		context.Background(), AccrueInterestTxParams{
+			AccountID: account1.ID,
+			Date:      today,
+		},
+	)
+	require.ErrorIs(t, err, ErrInterestAlreadyAccrued)
+
+	lastAccrualDate, err := testQueries.GetLastInterestAccrualDate(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.True(t, today.Equal(lastAccrualDate.UTC()))
+}
+
+func TestPostInterestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account, err := createSavingsAccount(100000)
+	require.NoError(t, err)
+
+	january := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	february := january.AddDate(0, 1, 0)
+	march := february.AddDate(0, 1, 0)
+
+	// 100000 at 2% earns 5.479452 a day under actual/365.
+	for _, day := range []time.Time{january.AddDate(0, 0, 28), january.AddDate(0, 0, 29), january.AddDate(0, 0, 30)} {
+		_, err := store.AccrueInterestTx(
+			context.Background(), AccrueInterestTxParams{
+				AccountID: account.ID,
+				Date:      day,
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	arg := PostInterestTxParams{
+		AccountID:   account.ID,
+		PeriodStart: january,
+		PeriodEnd:   february,
+	}
+
+	result, err := store.PostInterestTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(16), result.Posting.Amount)
+	require.Equal(t, int64(438356), result.Posting.CarryMicros)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Posting.TransferID.Int64)
+	require.Equal(t, account.ID, result.Transfer.DestinationAccount.ID)
+	require.Equal(t, int64(100016), result.Transfer.DestinationAccount.Balance)
+
+	expenseAccount := result.Transfer.SourceAccount
+	require.Equal(t, constants.BankUsername, expenseAccount.Owner)
+	require.Equal(t, constants.ProductInternal, expenseAccount.Product)
+	require.Equal(t, account.Currency, expenseAccount.Currency)
+
+	_, err = store.PostInterestTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInterestAlreadyPosted)
+
+	_, err = store.AccrueInterestTx(
+		context.Background(), AccrueInterestTxParams{
+			AccountID: account.ID,
+			Date:      february,
+		},
+	)
+	require.NoError(t, err)
+
+	result, err = store.PostInterestTx(
+		context.Background(), PostInterestTxParams{
+			AccountID:   account.ID,
+			PeriodStart: february,
+			PeriodEnd:   march,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), result.Posting.Amount)
+	require.Equal(t, int64(917808), result.Posting.CarryMicros)
+	require.Equal(t, expenseAccount.ID, result.Transfer.SourceAccount.ID)
+
+	_, err = store.PostInterestTx(
+		context.Background(), PostInterestTxParams{
+			AccountID:   account.ID,
+			PeriodStart: march,
+			PeriodEnd:   march.AddDate(0, 1, 0),
+		},
+	)
+	require.ErrorIs(t, err, ErrNoInterestAccrued)
+}
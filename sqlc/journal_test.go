@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetJournalTransactionEntries(t *testing.T) {
+	account, _, err := createRandomAccount()
+	require.NoError(t, err)
+
+	entry, _, err := createRandomEntry(account.ID)
+	require.NoError(t, err)
+
+	journalTransaction, err := testQueries.GetJournalTransaction(context.Background(), entry.JournalTransactionID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, constants.JournalKindDeposit, journalTransaction.Kind)
+
+	entries, err := testQueries.GetJournalTransactionEntries(context.Background(), entry.JournalTransactionID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, entry.ID, entries[0].ID)
+	require.Equal(t, -entry.Amount, entries[1].Amount)
+}
+
+func TestUnbalancedEntriesRejectedAtCommit(t *testing.T) {
+	account, _, err := createRandomAccount()
+	require.NoError(t, err)
+
+	tx, err := testDB.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	q := New(tx)
+	journalTransaction, err := q.CreateJournalTransaction(
+		context.Background(), CreateJournalTransactionParams{Kind: constants.JournalKindDeposit},
+	)
+	require.NoError(t, err)
+
+	_, err = q.CreateEntry(
+		context.Background(), CreateEntryParams{
+			AccountID:            account.ID,
+			Amount:               util.RandomInt(1, 1000),
+			JournalTransactionID: sql.NullInt64{Int64: journalTransaction.ID, Valid: true},
+		},
+	)
+	require.NoError(t, err)
+
+	require.Error(t, tx.Commit())
+}
+
+func TestEntryWithoutJournalTransactionRejected(t *testing.T) {
+	account, _, err := createRandomAccount()
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateEntry(
+		context.Background(), CreateEntryParams{
+			AccountID: account.ID,
+			Amount:    util.RandomInt(1, 1000),
+		},
+	)
+	require.Error(t, err)
+}
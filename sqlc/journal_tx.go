@@ -0,0 +1,292 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+)
+
+var (
+	ErrUnbalancedJournal    = errors.New("journal postings must sum to zero in each currency")
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+)
+
+type Posting struct {
+	AccountID int64 `json:"account_id"`
+	// Positive credits the account, negative debits it.
+	Amount int64 `json:"amount"`
+}
+
+type PostJournalTxParams struct {
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	Postings    []Posting `json:"postings"`
+	// Copied onto every entry
+	Remittance Remittance `json:"remittance"`
+}
+
+type PostJournalTxResult struct {
+	JournalTransaction JournalTransaction `json:"journal_transaction"`
+	// Entries and Accounts line up with the postings.
+	Entries  []Entry   `json:"entries"`
+	Accounts []Account `json:"accounts"`
+}
+
+// PostJournalTx records a journal transaction and applies its postings to the account balances. The postings must
+// balance per currency or nothing is written. It doesn't check that any account can afford its debit, so it is meant
+// for callers that have already decided the movement is allowed.
+func (store *SQLStore) PostJournalTx(ctx context.Context, arg PostJournalTxParams) (PostJournalTxResult, error) {
+	var result PostJournalTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			accountIDs := make([]int64, len(arg.Postings))
+			for i, posting := range arg.Postings {
+				accountIDs[i] = posting.AccountID
+			}
+
+			_, err := lockAccounts(ctx, q, accountIDs...)
+			if err != nil {
+				return err
+			}
+
+			result, err = postJournal(ctx, q, arg)
+			return err
+		},
+	)
+
+	return result, err
+}
+
+// postJournal writes a journal transaction inside an open transaction. Callers must already hold the account locks.
+// Postings that an account's status doesn't allow are rejected.
+// An unbalanced journal is only detected once written, so the caller's transaction must be rolled back on error; the
+// database rejects it at commit regardless.
+func postJournal(ctx context.Context, q *Queries, arg PostJournalTxParams) (PostJournalTxResult, error) {
+	var result PostJournalTxResult
+
+	if len(arg.Postings) < 2 {
+		return result, ErrUnbalancedJournal
+	}
+
+	var err error
+	result.JournalTransaction, err = q.CreateJournalTransaction(
+		ctx, CreateJournalTransactionParams{
+			Kind:        arg.Kind,
+			Description: arg.Description,
+		},
+	)
+	if err != nil {
+		return result, err
+	}
+
+	journalTransactionID := sql.NullInt64{Int64: result.JournalTransaction.ID, Valid: true}
+	totals := map[string]int64{}
+
+	for _, posting := range arg.Postings {
+		entry, err := q.CreateEntry(
+			ctx, CreateEntryParams{
+				AccountID:            posting.AccountID,
+				Amount:               posting.Amount,
+				JournalTransactionID: journalTransactionID,
+				Memo:                 arg.Remittance.Memo,
+				Reference:            arg.Remittance.Reference,
+				Category:             arg.Remittance.Category,
+				InvoiceNumber:        arg.Remittance.InvoiceNumber,
+				EndToEndID:           arg.Remittance.EndToEndID,
+			},
+		)
+		if err != nil {
+			return result, err
+		}
+
+		account, err := q.UpdateAccountBalance(
+			ctx, UpdateAccountBalanceParams{
+				ID:     posting.AccountID,
+				Amount: posting.Amount,
+			},
+		)
+		if err != nil {
+			return result, err
+		}
+
+		if err := checkAccountPosting(account, posting.Amount); err != nil {
+			return result, err
+		}
+
+		result.Entries = append(result.Entries, entry)
+		result.Accounts = append(result.Accounts, account)
+		totals[account.Currency] += posting.Amount
+	}
+
+	for _, total := range totals {
+		if total != 0 {
+			return result, ErrUnbalancedJournal
+		}
+	}
+
+	return result, nil
+}
+
+type CashTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Amount    int64  `json:"amount"`
+	Reference string `json:"reference"`
+	Channel   string `json:"channel"`
+	// IdempotencyKey is scoped to CreatedBy, so each caller only has to keep its own keys unique.
+	IdempotencyKey string `json:"idempotency_key"`
+	CreatedBy      string `json:"created_by"`
+}
+
+type CashTxResult struct {
+	CashTransaction    CashTransaction    `json:"cash_transaction"`
+	JournalTransaction JournalTransaction `json:"journal_transaction"`
+	Entry              Entry              `json:"entry"`
+	Account            Account            `json:"account"`
+	// Replayed is set when the idempotency key had already been used for the same request, in which case nothing
+	// new was posted and the original transaction is returned.
+	Replayed bool `json:"replayed"`
+}
+
+// DepositTx credits an account with cash brought into the bank, debiting the bank's cash account in its currency.
+func (store *SQLStore) DepositTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return store.cashTx(ctx, constants.JournalKindDeposit, arg)
+}
+
+// WithdrawTx debits an account for cash taken out of the bank, crediting the bank's cash account in its currency.
+// It fails with ErrInsufficientFunds if the account's available balance doesn't cover the amount.
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return store.cashTx(ctx, constants.JournalKindWithdrawal, arg)
+}
+
+func (store *SQLStore) cashTx(ctx context.Context, kind string, arg CashTxParams) (CashTxResult, error) {
+	var result CashTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			cashTransaction, err := q.CreateCashTransaction(
+				ctx, CreateCashTransactionParams{
+					AccountID:      arg.AccountID,
+					Kind:           kind,
+					Amount:         arg.Amount,
+					Reference:      arg.Reference,
+					Channel:        arg.Channel,
+					IdempotencyKey: arg.IdempotencyKey,
+					CreatedBy:      arg.CreatedBy,
+				},
+			)
+			if errors.Is(err, sql.ErrNoRows) {
+				return replayCashTx(ctx, q, kind, arg, &result)
+			}
+			if err != nil {
+				return err
+			}
+
+			account, err := q.GetAccount(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+
+			cashAccount, err := systemAccount(ctx, q, constants.SystemAccountCash, account.Currency)
+			if err != nil {
+				return err
+			}
+
+			accounts, err := lockAccounts(ctx, q, account.ID, cashAccount.ID)
+			if err != nil {
+				return err
+			}
+
+			amount := arg.Amount
+			if kind == constants.JournalKindWithdrawal {
+				amount = -arg.Amount
+			}
+
+			if err := checkAccountPosting(accounts[account.ID], amount); err != nil {
+				return err
+			}
+
+			if kind == constants.JournalKindWithdrawal {
+				if availableBalance(accounts[account.ID]) < arg.Amount {
+					return ErrInsufficientFunds
+				}
+			}
+
+			journal, err := postJournal(
+				ctx, q, PostJournalTxParams{
+					Kind:        kind,
+					Description: arg.Reference,
+					Postings: []Posting{
+						{AccountID: account.ID, Amount: amount},
+						{AccountID: cashAccount.ID, Amount: -amount},
+					},
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			result.CashTransaction, err = q.UpdateCashTransactionJournal(
+				ctx, UpdateCashTransactionJournalParams{
+					ID:                   cashTransaction.ID,
+					JournalTransactionID: sql.NullInt64{Int64: journal.JournalTransaction.ID, Valid: true},
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			result.JournalTransaction = journal.JournalTransaction
+			result.Entry = journal.Entries[0]
+			result.Account = journal.Accounts[0]
+			return nil
+		},
+	)
+
+	return result, err
+}
+
+// replayCashTx loads the transaction already recorded under the caller's idempotency key. A key reused for a
+// different request fails with ErrIdempotencyKeyReused rather than being silently answered with the old result.
+func replayCashTx(ctx context.Context, q *Queries, kind string, arg CashTxParams, result *CashTxResult) error {
+	cashTransaction, err := q.GetCashTransactionByIdempotencyKey(
+		ctx, GetCashTransactionByIdempotencyKeyParams{
+			CreatedBy:      arg.CreatedBy,
+			IdempotencyKey: arg.IdempotencyKey,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if cashTransaction.AccountID != arg.AccountID || cashTransaction.Kind != kind || cashTransaction.Amount != arg.Amount {
+		return ErrIdempotencyKeyReused
+	}
+
+	journal, err := q.GetJournalTransaction(ctx, cashTransaction.JournalTransactionID.Int64)
+	if err != nil {
+		return err
+	}
+
+	entries, err := q.GetJournalTransactionEntries(ctx, cashTransaction.JournalTransactionID)
+	if err != nil {
+		return err
+	}
+
+	account, err := q.GetAccount(ctx, arg.AccountID)
+	if err != nil {
+		return err
+	}
+
+	result.CashTransaction = cashTransaction
+	result.JournalTransaction = journal
+	for _, entry := range entries {
+		if entry.AccountID == account.ID {
+			result.Entry = entry
+		}
+	}
+	result.Account = account
+	result.Replayed = true
+	return nil
+}
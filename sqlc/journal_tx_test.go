@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPostJournalTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	account2, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	account3, err := createFundedAccount(1000)
+	require.NoError(t, err)
+
+	result, err := store.PostJournalTx(
+		context.Background(), PostJournalTxParams{
+			Kind:        constants.JournalKindTransfer,
+			Description: "split",
+			Postings: []Posting{
+				{AccountID: account1.ID, Amount: -300},
+				{AccountID: account2.ID, Amount: 100},
+				{AccountID: account3.ID, Amount: 200},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "split", result.JournalTransaction.Description)
+	require.Len(t, result.Entries, 3)
+	require.Equal(t, int64(700), result.Accounts[0].Balance)
+	require.Equal(t, int64(1100), result.Accounts[1].Balance)
+	require.Equal(t, int64(1200), result.Accounts[2].Balance)
+
+	for _, entry := range result.Entries {
+		require.Equal(t, result.JournalTransaction.ID, entry.JournalTransactionID.Int64)
+	}
+}
+
+func TestPostJournalTxUnbalanced(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	account2, err := createFundedAccount(1000)
+	require.NoError(t, err)
+
+	_, err = store.PostJournalTx(
+		context.Background(), PostJournalTxParams{
+			Kind: constants.JournalKindTransfer,
+			Postings: []Posting{
+				{AccountID: account1.ID, Amount: -300},
+				{AccountID: account2.ID, Amount: 200},
+			},
+		},
+	)
+	require.ErrorIs(t, err, ErrUnbalancedJournal)
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), account1.Balance)
+
+	_, err = store.PostJournalTx(
+		context.Background(), PostJournalTxParams{
+			Kind:     constants.JournalKindDeposit,
+			Postings: []Posting{{AccountID: account1.ID, Amount: 300}},
+		},
+	)
+	require.ErrorIs(t, err, ErrUnbalancedJournal)
+}
+
+func TestDepositAndWithdrawTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account, err := createFundedAccount(0)
+	require.NoError(t, err)
+	teller, _, err := createRandomUser()
+	require.NoError(t, err)
+	cashAccount, err := systemAccount(context.Background(), testQueries, constants.SystemAccountCash, account.Currency)
+	require.NoError(t, err)
+
+	deposit, err := store.DepositTx(
+		context.Background(), CashTxParams{
+			AccountID:      account.ID,
+			Amount:         500,
+			Reference:      "branch deposit",
+			Channel:        constants.ChannelBranch,
+			IdempotencyKey: util.RandomString(16),
+			CreatedBy:      teller.Username,
+		},
+	)
+	require.NoError(t, err)
+	require.False(t, deposit.Replayed)
+	require.Equal(t, constants.JournalKindDeposit, deposit.JournalTransaction.Kind)
+	require.Equal(t, "branch deposit", deposit.JournalTransaction.Description)
+	require.Equal(t, constants.JournalKindDeposit, deposit.CashTransaction.Kind)
+	require.Equal(t, constants.ChannelBranch, deposit.CashTransaction.Channel)
+	require.Equal(t, deposit.JournalTransaction.ID, deposit.CashTransaction.JournalTransactionID.Int64)
+	require.Equal(t, int64(500), deposit.Entry.Amount)
+	require.Equal(t, int64(500), deposit.Account.Balance)
+
+	withdrawal, err := store.WithdrawTx(
+		context.Background(), CashTxParams{
+			AccountID:      account.ID,
+			Amount:         200,
+			Channel:        constants.ChannelATM,
+			IdempotencyKey: util.RandomString(16),
+			CreatedBy:      teller.Username,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.JournalKindWithdrawal, withdrawal.JournalTransaction.Kind)
+	require.Equal(t, int64(-200), withdrawal.Entry.Amount)
+	require.Equal(t, int64(300), withdrawal.Account.Balance)
+
+	updatedCashAccount, err := testQueries.GetAccount(context.Background(), cashAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, cashAccount.Balance-300, updatedCashAccount.Balance)
+
+	_, err = store.WithdrawTx(
+		context.Background(), CashTxParams{
+			AccountID:      account.ID,
+			Amount:         301,
+			Channel:        constants.ChannelATM,
+			IdempotencyKey: util.RandomString(16),
+			CreatedBy:      teller.Username,
+		},
+	)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestDepositTxIdempotency(t *testing.T) {
+	store := NewStore(testDB)
+
+	account, err := createFundedAccount(0)
+	require.NoError(t, err)
+	teller, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	arg := CashTxParams{
+		AccountID:      account.ID,
+		Amount:         500,
+		Channel:        constants.ChannelWire,
+		IdempotencyKey: util.RandomString(16),
+		CreatedBy:      teller.Username,
+	}
+
+	first, err := store.DepositTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, first.Replayed)
+
+	second, err := store.DepositTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, second.Replayed)
+	require.Equal(t, first.CashTransaction, second.CashTransaction)
+	require.Equal(t, first.JournalTransaction.ID, second.JournalTransaction.ID)
+	require.Equal(t, first.Entry.ID, second.Entry.ID)
+	require.Equal(t, int64(500), second.Account.Balance)
+
+	arg.Amount = 600
+	_, err = store.DepositTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+	arg.Amount = 500
+	_, err = store.WithdrawTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+}
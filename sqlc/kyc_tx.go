@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+)
+
+var (
+	ErrKycNotEditable          = errors.New("KYC documents can't be changed while under review or once verified")
+	ErrKycDocumentsMissing     = errors.New("KYC submission needs a passport, national ID or driver's license")
+	ErrKycSubmissionNotPending = errors.New("KYC submission has already been reviewed")
+)
+
+// KycEditable reports whether a user with kycStatus can upload, delete and submit documents: only before their first
+// submission, or after one was rejected.
+func KycEditable(kycStatus string) bool {
+	return kycStatus == constants.KycStatusUnverified || kycStatus == constants.KycStatusRejected
+}
+
+// SubmitKycTx sends the documents a user has uploaded since their last submission for review, and marks the user as
+// pending until a reviewer approves or rejects them.
+func (store *SQLStore) SubmitKycTx(ctx context.Context, username string) (KycSubmission, error) {
+	var result KycSubmission
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			user, err := q.GetUserForUpdate(ctx, username)
+			if err != nil {
+				return err
+			}
+			if !KycEditable(user.KycStatus) {
+				return ErrKycNotEditable
+			}
+
+			documents, err := q.GetUnsubmittedKycDocuments(ctx, username)
+			if err != nil {
+				return err
+			}
+			if !hasIdentityDocument(documents) {
+				return ErrKycDocumentsMissing
+			}
+
+			result, err = q.CreateKycSubmission(ctx, username)
+			if err != nil {
+				return err
+			}
+
+			err = q.AttachKycDocuments(
+				ctx, AttachKycDocumentsParams{
+					SubmissionID: sql.NullInt64{Int64: result.ID, Valid: true},
+					Username:     username,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.UpdateUserKycStatus(
+				ctx, UpdateUserKycStatusParams{Username: username, KycStatus: constants.KycStatusPending},
+			)
+			return err
+		},
+	)
+
+	return result, err
+}
+
+func hasIdentityDocument(documents []KycDocument) bool {
+	for _, document := range documents {
+		for _, documentType := range constants.KycIdentityDocuments {
+			if document.DocumentType == documentType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type ReviewKycSubmissionTxParams struct {
+	ID         int64  `json:"id"`
+	ReviewedBy string `json:"reviewed_by"`
+	Approve    bool   `json:"approve"`
+	// The tier to move an approved user to; empty keeps their tier
+	Tier string `json:"tier"`
+	// Why the submission was rejected, which is shown to the user
+	Reason string `json:"reason"`
+}
+
+// ReviewKycSubmissionTx approves or rejects a pending submission and sets the user's KYC status to match. An approved
+// user has their tier's limits from then on; a rejected one can upload new documents and submit again.
+func (store *SQLStore) ReviewKycSubmissionTx(ctx context.Context, arg ReviewKycSubmissionTxParams) (
+	KycSubmission, error,
+) {
+	var result KycSubmission
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			submission, err := q.GetKycSubmissionForUpdate(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+			if submission.Status != constants.KycStatusPending {
+				return ErrKycSubmissionNotPending
+			}
+			if submission.Username == arg.ReviewedBy {
+				return ErrSelfApproval
+			}
+
+			status := constants.KycStatusRejected
+			if arg.Approve {
+				status = constants.KycStatusVerified
+			}
+			result, err = q.ReviewKycSubmission(
+				ctx, ReviewKycSubmissionParams{
+					ID:              submission.ID,
+					Status:          status,
+					ReviewedBy:      sql.NullString{String: arg.ReviewedBy, Valid: true},
+					RejectionReason: sql.NullString{String: arg.Reason, Valid: !arg.Approve && arg.Reason != ""},
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.UpdateUserKycStatus(
+				ctx, UpdateUserKycStatusParams{Username: submission.Username, KycStatus: status},
+			)
+			if err != nil || !arg.Approve || arg.Tier == "" {
+				return err
+			}
+
+			_, err = q.UpdateUserTier(ctx, UpdateUserTierParams{Username: submission.Username, Tier: arg.Tier})
+			return err
+		},
+	)
+
+	return result, err
+}
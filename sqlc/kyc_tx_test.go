@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/limit"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createRandomKycDocument(t *testing.T, username string, documentType string) KycDocument {
+	document, err := testQueries.CreateKycDocument(
+		context.Background(), CreateKycDocumentParams{
+			Username:     username,
+			DocumentType: documentType,
+			FileName:     util.RandomString(8) + ".pdf",
+			ContentType:  "application/pdf",
+			SizeBytes:    1024,
+			Sha256:       util.RandomString(64),
+			BlobKey:      "kyc/" + username + "/" + uuid.New().String(),
+		},
+	)
+	require.NoError(t, err)
+	return document
+}
+
+func TestKycSubmission(t *testing.T) {
+	store := NewStore(
+		testDB, WithLimitSchedule(
+			limit.Schedule{
+				Tiers: []limit.TierLimits{
+					{
+						Tier:     constants.UserTierUnverified,
+						Currency: constants.USD,
+						Limits:   limit.Limits{PerTransfer: 100},
+					},
+				},
+			},
+		),
+	)
+
+	source, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+	reviewer, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateUserKycStatus(
+		context.Background(),
+		UpdateUserKycStatusParams{Username: source.Owner, KycStatus: constants.KycStatusUnverified},
+	)
+	require.NoError(t, err)
+
+	// Until they pass KYC the user has the unverified tier's limits
+	_, err = store.TransferTx(
+		context.Background(),
+		TransferTxParams{SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 101},
+	)
+	require.ErrorIs(t, err, limit.ErrExceeded)
+
+	createRandomKycDocument(t, source.Owner, constants.KycDocumentProofOfAddress)
+	_, err = store.SubmitKycTx(context.Background(), source.Owner)
+	require.ErrorIs(t, err, ErrKycDocumentsMissing)
+
+	passport := createRandomKycDocument(t, source.Owner, constants.KycDocumentPassport)
+	submission, err := store.SubmitKycTx(context.Background(), source.Owner)
+	require.NoError(t, err)
+	require.Equal(t, constants.KycStatusPending, submission.Status)
+
+	documents, err := testQueries.GetKycSubmissionDocuments(
+		context.Background(), sql.NullInt64{Int64: submission.ID, Valid: true},
+	)
+	require.NoError(t, err)
+	require.Len(t, documents, 2)
+
+	// Submitted documents can't be deleted, and nothing more can be submitted while the review is pending
+	_, err = testQueries.DeleteKycDocument(context.Background(), passport.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.SubmitKycTx(context.Background(), source.Owner)
+	require.ErrorIs(t, err, ErrKycNotEditable)
+
+	_, err = store.ReviewKycSubmissionTx(
+		context.Background(), ReviewKycSubmissionTxParams{ID: submission.ID, ReviewedBy: source.Owner, Approve: true},
+	)
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	rejected, err := store.ReviewKycSubmissionTx(
+		context.Background(), ReviewKycSubmissionTxParams{
+			ID:         submission.ID,
+			ReviewedBy: reviewer.Username,
+			Reason:     "Passport has expired",
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.KycStatusRejected, rejected.Status)
+	require.Equal(t, "Passport has expired", rejected.RejectionReason.String)
+	require.True(t, rejected.ReviewedAt.Valid)
+
+	_, err = store.ReviewKycSubmissionTx(
+		context.Background(),
+		ReviewKycSubmissionTxParams{ID: submission.ID, ReviewedBy: reviewer.Username, Approve: true},
+	)
+	require.ErrorIs(t, err, ErrKycSubmissionNotPending)
+
+	// A rejected user submits new documents; the rejected ones stay with the first submission
+	createRandomKycDocument(t, source.Owner, constants.KycDocumentDriversLicense)
+	resubmission, err := store.SubmitKycTx(context.Background(), source.Owner)
+	require.NoError(t, err)
+
+	approved, err := store.ReviewKycSubmissionTx(
+		context.Background(), ReviewKycSubmissionTxParams{
+			ID:         resubmission.ID,
+			ReviewedBy: reviewer.Username,
+			Approve:    true,
+			Tier:       constants.UserTierPremium,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.KycStatusVerified, approved.Status)
+	require.False(t, approved.RejectionReason.Valid)
+
+	user, err := testQueries.GetUser(context.Background(), source.Owner)
+	require.NoError(t, err)
+	require.Equal(t, constants.KycStatusVerified, user.KycStatus)
+	require.Equal(t, constants.UserTierPremium, user.Tier)
+
+	// The premium tier has no limits in this schedule
+	_, err = store.TransferTx(
+		context.Background(),
+		TransferTxParams{SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 101},
+	)
+	require.NoError(t, err)
+}
@@ -3,10 +3,12 @@ package db
 import (
 	"context"
 	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
 	"github.com/CrunchyBlue/Golang-Bank/util"
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	_ "github.com/lib/pq"
 )
@@ -27,17 +29,35 @@ func createRandomUser() (User, CreateUserParams, error) {
 	}
 
 	user, err := testQueries.CreateUser(context.Background(), arg)
+	if err != nil {
+		return user, arg, err
+	}
+
+	// Most tests are about users who have passed KYC and have their tier's limits
+	user, err = testQueries.UpdateUserKycStatus(
+		context.Background(),
+		UpdateUserKycStatusParams{Username: user.Username, KycStatus: constants.KycStatusVerified},
+	)
 
 	return user, arg, err
 }
 
+// createRandomAccount opens accounts in a single currency, since money can only move between accounts in the same
+// currency.
 func createRandomAccount() (Account, CreateAccountParams, error) {
 	user, _, _ := createRandomUser()
 
+	accountNumber, err := util.NewAccountNumber()
+	if err != nil {
+		return Account{}, CreateAccountParams{}, err
+	}
+
 	arg := CreateAccountParams{
-		Owner:    user.Username,
-		Balance:  util.RandomInt(0, 1000),
-		Currency: util.RandomCurrency(),
+		Owner:         user.Username,
+		Balance:       util.RandomInt(0, 1000),
+		Currency:      constants.USD,
+		Product:       constants.ProductChecking,
+		AccountNumber: accountNumber,
 	}
 
 	account, err := testQueries.CreateAccount(context.Background(), arg)
@@ -45,15 +65,95 @@ func createRandomAccount() (Account, CreateAccountParams, error) {
 	return account, arg, err
 }
 
+func createFundedAccount(balance int64) (Account, error) {
+	account, _, err := createRandomAccount()
+	if err != nil {
+		return account, err
+	}
+
+	return testQueries.UpdateAccountBalance(
+		context.Background(), UpdateAccountBalanceParams{
+			ID:     account.ID,
+			Amount: balance - account.Balance,
+		},
+	)
+}
+
+func createSavingsAccount(balance int64) (Account, error) {
+	user, _, err := createRandomUser()
+	if err != nil {
+		return Account{}, err
+	}
+
+	accountNumber, err := util.NewAccountNumber()
+	if err != nil {
+		return Account{}, err
+	}
+
+	return testQueries.CreateAccount(
+		context.Background(), CreateAccountParams{
+			Owner:         user.Username,
+			Balance:       balance,
+			Currency:      constants.USD,
+			Product:       constants.ProductSavings,
+			AccountNumber: accountNumber,
+		},
+	)
+}
+
+// createRandomEntry credits an account from the bank's cash account, since entries can only be written as part of a
+// balanced journal transaction.
 func createRandomEntry(accountId int64) (Entry, CreateEntryParams, error) {
-	arg := CreateEntryParams{
-		AccountID: accountId,
-		Amount:    util.RandomInt(0, 1000),
+	var entry Entry
+	var arg CreateEntryParams
+
+	account, err := testQueries.GetAccount(context.Background(), accountId)
+	if err != nil {
+		return entry, arg, err
 	}
 
-	entry, err := testQueries.CreateEntry(context.Background(), arg)
+	tx, err := testDB.BeginTx(context.Background(), nil)
+	if err != nil {
+		return entry, arg, err
+	}
+	defer tx.Rollback()
 
-	return entry, arg, err
+	q := New(tx)
+	journalTransaction, err := q.CreateJournalTransaction(
+		context.Background(), CreateJournalTransactionParams{Kind: constants.JournalKindDeposit},
+	)
+	if err != nil {
+		return entry, arg, err
+	}
+
+	cashAccount, err := systemAccount(context.Background(), q, constants.SystemAccountCash, account.Currency)
+	if err != nil {
+		return entry, arg, err
+	}
+
+	arg = CreateEntryParams{
+		AccountID:            accountId,
+		Amount:               util.RandomInt(1, 1000),
+		JournalTransactionID: sql.NullInt64{Int64: journalTransaction.ID, Valid: true},
+	}
+
+	entry, err = q.CreateEntry(context.Background(), arg)
+	if err != nil {
+		return entry, arg, err
+	}
+
+	_, err = q.CreateEntry(
+		context.Background(), CreateEntryParams{
+			AccountID:            cashAccount.ID,
+			Amount:               -arg.Amount,
+			JournalTransactionID: arg.JournalTransactionID,
+		},
+	)
+	if err != nil {
+		return entry, arg, err
+	}
+
+	return entry, arg, tx.Commit()
 }
 
 func createRandomTransfer(sourceAccountId int64, destinationAccountId int64) (Transfer, CreateTransferParams, error) {
@@ -68,6 +168,39 @@ func createRandomTransfer(sourceAccountId int64, destinationAccountId int64) (Tr
 	return transfer, arg, err
 }
 
+func createRandomHold(accountID int64, destinationAccountID int64) (Hold, CreateHoldParams, error) {
+	arg := CreateHoldParams{
+		AccountID:            accountID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               util.RandomInt(1, 1000),
+		Reference:            util.RandomString(10),
+		ExpiresAt:            time.Now().Add(time.Hour),
+	}
+
+	hold, err := testQueries.CreateHold(context.Background(), arg)
+
+	return hold, arg, err
+}
+
+func createRandomScheduledTransfer(
+	source Account, destinationAccountID int64, nextOccurrenceAt time.Time,
+) (ScheduledTransfer, CreateScheduledTransferParams, error) {
+	arg := CreateScheduledTransferParams{
+		Owner:                source.Owner,
+		SourceAccountID:      source.ID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               util.RandomInt(1, 1000),
+		Currency:             source.Currency,
+		Frequency:            constants.FrequencyWeekly,
+		NextOccurrenceAt:     nextOccurrenceAt,
+		NextAttemptAt:        nextOccurrenceAt,
+	}
+
+	scheduled, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+
+	return scheduled, arg, err
+}
+
 func TestMain(m *testing.M) {
 	config, err := util.LoadConfig("../")
 	if err != nil {
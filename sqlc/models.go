@@ -5,6 +5,8 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,16 +18,438 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// Sum of active holds, excluded from the available balance
+	HeldBalance int64  `json:"held_balance"`
+	Product     string `json:"product"`
+	// active, frozen, dormant or closed
+	Status string `json:"status"`
+	// Ten random digits followed by a Luhn check digit
+	AccountNumber string `json:"account_number"`
+	// Only set when the bank is configured with a country and bank code
+	Iban sql.NullString `json:"iban"`
+	// Chosen by the owner to tell accounts of the same currency apart
+	Nickname sql.NullString `json:"nickname"`
+	// Set for accounts owned by an organization, whose members have access in place of the owner
+	OrganizationID sql.NullInt64 `json:"organization_id"`
+}
+
+type AccountStatusChange struct {
+	ID         int64     `json:"id"`
+	AccountID  int64     `json:"account_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedBy  string    `json:"changed_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AccountInvitation struct {
+	ID            int64         `json:"id"`
+	AccountID     int64         `json:"account_id"`
+	Invitee       string        `json:"invitee"`
+	Role          string        `json:"role"`
+	TransferLimit sql.NullInt64 `json:"transfer_limit"`
+	InvitedBy     string        `json:"invited_by"`
+	// pending, accepted, declined or revoked
+	Status      string       `json:"status"`
+	CreatedAt   time.Time    `json:"created_at"`
+	RespondedAt sql.NullTime `json:"responded_at"`
+}
+
+// Everyone with access to an account, including its owner
+type AccountMember struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+	// owner, co_owner, viewer or spender
+	Role string `json:"role"`
+	// Largest amount a spender can move in one transfer
+	TransferLimit sql.NullInt64 `json:"transfer_limit"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+type AccountProduct struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// checking, savings or internal
+	Kind string `json:"kind"`
+	// Annual interest rate in basis points
+	InterestRateBps    int32     `json:"interest_rate_bps"`
+	DayCountConvention string    `json:"day_count_convention"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// Activity on an account that matched an anti-money laundering scenario, and the case worked on it
+type AmlAlert struct {
+	ID int64 `json:"id"`
+	// structuring, rapid_movement or dormant_reactivation
+	Scenario  string `json:"scenario"`
+	AccountID int64  `json:"account_id"`
+	// The total that matched: transferred just under the threshold, or sent out
+	Amount int64 `json:"amount"`
+	// How many transfers or entries matched
+	EventCount  int64     `json:"event_count"`
+	Details     string    `json:"details"`
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	// open, assigned or closed
+	Status     string         `json:"status"`
+	AssignedTo sql.NullString `json:"assigned_to"`
+	// false_positive, no_action or reported
+	Resolution sql.NullString `json:"resolution"`
+	ClosedBy   sql.NullString `json:"closed_by"`
+	ClosedAt   sql.NullTime   `json:"closed_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type AmlAlertComment struct {
+	ID        int64     `json:"id"`
+	AlertID   int64     `json:"alert_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// An account's balance at the close of a UTC day
+type BalanceSnapshot struct {
+	AccountID int64     `json:"account_id"`
+	Date      time.Time `json:"date"`
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Deposits and withdrawals against the bank's cash clearing account
+type CashTransaction struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// deposit or withdrawal
+	Kind string `json:"kind"`
+	// Must be positive
+	Amount    int64  `json:"amount"`
+	Reference string `json:"reference"`
+	// branch, atm, ach or wire
+	Channel              string        `json:"channel"`
+	IdempotencyKey       string        `json:"idempotency_key"`
+	CreatedBy            string        `json:"created_by"`
+	JournalTransactionID sql.NullInt64 `json:"journal_transaction_id"`
+	CreatedAt            time.Time     `json:"created_at"`
 }
 
 type Entry struct {
 	ID        int64 `json:"id"`
 	AccountID int64 `json:"account_id"`
 	// Can be negative or positive
+	Amount               int64         `json:"amount"`
+	CreatedAt            time.Time     `json:"created_at"`
+	JournalTransactionID sql.NullInt64 `json:"journal_transaction_id"`
+	// Copied from the transfer that posted the entry
+	Memo          sql.NullString `json:"memo"`
+	Reference     sql.NullString `json:"reference"`
+	Category      sql.NullString `json:"category"`
+	InvoiceNumber sql.NullString `json:"invoice_number"`
+	EndToEndID    sql.NullString `json:"end_to_end_id"`
+}
+
+type Fee struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// transfer or maintenance
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Amount int64  `json:"amount"`
+	// Transfer the fee was charged on, for transfer fees
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	// Transfer that moved the fee to the bank's fee revenue account
+	FeeTransferID sql.NullInt64 `json:"fee_transfer_id"`
+	// Month the fee was charged for, for maintenance fees
+	PeriodStart sql.NullTime `json:"period_start"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+type FeeWaiver struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// Fee that is waived, every fee when empty
+	FeeName   string       `json:"fee_name"`
+	Reason    string       `json:"reason"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type Hold struct {
+	ID                   int64 `json:"id"`
+	AccountID            int64 `json:"account_id"`
+	DestinationAccountID int64 `json:"destination_account_id"`
+	// Must be positive
+	Amount         int64         `json:"amount"`
+	CapturedAmount int64         `json:"captured_amount"`
+	Status         string        `json:"status"`
+	Reference      string        `json:"reference"`
+	TransferID     sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt      time.Time     `json:"expires_at"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type InterestAccrual struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+	// End-of-day balance the interest was earned on
+	Balance            int64  `json:"balance"`
+	InterestRateBps    int32  `json:"interest_rate_bps"`
+	DayCountConvention string `json:"day_count_convention"`
+	// Interest in millionths of a minor unit
+	AmountMicros int64         `json:"amount_micros"`
+	PostingID    sql.NullInt64 `json:"posting_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type InterestPosting struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	PeriodStart time.Time `json:"period_start"`
+	// Exclusive
+	PeriodEnd time.Time `json:"period_end"`
 	Amount    int64     `json:"amount"`
+	// Accrued micro-units left over after posting whole minor units
+	CarryMicros int64         `json:"carry_micros"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// Groups the entries of one movement of money, which must balance per currency
+type JournalTransaction struct {
+	ID int64 `json:"id"`
+	// transfer, deposit or withdrawal
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type KycDocument struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// Set once the document is submitted for review, after which it can't be deleted
+	SubmissionID sql.NullInt64 `json:"submission_id"`
+	// passport, national_id, drivers_license or proof_of_address
+	DocumentType string `json:"document_type"`
+	FileName     string `json:"file_name"`
+	// Detected from the file's content, not taken from the upload
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+	// Hex digest of the file as uploaded, before encryption
+	Sha256 string `json:"sha256"`
+	// Where the encrypted file is kept in the document store
+	BlobKey   string    `json:"blob_key"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// A user's documents sent for KYC review
+type KycSubmission struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// pending, verified or rejected
+	Status          string         `json:"status"`
+	ReviewedBy      sql.NullString `json:"reviewed_by"`
+	RejectionReason sql.NullString `json:"rejection_reason"`
+	ReviewedAt      sql.NullTime   `json:"reviewed_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+type Notification struct {
+	ID        int64     `json:"id"`
+	Username  string    `json:"username"`
+	Kind      string    `json:"kind"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// A ledger invariant found broken by a reconciliation run
+type Organization struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Transfers of more than this need approval before they run
+	ApprovalThreshold int64 `json:"approval_threshold"`
+	// Distinct approvers, other than the initiator, a transfer needs
+	RequiredApprovals int32     `json:"required_approvals"`
+	CreatedBy         string    `json:"created_by"`
+	CreatedAt         time.Time `json:"created_at"`
+	// Picks the transfer limits of the organization's accounts from the limit schedule
+	Tier string `json:"tier"`
+	// clear, pending_review or blocked; only clear organizations' accounts can transfer and be paid
+	ScreeningStatus string `json:"screening_status"`
+}
+
+type OrganizationMember struct {
+	OrganizationID int64  `json:"organization_id"`
+	Username       string `json:"username"`
+	// admin, initiator or approver
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Accounts a user has saved to send transfers to
+type Payee struct {
+	ID        int64  `json:"id"`
+	Owner     string `json:"owner"`
+	Nickname  string `json:"nickname"`
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+	// Name the owner expects the account to be held under
+	ExpectedName sql.NullString `json:"expected_name"`
+	// match, close_match or no_match against the real account holder, set with expected_name
+	NameCheck sql.NullString `json:"name_check"`
+	// Transfers to the payee are limited until then
+	CoolingOffUntil time.Time `json:"cooling_off_until"`
+	CreatedAt       time.Time `json:"created_at"`
+	// clear, pending_review or blocked; only clear payees can be paid
+	ScreeningStatus string `json:"screening_status"`
+}
+
+// Money a user has asked another user, or the holders of an account, to send them
+type PaymentRequest struct {
+	ID        int64  `json:"id"`
+	Requester string `json:"requester"`
+	// Requester's account the money is paid in to
+	DestinationAccountID int64 `json:"destination_account_id"`
+	// Set when the request is to a user, who picks the account to pay from
+	Payer sql.NullString `json:"payer"`
+	// Set when the request is to an account, which anyone who can spend from it can pay
+	PayerAccountID sql.NullInt64  `json:"payer_account_id"`
+	Amount         int64          `json:"amount"`
+	Currency       string         `json:"currency"`
+	Memo           sql.NullString `json:"memo"`
+	// pending, accepted, declined, cancelled or expired
+	Status      string        `json:"status"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+	RespondedAt sql.NullTime  `json:"responded_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type PendingTransfer struct {
+	ID                   int64  `json:"id"`
+	OrganizationID       int64  `json:"organization_id"`
+	SourceAccountID      int64  `json:"source_account_id"`
+	DestinationAccountID int64  `json:"destination_account_id"`
+	Amount               int64  `json:"amount"`
+	InitiatedBy          string `json:"initiated_by"`
+	// Copied from the organization when the transfer was initiated
+	RequiredApprovals int32 `json:"required_approvals"`
+	// pending, executed, rejected or expired
+	Status          string         `json:"status"`
+	TransferID      sql.NullInt64  `json:"transfer_id"`
+	RejectedBy      sql.NullString `json:"rejected_by"`
+	RejectionReason sql.NullString `json:"rejection_reason"`
+	ExpiresAt       time.Time      `json:"expires_at"`
+	DecidedAt       sql.NullTime   `json:"decided_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	Memo            sql.NullString `json:"memo"`
+	Reference       sql.NullString `json:"reference"`
+	Category        sql.NullString `json:"category"`
+	InvoiceNumber   sql.NullString `json:"invoice_number"`
+	EndToEndID      sql.NullString `json:"end_to_end_id"`
+}
+
+type ReconciliationDiscrepancy struct {
+	ID    int64 `json:"id"`
+	RunID int64 `json:"run_id"`
+	// balance_mismatch, transfer_missing_entry, transfer_entry_mismatch or journal_missing_transfer
+	Kind                 string        `json:"kind"`
+	AccountID            sql.NullInt64 `json:"account_id"`
+	TransferID           sql.NullInt64 `json:"transfer_id"`
+	EntryID              sql.NullInt64 `json:"entry_id"`
+	JournalTransactionID sql.NullInt64 `json:"journal_transaction_id"`
+	Expected             sql.NullInt64 `json:"expected"`
+	Actual               sql.NullInt64 `json:"actual"`
+	Detail               string        `json:"detail"`
+	CreatedAt            time.Time     `json:"created_at"`
+}
+
+// One pass of the ledger reconciliation job
+type ReconciliationRun struct {
+	ID               int64     `json:"id"`
+	AccountsChecked  int64     `json:"accounts_checked"`
+	TransfersChecked int64     `json:"transfers_checked"`
+	DiscrepancyCount int64     `json:"discrepancy_count"`
+	StartedAt        time.Time `json:"started_at"`
+	// Null while the run is in progress or if it was interrupted
+	CompletedAt sql.NullTime `json:"completed_at"`
+}
+
+// The risk engine's verdict on a transfer, and the transfer itself while it waits for review
+type RiskAssessment struct {
+	ID                   int64  `json:"id"`
+	SourceAccountID      int64  `json:"source_account_id"`
+	DestinationAccountID int64  `json:"destination_account_id"`
+	Amount               int64  `json:"amount"`
+	InitiatedBy          string `json:"initiated_by"`
+	Score                int64  `json:"score"`
+	// allow, review or deny
+	Decision string `json:"decision"`
+	// Comma-separated names of the rules the transfer matched
+	Reasons string `json:"reasons"`
+	// executed, pending_review, rejected or denied
+	Status        string         `json:"status"`
+	TransferID    sql.NullInt64  `json:"transfer_id"`
+	ReviewedBy    sql.NullString `json:"reviewed_by"`
+	ReviewNote    sql.NullString `json:"review_note"`
+	ReviewedAt    sql.NullTime   `json:"reviewed_at"`
+	Memo          sql.NullString `json:"memo"`
+	Reference     sql.NullString `json:"reference"`
+	Category      sql.NullString `json:"category"`
+	InvoiceNumber sql.NullString `json:"invoice_number"`
+	EndToEndID    sql.NullString `json:"end_to_end_id"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type ScheduledTransfer struct {
+	ID                   int64  `json:"id"`
+	Owner                string `json:"owner"`
+	SourceAccountID      int64  `json:"source_account_id"`
+	DestinationAccountID int64  `json:"destination_account_id"`
+	Amount               int64  `json:"amount"`
+	Currency             string `json:"currency"`
+	Frequency            string `json:"frequency"`
+	DayOfMonth           int32  `json:"day_of_month"`
+	// Date the pending run is scheduled for
+	NextOccurrenceAt time.Time `json:"next_occurrence_at"`
+	// When the pending run is next tried, later than next_occurrence_at while retrying
+	NextAttemptAt time.Time    `json:"next_attempt_at"`
+	EndAt         sql.NullTime `json:"end_at"`
+	// Occurrences left, paid or skipped, before the schedule completes; unlimited when null
+	RemainingRuns sql.NullInt32 `json:"remaining_runs"`
+	Status        string        `json:"status"`
+	Attempts      int32         `json:"attempts"`
+	LastError     string        `json:"last_error"`
+	LastRunAt     sql.NullTime  `json:"last_run_at"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// A user's name, or the name behind one of their payees, that matched a watchlist entry
+type ScreeningHit struct {
+	ID int64 `json:"id"`
+	// The user screened, or the owner of the payee screened
+	Username     string        `json:"username"`
+	PayeeID      sql.NullInt64 `json:"payee_id"`
+	ScreenedName string        `json:"screened_name"`
+	EntryID      string        `json:"entry_id"`
+	EntryName    string        `json:"entry_name"`
+	// The entry's name or alias that matched
+	MatchedName string `json:"matched_name"`
+	// Comma-separated sanctions programs of the entry
+	Programs string `json:"programs"`
+	// Similarity of the names, from 0 to 100
+	Score int32 `json:"score"`
+	// pending_review, confirmed or cleared
+	Status     string         `json:"status"`
+	ReviewedBy sql.NullString `json:"reviewed_by"`
+	ReviewNote sql.NullString `json:"review_note"`
+	ReviewedAt sql.NullTime   `json:"reviewed_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	// The organization screened, if it was its name; username is then the user who created it
+	OrganizationID sql.NullInt64 `json:"organization_id"`
+}
+
 type Session struct {
 	ID           uuid.UUID `json:"id"`
 	Username     string    `json:"username"`
@@ -37,13 +461,106 @@ type Session struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+// Accounts owned by the bank, one per purpose and currency
+// Generated account statements, which are never changed once written
+type Statement struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	PeriodStart time.Time `json:"period_start"`
+	// Exclusive
+	PeriodEnd      time.Time `json:"period_end"`
+	OpeningBalance int64     `json:"opening_balance"`
+	ClosingBalance int64     `json:"closing_balance"`
+	// The statement exactly as generated, kept as json rather than jsonb so the hash still matches
+	Content json.RawMessage `json:"content"`
+	// Hex SHA-256 of content
+	ContentHash string    `json:"content_hash"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type SystemAccount struct {
+	Purpose   string `json:"purpose"`
+	Currency  string `json:"currency"`
+	AccountID int64  `json:"account_id"`
+}
+
 type Transfer struct {
 	ID                   int64 `json:"id"`
 	SourceAccountID      int64 `json:"source_account_id"`
 	DestinationAccountID int64 `json:"destination_account_id"`
 	// Must be positive
-	Amount    int64     `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
+	Amount               int64         `json:"amount"`
+	CreatedAt            time.Time     `json:"created_at"`
+	JournalTransactionID sql.NullInt64 `json:"journal_transaction_id"`
+	// Free text from the payer
+	Memo sql.NullString `json:"memo"`
+	// The payer's own reference for the transfer
+	Reference     sql.NullString `json:"reference"`
+	Category      sql.NullString `json:"category"`
+	InvoiceNumber sql.NullString `json:"invoice_number"`
+	// Identifier the payer assigned to travel with the payment end to end
+	EndToEndID sql.NullString `json:"end_to_end_id"`
+}
+
+type TransferApproval struct {
+	PendingTransferID int64     `json:"pending_transfer_id"`
+	Approver          string    `json:"approver"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// Transfers from one source account submitted together
+type TransferBatch struct {
+	ID              int64  `json:"id"`
+	SourceAccountID int64  `json:"source_account_id"`
+	CreatedBy       string `json:"created_by"`
+	// atomic runs every item in one transaction, best_effort each item on its own
+	Mode        string `json:"mode"`
+	Currency    string `json:"currency"`
+	ItemCount   int32  `json:"item_count"`
+	TotalAmount int64  `json:"total_amount"`
+	// pending, processing, completed, partially_completed or failed
+	Status string `json:"status"`
+	// Why an atomic batch was rolled back
+	Error       sql.NullString `json:"error"`
+	CreatedAt   time.Time      `json:"created_at"`
+	StartedAt   sql.NullTime   `json:"started_at"`
+	CompletedAt sql.NullTime   `json:"completed_at"`
+}
+
+type TransferBatchItem struct {
+	ID      int64 `json:"id"`
+	BatchID int64 `json:"batch_id"`
+	// One-based position of the item in the submitted list or file
+	Position             int32          `json:"position"`
+	DestinationAccountID int64          `json:"destination_account_id"`
+	Amount               int64          `json:"amount"`
+	Memo                 sql.NullString `json:"memo"`
+	Reference            sql.NullString `json:"reference"`
+	// pending, succeeded, failed or skipped
+	Status        string         `json:"status"`
+	TransferID    sql.NullInt64  `json:"transfer_id"`
+	Error         sql.NullString `json:"error"`
+	ProcessedAt   sql.NullTime   `json:"processed_at"`
+	Category      sql.NullString `json:"category"`
+	InvoiceNumber sql.NullString `json:"invoice_number"`
+	EndToEndID    sql.NullString `json:"end_to_end_id"`
+}
+
+// Limits an admin has set for a user in place of their tier's, until expires_at
+type TransferLimitOverride struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Currency string `json:"currency"`
+	// Null keeps the tier's limit, as for daily, monthly and hourly_count
+	PerTransfer sql.NullInt64 `json:"per_transfer"`
+	Daily       sql.NullInt64 `json:"daily"`
+	Monthly     sql.NullInt64 `json:"monthly"`
+	// Transfers allowed in any hour
+	HourlyCount sql.NullInt32 `json:"hourly_count"`
+	Reason      string        `json:"reason"`
+	CreatedBy   string        `json:"created_by"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+	CreatedAt   time.Time     `json:"created_at"`
 }
 
 type User struct {
@@ -53,4 +570,12 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	// customer, teller, admin, rail or analyst
+	Role string `json:"role"`
+	// Picks the user's transfer limits from the limit schedule
+	Tier string `json:"tier"`
+	// clear, pending_review or blocked; only clear users can sign in and transfer
+	ScreeningStatus string `json:"screening_status"`
+	// unverified, pending, verified or rejected; users who aren't verified have the limits of the unverified tier
+	KycStatus string `json:"kyc_status"`
 }
@@ -0,0 +1,199 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/fee"
+	"github.com/CrunchyBlue/Golang-Bank/limit"
+	"math"
+)
+
+var (
+	ErrNoTransferLegs        = errors.New("multi transfer has no legs")
+	ErrMultiTransferOverflow = errors.New("multi transfer total is too large")
+)
+
+// TransferLegError is a multi transfer failing because of one of its legs. Index is the leg's position in the legs,
+// from 0.
+type TransferLegError struct {
+	Index int
+	Err   error
+}
+
+func (e *TransferLegError) Error() string {
+	return fmt.Sprintf("leg %d: %v", e.Index+1, e.Err)
+}
+
+func (e *TransferLegError) Unwrap() error {
+	return e.Err
+}
+
+// TransferLeg is one destination of a multi transfer, with its own remittance.
+type TransferLeg struct {
+	DestinationAccountID int64 `json:"destination_account_id"`
+	Amount               int64 `json:"amount"`
+	Remittance
+}
+
+type MultiTransferTxParams struct {
+	SourceAccountID int64         `json:"source_account_id"`
+	Legs            []TransferLeg `json:"legs"`
+}
+
+type MultiTransferTxResult struct {
+	// One per leg, in the order of the legs
+	Transfers []Transfer `json:"transfers"`
+	// Every entry posted, the fees' included, in the order they were posted
+	Entries []Entry `json:"entries"`
+	// After every leg and fee
+	SourceAccount Account `json:"source_account"`
+	// One per leg, as the account was after its leg
+	DestinationAccounts []Account `json:"destination_accounts"`
+	Fees                []Fee     `json:"fees"`
+}
+
+// MultiTransferTx debits one source account and credits every leg's destination in a single transaction, so that
+// either every leg is transferred or none is. Each leg is recorded as a transfer of its own and charged the fees a
+// single transfer of its amount would be.
+func (store *SQLStore) MultiTransferTx(ctx context.Context, arg MultiTransferTxParams) (MultiTransferTxResult, error) {
+	var result MultiTransferTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			var err error
+			result, err = multiTransferTx(ctx, q, arg, store.fees, store.limits)
+			return err
+		},
+	)
+
+	return result, err
+}
+
+// multiTransferTx is transferTx for many destinations. Every account involved is locked up front, through
+// lockAccounts, before any balance changes, so the legs can't deadlock with each other or with other transfers.
+func multiTransferTx(
+	ctx context.Context, q *Queries, arg MultiTransferTxParams, schedule fee.Schedule, limits limit.Schedule,
+) (MultiTransferTxResult, error) {
+	var result MultiTransferTxResult
+
+	if len(arg.Legs) == 0 {
+		return result, ErrNoTransferLegs
+	}
+
+	source, err := q.GetAccount(ctx, arg.SourceAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	accountIDs := []int64{source.ID}
+	charges := make([][]fee.Charge, len(arg.Legs))
+	var total, totalFees int64
+	for i, leg := range arg.Legs {
+		destination, err := q.GetAccount(ctx, leg.DestinationAccountID)
+		if err != nil {
+			return result, &TransferLegError{Index: i, Err: err}
+		}
+		if err := checkDestinationScreening(ctx, q, destination); err != nil {
+			return result, &TransferLegError{Index: i, Err: err}
+		}
+		accountIDs = append(accountIDs, destination.ID)
+
+		charges[i], err = waiveFees(
+			ctx, q, source.ID, schedule.TransferCharges(source.Currency, leg.Amount),
+		)
+		if err != nil {
+			return result, err
+		}
+		// A total that wrapped around would pass the limit and balance checks
+		fees := fee.Total(charges[i])
+		if leg.Amount > math.MaxInt64-total || fees > math.MaxInt64-total-leg.Amount-totalFees {
+			return result, ErrMultiTransferOverflow
+		}
+		total += leg.Amount
+		totalFees += fees
+	}
+
+	if err := checkScreeningHold(ctx, q, source); err != nil {
+		return result, err
+	}
+
+	// The legs are limited as one transfer of their total, so that splitting a payment can't get around the per
+	// transfer limit, but each leg counts towards the hourly count
+	if err := checkTransferLimits(ctx, q, limits, source, total, int64(len(arg.Legs))); err != nil {
+		return result, err
+	}
+
+	var revenueAccount Account
+	if totalFees > 0 {
+		revenueAccount, err = systemAccount(ctx, q, constants.SystemAccountFeeRevenue, source.Currency)
+		if err != nil {
+			return result, err
+		}
+		accountIDs = append(accountIDs, revenueAccount.ID)
+	}
+
+	accounts, err := lockAccounts(ctx, q, accountIDs...)
+	if err != nil {
+		return result, err
+	}
+
+	if err := checkAccountPosting(accounts[source.ID], -total); err != nil {
+		return result, err
+	}
+	// The legs are paid for in order, so the funds run out on the first leg the balance can't cover
+	var spent int64
+	for i, leg := range arg.Legs {
+		if err := checkAccountPosting(accounts[leg.DestinationAccountID], leg.Amount); err != nil {
+			return result, &TransferLegError{Index: i, Err: err}
+		}
+		spent += leg.Amount + fee.Total(charges[i])
+		if availableBalance(accounts[source.ID]) < spent {
+			return result, &TransferLegError{Index: i, Err: ErrInsufficientFunds}
+		}
+	}
+
+	for i, leg := range arg.Legs {
+		transfer, err := moveFunds(
+			ctx, q, TransferTxParams{
+				SourceAccountID:      source.ID,
+				DestinationAccountID: leg.DestinationAccountID,
+				Amount:               leg.Amount,
+				Remittance:           leg.Remittance,
+			}, 0,
+		)
+		if err != nil {
+			return result, &TransferLegError{Index: i, Err: err}
+		}
+
+		result.Transfers = append(result.Transfers, transfer.Transfer)
+		result.Entries = append(result.Entries, transfer.FromEntry, transfer.ToEntry)
+		result.DestinationAccounts = append(result.DestinationAccounts, transfer.DestinationAccount)
+		result.SourceAccount = transfer.SourceAccount
+
+		for _, charge := range charges[i] {
+			charged, feeTransfer, err := chargeFee(
+				ctx, q, CreateFeeParams{
+					AccountID:  source.ID,
+					Kind:       constants.FeeKindTransfer,
+					Name:       charge.Name,
+					Amount:     charge.Amount,
+					TransferID: sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true},
+				}, revenueAccount.ID,
+			)
+			if err != nil {
+				return result, err
+			}
+
+			result.Fees = append(result.Fees, charged)
+			if charge.Amount > 0 {
+				result.Entries = append(result.Entries, feeTransfer.FromEntry, feeTransfer.ToEntry)
+				result.SourceAccount = feeTransfer.SourceAccount
+			}
+		}
+	}
+
+	return result, nil
+}
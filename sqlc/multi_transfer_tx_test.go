@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestMultiTransfer(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(100)
+	require.NoError(t, err)
+	seller, err := createFundedAccount(0)
+	require.NoError(t, err)
+	platform, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	result, err := store.MultiTransferTx(
+		context.Background(), MultiTransferTxParams{
+			SourceAccountID: source.ID,
+			Legs: []TransferLeg{
+				{
+					DestinationAccountID: seller.ID,
+					Amount:               45,
+					Remittance:           Remittance{Memo: sql.NullString{String: "Order 1001", Valid: true}},
+				},
+				{
+					DestinationAccountID: platform.ID,
+					Amount:               5,
+					Remittance:           Remittance{Memo: sql.NullString{String: "Commission", Valid: true}},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, result.Transfers, 2)
+	require.Equal(t, seller.ID, result.Transfers[0].DestinationAccountID)
+	require.Equal(t, "Order 1001", result.Transfers[0].Memo.String)
+	require.Equal(t, platform.ID, result.Transfers[1].DestinationAccountID)
+	require.Equal(t, "Commission", result.Transfers[1].Memo.String)
+
+	require.Len(t, result.Entries, 4)
+	require.Equal(t, source.ID, result.Entries[0].AccountID)
+	require.Equal(t, int64(-45), result.Entries[0].Amount)
+	require.Equal(t, seller.ID, result.Entries[1].AccountID)
+	require.Equal(t, int64(45), result.Entries[1].Amount)
+	require.Equal(t, "Order 1001", result.Entries[1].Memo.String)
+	require.Equal(t, source.ID, result.Entries[2].AccountID)
+	require.Equal(t, int64(-5), result.Entries[2].Amount)
+	require.Equal(t, platform.ID, result.Entries[3].AccountID)
+	require.Equal(t, "Commission", result.Entries[3].Memo.String)
+
+	require.Equal(t, int64(50), result.SourceAccount.Balance)
+	require.Equal(t, int64(45), result.DestinationAccounts[0].Balance)
+	require.Equal(t, int64(5), result.DestinationAccounts[1].Balance)
+}
+
+func TestMultiTransferInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(100)
+	require.NoError(t, err)
+	destination1, err := createFundedAccount(0)
+	require.NoError(t, err)
+	destination2, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	_, err = store.MultiTransferTx(
+		context.Background(), MultiTransferTxParams{
+			SourceAccountID: source.ID,
+			Legs: []TransferLeg{
+				{DestinationAccountID: destination1.ID, Amount: 60},
+				{DestinationAccountID: destination2.ID, Amount: 60},
+			},
+		},
+	)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	var legErr *TransferLegError
+	require.ErrorAs(t, err, &legErr)
+	require.Equal(t, 1, legErr.Index)
+
+	updatedDestination1, err := testQueries.GetAccount(context.Background(), destination1.ID)
+	require.NoError(t, err)
+	require.Zero(t, updatedDestination1.Balance)
+
+	updatedSource, err := testQueries.GetAccount(context.Background(), source.ID)
+	require.NoError(t, err)
+	require.Equal(t, source.Balance, updatedSource.Balance)
+}
+
+func TestMultiTransferOverflow(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(100)
+	require.NoError(t, err)
+	destination1, err := createFundedAccount(0)
+	require.NoError(t, err)
+	destination2, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	_, err = store.MultiTransferTx(
+		context.Background(), MultiTransferTxParams{
+			SourceAccountID: source.ID,
+			Legs: []TransferLeg{
+				{DestinationAccountID: destination1.ID, Amount: math.MaxInt64},
+				{DestinationAccountID: destination2.ID, Amount: math.MaxInt64},
+			},
+		},
+	)
+	require.ErrorIs(t, err, ErrMultiTransferOverflow)
+
+	updatedSource, err := testQueries.GetAccount(context.Background(), source.ID)
+	require.NoError(t, err)
+	require.Equal(t, source.Balance, updatedSource.Balance)
+}
+
+// TestMultiTransferDeadlock runs multi transfers between the same three accounts in every direction at once, which
+// would deadlock if the accounts weren't locked in a consistent order.
+func TestMultiTransferDeadlock(t *testing.T) {
+	store := NewStore(testDB)
+
+	accounts := make([]Account, 3)
+	for i := range accounts {
+		var err error
+		accounts[i], err = createFundedAccount(1000)
+		require.NoError(t, err)
+	}
+
+	n := 12
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		source := accounts[i%3]
+		first := accounts[(i+1)%3]
+		second := accounts[(i+2)%3]
+		if i%2 == 1 {
+			first, second = second, first
+		}
+
+		go func() {
+			_, err := store.MultiTransferTx(
+				context.Background(), MultiTransferTxParams{
+					SourceAccountID: source.ID,
+					Legs: []TransferLeg{
+						{DestinationAccountID: first.ID, Amount: 10},
+						{DestinationAccountID: second.ID, Amount: 10},
+					},
+				},
+			)
+
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+	}
+
+	for _, account := range accounts {
+		updatedAccount, err := testQueries.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Equal(t, account.Balance, updatedAccount.Balance)
+	}
+}
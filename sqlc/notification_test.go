@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCreateNotification(t *testing.T) {
+	user, _, _ := createRandomUser()
+
+	arg := CreateNotificationParams{
+		Username: user.Username,
+		Kind:     constants.NotificationScheduledTransferFailed,
+		Message:  util.RandomString(20),
+	}
+
+	notification, err := testQueries.CreateNotification(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Username, notification.Username)
+	require.Equal(t, arg.Kind, notification.Kind)
+	require.Equal(t, arg.Message, notification.Message)
+	require.NotZero(t, notification.ID)
+	require.NotZero(t, notification.CreatedAt)
+}
+
+func TestGetNotifications(t *testing.T) {
+	user, _, _ := createRandomUser()
+
+	for i := 0; i < 10; i++ {
+		_, err := testQueries.CreateNotification(
+			context.Background(), CreateNotificationParams{
+				Username: user.Username,
+				Kind:     constants.NotificationScheduledTransferFailed,
+				Message:  util.RandomString(20),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	arg := GetNotificationsParams{
+		Username: user.Username,
+		Limit:    5,
+		Offset:   5,
+	}
+
+	notifications, err := testQueries.GetNotifications(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, notifications, 5)
+
+	for i, notification := range notifications {
+		require.Equal(t, user.Username, notification.Username)
+		if i > 0 {
+			require.Less(t, notification.ID, notifications[i-1].ID)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"time"
+)
+
+var (
+	ErrPendingTransferNotPending = errors.New("transfer has already been executed, rejected or expired")
+	ErrPendingTransferExpired    = errors.New("transfer approval window has expired")
+	ErrSelfApproval              = errors.New("the initiator can't approve their own transfer")
+	ErrAlreadyApproved           = errors.New("transfer has already been approved by this user")
+)
+
+// CreateOrganizationTx creates an organization with its creator as its first admin.
+func (store *SQLStore) CreateOrganizationTx(ctx context.Context, arg CreateOrganizationParams) (Organization, error) {
+	var organization Organization
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			var err error
+			organization, err = q.CreateOrganization(ctx, arg)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreateOrganizationMember(
+				ctx, CreateOrganizationMemberParams{
+					OrganizationID: organization.ID,
+					Username:       arg.CreatedBy,
+					Role:           constants.OrganizationRoleAdmin,
+				},
+			)
+			return err
+		},
+	)
+
+	return organization, err
+}
+
+type ApprovePendingTransferTxParams struct {
+	PendingTransferID int64     `json:"pending_transfer_id"`
+	Approver          string    `json:"approver"`
+	Now               time.Time `json:"now"`
+}
+
+type ApprovePendingTransferTxResult struct {
+	PendingTransfer PendingTransfer    `json:"pending_transfer"`
+	Approvals       []TransferApproval `json:"approvals"`
+	// Only set once the approval was the last one needed and the transfer ran
+	Transfer *TransferTxResult `json:"transfer"`
+}
+
+// ApprovePendingTransferTx records an approval and, once the transfer has as many distinct approvers as it needs,
+// runs it the same way TransferTx would, in the same transaction. A transfer that can't run, for example because the
+// source account no longer has the funds, leaves no approval behind so it can be approved again later.
+func (store *SQLStore) ApprovePendingTransferTx(ctx context.Context, arg ApprovePendingTransferTxParams) (
+	ApprovePendingTransferTxResult, error,
+) {
+	var result ApprovePendingTransferTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			pending, err := q.GetPendingTransferForUpdate(ctx, arg.PendingTransferID)
+			if err != nil {
+				return err
+			}
+			if pending.Status != constants.PendingTransferStatusPending {
+				return ErrPendingTransferNotPending
+			}
+			if !arg.Now.Before(pending.ExpiresAt) {
+				return ErrPendingTransferExpired
+			}
+			if pending.InitiatedBy == arg.Approver {
+				return ErrSelfApproval
+			}
+
+			approvals, err := q.GetTransferApprovals(ctx, pending.ID)
+			if err != nil {
+				return err
+			}
+			for _, approval := range approvals {
+				if approval.Approver == arg.Approver {
+					return ErrAlreadyApproved
+				}
+			}
+
+			approval, err := q.CreateTransferApproval(
+				ctx, CreateTransferApprovalParams{PendingTransferID: pending.ID, Approver: arg.Approver},
+			)
+			if err != nil {
+				return err
+			}
+			result.Approvals = append(approvals, approval)
+			result.PendingTransfer = pending
+
+			if len(result.Approvals) < int(pending.RequiredApprovals) {
+				return nil
+			}
+
+			transfer, err := transferTx(
+				ctx, q, TransferTxParams{
+					SourceAccountID:      pending.SourceAccountID,
+					DestinationAccountID: pending.DestinationAccountID,
+					Amount:               pending.Amount,
+					Remittance: Remittance{
+						Memo:          pending.Memo,
+						Reference:     pending.Reference,
+						Category:      pending.Category,
+						InvoiceNumber: pending.InvoiceNumber,
+						EndToEndID:    pending.EndToEndID,
+					},
+				}, 0, store.fees, store.limits,
+			)
+			if err != nil {
+				return err
+			}
+			result.Transfer = &transfer
+
+			result.PendingTransfer, err = q.ExecutePendingTransfer(
+				ctx, ExecutePendingTransferParams{
+					ID:         pending.ID,
+					TransferID: sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true},
+				},
+			)
+			return err
+		},
+	)
+
+	return result, err
+}
@@ -0,0 +1,245 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createTestOrganization(t *testing.T, store Store, requiredApprovals int32) (Organization, User) {
+	admin, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	organization, err := store.CreateOrganizationTx(
+		context.Background(), CreateOrganizationParams{
+			Name:              util.RandomOwner(),
+			ApprovalThreshold: 100,
+			RequiredApprovals: requiredApprovals,
+			CreatedBy:         admin.Username,
+		},
+	)
+	require.NoError(t, err)
+	return organization, admin
+}
+
+func addOrganizationMember(t *testing.T, organization Organization, role string) User {
+	user, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateOrganizationMember(
+		context.Background(), CreateOrganizationMemberParams{
+			OrganizationID: organization.ID,
+			Username:       user.Username,
+			Role:           role,
+		},
+	)
+	require.NoError(t, err)
+	return user
+}
+
+func createOrganizationAccount(t *testing.T, store Store, organization Organization, balance int64) Account {
+	account, err := createAccountTx(
+		t, store, CreateAccountTxParams{
+			CreateAccountParams: CreateAccountParams{
+				Owner:          organization.CreatedBy,
+				Currency:       constants.USD,
+				OrganizationID: sql.NullInt64{Int64: organization.ID, Valid: true},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	account, err = testQueries.UpdateAccountBalance(
+		context.Background(), UpdateAccountBalanceParams{ID: account.ID, Amount: balance},
+	)
+	require.NoError(t, err)
+	return account
+}
+
+func createTestPendingTransfer(
+	t *testing.T, organization Organization, source Account, initiatedBy string, expiresAt time.Time,
+) PendingTransfer {
+	destination, _, err := createRandomAccount()
+	require.NoError(t, err)
+
+	pending, err := testQueries.CreatePendingTransfer(
+		context.Background(), CreatePendingTransferParams{
+			OrganizationID:       organization.ID,
+			SourceAccountID:      source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               500,
+			InitiatedBy:          initiatedBy,
+			RequiredApprovals:    organization.RequiredApprovals,
+			ExpiresAt:            expiresAt,
+		},
+	)
+	require.NoError(t, err)
+	return pending
+}
+
+func TestCreateOrganizationAccount(t *testing.T) {
+	store := NewStore(testDB)
+	organization, admin := createTestOrganization(t, store, 2)
+	initiator := addOrganizationMember(t, organization, constants.OrganizationRoleInitiator)
+
+	members, err := testQueries.GetOrganizationMembers(context.Background(), organization.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	require.Equal(t, admin.Username, members[0].Username)
+	require.Equal(t, constants.OrganizationRoleAdmin, members[0].Role)
+
+	account := createOrganizationAccount(t, store, organization, 0)
+	require.Equal(t, sql.NullInt64{Int64: organization.ID, Valid: true}, account.OrganizationID)
+
+	accountMembers, err := testQueries.GetAccountMembers(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Empty(t, accountMembers)
+
+	count, err := testQueries.CountOpenAccounts(context.Background(), CountOpenAccountsParams{Owner: admin.Username})
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	accounts, err := testQueries.GetAccounts(
+		context.Background(), GetAccountsParams{Owner: initiator.Username, Limit: 5},
+	)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+}
+
+func TestApprovePendingTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	organization, admin := createTestOrganization(t, store, 2)
+	initiator := addOrganizationMember(t, organization, constants.OrganizationRoleInitiator)
+	approver := addOrganizationMember(t, organization, constants.OrganizationRoleApprover)
+
+	source := createOrganizationAccount(t, store, organization, 1000)
+	pending := createTestPendingTransfer(t, organization, source, initiator.Username, time.Now().Add(time.Hour))
+
+	_, err := store.ApprovePendingTransferTx(
+		context.Background(), ApprovePendingTransferTxParams{
+			PendingTransferID: pending.ID,
+			Approver:          initiator.Username,
+			Now:               time.Now(),
+		},
+	)
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	result, err := store.ApprovePendingTransferTx(
+		context.Background(), ApprovePendingTransferTxParams{
+			PendingTransferID: pending.ID,
+			Approver:          approver.Username,
+			Now:               time.Now(),
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.PendingTransferStatusPending, result.PendingTransfer.Status)
+	require.Len(t, result.Approvals, 1)
+	require.Nil(t, result.Transfer)
+
+	_, err = store.ApprovePendingTransferTx(
+		context.Background(), ApprovePendingTransferTxParams{
+			PendingTransferID: pending.ID,
+			Approver:          approver.Username,
+			Now:               time.Now(),
+		},
+	)
+	require.ErrorIs(t, err, ErrAlreadyApproved)
+
+	result, err = store.ApprovePendingTransferTx(
+		context.Background(), ApprovePendingTransferTxParams{
+			PendingTransferID: pending.ID,
+			Approver:          admin.Username,
+			Now:               time.Now(),
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.PendingTransferStatusExecuted, result.PendingTransfer.Status)
+	require.True(t, result.PendingTransfer.DecidedAt.Valid)
+	require.Len(t, result.Approvals, 2)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, pending.Amount, result.Transfer.Transfer.Amount)
+	require.Equal(t, sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}, result.PendingTransfer.TransferID)
+	require.Equal(t, source.Balance-pending.Amount, result.Transfer.SourceAccount.Balance)
+
+	_, err = testQueries.RejectPendingTransfer(
+		context.Background(), RejectPendingTransferParams{
+			ID:         pending.ID,
+			RejectedBy: sql.NullString{String: approver.Username, Valid: true},
+		},
+	)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestApprovePendingTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+	organization, _ := createTestOrganization(t, store, 1)
+	initiator := addOrganizationMember(t, organization, constants.OrganizationRoleInitiator)
+	approver := addOrganizationMember(t, organization, constants.OrganizationRoleApprover)
+
+	source := createOrganizationAccount(t, store, organization, 10)
+	pending := createTestPendingTransfer(t, organization, source, initiator.Username, time.Now().Add(time.Hour))
+
+	_, err := store.ApprovePendingTransferTx(
+		context.Background(), ApprovePendingTransferTxParams{
+			PendingTransferID: pending.ID,
+			Approver:          approver.Username,
+			Now:               time.Now(),
+		},
+	)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	approvals, err := testQueries.GetTransferApprovals(context.Background(), pending.ID)
+	require.NoError(t, err)
+	require.Empty(t, approvals)
+}
+
+func TestRejectAndExpirePendingTransfers(t *testing.T) {
+	store := NewStore(testDB)
+	organization, admin := createTestOrganization(t, store, 1)
+	initiator := addOrganizationMember(t, organization, constants.OrganizationRoleInitiator)
+	source := createOrganizationAccount(t, store, organization, 1000)
+
+	rejected := createTestPendingTransfer(t, organization, source, initiator.Username, time.Now().Add(time.Hour))
+	rejected, err := testQueries.RejectPendingTransfer(
+		context.Background(), RejectPendingTransferParams{
+			ID:              rejected.ID,
+			RejectedBy:      sql.NullString{String: admin.Username, Valid: true},
+			RejectionReason: sql.NullString{String: "duplicate", Valid: true},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.PendingTransferStatusRejected, rejected.Status)
+	require.True(t, rejected.DecidedAt.Valid)
+
+	expiresAt := time.Now().Add(-time.Minute)
+	stale := createTestPendingTransfer(t, organization, source, initiator.Username, expiresAt)
+
+	_, err = store.ApprovePendingTransferTx(
+		context.Background(), ApprovePendingTransferTxParams{
+			PendingTransferID: stale.ID,
+			Approver:          admin.Username,
+			Now:               time.Now(),
+		},
+	)
+	require.ErrorIs(t, err, ErrPendingTransferExpired)
+
+	expired, err := testQueries.ExpirePendingTransfers(
+		context.Background(), ExpirePendingTransfersParams{ExpiresAt: time.Now(), Limit: 1000},
+	)
+	require.NoError(t, err)
+
+	var found bool
+	for _, pending := range expired {
+		require.NotEqual(t, rejected.ID, pending.ID)
+		if pending.ID == stale.ID {
+			found = true
+			require.Equal(t, constants.PendingTransferStatusExpired, pending.Status)
+		}
+	}
+	require.True(t, found)
+}
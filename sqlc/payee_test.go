@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomPayee(t *testing.T, owner User) Payee {
+	account, _, err := createRandomAccount()
+	require.NoError(t, err)
+
+	arg := CreatePayeeParams{
+		Owner:           owner.Username,
+		Nickname:        util.RandomOwner(),
+		AccountID:       account.ID,
+		Currency:        account.Currency,
+		ExpectedName:    sql.NullString{String: "Jane Smith", Valid: true},
+		NameCheck:       sql.NullString{String: constants.NameCheckNoMatch, Valid: true},
+		CoolingOffUntil: time.Now().Add(time.Hour),
+	}
+
+	payee, err := testQueries.CreatePayee(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, payee.ID)
+	require.Equal(t, arg.AccountID, payee.AccountID)
+	require.Equal(t, arg.NameCheck, payee.NameCheck)
+	require.WithinDuration(t, arg.CoolingOffUntil, payee.CoolingOffUntil, time.Second)
+	return payee
+}
+
+func TestCreatePayee(t *testing.T) {
+	owner, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	payee := createRandomPayee(t, owner)
+
+	_, err = testQueries.CreatePayee(
+		context.Background(), CreatePayeeParams{
+			Owner:           owner.Username,
+			Nickname:        util.RandomOwner(),
+			AccountID:       payee.AccountID,
+			Currency:        payee.Currency,
+			CoolingOffUntil: time.Now(),
+		},
+	)
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, constants.UniqueViolation, pqErr.Code.Name())
+}
+
+func TestGetPayeeByAccount(t *testing.T) {
+	owner, _, err := createRandomUser()
+	require.NoError(t, err)
+	other, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	payee := createRandomPayee(t, owner)
+
+	found, err := testQueries.GetPayeeByAccount(
+		context.Background(), GetPayeeByAccountParams{Owner: owner.Username, AccountID: payee.AccountID},
+	)
+	require.NoError(t, err)
+	require.Equal(t, payee.ID, found.ID)
+
+	_, err = testQueries.GetPayeeByAccount(
+		context.Background(), GetPayeeByAccountParams{Owner: other.Username, AccountID: payee.AccountID},
+	)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCountTransfersToAccountBefore(t *testing.T) {
+	source, _, err := createRandomAccount()
+	require.NoError(t, err)
+	destination, _, err := createRandomAccount()
+	require.NoError(t, err)
+
+	transfer, _, err := createRandomTransfer(source.ID, destination.ID)
+	require.NoError(t, err)
+
+	arg := CountTransfersToAccountBeforeParams{
+		Owner:                source.Owner,
+		DestinationAccountID: destination.ID,
+		CreatedAt:            transfer.CreatedAt.Add(time.Second),
+	}
+	count, err := testQueries.CountTransfersToAccountBefore(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	arg.CreatedAt = transfer.CreatedAt
+	count, err = testQueries.CountTransfersToAccountBefore(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	arg.CreatedAt = transfer.CreatedAt.Add(time.Second)
+	arg.Owner = destination.Owner
+	count, err = testQueries.CountTransfersToAccountBefore(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestGetPayees(t *testing.T) {
+	owner, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		createRandomPayee(t, owner)
+	}
+
+	payees, err := testQueries.GetPayees(
+		context.Background(), GetPayeesParams{Owner: owner.Username, Limit: 2, Offset: 1},
+	)
+	require.NoError(t, err)
+	require.Len(t, payees, 2)
+	require.LessOrEqual(t, payees[0].Nickname, payees[1].Nickname)
+	for _, payee := range payees {
+		require.Equal(t, owner.Username, payee.Owner)
+	}
+}
+
+func TestUpdateAndDeletePayee(t *testing.T) {
+	owner, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	payee := createRandomPayee(t, owner)
+
+	updated, err := testQueries.UpdatePayeeNickname(
+		context.Background(), UpdatePayeeNicknameParams{ID: payee.ID, Nickname: "Landlord"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "Landlord", updated.Nickname)
+	require.Equal(t, payee.AccountID, updated.AccountID)
+
+	err = testQueries.DeletePayee(context.Background(), payee.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetPayee(context.Background(), payee.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"time"
+)
+
+var (
+	ErrPaymentRequestNotPending = errors.New("payment request has already been answered, cancelled or expired")
+	ErrPaymentRequestExpired    = errors.New("payment request has expired")
+)
+
+type AcceptPaymentRequestTxParams struct {
+	PaymentRequestID int64     `json:"payment_request_id"`
+	SourceAccountID  int64     `json:"source_account_id"`
+	Now              time.Time `json:"now"`
+}
+
+type AcceptPaymentRequestTxResult struct {
+	PaymentRequest PaymentRequest `json:"payment_request"`
+	Transfer       Transfer       `json:"transfer"`
+}
+
+// AcceptPaymentRequestTx pays a payment request from the source account the same way TransferTx would. Accepting a
+// request that has already been accepted is not an error: it returns the transfer that paid it, without paying it
+// again, so a payer can safely retry.
+func (store *SQLStore) AcceptPaymentRequestTx(ctx context.Context, arg AcceptPaymentRequestTxParams) (
+	AcceptPaymentRequestTxResult, error,
+) {
+	var result AcceptPaymentRequestTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			request, err := q.GetPaymentRequestForUpdate(ctx, arg.PaymentRequestID)
+			if err != nil {
+				return err
+			}
+			result.PaymentRequest = request
+
+			if request.Status == constants.PaymentRequestStatusAccepted {
+				result.Transfer, err = q.GetTransfer(ctx, request.TransferID.Int64)
+				return err
+			}
+			if request.Status != constants.PaymentRequestStatusPending {
+				return ErrPaymentRequestNotPending
+			}
+			if !arg.Now.Before(request.ExpiresAt) {
+				return ErrPaymentRequestExpired
+			}
+
+			transfer, err := transferTx(
+				ctx, q, TransferTxParams{
+					SourceAccountID:      arg.SourceAccountID,
+					DestinationAccountID: request.DestinationAccountID,
+					Amount:               request.Amount,
+					Remittance:           Remittance{Memo: request.Memo},
+				}, 0, store.fees, store.limits,
+			)
+			if err != nil {
+				return err
+			}
+			result.Transfer = transfer.Transfer
+
+			result.PaymentRequest, err = q.AcceptPaymentRequest(
+				ctx, AcceptPaymentRequestParams{
+					ID:         request.ID,
+					TransferID: sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true},
+				},
+			)
+			return err
+		},
+	)
+
+	return result, err
+}
@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createTestPaymentRequest(t *testing.T, destination Account, payer Account, expiresAt time.Time) PaymentRequest {
+	request, err := testQueries.CreatePaymentRequest(
+		context.Background(), CreatePaymentRequestParams{
+			Requester:            destination.Owner,
+			DestinationAccountID: destination.ID,
+			Payer:                sql.NullString{String: payer.Owner, Valid: true},
+			Amount:               40,
+			Currency:             destination.Currency,
+			Memo:                 sql.NullString{String: "Concert tickets", Valid: true},
+			ExpiresAt:            expiresAt,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.PaymentRequestStatusPending, request.Status)
+	return request
+}
+
+func TestAcceptPaymentRequestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+	source, err := createFundedAccount(100)
+	require.NoError(t, err)
+
+	request := createTestPaymentRequest(t, destination, source, time.Now().Add(time.Hour))
+	arg := AcceptPaymentRequestTxParams{PaymentRequestID: request.ID, SourceAccountID: source.ID, Now: time.Now()}
+
+	result, err := store.AcceptPaymentRequestTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, constants.PaymentRequestStatusAccepted, result.PaymentRequest.Status)
+	require.True(t, result.PaymentRequest.RespondedAt.Valid)
+	require.Equal(t, sql.NullInt64{Int64: result.Transfer.ID, Valid: true}, result.PaymentRequest.TransferID)
+	require.Equal(t, source.ID, result.Transfer.SourceAccountID)
+	require.Equal(t, destination.ID, result.Transfer.DestinationAccountID)
+	require.Equal(t, request.Amount, result.Transfer.Amount)
+	require.Equal(t, request.Memo, result.Transfer.Memo)
+
+	replay, err := store.AcceptPaymentRequestTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, result.Transfer.ID, replay.Transfer.ID)
+
+	updatedSource, err := testQueries.GetAccount(context.Background(), source.ID)
+	require.NoError(t, err)
+	require.Equal(t, source.Balance-request.Amount, updatedSource.Balance)
+
+	_, err = testQueries.DeclinePaymentRequest(context.Background(), request.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestAcceptPaymentRequestTxNotPending(t *testing.T) {
+	store := NewStore(testDB)
+
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+	source, err := createFundedAccount(100)
+	require.NoError(t, err)
+
+	declined := createTestPaymentRequest(t, destination, source, time.Now().Add(time.Hour))
+	declined, err = testQueries.DeclinePaymentRequest(context.Background(), declined.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.PaymentRequestStatusDeclined, declined.Status)
+
+	_, err = store.AcceptPaymentRequestTx(
+		context.Background(), AcceptPaymentRequestTxParams{
+			PaymentRequestID: declined.ID,
+			SourceAccountID:  source.ID,
+			Now:              time.Now(),
+		},
+	)
+	require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+
+	stale := createTestPaymentRequest(t, destination, source, time.Now().Add(-time.Minute))
+	_, err = store.AcceptPaymentRequestTx(
+		context.Background(), AcceptPaymentRequestTxParams{
+			PaymentRequestID: stale.ID,
+			SourceAccountID:  source.ID,
+			Now:              time.Now(),
+		},
+	)
+	require.ErrorIs(t, err, ErrPaymentRequestExpired)
+
+	expired, err := testQueries.ExpirePaymentRequests(
+		context.Background(), ExpirePaymentRequestsParams{ExpiresAt: time.Now(), Limit: 1000},
+	)
+	require.NoError(t, err)
+
+	var found bool
+	for _, request := range expired {
+		require.NotEqual(t, declined.ID, request.ID)
+		if request.ID == stale.ID {
+			found = true
+			require.Equal(t, constants.PaymentRequestStatusExpired, request.Status)
+		}
+	}
+	require.True(t, found)
+
+	incoming, err := testQueries.GetIncomingPaymentRequests(
+		context.Background(), GetIncomingPaymentRequestsParams{Username: source.Owner, PageLimit: 10},
+	)
+	require.NoError(t, err)
+	require.Len(t, incoming, 2)
+	require.Equal(t, stale.ID, incoming[0].ID)
+}
+
+func TestAcceptPaymentRequestTxDestinationScreeningHold(t *testing.T) {
+	store := NewStore(testDB)
+
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+	source, err := createFundedAccount(100)
+	require.NoError(t, err)
+
+	request := createTestPaymentRequest(t, destination, source, time.Now().Add(time.Hour))
+
+	err = testQueries.UpdateUserScreeningStatus(
+		context.Background(), UpdateUserScreeningStatusParams{
+			Username:        destination.Owner,
+			ScreeningStatus: constants.ScreeningStatusPendingReview,
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = store.AcceptPaymentRequestTx(
+		context.Background(), AcceptPaymentRequestTxParams{
+			PaymentRequestID: request.ID,
+			SourceAccountID:  source.ID,
+			Now:              time.Now(),
+		},
+	)
+	require.ErrorIs(t, err, ErrDestinationScreeningHold)
+
+	request, err = testQueries.GetPaymentRequest(context.Background(), request.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.PaymentRequestStatusPending, request.Status)
+
+	source, err = testQueries.GetAccount(context.Background(), source.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), source.Balance)
+}
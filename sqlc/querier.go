@@ -6,35 +6,218 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AcceptPaymentRequest(ctx context.Context, arg AcceptPaymentRequestParams) (PaymentRequest, error)
+	AddAccountHeldBalance(ctx context.Context, arg AddAccountHeldBalanceParams) (Account, error)
+	AssignAmlAlert(ctx context.Context, arg AssignAmlAlertParams) (AmlAlert, error)
+	AttachKycDocuments(ctx context.Context, arg AttachKycDocumentsParams) error
+	BlockUserSessions(ctx context.Context, username string) error
+	CancelPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
+	CancelScheduledTransfersForAccountMember(ctx context.Context, arg CancelScheduledTransfersForAccountMemberParams) error
+	CaptureHold(ctx context.Context, arg CaptureHoldParams) (Hold, error)
+	ClaimTransferBatch(ctx context.Context, arg ClaimTransferBatchParams) (TransferBatch, error)
+	CloseAmlAlert(ctx context.Context, arg CloseAmlAlertParams) (AmlAlert, error)
+	CompleteReconciliationRun(ctx context.Context, arg CompleteReconciliationRunParams) (ReconciliationRun, error)
+	CompleteTransferBatchItem(ctx context.Context, arg CompleteTransferBatchItemParams) (TransferBatchItem, error)
+	CountOpenAccounts(ctx context.Context, arg CountOpenAccountsParams) (int64, error)
+	CountOrganizationMembersByRole(ctx context.Context, arg CountOrganizationMembersByRoleParams) (int64, error)
+	CountTransferBatchItems(ctx context.Context, batchID int64) ([]CountTransferBatchItemsRow, error)
+	CountTransfersToAccountBefore(ctx context.Context, arg CountTransfersToAccountBeforeParams) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountInvitation(ctx context.Context, arg CreateAccountInvitationParams) (AccountInvitation, error)
+	CreateAccountMember(ctx context.Context, arg CreateAccountMemberParams) (AccountMember, error)
+	CreateAccountStatusChange(ctx context.Context, arg CreateAccountStatusChangeParams) (AccountStatusChange, error)
+	CreateAmlAlert(ctx context.Context, arg CreateAmlAlertParams) (AmlAlert, error)
+	CreateAmlAlertComment(ctx context.Context, arg CreateAmlAlertCommentParams) (AmlAlertComment, error)
+	CreateBalanceSnapshot(ctx context.Context, arg CreateBalanceSnapshotParams) (BalanceSnapshot, error)
+	CreateCashTransaction(ctx context.Context, arg CreateCashTransactionParams) (CashTransaction, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFee(ctx context.Context, arg CreateFeeParams) (Fee, error)
+	CreateFeeWaiver(ctx context.Context, arg CreateFeeWaiverParams) (FeeWaiver, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error)
+	CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error)
+	CreateJournalTransaction(ctx context.Context, arg CreateJournalTransactionParams) (JournalTransaction, error)
+	CreateKycDocument(ctx context.Context, arg CreateKycDocumentParams) (KycDocument, error)
+	CreateKycSubmission(ctx context.Context, username string) (KycSubmission, error)
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
+	CreateOrganizationMember(ctx context.Context, arg CreateOrganizationMemberParams) (OrganizationMember, error)
+	CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error)
+	CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error)
+	CreatePendingTransfer(ctx context.Context, arg CreatePendingTransferParams) (PendingTransfer, error)
+	CreateReconciliationDiscrepancy(ctx context.Context, arg CreateReconciliationDiscrepancyParams) (ReconciliationDiscrepancy, error)
+	CreateReconciliationRun(ctx context.Context) (ReconciliationRun, error)
+	CreateRiskAssessment(ctx context.Context, arg CreateRiskAssessmentParams) (RiskAssessment, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateScreeningHit(ctx context.Context, arg CreateScreeningHitParams) (ScreeningHit, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateStatement(ctx context.Context, arg CreateStatementParams) (Statement, error)
+	CreateSystemAccount(ctx context.Context, arg CreateSystemAccountParams) (SystemAccount, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error)
+	CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error)
+	CreateTransferBatchItem(ctx context.Context, arg CreateTransferBatchItemParams) (TransferBatchItem, error)
+	CreateTransferLimitOverride(ctx context.Context, arg CreateTransferLimitOverrideParams) (TransferLimitOverride, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeclinePaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
 	DeleteAccount(ctx context.Context, id int64) error
-	DeleteEntry(ctx context.Context, id int64) error
+	DeleteAccountMember(ctx context.Context, arg DeleteAccountMemberParams) (AccountMember, error)
+	DeleteKycDocument(ctx context.Context, id int64) (KycDocument, error)
+	DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) (OrganizationMember, error)
+	DeletePayee(ctx context.Context, id int64) error
 	DeleteTransfer(ctx context.Context, id int64) error
+	DeleteTransferLimitOverride(ctx context.Context, id int64) error
+	ExecutePendingTransfer(ctx context.Context, arg ExecutePendingTransferParams) (PendingTransfer, error)
+	ExpirePaymentRequests(ctx context.Context, arg ExpirePaymentRequestsParams) ([]PaymentRequest, error)
+	ExpirePendingTransfers(ctx context.Context, arg ExpirePendingTransfersParams) ([]PendingTransfer, error)
+	FailTransferBatchItem(ctx context.Context, arg FailTransferBatchItemParams) (TransferBatchItem, error)
+	FinishTransferBatch(ctx context.Context, arg FinishTransferBatchParams) (TransferBatch, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error)
+	GetAccountByIban(ctx context.Context, iban sql.NullString) (Account, error)
+	GetAccountByNumber(ctx context.Context, accountNumber string) (Account, error)
+	GetAccountEntryTotalBetween(ctx context.Context, arg GetAccountEntryTotalBetweenParams) (int64, error)
+	GetAccountEntryTotals(ctx context.Context, arg GetAccountEntryTotalsParams) ([]GetAccountEntryTotalsRow, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountInvitation(ctx context.Context, id int64) (AccountInvitation, error)
+	GetAccountInvitationForUpdate(ctx context.Context, id int64) (AccountInvitation, error)
+	GetAccountInvitations(ctx context.Context, arg GetAccountInvitationsParams) ([]AccountInvitation, error)
+	GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error)
+	GetAccountMembers(ctx context.Context, accountID int64) ([]AccountMember, error)
+	GetAccountProduct(ctx context.Context, code string) (AccountProduct, error)
+	GetAccountProducts(ctx context.Context) ([]AccountProduct, error)
+	GetAccountStatusChanges(ctx context.Context, arg GetAccountStatusChangesParams) ([]AccountStatusChange, error)
 	GetAccounts(ctx context.Context, arg GetAccountsParams) ([]Account, error)
+	GetAccountsByProduct(ctx context.Context, arg GetAccountsByProductParams) ([]Account, error)
+	GetAccountsOpenedBefore(ctx context.Context, arg GetAccountsOpenedBeforeParams) ([]Account, error)
+	GetActiveFeeWaivers(ctx context.Context, accountID int64) ([]FeeWaiver, error)
+	GetActiveTransferLimitOverride(ctx context.Context, arg GetActiveTransferLimitOverrideParams) (TransferLimitOverride, error)
+	GetAmlAlert(ctx context.Context, id int64) (AmlAlert, error)
+	GetAmlAlertComments(ctx context.Context, alertID int64) ([]AmlAlertComment, error)
+	GetAmlAlertForUpdate(ctx context.Context, id int64) (AmlAlert, error)
+	GetAmlAlerts(ctx context.Context, arg GetAmlAlertsParams) ([]AmlAlert, error)
+	GetAmlAlertsForExport(ctx context.Context, arg GetAmlAlertsForExportParams) ([]GetAmlAlertsForExportRow, error)
+	GetBalanceSnapshotOnOrBefore(ctx context.Context, arg GetBalanceSnapshotOnOrBeforeParams) (BalanceSnapshot, error)
+	GetCashTransactionByIdempotencyKey(ctx context.Context, arg GetCashTransactionByIdempotencyKeyParams) (CashTransaction, error)
+	GetCashTransactionsForAccount(ctx context.Context, arg GetCashTransactionsForAccountParams) ([]CashTransaction, error)
+	GetDormantReactivationCandidates(ctx context.Context, arg GetDormantReactivationCandidatesParams) ([]GetDormantReactivationCandidatesRow, error)
+	GetDueScheduledTransferForUpdate(ctx context.Context, nextAttemptAt time.Time) (ScheduledTransfer, error)
 	GetEntries(ctx context.Context, arg GetEntriesParams) ([]Entry, error)
 	GetEntriesForAccount(ctx context.Context, arg GetEntriesForAccountParams) ([]Entry, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetExpiredHolds(ctx context.Context, arg GetExpiredHoldsParams) ([]Hold, error)
+	GetFeesForAccount(ctx context.Context, arg GetFeesForAccountParams) ([]Fee, error)
+	GetFirstDeviceSession(ctx context.Context, arg GetFirstDeviceSessionParams) (Session, error)
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetHoldsForAccount(ctx context.Context, arg GetHoldsForAccountParams) ([]Hold, error)
+	GetInactiveAccounts(ctx context.Context, arg GetInactiveAccountsParams) ([]Account, error)
 	GetInboundTransfersForAccount(ctx context.Context, arg GetInboundTransfersForAccountParams) ([]Transfer, error)
+	GetIncomingPaymentRequests(ctx context.Context, arg GetIncomingPaymentRequestsParams) ([]PaymentRequest, error)
+	GetInterestAccruals(ctx context.Context, arg GetInterestAccrualsParams) ([]InterestAccrual, error)
+	GetInterestBearingAccounts(ctx context.Context, arg GetInterestBearingAccountsParams) ([]Account, error)
+	GetInterestPostings(ctx context.Context, arg GetInterestPostingsParams) ([]InterestPosting, error)
+	GetJournalTransaction(ctx context.Context, id int64) (JournalTransaction, error)
+	GetJournalTransactionEntries(ctx context.Context, journalTransactionID sql.NullInt64) ([]Entry, error)
+	GetJournalTransactionsMissingTransfer(ctx context.Context, arg GetJournalTransactionsMissingTransferParams) ([]JournalTransaction, error)
+	GetKycDocument(ctx context.Context, id int64) (KycDocument, error)
+	GetKycDocuments(ctx context.Context, username string) ([]KycDocument, error)
+	GetKycSubmission(ctx context.Context, id int64) (KycSubmission, error)
+	GetKycSubmissionDocuments(ctx context.Context, submissionID sql.NullInt64) ([]KycDocument, error)
+	GetKycSubmissionForUpdate(ctx context.Context, id int64) (KycSubmission, error)
+	GetKycSubmissions(ctx context.Context, arg GetKycSubmissionsParams) ([]KycSubmission, error)
+	GetLastInterestAccrualDate(ctx context.Context, accountID int64) (time.Time, error)
+	GetLastInterestPosting(ctx context.Context, accountID int64) (InterestPosting, error)
+	GetLatestBalanceSnapshot(ctx context.Context, accountID int64) (BalanceSnapshot, error)
+	GetLatestReconciliationRun(ctx context.Context) (ReconciliationRun, error)
+	GetNotifications(ctx context.Context, arg GetNotificationsParams) ([]Notification, error)
+	GetOrganization(ctx context.Context, id int64) (Organization, error)
+	GetOrganizationForUpdate(ctx context.Context, id int64) (Organization, error)
+	GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (OrganizationMember, error)
+	GetOrganizationMembers(ctx context.Context, organizationID int64) ([]OrganizationMember, error)
+	GetOrganizationTransferUsage(ctx context.Context, arg GetOrganizationTransferUsageParams) (GetOrganizationTransferUsageRow, error)
+	GetOrganizationsForScreening(ctx context.Context, arg GetOrganizationsForScreeningParams) ([]Organization, error)
+	GetOrganizationsForUser(ctx context.Context, arg GetOrganizationsForUserParams) ([]Organization, error)
 	GetOutboundTransfersForAccount(ctx context.Context, arg GetOutboundTransfersForAccountParams) ([]Transfer, error)
+	GetOutgoingPaymentRequests(ctx context.Context, arg GetOutgoingPaymentRequestsParams) ([]PaymentRequest, error)
+	GetPayee(ctx context.Context, id int64) (Payee, error)
+	GetPayeeByAccount(ctx context.Context, arg GetPayeeByAccountParams) (Payee, error)
+	GetPayees(ctx context.Context, arg GetPayeesParams) ([]Payee, error)
+	GetPayeesForScreening(ctx context.Context, arg GetPayeesForScreeningParams) ([]GetPayeesForScreeningRow, error)
+	GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPendingInvitationsForUser(ctx context.Context, arg GetPendingInvitationsForUserParams) ([]AccountInvitation, error)
+	GetPendingTransfer(ctx context.Context, id int64) (PendingTransfer, error)
+	GetPendingTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error)
+	GetPendingTransferForUpdate(ctx context.Context, id int64) (PendingTransfer, error)
+	GetPendingTransfers(ctx context.Context, arg GetPendingTransfersParams) ([]PendingTransfer, error)
+	GetRapidMovementCandidates(ctx context.Context, arg GetRapidMovementCandidatesParams) ([]GetRapidMovementCandidatesRow, error)
+	GetReconciliationDiscrepancies(ctx context.Context, arg GetReconciliationDiscrepanciesParams) ([]ReconciliationDiscrepancy, error)
+	GetRiskAssessment(ctx context.Context, id int64) (RiskAssessment, error)
+	GetRiskAssessmentForUpdate(ctx context.Context, id int64) (RiskAssessment, error)
+	GetRiskAssessments(ctx context.Context, arg GetRiskAssessmentsParams) ([]RiskAssessment, error)
+	GetRunnableTransferBatches(ctx context.Context, arg GetRunnableTransferBatchesParams) ([]TransferBatch, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetScheduledTransfers(ctx context.Context, arg GetScheduledTransfersParams) ([]ScheduledTransfer, error)
+	GetScreeningHit(ctx context.Context, id int64) (ScreeningHit, error)
+	GetScreeningHitCounts(ctx context.Context, arg GetScreeningHitCountsParams) (GetScreeningHitCountsRow, error)
+	GetScreeningHitForUpdate(ctx context.Context, id int64) (ScreeningHit, error)
+	GetScreeningHits(ctx context.Context, arg GetScreeningHitsParams) ([]ScreeningHit, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetStatement(ctx context.Context, arg GetStatementParams) (Statement, error)
+	GetStatementLines(ctx context.Context, arg GetStatementLinesParams) ([]GetStatementLinesRow, error)
+	GetStatementsForAccount(ctx context.Context, arg GetStatementsForAccountParams) ([]Statement, error)
+	GetStructuringCandidates(ctx context.Context, arg GetStructuringCandidatesParams) ([]GetStructuringCandidatesRow, error)
+	GetSystemAccount(ctx context.Context, arg GetSystemAccountParams) (SystemAccount, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
-	GetTransfers(ctx context.Context, arg GetTransfersParams) ([]Transfer, error)
+	GetTransferApprovals(ctx context.Context, pendingTransferID int64) ([]TransferApproval, error)
+	GetTransferBatch(ctx context.Context, id int64) (TransferBatch, error)
+	GetTransferBatchItemForUpdate(ctx context.Context, id int64) (TransferBatchItem, error)
+	GetTransferBatchItems(ctx context.Context, arg GetTransferBatchItemsParams) ([]TransferBatchItem, error)
+	GetTransferEntryMatches(ctx context.Context, arg GetTransferEntryMatchesParams) ([]GetTransferEntryMatchesRow, error)
+	GetTransferLimitOverride(ctx context.Context, id int64) (TransferLimitOverride, error)
+	GetTransferLimitOverrides(ctx context.Context, arg GetTransferLimitOverridesParams) ([]TransferLimitOverride, error)
+	GetTransferRiskStats(ctx context.Context, arg GetTransferRiskStatsParams) (GetTransferRiskStatsRow, error)
+	GetTransferUsage(ctx context.Context, arg GetTransferUsageParams) (GetTransferUsageRow, error)
+	GetTransfers(ctx context.Context, arg GetTransfersParams) ([]GetTransfersRow, error)
+	GetUnsubmittedKycDocuments(ctx context.Context, username string) ([]KycDocument, error)
 	GetUser(ctx context.Context, username string) (User, error)
-	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetUsersForScreening(ctx context.Context, arg GetUsersForScreeningParams) ([]User, error)
+	PostInterestAccruals(ctx context.Context, arg PostInterestAccrualsParams) ([]int64, error)
+	RejectPendingTransfer(ctx context.Context, arg RejectPendingTransferParams) (PendingTransfer, error)
+	ReviewKycSubmission(ctx context.Context, arg ReviewKycSubmissionParams) (KycSubmission, error)
+	ReviewRiskAssessment(ctx context.Context, arg ReviewRiskAssessmentParams) (RiskAssessment, error)
+	ReviewScreeningHit(ctx context.Context, arg ReviewScreeningHitParams) (ScreeningHit, error)
+	SearchTransfers(ctx context.Context, arg SearchTransfersParams) ([]Transfer, error)
+	SkipPendingTransferBatchItems(ctx context.Context, batchID int64) error
 	UpdateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) (Account, error)
-	UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry, error)
+	UpdateAccountInvitationStatus(ctx context.Context, arg UpdateAccountInvitationStatusParams) (AccountInvitation, error)
+	UpdateAccountNickname(ctx context.Context, arg UpdateAccountNicknameParams) (Account, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateCashTransactionJournal(ctx context.Context, arg UpdateCashTransactionJournalParams) (CashTransaction, error)
+	UpdateFeeTransfer(ctx context.Context, arg UpdateFeeTransferParams) (Fee, error)
+	UpdateHoldStatus(ctx context.Context, arg UpdateHoldStatusParams) (Hold, error)
+	UpdateInterestPosting(ctx context.Context, arg UpdateInterestPostingParams) (InterestPosting, error)
+	UpdateOrganizationApprovalRule(ctx context.Context, arg UpdateOrganizationApprovalRuleParams) (Organization, error)
+	UpdateOrganizationScreeningStatus(ctx context.Context, arg UpdateOrganizationScreeningStatusParams) error
+	UpdateOrganizationTier(ctx context.Context, arg UpdateOrganizationTierParams) (Organization, error)
+	UpdatePayeeNickname(ctx context.Context, arg UpdatePayeeNicknameParams) (Payee, error)
+	UpdatePayeeScreeningStatus(ctx context.Context, arg UpdatePayeeScreeningStatusParams) error
+	UpdateScheduledTransferRun(ctx context.Context, arg UpdateScheduledTransferRunParams) (ScheduledTransfer, error)
+	UpdateScheduledTransferStatus(ctx context.Context, arg UpdateScheduledTransferStatusParams) (ScheduledTransfer, error)
 	UpdateTransfer(ctx context.Context, arg UpdateTransferParams) (Transfer, error)
+	UpdateUserKycStatus(ctx context.Context, arg UpdateUserKycStatusParams) (User, error)
+	UpdateUserScreeningStatus(ctx context.Context, arg UpdateUserScreeningStatusParams) error
+	UpdateUserTier(ctx context.Context, arg UpdateUserTierParams) (User, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,204 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// createReconciledAccount opens an account whose balance is fully backed by a cash deposit entry.
+func createReconciledAccount(t *testing.T, store Store, amount int64) Account {
+	account, err := createFundedAccount(0)
+	require.NoError(t, err)
+	teller, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	result, err := store.DepositTx(
+		context.Background(), CashTxParams{
+			AccountID:      account.ID,
+			Amount:         amount,
+			Channel:        constants.ChannelBranch,
+			IdempotencyKey: util.RandomString(16),
+			CreatedBy:      teller.Username,
+		},
+	)
+	require.NoError(t, err)
+
+	return result.Account
+}
+
+func getAccountEntryTotal(t *testing.T, accountID int64) GetAccountEntryTotalsRow {
+	totals, err := testQueries.GetAccountEntryTotals(
+		context.Background(), GetAccountEntryTotalsParams{
+			ID:    accountID - 1,
+			Limit: 1,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, totals, 1)
+	require.Equal(t, accountID, totals[0].ID)
+
+	return totals[0]
+}
+
+func getTransferEntryMatch(t *testing.T, transferID int64) GetTransferEntryMatchesRow {
+	matches, err := testQueries.GetTransferEntryMatches(
+		context.Background(), GetTransferEntryMatchesParams{
+			ID:    transferID - 1,
+			Limit: 1,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, transferID, matches[0].ID)
+
+	return matches[0]
+}
+
+func TestGetAccountEntryTotalsDetectsCorruptedBalance(t *testing.T) {
+	store := NewStore(testDB)
+	account := createReconciledAccount(t, store, 500)
+
+	total := getAccountEntryTotal(t, account.ID)
+	require.Equal(t, int64(500), total.Balance)
+	require.Equal(t, int64(500), total.EntryTotal)
+
+	_, err := testQueries.UpdateAccountBalance(
+		context.Background(), UpdateAccountBalanceParams{
+			ID:     account.ID,
+			Amount: 7,
+		},
+	)
+	require.NoError(t, err)
+
+	total = getAccountEntryTotal(t, account.ID)
+	require.Equal(t, int64(507), total.Balance)
+	require.Equal(t, int64(500), total.EntryTotal)
+}
+
+func TestGetTransferEntryMatchesDetectsCorruptedTransfer(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createReconciledAccount(t, store, 500)
+	account2 := createReconciledAccount(t, store, 0)
+
+	result, err := store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               100,
+		},
+	)
+	require.NoError(t, err)
+
+	match := getTransferEntryMatch(t, result.Transfer.ID)
+	require.Equal(t, result.FromEntry.ID, match.SourceEntryID.Int64)
+	require.Equal(t, int64(-100), match.SourceEntryAmount.Int64)
+	require.Equal(t, result.ToEntry.ID, match.DestinationEntryID.Int64)
+	require.Equal(t, int64(100), match.DestinationEntryAmount.Int64)
+
+	_, err = testQueries.UpdateTransfer(
+		context.Background(), UpdateTransferParams{
+			ID:     result.Transfer.ID,
+			Amount: 150,
+		},
+	)
+	require.NoError(t, err)
+
+	match = getTransferEntryMatch(t, result.Transfer.ID)
+	require.Equal(t, int64(150), match.Amount)
+	require.Equal(t, result.FromEntry.ID, match.SourceEntryID.Int64)
+	require.Equal(t, int64(-100), match.SourceEntryAmount.Int64)
+}
+
+func TestGetTransferEntryMatchesDetectsMissingEntries(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, _, _ := createRandomAccount()
+	transfer, _, err := createRandomTransfer(account1.ID, account2.ID)
+	require.NoError(t, err)
+
+	match := getTransferEntryMatch(t, transfer.ID)
+	require.False(t, match.JournalTransactionID.Valid)
+	require.False(t, match.SourceEntryID.Valid)
+	require.False(t, match.DestinationEntryID.Valid)
+}
+
+func TestGetJournalTransactionsMissingTransferDetectsDeletedTransfer(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createReconciledAccount(t, store, 500)
+	account2 := createReconciledAccount(t, store, 0)
+
+	result, err := store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               100,
+		},
+	)
+	require.NoError(t, err)
+
+	arg := GetJournalTransactionsMissingTransferParams{
+		ID:    result.JournalTransaction.ID - 1,
+		Limit: 1,
+	}
+
+	journals, err := testQueries.GetJournalTransactionsMissingTransfer(context.Background(), arg)
+	require.NoError(t, err)
+	for _, journal := range journals {
+		require.NotEqual(t, result.JournalTransaction.ID, journal.ID)
+	}
+
+	err = testQueries.DeleteTransfer(context.Background(), result.Transfer.ID)
+	require.NoError(t, err)
+
+	journals, err = testQueries.GetJournalTransactionsMissingTransfer(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, journals, 1)
+	require.Equal(t, result.JournalTransaction.ID, journals[0].ID)
+}
+
+func TestReconciliationRun(t *testing.T) {
+	run, err := testQueries.CreateReconciliationRun(context.Background())
+	require.NoError(t, err)
+	require.False(t, run.CompletedAt.Valid)
+
+	account, _, _ := createRandomAccount()
+	discrepancy, err := testQueries.CreateReconciliationDiscrepancy(
+		context.Background(), CreateReconciliationDiscrepancyParams{
+			RunID:     run.ID,
+			Kind:      constants.DiscrepancyBalanceMismatch,
+			AccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+			Expected:  sql.NullInt64{Int64: 0, Valid: true},
+			Actual:    sql.NullInt64{Int64: account.Balance, Valid: true},
+			Detail:    util.RandomString(20),
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, run.ID, discrepancy.RunID)
+	require.False(t, discrepancy.TransferID.Valid)
+
+	completed, err := testQueries.CompleteReconciliationRun(
+		context.Background(), CompleteReconciliationRunParams{
+			ID:               run.ID,
+			AccountsChecked:  1,
+			DiscrepancyCount: 1,
+		},
+	)
+	require.NoError(t, err)
+	require.True(t, completed.CompletedAt.Valid)
+
+	latest, err := testQueries.GetLatestReconciliationRun(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, latest.ID, run.ID)
+
+	discrepancies, err := testQueries.GetReconciliationDiscrepancies(
+		context.Background(), GetReconciliationDiscrepanciesParams{
+			RunID: run.ID,
+			Limit: 5,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []ReconciliationDiscrepancy{discrepancy}, discrepancies)
+}
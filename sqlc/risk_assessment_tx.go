@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/risk"
+	"strings"
+)
+
+var ErrRiskAssessmentNotPending = errors.New("transfer has already been reviewed")
+
+type AssessedTransferTxParams struct {
+	TransferTxParams
+	InitiatedBy string          `json:"initiated_by"`
+	Assessment  risk.Assessment `json:"assessment"`
+}
+
+type AssessedTransferTxResult struct {
+	RiskAssessment RiskAssessment `json:"risk_assessment"`
+	// Only set for transfers the assessment allowed
+	Transfer *TransferTxResult `json:"transfer"`
+}
+
+// AssessedTransferTx records a transfer's risk assessment and acts on its decision: an allowed transfer is made
+// along with it, one to review is held until an admin decides, and a denied one is only recorded.
+func (store *SQLStore) AssessedTransferTx(ctx context.Context, arg AssessedTransferTxParams) (
+	AssessedTransferTxResult, error,
+) {
+	var result AssessedTransferTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			status := constants.RiskAssessmentStatusDenied
+			var transferID sql.NullInt64
+
+			switch arg.Assessment.Decision {
+			case constants.RiskDecisionAllow:
+				transfer, err := transferTx(ctx, q, arg.TransferTxParams, 0, store.fees, store.limits)
+				if err != nil {
+					return err
+				}
+				result.Transfer = &transfer
+				status = constants.RiskAssessmentStatusExecuted
+				transferID = sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true}
+			case constants.RiskDecisionReview:
+				status = constants.RiskAssessmentStatusPendingReview
+			}
+
+			var err error
+			result.RiskAssessment, err = q.CreateRiskAssessment(
+				ctx, CreateRiskAssessmentParams{
+					SourceAccountID:      arg.SourceAccountID,
+					DestinationAccountID: arg.DestinationAccountID,
+					Amount:               arg.Amount,
+					InitiatedBy:          arg.InitiatedBy,
+					Score:                arg.Assessment.Score,
+					Decision:             arg.Assessment.Decision,
+					Reasons:              strings.Join(arg.Assessment.Reasons, ","),
+					Status:               status,
+					TransferID:           transferID,
+					Memo:                 arg.Memo,
+					Reference:            arg.Reference,
+					Category:             arg.Category,
+					InvoiceNumber:        arg.InvoiceNumber,
+					EndToEndID:           arg.EndToEndID,
+				},
+			)
+			return err
+		},
+	)
+
+	return result, err
+}
+
+type ReviewRiskAssessmentTxParams struct {
+	ID       int64  `json:"id"`
+	Reviewer string `json:"reviewer"`
+	Approve  bool   `json:"approve"`
+	Note     string `json:"note"`
+}
+
+type ReviewRiskAssessmentTxResult struct {
+	RiskAssessment RiskAssessment `json:"risk_assessment"`
+	// Only set when the transfer was approved
+	Transfer *TransferTxResult `json:"transfer"`
+}
+
+// ReviewRiskAssessmentTx decides a transfer held for review, making it if it is approved. Nobody can review their own
+// transfer.
+func (store *SQLStore) ReviewRiskAssessmentTx(ctx context.Context, arg ReviewRiskAssessmentTxParams) (
+	ReviewRiskAssessmentTxResult, error,
+) {
+	var result ReviewRiskAssessmentTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			assessment, err := q.GetRiskAssessmentForUpdate(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+			if assessment.Status != constants.RiskAssessmentStatusPendingReview {
+				return ErrRiskAssessmentNotPending
+			}
+			if assessment.InitiatedBy == arg.Reviewer {
+				return ErrSelfApproval
+			}
+
+			review := ReviewRiskAssessmentParams{
+				ID:         assessment.ID,
+				Status:     constants.RiskAssessmentStatusRejected,
+				ReviewedBy: sql.NullString{String: arg.Reviewer, Valid: true},
+				ReviewNote: sql.NullString{String: arg.Note, Valid: arg.Note != ""},
+			}
+
+			if arg.Approve {
+				transfer, err := transferTx(
+					ctx, q, TransferTxParams{
+						SourceAccountID:      assessment.SourceAccountID,
+						DestinationAccountID: assessment.DestinationAccountID,
+						Amount:               assessment.Amount,
+						Remittance: Remittance{
+							Memo:          assessment.Memo,
+							Reference:     assessment.Reference,
+							Category:      assessment.Category,
+							InvoiceNumber: assessment.InvoiceNumber,
+							EndToEndID:    assessment.EndToEndID,
+						},
+					}, 0, store.fees, store.limits,
+				)
+				if err != nil {
+					return err
+				}
+				result.Transfer = &transfer
+				review.Status = constants.RiskAssessmentStatusExecuted
+				review.TransferID = sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true}
+			}
+
+			result.RiskAssessment, err = q.ReviewRiskAssessment(ctx, review)
+			return err
+		},
+	)
+
+	return result, err
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/risk"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAssessedTransferReview(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+	reviewer, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	result, err := store.AssessedTransferTx(
+		context.Background(), AssessedTransferTxParams{
+			TransferTxParams: TransferTxParams{
+				SourceAccountID:      source.ID,
+				DestinationAccountID: destination.ID,
+				Amount:               100,
+			},
+			InitiatedBy: source.Owner,
+			Assessment: risk.Assessment{
+				Score:    60,
+				Decision: constants.RiskDecisionReview,
+				Reasons:  []string{"new_payee", "new_device"},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Nil(t, result.Transfer)
+	require.Equal(t, constants.RiskAssessmentStatusPendingReview, result.RiskAssessment.Status)
+	require.Equal(t, "new_payee,new_device", result.RiskAssessment.Reasons)
+	require.False(t, result.RiskAssessment.TransferID.Valid)
+
+	// Nobody can clear their own transfer
+	_, err = store.ReviewRiskAssessmentTx(
+		context.Background(),
+		ReviewRiskAssessmentTxParams{ID: result.RiskAssessment.ID, Reviewer: source.Owner, Approve: true},
+	)
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	reviewed, err := store.ReviewRiskAssessmentTx(
+		context.Background(),
+		ReviewRiskAssessmentTxParams{ID: result.RiskAssessment.ID, Reviewer: reviewer.Username, Approve: true},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, reviewed.Transfer)
+	require.Equal(t, constants.RiskAssessmentStatusExecuted, reviewed.RiskAssessment.Status)
+	require.Equal(t, reviewed.Transfer.Transfer.ID, reviewed.RiskAssessment.TransferID.Int64)
+	require.Equal(t, reviewer.Username, reviewed.RiskAssessment.ReviewedBy.String)
+	require.True(t, reviewed.RiskAssessment.ReviewedAt.Valid)
+	require.Equal(t, int64(100), reviewed.Transfer.DestinationAccount.Balance)
+
+	_, err = store.ReviewRiskAssessmentTx(
+		context.Background(),
+		ReviewRiskAssessmentTxParams{ID: result.RiskAssessment.ID, Reviewer: reviewer.Username},
+	)
+	require.ErrorIs(t, err, ErrRiskAssessmentNotPending)
+}
+
+func TestAssessedTransferAllowAndDeny(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	transfer := TransferTxParams{SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 100}
+
+	allowed, err := store.AssessedTransferTx(
+		context.Background(), AssessedTransferTxParams{
+			TransferTxParams: transfer,
+			InitiatedBy:      source.Owner,
+			Assessment:       risk.Assessment{Decision: constants.RiskDecisionAllow},
+		},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, allowed.Transfer)
+	require.Equal(t, constants.RiskAssessmentStatusExecuted, allowed.RiskAssessment.Status)
+	require.Equal(t, allowed.Transfer.Transfer.ID, allowed.RiskAssessment.TransferID.Int64)
+
+	denied, err := store.AssessedTransferTx(
+		context.Background(), AssessedTransferTxParams{
+			TransferTxParams: transfer,
+			InitiatedBy:      source.Owner,
+			Assessment:       risk.Assessment{Score: 100, Decision: constants.RiskDecisionDeny},
+		},
+	)
+	require.NoError(t, err)
+	require.Nil(t, denied.Transfer)
+	require.Equal(t, constants.RiskAssessmentStatusDenied, denied.RiskAssessment.Status)
+
+	updatedDestination, err := testQueries.GetAccount(context.Background(), destination.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), updatedDestination.Balance)
+
+	stats, err := testQueries.GetTransferRiskStats(
+		context.Background(), GetTransferRiskStatsParams{
+			HistoryStart:         allowed.Transfer.Transfer.CreatedAt.AddDate(0, 0, -1),
+			RecentStart:          allowed.Transfer.Transfer.CreatedAt.AddDate(0, 0, -1),
+			DestinationAccountID: destination.ID,
+			SourceAccountID:      source.ID,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t, GetTransferRiskStatsRow{AverageAmount: 100, HistoryCount: 1, RecentCount: 1, DestinationCount: 1}, stats,
+	)
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCreateScheduledTransfer(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, _, _ := createRandomAccount()
+
+	scheduled, arg, err := createRandomScheduledTransfer(account1, account2.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.NotEmpty(t, scheduled)
+
+	require.Equal(t, arg.Owner, scheduled.Owner)
+	require.Equal(t, arg.SourceAccountID, scheduled.SourceAccountID)
+	require.Equal(t, arg.DestinationAccountID, scheduled.DestinationAccountID)
+	require.Equal(t, arg.Amount, scheduled.Amount)
+	require.Equal(t, arg.Currency, scheduled.Currency)
+	require.Equal(t, arg.Frequency, scheduled.Frequency)
+	require.Equal(t, constants.ScheduledTransferStatusActive, scheduled.Status)
+	require.WithinDuration(t, arg.NextOccurrenceAt, scheduled.NextOccurrenceAt, time.Second)
+	require.WithinDuration(t, arg.NextAttemptAt, scheduled.NextAttemptAt, time.Second)
+	require.False(t, scheduled.EndAt.Valid)
+	require.False(t, scheduled.RemainingRuns.Valid)
+	require.False(t, scheduled.LastRunAt.Valid)
+	require.Zero(t, scheduled.Attempts)
+
+	require.NotZero(t, scheduled.ID)
+	require.NotZero(t, scheduled.CreatedAt)
+}
+
+func TestGetScheduledTransfer(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, _, _ := createRandomAccount()
+
+	scheduled1, _, _ := createRandomScheduledTransfer(account1, account2.ID, time.Now().Add(time.Hour))
+	scheduled2, err := testQueries.GetScheduledTransfer(context.Background(), scheduled1.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, scheduled2)
+
+	require.Equal(t, scheduled1.ID, scheduled2.ID)
+	require.Equal(t, scheduled1.Owner, scheduled2.Owner)
+	require.Equal(t, scheduled1.Amount, scheduled2.Amount)
+	require.Equal(t, scheduled1.Status, scheduled2.Status)
+	require.WithinDuration(t, scheduled1.CreatedAt, scheduled2.CreatedAt, time.Second)
+}
+
+func TestGetScheduledTransfers(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, _, _ := createRandomAccount()
+
+	for i := 0; i < 10; i++ {
+		_, _, err := createRandomScheduledTransfer(account1, account2.ID, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+	}
+
+	arg := GetScheduledTransfersParams{
+		Owner:  account1.Owner,
+		Limit:  5,
+		Offset: 5,
+	}
+
+	scheduledTransfers, err := testQueries.GetScheduledTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, scheduledTransfers, 5)
+
+	for _, scheduled := range scheduledTransfers {
+		require.Equal(t, account1.Owner, scheduled.Owner)
+	}
+}
+
+func TestUpdateScheduledTransferStatus(t *testing.T) {
+	account1, _, _ := createRandomAccount()
+	account2, _, _ := createRandomAccount()
+
+	scheduled1, _, _ := createRandomScheduledTransfer(account1, account2.ID, time.Now().Add(time.Hour))
+
+	arg := UpdateScheduledTransferStatusParams{
+		ID:     scheduled1.ID,
+		Status: constants.ScheduledTransferStatusPaused,
+	}
+
+	scheduled2, err := testQueries.UpdateScheduledTransferStatus(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, scheduled1.ID, scheduled2.ID)
+	require.Equal(t, constants.ScheduledTransferStatusPaused, scheduled2.Status)
+}
@@ -0,0 +1,209 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/limit"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"time"
+)
+
+type RunDueScheduledTransferTxParams struct {
+	Now time.Time `json:"now"`
+	// Delay before an occurrence that failed for lack of funds is tried again.
+	RetryInterval time.Duration `json:"retry_interval"`
+	// Attempts made at one occurrence before it is skipped.
+	MaxAttempts int32 `json:"max_attempts"`
+}
+
+type RunDueScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer `json:"scheduled_transfer"`
+	// Empty when the attempt failed.
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// RunDueScheduledTransferTx locks one due scheduled transfer, skipping rows locked by other replicas, and makes the
+// transfer in the same transaction. An attempt that fails for lack of funds, because either account's status doesn't
+// allow it, because it would go over the owner's transfer limits, or because either account's owner is on a screening
+// hold, is retried later and the owner is notified; once the attempts run out that occurrence is skipped. Any other
+// failure is recorded and retried the same way, but once its attempts run out the whole schedule fails, so that a
+// scheduled transfer that can never succeed doesn't hold up the ones due after it. A schedule whose owner may no longer
+// spend from the source account fails straight away. sql.ErrNoRows is returned when nothing is due.
+func (store *SQLStore) RunDueScheduledTransferTx(
+	ctx context.Context, arg RunDueScheduledTransferTxParams,
+) (RunDueScheduledTransferTxResult, error) {
+	var result RunDueScheduledTransferTxResult
+
+	var scheduled ScheduledTransfer
+	var failure error
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			var err error
+			scheduled, err = q.GetDueScheduledTransferForUpdate(ctx, arg.Now)
+			if err != nil {
+				return err
+			}
+
+			run := newScheduledTransferRun(scheduled, arg.Now)
+
+			// The owner's access may have changed since they set the transfer up
+			source, err := q.GetAccount(ctx, scheduled.SourceAccountID)
+			if err == nil {
+				err = checkAccountSpender(ctx, q, source, scheduled.Owner, scheduled.Amount)
+			}
+			if err == nil {
+				result.Transfer, err = transferTx(
+					ctx, q, TransferTxParams{
+						SourceAccountID:      scheduled.SourceAccountID,
+						DestinationAccountID: scheduled.DestinationAccountID,
+						Amount:               scheduled.Amount,
+					}, 0, store.fees, store.limits,
+				)
+			}
+			switch {
+			case errors.Is(err, ErrNotAccountSpender):
+				if err := stopScheduledTransfer(ctx, q, &run, scheduled, err); err != nil {
+					return err
+				}
+			case errors.Is(err, ErrInsufficientFunds), errors.Is(err, ErrAccountFrozen),
+				errors.Is(err, ErrAccountDormant), errors.Is(err, ErrAccountClosed),
+				errors.Is(err, limit.ErrExceeded), errors.Is(err, ErrScreeningHold),
+				errors.Is(err, ErrDestinationScreeningHold):
+				if err := retryScheduledTransfer(ctx, q, &run, scheduled, arg, err, false); err != nil {
+					return err
+				}
+			case err != nil:
+				failure = err
+				return err
+			default:
+				advanceScheduledTransfer(&run, scheduled)
+			}
+
+			result.ScheduledTransfer, err = q.UpdateScheduledTransferRun(ctx, run)
+			return err
+		},
+	)
+	if failure == nil {
+		return result, err
+	}
+
+	// The failure may have aborted the transaction, so it is recorded in a transaction of its own
+	result.Transfer = TransferTxResult{}
+	err = store.execTx(
+		ctx, func(q *Queries) error {
+			locked, err := q.GetScheduledTransferForUpdate(ctx, scheduled.ID)
+			if err != nil {
+				return err
+			}
+
+			result.ScheduledTransfer = locked
+			if locked.Status != constants.ScheduledTransferStatusActive || locked.Attempts != scheduled.Attempts ||
+				!locked.NextAttemptAt.Equal(scheduled.NextAttemptAt) {
+				// Another replica ran it in the meantime
+				return nil
+			}
+
+			run := newScheduledTransferRun(locked, arg.Now)
+			if err := retryScheduledTransfer(ctx, q, &run, locked, arg, failure, true); err != nil {
+				return err
+			}
+
+			result.ScheduledTransfer, err = q.UpdateScheduledTransferRun(ctx, run)
+			return err
+		},
+	)
+
+	return result, err
+}
+
+// newScheduledTransferRun starts a run of scheduled at now, counting it as another attempt at the current occurrence.
+func newScheduledTransferRun(scheduled ScheduledTransfer, now time.Time) UpdateScheduledTransferRunParams {
+	return UpdateScheduledTransferRunParams{
+		ID:               scheduled.ID,
+		NextOccurrenceAt: scheduled.NextOccurrenceAt,
+		NextAttemptAt:    scheduled.NextAttemptAt,
+		RemainingRuns:    scheduled.RemainingRuns,
+		Status:           scheduled.Status,
+		Attempts:         scheduled.Attempts + 1,
+		LastRunAt:        sql.NullTime{Time: now, Valid: true},
+	}
+}
+
+// retryScheduledTransfer records a failed attempt on run and notifies the owner. The occurrence is tried again after
+// the retry interval until the attempts run out; then it is skipped, or, when abandon is set, the schedule fails.
+func retryScheduledTransfer(
+	ctx context.Context, q *Queries, run *UpdateScheduledTransferRunParams, scheduled ScheduledTransfer,
+	arg RunDueScheduledTransferTxParams, failure error, abandon bool,
+) error {
+	run.LastError = failure.Error()
+	notification := CreateNotificationParams{Username: scheduled.Owner}
+
+	switch {
+	case run.Attempts < arg.MaxAttempts:
+		run.NextAttemptAt = arg.Now.Add(arg.RetryInterval)
+		notification.Kind = constants.NotificationScheduledTransferFailed
+		notification.Message = fmt.Sprintf(
+			"scheduled transfer %d failed: %v, retrying at %s", scheduled.ID, failure,
+			run.NextAttemptAt.Format(time.RFC3339),
+		)
+	case abandon:
+		return stopScheduledTransfer(ctx, q, run, scheduled, failure)
+	default:
+		advanceScheduledTransfer(run, scheduled)
+		if run.Status == constants.ScheduledTransferStatusCompleted &&
+			scheduled.Frequency == constants.FrequencyOnce {
+			run.Status = constants.ScheduledTransferStatusFailed
+		}
+		notification.Kind = constants.NotificationScheduledTransferSkipped
+		notification.Message = fmt.Sprintf(
+			"scheduled transfer %d skipped the occurrence on %s after %d failed attempts", scheduled.ID,
+			scheduled.NextOccurrenceAt.Format(time.RFC3339), arg.MaxAttempts,
+		)
+	}
+
+	_, err := q.CreateNotification(ctx, notification)
+	return err
+}
+
+// stopScheduledTransfer fails the whole schedule on run after a failure that retrying won't fix, and notifies the
+// owner.
+func stopScheduledTransfer(
+	ctx context.Context, q *Queries, run *UpdateScheduledTransferRunParams, scheduled ScheduledTransfer,
+	failure error,
+) error {
+	run.LastError = failure.Error()
+	run.Status = constants.ScheduledTransferStatusFailed
+
+	_, err := q.CreateNotification(
+		ctx, CreateNotificationParams{
+			Username: scheduled.Owner,
+			Kind:     constants.NotificationScheduledTransferFailed,
+			Message:  fmt.Sprintf("scheduled transfer %d was stopped: %v", scheduled.ID, failure),
+		},
+	)
+	return err
+}
+
+// advanceScheduledTransfer moves run on to the occurrence after the current one, completing the schedule when there
+// is none left.
+func advanceScheduledTransfer(run *UpdateScheduledTransferRunParams, scheduled ScheduledTransfer) {
+	run.Attempts = 0
+
+	if run.RemainingRuns.Valid {
+		run.RemainingRuns.Int32--
+	}
+
+	next, ok := util.NextOccurrence(scheduled.Frequency, int(scheduled.DayOfMonth), scheduled.NextOccurrenceAt)
+	if !ok ||
+		(run.RemainingRuns.Valid && run.RemainingRuns.Int32 <= 0) ||
+		(scheduled.EndAt.Valid && next.After(scheduled.EndAt.Time)) {
+		run.Status = constants.ScheduledTransferStatusCompleted
+		return
+	}
+
+	run.NextOccurrenceAt = next
+	run.NextAttemptAt = next
+}
@@ -0,0 +1,503 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/limit"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// dueAt returns a time far enough in the past that no other test's scheduled transfer falls due before it.
+func dueAt() time.Time {
+	return time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(util.RandomInt(0, 1000000)) * time.Second)
+}
+
+func TestRunDueScheduledTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	now := dueAt()
+	scheduled, err := testQueries.CreateScheduledTransfer(
+		context.Background(), CreateScheduledTransferParams{
+			Owner:                account1.Owner,
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               40,
+			Currency:             account1.Currency,
+			Frequency:            constants.FrequencyWeekly,
+			NextOccurrenceAt:     now,
+			NextAttemptAt:        now,
+			RemainingRuns:        sql.NullInt32{Int32: 2, Valid: true},
+		},
+	)
+	require.NoError(t, err)
+
+	arg := RunDueScheduledTransferTxParams{
+		Now:           now,
+		RetryInterval: time.Hour,
+		MaxAttempts:   3,
+	}
+
+	result, err := store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, int64(40), result.Transfer.Transfer.Amount)
+	require.Equal(t, int64(60), result.Transfer.SourceAccount.Balance)
+	require.Equal(t, constants.ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.Equal(t, int32(1), result.ScheduledTransfer.RemainingRuns.Int32)
+	require.WithinDuration(t, now.AddDate(0, 0, 7), result.ScheduledTransfer.NextOccurrenceAt, time.Second)
+	require.WithinDuration(t, now.AddDate(0, 0, 7), result.ScheduledTransfer.NextAttemptAt, time.Second)
+
+	_, err = store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.Now = now.AddDate(0, 0, 7)
+	result, err = store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, int64(20), result.Transfer.SourceAccount.Balance)
+	require.Equal(t, constants.ScheduledTransferStatusCompleted, result.ScheduledTransfer.Status)
+	require.Zero(t, result.ScheduledTransfer.RemainingRuns.Int32)
+}
+
+func TestRunDueScheduledTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(10)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	now := dueAt()
+	scheduled, err := testQueries.CreateScheduledTransfer(
+		context.Background(), CreateScheduledTransferParams{
+			Owner:                account1.Owner,
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               40,
+			Currency:             account1.Currency,
+			Frequency:            constants.FrequencyOnce,
+			NextOccurrenceAt:     now,
+			NextAttemptAt:        now,
+		},
+	)
+	require.NoError(t, err)
+
+	arg := RunDueScheduledTransferTxParams{
+		Now:           now,
+		RetryInterval: time.Hour,
+		MaxAttempts:   2,
+	}
+
+	result, err := store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+	require.Zero(t, result.Transfer.Transfer.ID)
+	require.Equal(t, constants.ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.Equal(t, int32(1), result.ScheduledTransfer.Attempts)
+	require.Equal(t, ErrInsufficientFunds.Error(), result.ScheduledTransfer.LastError)
+	require.WithinDuration(t, now.Add(time.Hour), result.ScheduledTransfer.NextAttemptAt, time.Second)
+
+	arg.Now = now.Add(time.Hour)
+	result, err = store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, constants.ScheduledTransferStatusFailed, result.ScheduledTransfer.Status)
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), account1.Balance)
+
+	notifications, err := testQueries.GetNotifications(
+		context.Background(), GetNotificationsParams{
+			Username: account1.Owner,
+			Limit:    10,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	require.Equal(t, constants.NotificationScheduledTransferSkipped, notifications[0].Kind)
+	require.Equal(t, constants.NotificationScheduledTransferFailed, notifications[1].Kind)
+}
+
+// TestRunDueScheduledTransferTxLimitExceeded checks that a scheduled transfer over its owner's limits is retried
+// later, rather than failing the run and holding up the scheduled transfers due after it.
+func TestRunDueScheduledTransferTxLimitExceeded(t *testing.T) {
+	store := NewStore(
+		testDB, WithLimitSchedule(
+			limit.Schedule{
+				Tiers: []limit.TierLimits{
+					{Tier: constants.UserTierStandard, Currency: constants.USD, Limits: limit.Limits{PerTransfer: 30}},
+				},
+			},
+		),
+	)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	now := dueAt()
+	overLimit, err := testQueries.CreateScheduledTransfer(
+		context.Background(), CreateScheduledTransferParams{
+			Owner:                account1.Owner,
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               40,
+			Currency:             account1.Currency,
+			Frequency:            constants.FrequencyOnce,
+			NextOccurrenceAt:     now.Add(-time.Minute),
+			NextAttemptAt:        now.Add(-time.Minute),
+		},
+	)
+	require.NoError(t, err)
+	withinLimit, err := testQueries.CreateScheduledTransfer(
+		context.Background(), CreateScheduledTransferParams{
+			Owner:                account1.Owner,
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               20,
+			Currency:             account1.Currency,
+			Frequency:            constants.FrequencyOnce,
+			NextOccurrenceAt:     now,
+			NextAttemptAt:        now,
+		},
+	)
+	require.NoError(t, err)
+
+	arg := RunDueScheduledTransferTxParams{
+		Now:           now,
+		RetryInterval: time.Hour,
+		MaxAttempts:   2,
+	}
+
+	result, err := store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, overLimit.ID, result.ScheduledTransfer.ID)
+	require.Zero(t, result.Transfer.Transfer.ID)
+	require.Equal(t, constants.ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.Equal(t, int32(1), result.ScheduledTransfer.Attempts)
+	require.Contains(t, result.ScheduledTransfer.LastError, limit.ErrExceeded.Error())
+	require.WithinDuration(t, now.Add(time.Hour), result.ScheduledTransfer.NextAttemptAt, time.Second)
+
+	result, err = store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, withinLimit.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, int64(80), result.Transfer.SourceAccount.Balance)
+	require.Equal(t, constants.ScheduledTransferStatusCompleted, result.ScheduledTransfer.Status)
+
+	notifications, err := testQueries.GetNotifications(
+		context.Background(), GetNotificationsParams{
+			Username: account1.Owner,
+			Limit:    10,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, constants.NotificationScheduledTransferFailed, notifications[0].Kind)
+}
+
+// TestRunDueScheduledTransferTxScreeningHold checks that a scheduled transfer whose owner is on a screening hold is
+// retried later, and skipped once its attempts run out, rather than failing the run.
+func TestRunDueScheduledTransferTxScreeningHold(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	err = testQueries.UpdateUserScreeningStatus(
+		context.Background(), UpdateUserScreeningStatusParams{
+			Username:        account1.Owner,
+			ScreeningStatus: constants.ScreeningStatusPendingReview,
+		},
+	)
+	require.NoError(t, err)
+
+	now := dueAt()
+	scheduled, err := testQueries.CreateScheduledTransfer(
+		context.Background(), CreateScheduledTransferParams{
+			Owner:                account1.Owner,
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               10,
+			Currency:             account1.Currency,
+			Frequency:            constants.FrequencyOnce,
+			NextOccurrenceAt:     now,
+			NextAttemptAt:        now,
+		},
+	)
+	require.NoError(t, err)
+
+	arg := RunDueScheduledTransferTxParams{
+		Now:           now,
+		RetryInterval: time.Hour,
+		MaxAttempts:   2,
+	}
+
+	result, err := store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+	require.Zero(t, result.Transfer.Transfer.ID)
+	require.Equal(t, constants.ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.Equal(t, int32(1), result.ScheduledTransfer.Attempts)
+	require.Equal(t, ErrScreeningHold.Error(), result.ScheduledTransfer.LastError)
+
+	arg.Now = now.Add(time.Hour)
+	result, err = store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, constants.ScheduledTransferStatusFailed, result.ScheduledTransfer.Status)
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), account1.Balance)
+
+	notifications, err := testQueries.GetNotifications(
+		context.Background(), GetNotificationsParams{
+			Username: account1.Owner,
+			Limit:    10,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	require.Equal(t, constants.NotificationScheduledTransferSkipped, notifications[0].Kind)
+	require.Equal(t, constants.NotificationScheduledTransferFailed, notifications[1].Kind)
+}
+
+// TestRunDueScheduledTransferTxDestinationScreeningHold checks that a scheduled transfer isn't paid to an account whose
+// owner was put on a screening hold after the transfer was set up.
+func TestRunDueScheduledTransferTxDestinationScreeningHold(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	now := dueAt()
+	scheduled, err := testQueries.CreateScheduledTransfer(
+		context.Background(), CreateScheduledTransferParams{
+			Owner:                account1.Owner,
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               10,
+			Currency:             account1.Currency,
+			Frequency:            constants.FrequencyWeekly,
+			NextOccurrenceAt:     now,
+			NextAttemptAt:        now,
+		},
+	)
+	require.NoError(t, err)
+
+	err = testQueries.UpdateUserScreeningStatus(
+		context.Background(), UpdateUserScreeningStatusParams{
+			Username:        account2.Owner,
+			ScreeningStatus: constants.ScreeningStatusPendingReview,
+		},
+	)
+	require.NoError(t, err)
+
+	arg := RunDueScheduledTransferTxParams{
+		Now:           now,
+		RetryInterval: time.Hour,
+		MaxAttempts:   2,
+	}
+
+	result, err := store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+	require.Zero(t, result.Transfer.Transfer.ID)
+	require.Equal(t, constants.ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.Equal(t, int32(1), result.ScheduledTransfer.Attempts)
+	require.Equal(t, ErrDestinationScreeningHold.Error(), result.ScheduledTransfer.LastError)
+	require.WithinDuration(t, now.Add(time.Hour), result.ScheduledTransfer.NextAttemptAt, time.Second)
+
+	err = testQueries.UpdateUserScreeningStatus(
+		context.Background(), UpdateUserScreeningStatusParams{
+			Username:        account2.Owner,
+			ScreeningStatus: constants.ScreeningStatusClear,
+		},
+	)
+	require.NoError(t, err)
+
+	arg.Now = now.Add(time.Hour)
+	result, err = store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, int64(90), result.Transfer.SourceAccount.Balance)
+	require.Equal(t, account2.Balance+10, result.Transfer.DestinationAccount.Balance)
+}
+
+// TestRunDueScheduledTransferTxUnexpectedError checks that a scheduled transfer that fails for a reason retrying
+// won't fix is recorded and eventually stopped, rather than failing every run and holding up the ones due after it.
+func TestRunDueScheduledTransferTxUnexpectedError(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+
+	accountNumber, err := util.NewAccountNumber()
+	require.NoError(t, err)
+	euroAccount, err := testQueries.CreateAccount(
+		context.Background(), CreateAccountParams{
+			Owner:         account2.Owner,
+			Currency:      constants.EUR,
+			Product:       constants.ProductChecking,
+			AccountNumber: accountNumber,
+		},
+	)
+	require.NoError(t, err)
+
+	now := dueAt()
+	mismatched, err := testQueries.CreateScheduledTransfer(
+		context.Background(), CreateScheduledTransferParams{
+			Owner:                account1.Owner,
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: euroAccount.ID,
+			Amount:               40,
+			Currency:             account1.Currency,
+			Frequency:            constants.FrequencyWeekly,
+			NextOccurrenceAt:     now.Add(-time.Minute),
+			NextAttemptAt:        now.Add(-time.Minute),
+		},
+	)
+	require.NoError(t, err)
+	valid, err := testQueries.CreateScheduledTransfer(
+		context.Background(), CreateScheduledTransferParams{
+			Owner:                account1.Owner,
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               20,
+			Currency:             account1.Currency,
+			Frequency:            constants.FrequencyOnce,
+			NextOccurrenceAt:     now,
+			NextAttemptAt:        now,
+		},
+	)
+	require.NoError(t, err)
+
+	arg := RunDueScheduledTransferTxParams{
+		Now:           now,
+		RetryInterval: time.Hour,
+		MaxAttempts:   2,
+	}
+
+	result, err := store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, mismatched.ID, result.ScheduledTransfer.ID)
+	require.Zero(t, result.Transfer.Transfer.ID)
+	require.Equal(t, constants.ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.Equal(t, int32(1), result.ScheduledTransfer.Attempts)
+	require.Contains(t, result.ScheduledTransfer.LastError, ErrUnbalancedJournal.Error())
+	require.WithinDuration(t, now.Add(time.Hour), result.ScheduledTransfer.NextAttemptAt, time.Second)
+
+	result, err = store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, valid.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, int64(80), result.Transfer.SourceAccount.Balance)
+
+	arg.Now = now.Add(time.Hour)
+	result, err = store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, mismatched.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, constants.ScheduledTransferStatusFailed, result.ScheduledTransfer.Status)
+	require.Equal(t, int32(2), result.ScheduledTransfer.Attempts)
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(80), account1.Balance)
+
+	notifications, err := testQueries.GetNotifications(
+		context.Background(), GetNotificationsParams{
+			Username: account1.Owner,
+			Limit:    10,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	require.Equal(t, constants.NotificationScheduledTransferFailed, notifications[0].Kind)
+	require.Equal(t, constants.NotificationScheduledTransferFailed, notifications[1].Kind)
+}
+
+// TestRunDueScheduledTransferTxNotSpender checks that a scheduled transfer stops once its owner may no longer spend
+// from the source account, instead of carrying on with the access they had when they set it up.
+func TestRunDueScheduledTransferTxNotSpender(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, _, _ := createRandomAccount()
+	spender, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateAccountMember(
+		context.Background(), CreateAccountMemberParams{
+			AccountID:     account1.ID,
+			Username:      spender.Username,
+			Role:          constants.AccountRoleSpender,
+			TransferLimit: sql.NullInt64{Int64: 50, Valid: true},
+		},
+	)
+	require.NoError(t, err)
+
+	now := dueAt()
+	scheduled, err := testQueries.CreateScheduledTransfer(
+		context.Background(), CreateScheduledTransferParams{
+			Owner:                spender.Username,
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               40,
+			Currency:             account1.Currency,
+			Frequency:            constants.FrequencyWeekly,
+			NextOccurrenceAt:     now,
+			NextAttemptAt:        now,
+		},
+	)
+	require.NoError(t, err)
+
+	arg := RunDueScheduledTransferTxParams{
+		Now:           now,
+		RetryInterval: time.Hour,
+		MaxAttempts:   3,
+	}
+
+	result, err := store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, int64(60), result.Transfer.SourceAccount.Balance)
+
+	// Removed without going through RemoveAccountMemberTx, so the schedule is still active
+	_, err = testQueries.DeleteAccountMember(
+		context.Background(), DeleteAccountMemberParams{AccountID: account1.ID, Username: spender.Username},
+	)
+	require.NoError(t, err)
+
+	arg.Now = result.ScheduledTransfer.NextAttemptAt
+	result, err = store.RunDueScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+	require.Zero(t, result.Transfer.Transfer.ID)
+	require.Equal(t, constants.ScheduledTransferStatusFailed, result.ScheduledTransfer.Status)
+	require.Equal(t, ErrNotAccountSpender.Error(), result.ScheduledTransfer.LastError)
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(60), account1.Balance)
+
+	notifications, err := testQueries.GetNotifications(
+		context.Background(), GetNotificationsParams{
+			Username: spender.Username,
+			Limit:    10,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, constants.NotificationScheduledTransferFailed, notifications[0].Kind)
+}
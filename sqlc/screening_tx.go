@@ -0,0 +1,236 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/screening"
+	"strings"
+)
+
+var (
+	ErrScreeningHold            = errors.New("user is on hold pending sanctions screening review")
+	ErrDestinationScreeningHold = errors.New("destination account is on hold pending sanctions screening review")
+	ErrScreeningHitNotPending   = errors.New("screening hit has already been reviewed")
+)
+
+type RecordScreeningHitsTxParams struct {
+	Username string `json:"username"`
+	// Set when the name screened is the holder of one of the user's payees rather than the user
+	PayeeID sql.NullInt64 `json:"payee_id"`
+	// Set when the name screened is an organization's, in which case Username is the user who created it
+	OrganizationID sql.NullInt64     `json:"organization_id"`
+	ScreenedName   string            `json:"screened_name"`
+	Matches        []screening.Match `json:"matches"`
+}
+
+// RecordScreeningHitsTx records watchlist matches for a user, organization or payee and puts it on hold until they are
+// reviewed. A user on hold has their sessions blocked, so they are signed out. Entries that have already been recorded
+// against the user, organization or payee, whatever the outcome of their review, are skipped; the new hits are
+// returned.
+func (store *SQLStore) RecordScreeningHitsTx(ctx context.Context, arg RecordScreeningHitsTxParams) (
+	[]ScreeningHit, error,
+) {
+	var result []ScreeningHit
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			if err := lockScreeningHolder(ctx, q, arg.Username, arg.OrganizationID); err != nil {
+				return err
+			}
+
+			for _, match := range arg.Matches {
+				hit, err := q.CreateScreeningHit(
+					ctx, CreateScreeningHitParams{
+						Username:       arg.Username,
+						PayeeID:        arg.PayeeID,
+						OrganizationID: arg.OrganizationID,
+						ScreenedName:   arg.ScreenedName,
+						EntryID:        match.Entry.ID,
+						EntryName:      match.Entry.Names[0],
+						MatchedName:    match.MatchedName,
+						Programs:       strings.Join(match.Entry.Programs, ", "),
+						Score:          int32(match.Score),
+					},
+				)
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				result = append(result, hit)
+			}
+
+			if len(result) == 0 {
+				return nil
+			}
+			return updateScreeningStatus(ctx, q, arg.Username, arg.PayeeID, arg.OrganizationID)
+		},
+	)
+
+	return result, err
+}
+
+type ReviewScreeningHitTxParams struct {
+	ID         int64  `json:"id"`
+	ReviewedBy string `json:"reviewed_by"`
+	// Whether the hit is a true match, which blocks the user, organization or payee for good
+	Confirm bool   `json:"confirm"`
+	Note    string `json:"note"`
+}
+
+// ReviewScreeningHitTx confirms or clears a hit, and releases the user, organization or payee once none of their hits
+// are pending.
+func (store *SQLStore) ReviewScreeningHitTx(ctx context.Context, arg ReviewScreeningHitTxParams) (ScreeningHit, error) {
+	var result ScreeningHit
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			hit, err := q.GetScreeningHitForUpdate(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+			if hit.Status != constants.ScreeningHitStatusPendingReview {
+				return ErrScreeningHitNotPending
+			}
+			if hit.Username == arg.ReviewedBy {
+				return ErrSelfApproval
+			}
+
+			status := constants.ScreeningHitStatusCleared
+			if arg.Confirm {
+				status = constants.ScreeningHitStatusConfirmed
+			}
+			result, err = q.ReviewScreeningHit(
+				ctx, ReviewScreeningHitParams{
+					ID:         hit.ID,
+					Status:     status,
+					ReviewedBy: sql.NullString{String: arg.ReviewedBy, Valid: true},
+					ReviewNote: sql.NullString{String: arg.Note, Valid: arg.Note != ""},
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			// Hits are recorded with the user or organization locked, so lock it too before counting them
+			if err := lockScreeningHolder(ctx, q, hit.Username, hit.OrganizationID); err != nil {
+				return err
+			}
+			return updateScreeningStatus(ctx, q, hit.Username, hit.PayeeID, hit.OrganizationID)
+		},
+	)
+
+	return result, err
+}
+
+// lockScreeningHolder locks the organization whose name was screened, or otherwise the user.
+func lockScreeningHolder(ctx context.Context, q *Queries, username string, organizationID sql.NullInt64) error {
+	if organizationID.Valid {
+		_, err := q.GetOrganizationForUpdate(ctx, organizationID.Int64)
+		return err
+	}
+
+	_, err := q.GetUserForUpdate(ctx, username)
+	return err
+}
+
+// updateScreeningStatus sets a user's, organization's or payee's screening status from its hits: blocked if any were
+// confirmed, pending review if any are still to be reviewed, and otherwise clear.
+func updateScreeningStatus(
+	ctx context.Context, q *Queries, username string, payeeID sql.NullInt64, organizationID sql.NullInt64,
+) error {
+	counts, err := q.GetScreeningHitCounts(
+		ctx, GetScreeningHitCountsParams{Username: username, PayeeID: payeeID, OrganizationID: organizationID},
+	)
+	if err != nil {
+		return err
+	}
+
+	status := constants.ScreeningStatusClear
+	switch {
+	case counts.ConfirmedCount > 0:
+		status = constants.ScreeningStatusBlocked
+	case counts.PendingCount > 0:
+		status = constants.ScreeningStatusPendingReview
+	}
+
+	if payeeID.Valid {
+		return q.UpdatePayeeScreeningStatus(
+			ctx, UpdatePayeeScreeningStatusParams{ID: payeeID.Int64, ScreeningStatus: status},
+		)
+	}
+	if organizationID.Valid {
+		return q.UpdateOrganizationScreeningStatus(
+			ctx, UpdateOrganizationScreeningStatusParams{ID: organizationID.Int64, ScreeningStatus: status},
+		)
+	}
+
+	err = q.UpdateUserScreeningStatus(ctx, UpdateUserScreeningStatusParams{Username: username, ScreeningStatus: status})
+	if err != nil || status == constants.ScreeningStatusClear {
+		return err
+	}
+	return q.BlockUserSessions(ctx, username)
+}
+
+// checkScreeningHold returns ErrScreeningHold if the source account's holder, its organization or otherwise its owner,
+// is on hold, or blocked, after sanctions screening. It locks the holder's row, as hits are recorded with it locked, so
+// callers must check before locking any accounts.
+func checkScreeningHold(ctx context.Context, q *Queries, source Account) error {
+	if source.Product == constants.ProductInternal {
+		return nil
+	}
+
+	var status string
+	if source.OrganizationID.Valid {
+		organization, err := q.GetOrganizationForUpdate(ctx, source.OrganizationID.Int64)
+		if err != nil {
+			return err
+		}
+		status = organization.ScreeningStatus
+	} else {
+		user, err := q.GetUserForUpdate(ctx, source.Owner)
+		if err != nil {
+			return err
+		}
+		status = user.ScreeningStatus
+	}
+	if status != constants.ScreeningStatusClear {
+		return ErrScreeningHold
+	}
+
+	return nil
+}
+
+// checkDestinationScreening returns ErrDestinationScreeningHold if the destination account's holder, its organization
+// or otherwise its owner, is on hold, or blocked, after sanctions screening. It doesn't lock the holder, who isn't
+// spending anything.
+func checkDestinationScreening(ctx context.Context, q *Queries, destination Account) error {
+	if destination.Product == constants.ProductInternal {
+		return nil
+	}
+
+	status, err := holderScreeningStatus(ctx, q, destination)
+	if err != nil {
+		return err
+	}
+	if status != constants.ScreeningStatusClear {
+		return ErrDestinationScreeningHold
+	}
+
+	return nil
+}
+
+// holderScreeningStatus returns the screening status of whoever holds account: its organization if it has one, and
+// otherwise its owner.
+func holderScreeningStatus(ctx context.Context, q *Queries, account Account) (string, error) {
+	if account.OrganizationID.Valid {
+		organization, err := q.GetOrganization(ctx, account.OrganizationID.Int64)
+		return organization.ScreeningStatus, err
+	}
+
+	user, err := q.GetUser(ctx, account.Owner)
+	return user.ScreeningStatus, err
+}
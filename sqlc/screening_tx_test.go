@@ -0,0 +1,249 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/screening"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func testScreeningMatch(entryID string) screening.Match {
+	return screening.Match{
+		Entry: screening.Entry{
+			ID:       entryID,
+			Type:     screening.EntryTypeIndividual,
+			Names:    []string{"HAMADA, Hoda Salih"},
+			Programs: []string{"IRAQ2", "SDGT"},
+		},
+		MatchedName: "HAMADA, Hoda Salih",
+		Score:       94,
+	}
+}
+
+func TestScreenUser(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+	analyst, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	session, err := testQueries.CreateSession(
+		context.Background(), CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     source.Owner,
+			RefreshToken: "refresh",
+			ExpiresAt:    time.Now().Add(time.Hour),
+		},
+	)
+	require.NoError(t, err)
+
+	arg := RecordScreeningHitsTxParams{
+		Username:     source.Owner,
+		ScreenedName: "Hoda Salih Hamade",
+		Matches:      []screening.Match{testScreeningMatch("2674"), testScreeningMatch("2675")},
+	}
+	hits, err := store.RecordScreeningHitsTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	require.Equal(t, constants.ScreeningHitStatusPendingReview, hits[0].Status)
+	require.Equal(t, "IRAQ2, SDGT", hits[0].Programs)
+	require.Equal(t, int32(94), hits[0].Score)
+
+	user, err := testQueries.GetUser(context.Background(), source.Owner)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScreeningStatusPendingReview, user.ScreeningStatus)
+
+	session, err = testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               100,
+		},
+	)
+	require.ErrorIs(t, err, ErrScreeningHold)
+
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      destination.ID,
+			DestinationAccountID: source.ID,
+			Amount:               100,
+		},
+	)
+	require.ErrorIs(t, err, ErrDestinationScreeningHold)
+
+	// Screening again against the same entries records nothing new
+	again, err := store.RecordScreeningHitsTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, again)
+
+	_, err = store.ReviewScreeningHitTx(
+		context.Background(), ReviewScreeningHitTxParams{ID: hits[0].ID, ReviewedBy: source.Owner},
+	)
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	for _, hit := range hits {
+		reviewed, err := store.ReviewScreeningHitTx(
+			context.Background(), ReviewScreeningHitTxParams{
+				ID:         hit.ID,
+				ReviewedBy: analyst.Username,
+				Note:       "Different date of birth",
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, constants.ScreeningHitStatusCleared, reviewed.Status)
+		require.Equal(t, analyst.Username, reviewed.ReviewedBy.String)
+		require.True(t, reviewed.ReviewedAt.Valid)
+	}
+
+	_, err = store.ReviewScreeningHitTx(
+		context.Background(), ReviewScreeningHitTxParams{ID: hits[0].ID, ReviewedBy: analyst.Username, Confirm: true},
+	)
+	require.ErrorIs(t, err, ErrScreeningHitNotPending)
+
+	user, err = testQueries.GetUser(context.Background(), source.Owner)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScreeningStatusClear, user.ScreeningStatus)
+
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               100,
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestScreenOrganization(t *testing.T) {
+	store := NewStore(testDB)
+
+	organization, admin := createTestOrganization(t, store, 0)
+	source := createOrganizationAccount(t, store, organization, 1000)
+	destination, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	analyst, _, err := createRandomUser()
+	require.NoError(t, err)
+
+	hits, err := store.RecordScreeningHitsTx(
+		context.Background(), RecordScreeningHitsTxParams{
+			Username:       admin.Username,
+			OrganizationID: sql.NullInt64{Int64: organization.ID, Valid: true},
+			ScreenedName:   organization.Name,
+			Matches:        []screening.Match{testScreeningMatch("2674")},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	require.Equal(t, organization.ID, hits[0].OrganizationID.Int64)
+
+	organization, err = testQueries.GetOrganization(context.Background(), organization.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScreeningStatusPendingReview, organization.ScreeningStatus)
+
+	// The hold is on the organization, not the admin who created it
+	user, err := testQueries.GetUser(context.Background(), admin.Username)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScreeningStatusClear, user.ScreeningStatus)
+
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               100,
+		},
+	)
+	require.ErrorIs(t, err, ErrScreeningHold)
+
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      destination.ID,
+			DestinationAccountID: source.ID,
+			Amount:               100,
+		},
+	)
+	require.ErrorIs(t, err, ErrDestinationScreeningHold)
+
+	reviewed, err := store.ReviewScreeningHitTx(
+		context.Background(), ReviewScreeningHitTxParams{ID: hits[0].ID, ReviewedBy: analyst.Username},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScreeningHitStatusCleared, reviewed.Status)
+
+	organization, err = testQueries.GetOrganization(context.Background(), organization.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScreeningStatusClear, organization.ScreeningStatus)
+
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               100,
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestScreenPayee(t *testing.T) {
+	store := NewStore(testDB)
+
+	owner, _, err := createRandomUser()
+	require.NoError(t, err)
+	analyst, _, err := createRandomUser()
+	require.NoError(t, err)
+	payee := createRandomPayee(t, owner)
+
+	hits, err := store.RecordScreeningHitsTx(
+		context.Background(), RecordScreeningHitsTxParams{
+			Username:     owner.Username,
+			PayeeID:      sql.NullInt64{Int64: payee.ID, Valid: true},
+			ScreenedName: "Hoda Salih Hamada",
+			Matches:      []screening.Match{testScreeningMatch("2674")},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+
+	payee, err = testQueries.GetPayee(context.Background(), payee.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScreeningStatusPendingReview, payee.ScreeningStatus)
+
+	// Only the payee is held, not its owner
+	user, err := testQueries.GetUser(context.Background(), owner.Username)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScreeningStatusClear, user.ScreeningStatus)
+
+	_, err = store.ReviewScreeningHitTx(
+		context.Background(), ReviewScreeningHitTxParams{
+			ID:         hits[0].ID,
+			ReviewedBy: analyst.Username,
+			Confirm:    true,
+			Note:       "Account is held by the listed person",
+		},
+	)
+	require.NoError(t, err)
+
+	payee, err = testQueries.GetPayee(context.Background(), payee.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.ScreeningStatusBlocked, payee.ScreeningStatus)
+
+	payees, err := testQueries.GetPayeesForScreening(
+		context.Background(), GetPayeesForScreeningParams{ID: payee.ID - 1, Limit: 1},
+	)
+	require.NoError(t, err)
+	require.Len(t, payees, 1)
+	require.Equal(t, payee.ID, payees[0].ID)
+	require.Equal(t, owner.Username, payees[0].Owner)
+	require.Equal(t, payee.ExpectedName, payees[0].ExpectedName)
+	require.NotEmpty(t, payees[0].HolderName)
+}
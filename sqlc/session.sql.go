@@ -12,6 +12,17 @@ import (
 	"github.com/google/uuid"
 )
 
+const blockUserSessions = `-- name: BlockUserSessions :exec
+UPDATE "session"
+SET is_blocked = true
+WHERE username = $1
+`
+
+func (q *Queries) BlockUserSessions(ctx context.Context, username string) error {
+	_, err := q.db.ExecContext(ctx, blockUserSessions, username)
+	return err
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO "session" (id,
                        username,
@@ -58,6 +69,38 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 	return i, err
 }
 
+const getFirstDeviceSession = `-- name: GetFirstDeviceSession :one
+SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+FROM "session"
+WHERE username = $1
+  AND user_agent = $2
+  AND client_ip = $3
+ORDER BY created_at
+LIMIT 1
+`
+
+type GetFirstDeviceSessionParams struct {
+	Username  string `json:"username"`
+	UserAgent string `json:"user_agent"`
+	ClientIp  string `json:"client_ip"`
+}
+
+func (q *Queries) GetFirstDeviceSession(ctx context.Context, arg GetFirstDeviceSessionParams) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getFirstDeviceSession, arg.Username, arg.UserAgent, arg.ClientIp)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getSession = `-- name: GetSession :one
 SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
 FROM "session"
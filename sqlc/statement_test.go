@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/statement"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestBuildStatement(t *testing.T) {
+	store := NewStore(testDB)
+	source := createReconciledAccount(t, store, 500)
+	destination := createReconciledAccount(t, store, 0)
+
+	result, err := store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               100,
+		},
+	)
+	require.NoError(t, err)
+
+	today := util.StartOfDay(time.Now())
+	s, err := store.BuildStatement(
+		context.Background(), StatementParams{
+			AccountID:   source.ID,
+			PeriodStart: today,
+			PeriodEnd:   today.AddDate(0, 0, 1),
+		},
+	)
+	require.NoError(t, err)
+	require.Zero(t, s.OpeningBalance)
+	require.Equal(t, int64(500), s.TotalIn)
+	require.Equal(t, result.SourceAccount.Balance, s.ClosingBalance)
+	require.NotEmpty(t, s.Lines)
+	require.Equal(t, s.ClosingBalance, s.Lines[len(s.Lines)-1].RunningBalance)
+
+	var transferLine *statement.Line
+	for i := range s.Lines {
+		if s.Lines[i].EntryID == result.FromEntry.ID {
+			transferLine = &s.Lines[i]
+		}
+	}
+	require.NotNil(t, transferLine)
+	require.Equal(t, int64(-100), transferLine.Amount)
+	require.NotNil(t, transferLine.TransferID)
+	require.Equal(t, result.Transfer.ID, *transferLine.TransferID)
+	require.NotNil(t, transferLine.CounterpartyAccountID)
+	require.Equal(t, destination.ID, *transferLine.CounterpartyAccountID)
+	require.NotEmpty(t, transferLine.CounterpartyName)
+}
+
+func TestCreateStatementTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createReconciledAccount(t, store, 500)
+
+	today := util.StartOfDay(time.Now())
+	arg := StatementParams{
+		AccountID:   account.ID,
+		PeriodStart: today,
+		PeriodEnd:   today.AddDate(0, 0, 1),
+	}
+
+	stored, err := store.CreateStatementTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, stored.AccountID)
+	require.Equal(t, int64(500), stored.ClosingBalance)
+	require.Equal(t, statement.Hash(stored.Content), stored.ContentHash)
+
+	_, err = store.CreateStatementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrStatementExists)
+
+	_, err = testDB.Exec("UPDATE statement SET closing_balance = 0 WHERE id = $1", stored.ID)
+	require.Error(t, err)
+
+	fetched, err := testQueries.GetStatement(
+		context.Background(), GetStatementParams{
+			AccountID:   arg.AccountID,
+			PeriodStart: arg.PeriodStart,
+			PeriodEnd:   arg.PeriodEnd,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, stored.ContentHash, fetched.ContentHash)
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/statement"
+	"time"
+)
+
+var ErrStatementExists = errors.New("statement already generated for this period")
+
+type StatementParams struct {
+	AccountID int64 `json:"account_id"`
+	// PeriodStart is inclusive and PeriodEnd exclusive, both midnight UTC.
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+}
+
+// BuildStatement assembles an account's statement for a period from its balance at the start of the period and every
+// entry made during it, each with the counterparty of the transfer it belongs to.
+func (store *SQLStore) BuildStatement(ctx context.Context, arg StatementParams) (statement.Statement, error) {
+	var s statement.Statement
+
+	account, err := store.GetAccount(ctx, arg.AccountID)
+	if err != nil {
+		return s, err
+	}
+
+	owner, err := store.GetUser(ctx, account.Owner)
+	if err != nil {
+		return s, err
+	}
+
+	product, err := store.GetAccountProduct(ctx, account.Product)
+	if err != nil {
+		return s, err
+	}
+
+	opening, err := store.BalanceAsOf(ctx, account.ID, arg.PeriodStart)
+	if err != nil {
+		return s, err
+	}
+
+	rows, err := store.GetStatementLines(
+		ctx, GetStatementLinesParams{
+			AccountID: account.ID,
+			FromTime:  arg.PeriodStart,
+			ToTime:    arg.PeriodEnd,
+		},
+	)
+	if err != nil {
+		return s, err
+	}
+
+	s = statement.Statement{
+		AccountID:       account.ID,
+		Owner:           account.Owner,
+		OwnerName:       owner.FullName,
+		Currency:        account.Currency,
+		Product:         account.Product,
+		InterestRateBps: product.InterestRateBps,
+		PeriodStart:     arg.PeriodStart.UTC(),
+		PeriodEnd:       arg.PeriodEnd.UTC(),
+		OpeningBalance:  opening.Balance,
+		Lines:           make([]statement.Line, len(rows)),
+	}
+	for i, row := range rows {
+		s.Lines[i] = statement.Line{
+			EntryID:               row.ID,
+			PostedAt:              row.CreatedAt.UTC(),
+			Kind:                  row.Kind.String,
+			Description:           row.Description.String,
+			Amount:                row.Amount,
+			TransferID:            nullInt64Pointer(row.TransferID),
+			CounterpartyAccountID: nullInt64Pointer(row.CounterpartyAccountID),
+			CounterpartyName:      row.CounterpartyName.String,
+		}
+	}
+	s.Tally()
+
+	return s, nil
+}
+
+// CreateStatementTx builds and stores an account's statement for a period. Statements can't be changed once stored,
+// so generating the same period twice returns ErrStatementExists.
+func (store *SQLStore) CreateStatementTx(ctx context.Context, arg StatementParams) (Statement, error) {
+	var stored Statement
+
+	s, err := store.BuildStatement(ctx, arg)
+	if err != nil {
+		return stored, err
+	}
+
+	content, hash, err := statement.Encode(s)
+	if err != nil {
+		return stored, err
+	}
+
+	stored, err = store.CreateStatement(
+		ctx, CreateStatementParams{
+			AccountID:      arg.AccountID,
+			PeriodStart:    arg.PeriodStart,
+			PeriodEnd:      arg.PeriodEnd,
+			OpeningBalance: s.OpeningBalance,
+			ClosingBalance: s.ClosingBalance,
+			Content:        content,
+			ContentHash:    hash,
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return stored, ErrStatementExists
+	}
+	return stored, err
+}
+
+func nullInt64Pointer(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int64
+}
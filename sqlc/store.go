@@ -3,24 +3,99 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/fee"
+	"github.com/CrunchyBlue/Golang-Bank/limit"
+	"github.com/CrunchyBlue/Golang-Bank/statement"
+	"sort"
+	"time"
 )
 
+var ErrInsufficientFunds = errors.New("insufficient available balance")
+
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	MultiTransferTx(ctx context.Context, arg MultiTransferTxParams) (MultiTransferTxResult, error)
+	PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (Hold, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	VoidHoldTx(ctx context.Context, holdID int64) (Hold, error)
+	ExpireHoldTx(ctx context.Context, holdID int64) (Hold, error)
+	RunDueScheduledTransferTx(
+		ctx context.Context, arg RunDueScheduledTransferTxParams,
+	) (RunDueScheduledTransferTxResult, error)
+	AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (InterestAccrual, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	ChargeMaintenanceFeeTx(ctx context.Context, arg ChargeMaintenanceFeeTxParams) ([]Fee, error)
+	PostJournalTx(ctx context.Context, arg PostJournalTxParams) (PostJournalTxResult, error)
+	DepositTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	WithdrawTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	SnapshotBalanceTx(ctx context.Context, arg SnapshotBalanceTxParams) (BalanceSnapshot, error)
+	BalanceAsOf(ctx context.Context, accountID int64, asOf time.Time) (BalanceAsOfResult, error)
+	BuildStatement(ctx context.Context, arg StatementParams) (statement.Statement, error)
+	CreateStatementTx(ctx context.Context, arg StatementParams) (Statement, error)
+	ChangeAccountStatusTx(ctx context.Context, arg ChangeAccountStatusTxParams) (ChangeAccountStatusTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (Account, error)
+	InviteAccountMemberTx(ctx context.Context, arg InviteAccountMemberTxParams) (AccountInvitation, error)
+	AcceptAccountInvitationTx(ctx context.Context, invitationID int64) (AcceptAccountInvitationTxResult, error)
+	RemoveAccountMemberTx(ctx context.Context, arg DeleteAccountMemberParams) (AccountMember, error)
+	CreateOrganizationTx(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
+	ApprovePendingTransferTx(ctx context.Context, arg ApprovePendingTransferTxParams) (
+		ApprovePendingTransferTxResult, error,
+	)
+	AcceptPaymentRequestTx(ctx context.Context, arg AcceptPaymentRequestTxParams) (
+		AcceptPaymentRequestTxResult, error,
+	)
+	CreateTransferBatchTx(ctx context.Context, arg CreateTransferBatchTxParams) (CreateTransferBatchTxResult, error)
+	RunTransferBatch(ctx context.Context, batchID int64) (TransferBatch, error)
+	TransferAllowance(ctx context.Context, arg TransferAllowanceParams) (TransferAllowanceResult, error)
+	AssessedTransferTx(ctx context.Context, arg AssessedTransferTxParams) (AssessedTransferTxResult, error)
+	ReviewRiskAssessmentTx(ctx context.Context, arg ReviewRiskAssessmentTxParams) (
+		ReviewRiskAssessmentTxResult, error,
+	)
+	AssignAmlAlertTx(ctx context.Context, arg AssignAmlAlertTxParams) (AmlAlert, error)
+	CloseAmlAlertTx(ctx context.Context, arg CloseAmlAlertTxParams) (CloseAmlAlertTxResult, error)
+	RecordScreeningHitsTx(ctx context.Context, arg RecordScreeningHitsTxParams) ([]ScreeningHit, error)
+	ReviewScreeningHitTx(ctx context.Context, arg ReviewScreeningHitTxParams) (ScreeningHit, error)
+	SubmitKycTx(ctx context.Context, username string) (KycSubmission, error)
+	ReviewKycSubmissionTx(ctx context.Context, arg ReviewKycSubmissionTxParams) (KycSubmission, error)
 }
 
 type SQLStore struct {
 	*Queries
-	db *sql.DB
+	db     *sql.DB
+	fees   fee.Schedule
+	limits limit.Schedule
+}
+
+type StoreOption func(store *SQLStore)
+
+// WithFeeSchedule makes the store charge fees from schedule. A store without one charges no fees.
+func WithFeeSchedule(schedule fee.Schedule) StoreOption {
+	return func(store *SQLStore) {
+		store.fees = schedule
+	}
+}
+
+// WithLimitSchedule makes the store enforce transfer limits from schedule. A store without one only enforces the
+// limits admins have set for individual users.
+func WithLimitSchedule(schedule limit.Schedule) StoreOption {
+	return func(store *SQLStore) {
+		store.limits = schedule
+	}
 }
 
-func NewStore(db *sql.DB) Store {
-	return &SQLStore{
+func NewStore(db *sql.DB, opts ...StoreOption) Store {
+	store := &SQLStore{
 		db:      db,
 		Queries: New(db),
 	}
+	for _, opt := range opts {
+		opt(store)
+	}
+	return store
 }
 
 func (store *SQLStore) execTx(ctx context.Context, fn func(queries *Queries) error) error {
@@ -35,23 +110,37 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(queries *Queries) err
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
 		}
+		return err
 	}
 
 	return tx.Commit()
 }
 
+// Remittance is what the payer says about a transfer. It is stored on the transfer and on both of its entries.
+type Remittance struct {
+	Memo          sql.NullString `json:"memo"`
+	Reference     sql.NullString `json:"reference"`
+	Category      sql.NullString `json:"category"`
+	InvoiceNumber sql.NullString `json:"invoice_number"`
+	EndToEndID    sql.NullString `json:"end_to_end_id"`
+}
+
 type TransferTxParams struct {
 	SourceAccountID      int64 `json:"source_account_id"`
 	DestinationAccountID int64 `json:"destination_account_id"`
 	Amount               int64 `json:"amount"`
+	Remittance
 }
 
 type TransferTxResult struct {
-	Transfer           Transfer `json:"transfer"`
-	SourceAccount      Account  `json:"source_account"`
-	DestinationAccount Account  `json:"destination_account"`
-	FromEntry          Entry    `json:"from_entry"`
-	ToEntry            Entry    `json:"to_entry"`
+	Transfer           Transfer           `json:"transfer"`
+	JournalTransaction JournalTransaction `json:"journal_transaction"`
+	SourceAccount      Account            `json:"source_account"`
+	DestinationAccount Account            `json:"destination_account"`
+	FromEntry          Entry              `json:"from_entry"`
+	ToEntry            Entry              `json:"to_entry"`
+	// Fees charged to the source account on top of the amount. SourceAccount's balance is after the fees.
+	Fees []Fee `json:"fees"`
 }
 
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
@@ -60,75 +149,182 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 	err := store.execTx(
 		ctx, func(q *Queries) error {
 			var err error
-
-			result.Transfer, err = q.CreateTransfer(
-				ctx, CreateTransferParams{
-					SourceAccountID:      arg.SourceAccountID,
-					DestinationAccountID: arg.DestinationAccountID,
-					Amount:               arg.Amount,
-				},
-			)
-			if err != nil {
-				return err
-			}
-
-			result.FromEntry, err = q.CreateEntry(
-				ctx, CreateEntryParams{
-					AccountID: arg.SourceAccountID,
-					Amount:    -arg.Amount,
-				},
-			)
-			if err != nil {
-				return err
-			}
-
-			result.ToEntry, err = q.CreateEntry(
-				ctx, CreateEntryParams{
-					AccountID: arg.DestinationAccountID,
-					Amount:    arg.Amount,
-				},
-			)
-			if err != nil {
-				return err
-			}
-
-			// Order transaction queries to prevent deadlock
-			if arg.SourceAccountID < arg.DestinationAccountID {
-				result.SourceAccount, result.DestinationAccount, err = transfer(
-					ctx, q, arg.SourceAccountID, arg.DestinationAccountID, arg.Amount,
-				)
-			} else {
-				result.DestinationAccount, result.SourceAccount, err = transfer(
-					ctx, q, arg.DestinationAccountID, arg.SourceAccountID, -arg.Amount,
-				)
-			}
-
-			return nil
+			result, err = transferTx(ctx, q, arg, 0, store.fees, store.limits)
+			return err
 		},
 	)
 
 	return result, err
 }
 
-func transfer(
-	ctx context.Context, q *Queries, firstAccountID int64, secondAccountID int64, amount int64,
-) (firstAccount Account, secondAccount Account, err error) {
-	firstAccount, err = q.UpdateAccountBalance(
-		ctx, UpdateAccountBalanceParams{
-			ID:     firstAccountID,
-			Amount: -amount,
+// transferTx moves funds between two accounts inside an open transaction and charges the source account the fees that
+// schedule sets on the transfer, after checking that neither account's owner is on a screening hold and that the
+// transfer is within the source account owner's limits. released is the part of the source account's held balance that
+// this transfer settles, so that a hold capture can spend the funds it reserved.
+func transferTx(
+	ctx context.Context, q *Queries, arg TransferTxParams, released int64, schedule fee.Schedule,
+	limits limit.Schedule,
+) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	source, err := q.GetAccount(ctx, arg.SourceAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if err := checkScreeningHold(ctx, q, source); err != nil {
+		return result, err
+	}
+	if err := checkTransferLimits(ctx, q, limits, source, arg.Amount, 1); err != nil {
+		return result, err
+	}
+
+	destination, err := q.GetAccount(ctx, arg.DestinationAccountID)
+	if err != nil {
+		return result, err
+	}
+	if err := checkDestinationScreening(ctx, q, destination); err != nil {
+		return result, err
+	}
+
+	charges, err := waiveFees(
+		ctx, q, source.ID, schedule.TransferCharges(source.Currency, arg.Amount),
+	)
+	if err != nil {
+		return result, err
+	}
+
+	accountIDs := []int64{source.ID, destination.ID}
+	var revenueAccount Account
+	if len(charges) > 0 {
+		revenueAccount, err = systemAccount(ctx, q, constants.SystemAccountFeeRevenue, source.Currency)
+		if err != nil {
+			return result, err
+		}
+		accountIDs = append(accountIDs, revenueAccount.ID)
+	}
+
+	accounts, err := lockAccounts(ctx, q, accountIDs...)
+	if err != nil {
+		return result, err
+	}
+
+	if err := checkAccountPosting(accounts[source.ID], -arg.Amount); err != nil {
+		return result, err
+	}
+	if err := checkAccountPosting(accounts[destination.ID], arg.Amount); err != nil {
+		return result, err
+	}
+
+	if availableBalance(accounts[source.ID])+released < arg.Amount+fee.Total(charges) {
+		return result, ErrInsufficientFunds
+	}
+
+	result, err = moveFunds(ctx, q, arg, released)
+	if err != nil {
+		return result, err
+	}
+
+	for _, charge := range charges {
+		charged, feeTransfer, err := chargeFee(
+			ctx, q, CreateFeeParams{
+				AccountID:  source.ID,
+				Kind:       constants.FeeKindTransfer,
+				Name:       charge.Name,
+				Amount:     charge.Amount,
+				TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+			}, revenueAccount.ID,
+		)
+		if err != nil {
+			return result, err
+		}
+
+		result.Fees = append(result.Fees, charged)
+		result.SourceAccount = feeTransfer.SourceAccount
+	}
+
+	return result, nil
+}
+
+// moveFunds records a transfer as a journal transaction debiting the source account and crediting the destination
+// account, without checking the source account's funds. Callers must already hold the account locks.
+func moveFunds(ctx context.Context, q *Queries, arg TransferTxParams, released int64) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	if released > 0 {
+		_, err := q.AddAccountHeldBalance(
+			ctx, AddAccountHeldBalanceParams{
+				ID:     arg.SourceAccountID,
+				Amount: -released,
+			},
+		)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	journal, err := postJournal(
+		ctx, q, PostJournalTxParams{
+			Kind: constants.JournalKindTransfer,
+			Postings: []Posting{
+				{AccountID: arg.SourceAccountID, Amount: -arg.Amount},
+				{AccountID: arg.DestinationAccountID, Amount: arg.Amount},
+			},
+			Remittance: arg.Remittance,
 		},
 	)
 	if err != nil {
-		return
+		return result, err
 	}
 
-	secondAccount, err = q.UpdateAccountBalance(
-		ctx, UpdateAccountBalanceParams{
-			ID:     secondAccountID,
-			Amount: amount,
+	result.JournalTransaction = journal.JournalTransaction
+	result.FromEntry = journal.Entries[0]
+	result.ToEntry = journal.Entries[1]
+	result.SourceAccount = journal.Accounts[0]
+	result.DestinationAccount = journal.Accounts[1]
+
+	result.Transfer, err = q.CreateTransfer(
+		ctx, CreateTransferParams{
+			SourceAccountID:      arg.SourceAccountID,
+			DestinationAccountID: arg.DestinationAccountID,
+			Amount:               arg.Amount,
+			JournalTransactionID: sql.NullInt64{Int64: journal.JournalTransaction.ID, Valid: true},
+			Memo:                 arg.Memo,
+			Reference:            arg.Reference,
+			Category:             arg.Category,
+			InvoiceNumber:        arg.InvoiceNumber,
+			EndToEndID:           arg.EndToEndID,
 		},
 	)
 
-	return
+	return result, err
+}
+
+// lockAccounts takes row locks on the given accounts in ascending ID order. Every transaction that touches more than
+// one account must go through here so that concurrent transactions always lock in the same order and cannot deadlock.
+func lockAccounts(ctx context.Context, q *Queries, accountIDs ...int64) (map[int64]Account, error) {
+	ids := make([]int64, 0, len(accountIDs))
+	accounts := make(map[int64]Account, len(accountIDs))
+	for _, id := range accountIDs {
+		if _, ok := accounts[id]; ok {
+			continue
+		}
+		accounts[id] = Account{}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		account, err := q.GetAccountForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		accounts[id] = account
+	}
+
+	return accounts, nil
+}
+
+func availableBalance(account Account) int64 {
+	return account.Balance - account.HeldBalance
 }
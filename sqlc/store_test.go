@@ -2,7 +2,10 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -10,8 +13,10 @@ import (
 func TestTransfer(t *testing.T) {
 	store := NewStore(testDB)
 
-	account1, _, _ := createRandomAccount()
-	account2, _, _ := createRandomAccount()
+	account1, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	account2, err := createFundedAccount(1000)
+	require.NoError(t, err)
 
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
@@ -39,7 +44,7 @@ func TestTransfer(t *testing.T) {
 	existed := make(map[int]bool)
 
 	for i := 0; i < n; i++ {
-		err := <-errs
+		err = <-errs
 		require.NoError(t, err)
 
 		result := <-results
@@ -56,6 +61,9 @@ func TestTransfer(t *testing.T) {
 		_, err = store.GetTransfer(context.Background(), transfer.ID)
 		require.NoError(t, err)
 
+		require.Equal(t, result.JournalTransaction.ID, transfer.JournalTransactionID.Int64)
+		require.Equal(t, constants.JournalKindTransfer, result.JournalTransaction.Kind)
+
 		fromEntry := result.FromEntry
 		require.NotEmpty(t, fromEntry)
 		require.Equal(t, account1.ID, fromEntry.AccountID)
@@ -70,6 +78,8 @@ func TestTransfer(t *testing.T) {
 		require.NotEmpty(t, toEntry)
 		require.Equal(t, account2.ID, toEntry.AccountID)
 		require.Equal(t, amount, toEntry.Amount)
+		require.Equal(t, transfer.JournalTransactionID, toEntry.JournalTransactionID)
+		require.Equal(t, transfer.JournalTransactionID, fromEntry.JournalTransactionID)
 		require.NotZero(t, toEntry.ID)
 		require.NotZero(t, toEntry.CreatedAt)
 
@@ -113,8 +123,10 @@ func TestTransfer(t *testing.T) {
 func TestTransferDeadlock(t *testing.T) {
 	store := NewStore(testDB)
 
-	account1, _, _ := createRandomAccount()
-	account2, _, _ := createRandomAccount()
+	account1, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	account2, err := createFundedAccount(1000)
+	require.NoError(t, err)
 
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
@@ -146,7 +158,7 @@ func TestTransferDeadlock(t *testing.T) {
 	}
 
 	for i := 0; i < n; i++ {
-		err := <-errs
+		err = <-errs
 		require.NoError(t, err)
 	}
 
@@ -161,3 +173,86 @@ func TestTransferDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestTransferInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               101,
+		},
+	)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	updatedAccount2, err := testQueries.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+}
+
+func TestTransferRemittance(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1, err := createFundedAccount(100)
+	require.NoError(t, err)
+	account2, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	remittance := Remittance{
+		Memo:          sql.NullString{String: "Invoice 42, thanks!", Valid: true},
+		Reference:     sql.NullString{String: "REF/" + util.RandomString(8), Valid: true},
+		Category:      sql.NullString{String: "supplies", Valid: true},
+		InvoiceNumber: sql.NullString{String: "INV-42", Valid: true},
+	}
+
+	result, err := store.TransferTx(
+		context.Background(), TransferTxParams{
+			SourceAccountID:      account1.ID,
+			DestinationAccountID: account2.ID,
+			Amount:               10,
+			Remittance:           remittance,
+		},
+	)
+	require.NoError(t, err)
+
+	for _, entry := range []Entry{result.FromEntry, result.ToEntry} {
+		require.Equal(t, remittance.Memo, entry.Memo)
+		require.Equal(t, remittance.Reference, entry.Reference)
+		require.Equal(t, remittance.Category, entry.Category)
+		require.Equal(t, remittance.InvoiceNumber, entry.InvoiceNumber)
+		require.False(t, entry.EndToEndID.Valid)
+	}
+
+	transfers, err := testQueries.SearchTransfers(
+		context.Background(), SearchTransfersParams{
+			AccountID: account2.ID,
+			Search:    sql.NullString{String: "invoice 42", Valid: true},
+			Category:  remittance.Category,
+			PageLimit: 5,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, result.Transfer.ID, transfers[0].ID)
+	require.Equal(t, remittance.Reference, transfers[0].Reference)
+
+	transfers, err = testQueries.SearchTransfers(
+		context.Background(), SearchTransfersParams{
+			AccountID: account2.ID,
+			Reference: sql.NullString{String: "REF/unknown", Valid: true},
+			PageLimit: 5,
+		},
+	)
+	require.NoError(t, err)
+	require.Empty(t, transfers)
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+)
+
+// systemAccount returns the bank's account for purpose in currency, opening it on first use. If two transactions open
+// the same account at once, the primary key on system_account fails the later one, which rolls back with it.
+func systemAccount(ctx context.Context, q *Queries, purpose string, currency string) (Account, error) {
+	systemAccount, err := q.GetSystemAccount(
+		ctx, GetSystemAccountParams{
+			Purpose:  purpose,
+			Currency: currency,
+		},
+	)
+	if err == nil {
+		return q.GetAccount(ctx, systemAccount.AccountID)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return Account{}, err
+	}
+
+	accountNumber, err := util.NewAccountNumber()
+	if err != nil {
+		return Account{}, err
+	}
+
+	account, err := q.CreateAccount(
+		ctx, CreateAccountParams{
+			Owner:         constants.BankUsername,
+			Balance:       0,
+			Currency:      currency,
+			Product:       constants.ProductInternal,
+			AccountNumber: accountNumber,
+		},
+	)
+	if err != nil {
+		return account, err
+	}
+
+	_, err = q.CreateSystemAccount(
+		ctx, CreateSystemAccountParams{
+			Purpose:   purpose,
+			Currency:  currency,
+			AccountID: account.ID,
+		},
+	)
+	return account, err
+}
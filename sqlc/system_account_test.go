@@ -0,0 +1,33 @@
+package db
+
+import (
+	"context"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSystemAccount(t *testing.T) {
+	purpose := util.RandomString(12)
+	currency := util.RandomCurrency()
+
+	account1, err := systemAccount(context.Background(), testQueries, purpose, currency)
+	require.NoError(t, err)
+	require.Equal(t, constants.BankUsername, account1.Owner)
+	require.Equal(t, currency, account1.Currency)
+	require.Equal(t, constants.ProductInternal, account1.Product)
+
+	account2, err := systemAccount(context.Background(), testQueries, purpose, currency)
+	require.NoError(t, err)
+	require.Equal(t, account1.ID, account2.ID)
+
+	mapping, err := testQueries.GetSystemAccount(
+		context.Background(), GetSystemAccountParams{
+			Purpose:  purpose,
+			Currency: currency,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, account1.ID, mapping.AccountID)
+}
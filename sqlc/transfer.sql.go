@@ -7,24 +7,48 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfer (source_account_id,
                       destination_account_id,
-                      amount)
-VALUES ($1, $2, $3)
-RETURNING id, source_account_id, destination_account_id, amount, created_at
+                      amount,
+                      journal_transaction_id,
+                      memo,
+                      reference,
+                      category,
+                      invoice_number,
+                      end_to_end_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, source_account_id, destination_account_id, amount, created_at, journal_transaction_id, memo, reference, category, invoice_number, end_to_end_id
 `
 
 type CreateTransferParams struct {
-	SourceAccountID      int64 `json:"source_account_id"`
-	DestinationAccountID int64 `json:"destination_account_id"`
-	Amount               int64 `json:"amount"`
+	SourceAccountID      int64          `json:"source_account_id"`
+	DestinationAccountID int64          `json:"destination_account_id"`
+	Amount               int64          `json:"amount"`
+	JournalTransactionID sql.NullInt64  `json:"journal_transaction_id"`
+	Memo                 sql.NullString `json:"memo"`
+	Reference            sql.NullString `json:"reference"`
+	Category             sql.NullString `json:"category"`
+	InvoiceNumber        sql.NullString `json:"invoice_number"`
+	EndToEndID           sql.NullString `json:"end_to_end_id"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.SourceAccountID, arg.DestinationAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.SourceAccountID,
+		arg.DestinationAccountID,
+		arg.Amount,
+		arg.JournalTransactionID,
+		arg.Memo,
+		arg.Reference,
+		arg.Category,
+		arg.InvoiceNumber,
+		arg.EndToEndID,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -32,6 +56,12 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.DestinationAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.JournalTransactionID,
+		&i.Memo,
+		&i.Reference,
+		&i.Category,
+		&i.InvoiceNumber,
+		&i.EndToEndID,
 	)
 	return i, err
 }
@@ -48,7 +78,7 @@ func (q *Queries) DeleteTransfer(ctx context.Context, id int64) error {
 }
 
 const getInboundTransfersForAccount = `-- name: GetInboundTransfersForAccount :many
-SELECT id, source_account_id, destination_account_id, amount, created_at
+SELECT id, source_account_id, destination_account_id, amount, created_at, journal_transaction_id, memo, reference, category, invoice_number, end_to_end_id
 FROM transfer
 WHERE destination_account_id = $1
 ORDER BY id
@@ -76,6 +106,12 @@ func (q *Queries) GetInboundTransfersForAccount(ctx context.Context, arg GetInbo
 			&i.DestinationAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.JournalTransactionID,
+			&i.Memo,
+			&i.Reference,
+			&i.Category,
+			&i.InvoiceNumber,
+			&i.EndToEndID,
 		); err != nil {
 			return nil, err
 		}
@@ -91,7 +127,7 @@ func (q *Queries) GetInboundTransfersForAccount(ctx context.Context, arg GetInbo
 }
 
 const getOutboundTransfersForAccount = `-- name: GetOutboundTransfersForAccount :many
-SELECT id, source_account_id, destination_account_id, amount, created_at
+SELECT id, source_account_id, destination_account_id, amount, created_at, journal_transaction_id, memo, reference, category, invoice_number, end_to_end_id
 FROM transfer
 WHERE source_account_id = $1
 ORDER BY id
@@ -119,6 +155,12 @@ func (q *Queries) GetOutboundTransfersForAccount(ctx context.Context, arg GetOut
 			&i.DestinationAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.JournalTransactionID,
+			&i.Memo,
+			&i.Reference,
+			&i.Category,
+			&i.InvoiceNumber,
+			&i.EndToEndID,
 		); err != nil {
 			return nil, err
 		}
@@ -134,7 +176,7 @@ func (q *Queries) GetOutboundTransfersForAccount(ctx context.Context, arg GetOut
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, source_account_id, destination_account_id, amount, created_at
+SELECT id, source_account_id, destination_account_id, amount, created_at, journal_transaction_id, memo, reference, category, invoice_number, end_to_end_id
 FROM transfer
 WHERE id = $1
 `
@@ -148,14 +190,21 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.DestinationAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.JournalTransactionID,
+		&i.Memo,
+		&i.Reference,
+		&i.Category,
+		&i.InvoiceNumber,
+		&i.EndToEndID,
 	)
 	return i, err
 }
 
 const getTransfers = `-- name: GetTransfers :many
-SELECT id, source_account_id, destination_account_id, amount, created_at
+SELECT transfer.id, transfer.source_account_id, transfer.destination_account_id, transfer.amount, transfer.created_at, transfer.journal_transaction_id, transfer.memo, transfer.reference, transfer.category, transfer.invoice_number, transfer.end_to_end_id, account.currency
 FROM transfer
-ORDER BY id
+         JOIN account ON account.id = transfer.source_account_id
+ORDER BY transfer.id
 LIMIT $1 OFFSET $2
 `
 
@@ -164,12 +213,98 @@ type GetTransfersParams struct {
 	Offset int32 `json:"offset"`
 }
 
-func (q *Queries) GetTransfers(ctx context.Context, arg GetTransfersParams) ([]Transfer, error) {
+type GetTransfersRow struct {
+	ID                   int64          `json:"id"`
+	SourceAccountID      int64          `json:"source_account_id"`
+	DestinationAccountID int64          `json:"destination_account_id"`
+	Amount               int64          `json:"amount"`
+	CreatedAt            time.Time      `json:"created_at"`
+	JournalTransactionID sql.NullInt64  `json:"journal_transaction_id"`
+	Memo                 sql.NullString `json:"memo"`
+	Reference            sql.NullString `json:"reference"`
+	Category             sql.NullString `json:"category"`
+	InvoiceNumber        sql.NullString `json:"invoice_number"`
+	EndToEndID           sql.NullString `json:"end_to_end_id"`
+	Currency             string         `json:"currency"`
+}
+
+func (q *Queries) GetTransfers(ctx context.Context, arg GetTransfersParams) ([]GetTransfersRow, error) {
 	rows, err := q.db.QueryContext(ctx, getTransfers, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	items := []GetTransfersRow{}
+	for rows.Next() {
+		var i GetTransfersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SourceAccountID,
+			&i.DestinationAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.JournalTransactionID,
+			&i.Memo,
+			&i.Reference,
+			&i.Category,
+			&i.InvoiceNumber,
+			&i.EndToEndID,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchTransfers = `-- name: SearchTransfers :many
+SELECT id, source_account_id, destination_account_id, amount, created_at, journal_transaction_id, memo, reference, category, invoice_number, end_to_end_id
+FROM transfer
+WHERE (source_account_id = $1 OR destination_account_id = $1)
+  AND ($2::varchar IS NULL
+    OR memo ILIKE '%' || $2 || '%'
+    OR reference ILIKE '%' || $2 || '%')
+  AND ($3::varchar IS NULL OR reference = $3)
+  AND ($4::varchar IS NULL OR category = $4)
+  AND ($5::varchar IS NULL OR invoice_number = $5)
+  AND ($6::varchar IS NULL OR end_to_end_id = $6)
+ORDER BY id DESC
+LIMIT $7 OFFSET $8
+`
+
+type SearchTransfersParams struct {
+	AccountID     int64          `json:"account_id"`
+	Search        sql.NullString `json:"search"`
+	Reference     sql.NullString `json:"reference"`
+	Category      sql.NullString `json:"category"`
+	InvoiceNumber sql.NullString `json:"invoice_number"`
+	EndToEndID    sql.NullString `json:"end_to_end_id"`
+	PageLimit     int32          `json:"page_limit"`
+	PageOffset    int32          `json:"page_offset"`
+}
+
+func (q *Queries) SearchTransfers(ctx context.Context, arg SearchTransfersParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, searchTransfers,
+		arg.AccountID,
+		arg.Search,
+		arg.Reference,
+		arg.Category,
+		arg.InvoiceNumber,
+		arg.EndToEndID,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 	items := []Transfer{}
 	for rows.Next() {
 		var i Transfer
@@ -179,6 +314,12 @@ func (q *Queries) GetTransfers(ctx context.Context, arg GetTransfersParams) ([]T
 			&i.DestinationAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.JournalTransactionID,
+			&i.Memo,
+			&i.Reference,
+			&i.Category,
+			&i.InvoiceNumber,
+			&i.EndToEndID,
 		); err != nil {
 			return nil, err
 		}
@@ -197,7 +338,7 @@ const updateTransfer = `-- name: UpdateTransfer :one
 UPDATE transfer
 SET amount = $2
 WHERE id = $1
-RETURNING id, source_account_id, destination_account_id, amount, created_at
+RETURNING id, source_account_id, destination_account_id, amount, created_at, journal_transaction_id, memo, reference, category, invoice_number, end_to_end_id
 `
 
 type UpdateTransferParams struct {
@@ -214,6 +355,12 @@ func (q *Queries) UpdateTransfer(ctx context.Context, arg UpdateTransferParams)
 		&i.DestinationAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.JournalTransactionID,
+		&i.Memo,
+		&i.Reference,
+		&i.Category,
+		&i.InvoiceNumber,
+		&i.EndToEndID,
 	)
 	return i, err
 }
@@ -0,0 +1,296 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"time"
+)
+
+var (
+	ErrTransferBatchNotPending     = errors.New("transfer batch has already been run")
+	errTransferBatchItemNotPending = errors.New("transfer batch item has already been run")
+)
+
+type CreateTransferBatchTxParams struct {
+	CreateTransferBatchParams
+	// BatchID is set by the store
+	Items []CreateTransferBatchItemParams `json:"items"`
+}
+
+type CreateTransferBatchTxResult struct {
+	Batch TransferBatch       `json:"batch"`
+	Items []TransferBatchItem `json:"items"`
+}
+
+// CreateTransferBatchTx saves a batch and all of its items as pending. Nothing is transferred until the batch is run.
+func (store *SQLStore) CreateTransferBatchTx(ctx context.Context, arg CreateTransferBatchTxParams) (
+	CreateTransferBatchTxResult, error,
+) {
+	var result CreateTransferBatchTxResult
+
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			var err error
+			result.Batch, err = q.CreateTransferBatch(ctx, arg.CreateTransferBatchParams)
+			if err != nil {
+				return err
+			}
+
+			result.Items = make([]TransferBatchItem, len(arg.Items))
+			for i, item := range arg.Items {
+				item.BatchID = result.Batch.ID
+				result.Items[i], err = q.CreateTransferBatchItem(ctx, item)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+
+	return result, err
+}
+
+// TransferBatchClaimTimeout is how long a batch can be processing before its run is taken to have been interrupted,
+// so that it can be claimed again.
+const TransferBatchClaimTimeout = time.Hour
+
+// RunTransferBatch claims a pending batch, or one whose run was interrupted, and runs its pending items in order. An
+// atomic batch is claimed, run and finished in one transaction, so either every item is transferred or, when one
+// fails, none are and the others are skipped. A best-effort batch runs each item in its own transaction and carries
+// on past failures.
+func (store *SQLStore) RunTransferBatch(ctx context.Context, batchID int64) (TransferBatch, error) {
+	batch, err := store.GetTransferBatch(ctx, batchID)
+	if err != nil {
+		return batch, err
+	}
+
+	claim := ClaimTransferBatchParams{
+		ID:        batch.ID,
+		StartedAt: sql.NullTime{Time: time.Now().Add(-TransferBatchClaimTimeout), Valid: true},
+	}
+	if batch.Mode == constants.TransferBatchModeAtomic {
+		return store.runAtomicTransferBatch(ctx, claim)
+	}
+	return store.runBestEffortTransferBatch(ctx, claim)
+}
+
+func claimRunnableTransferBatch(ctx context.Context, q *Queries, arg ClaimTransferBatchParams) (TransferBatch, error) {
+	batch, err := q.ClaimTransferBatch(ctx, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		return batch, ErrTransferBatchNotPending
+	}
+	return batch, err
+}
+
+// runAtomicTransferBatch runs every item as a leg of one multi transfer, so every account is locked before any money
+// moves and the batch is limited as one transfer of its total.
+func (store *SQLStore) runAtomicTransferBatch(ctx context.Context, claim ClaimTransferBatchParams) (
+	TransferBatch, error,
+) {
+	var batch TransferBatch
+	var items []TransferBatchItem
+	var failure error
+	err := store.execTx(
+		ctx, func(q *Queries) error {
+			var err error
+			batch, err = claimRunnableTransferBatch(ctx, q, claim)
+			if err != nil {
+				return err
+			}
+
+			items, err = q.GetPendingTransferBatchItems(ctx, batch.ID)
+			if err != nil {
+				return err
+			}
+
+			arg := MultiTransferTxParams{SourceAccountID: batch.SourceAccountID, Legs: make([]TransferLeg, len(items))}
+			for i, item := range items {
+				arg.Legs[i] = TransferLeg{
+					DestinationAccountID: item.DestinationAccountID,
+					Amount:               item.Amount,
+					Remittance:           transferBatchItemRemittance(item),
+				}
+			}
+
+			result, err := multiTransferTx(ctx, q, arg, store.fees, store.limits)
+			if err != nil {
+				failure = err
+				return err
+			}
+
+			for i, item := range items {
+				_, err = q.CompleteTransferBatchItem(
+					ctx, CompleteTransferBatchItemParams{
+						ID:         item.ID,
+						TransferID: sql.NullInt64{Int64: result.Transfers[i].ID, Valid: true},
+					},
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			batch, err = q.FinishTransferBatch(
+				ctx, FinishTransferBatchParams{ID: batch.ID, Status: constants.TransferBatchStatusCompleted},
+			)
+			return err
+		},
+	)
+	if failure == nil {
+		return batch, err
+	}
+
+	// Everything was rolled back, the claim included, so the failure is recorded in a transaction of its own. A
+	// failure of one item is recorded against it; one of the whole batch, such as its limits, only on the batch.
+	finish := FinishTransferBatchParams{
+		ID:     batch.ID,
+		Status: constants.TransferBatchStatusFailed,
+		Error:  sql.NullString{String: failure.Error(), Valid: true},
+	}
+	var legErr *TransferLegError
+	failed := errors.As(failure, &legErr)
+	if failed {
+		finish.Error.String = fmt.Sprintf("item %d: %v", items[legErr.Index].Position, legErr.Err)
+	}
+	err = store.execTx(
+		ctx, func(q *Queries) error {
+			if _, err := claimRunnableTransferBatch(ctx, q, claim); err != nil {
+				return err
+			}
+
+			if failed {
+				_, err := q.FailTransferBatchItem(
+					ctx, FailTransferBatchItemParams{
+						ID:    items[legErr.Index].ID,
+						Error: sql.NullString{String: legErr.Err.Error(), Valid: true},
+					},
+				)
+				if err != nil {
+					return err
+				}
+			}
+			if err := q.SkipPendingTransferBatchItems(ctx, batch.ID); err != nil {
+				return err
+			}
+
+			var err error
+			batch, err = q.FinishTransferBatch(ctx, finish)
+			return err
+		},
+	)
+
+	return batch, err
+}
+
+// runBestEffortTransferBatch commits the claim before running the items, so that a run that's interrupted leaves the
+// batch processing until the claim times out. Each item is checked to still be pending in its own transaction, so an
+// item finished by an earlier run isn't transferred twice.
+func (store *SQLStore) runBestEffortTransferBatch(ctx context.Context, claim ClaimTransferBatchParams) (
+	TransferBatch, error,
+) {
+	batch, err := claimRunnableTransferBatch(ctx, store.Queries, claim)
+	if err != nil {
+		return batch, err
+	}
+
+	items, err := store.GetPendingTransferBatchItems(ctx, batch.ID)
+	if err != nil {
+		return batch, err
+	}
+
+	for _, item := range items {
+		err := store.execTx(
+			ctx, func(q *Queries) error {
+				return store.runTransferBatchItem(ctx, q, batch, item)
+			},
+		)
+		if err == nil || errors.Is(err, errTransferBatchItemNotPending) {
+			continue
+		}
+
+		_, err = store.FailTransferBatchItem(
+			ctx, FailTransferBatchItemParams{
+				ID:    item.ID,
+				Error: sql.NullString{String: err.Error(), Valid: true},
+			},
+		)
+		if err != nil {
+			return batch, err
+		}
+	}
+
+	// The items of an interrupted run count as well as this one's
+	counts, err := store.CountTransferBatchItems(ctx, batch.ID)
+	if err != nil {
+		return batch, err
+	}
+
+	var succeeded, failed int64
+	for _, count := range counts {
+		switch count.Status {
+		case constants.TransferBatchItemStatusSucceeded:
+			succeeded = count.Count
+		case constants.TransferBatchItemStatusFailed:
+			failed = count.Count
+		}
+	}
+
+	finish := FinishTransferBatchParams{ID: batch.ID}
+	switch {
+	case failed == 0:
+		finish.Status = constants.TransferBatchStatusCompleted
+	case succeeded == 0:
+		finish.Status = constants.TransferBatchStatusFailed
+	default:
+		finish.Status = constants.TransferBatchStatusPartiallyCompleted
+	}
+
+	return store.FinishTransferBatch(ctx, finish)
+}
+
+// runTransferBatchItem transfers a single item and marks it as succeeded, unless it has already been run.
+func (store *SQLStore) runTransferBatchItem(
+	ctx context.Context, q *Queries, batch TransferBatch, item TransferBatchItem,
+) error {
+	item, err := q.GetTransferBatchItemForUpdate(ctx, item.ID)
+	if err != nil {
+		return err
+	}
+	if item.Status != constants.TransferBatchItemStatusPending {
+		return errTransferBatchItemNotPending
+	}
+
+	result, err := transferTx(
+		ctx, q, TransferTxParams{
+			SourceAccountID:      batch.SourceAccountID,
+			DestinationAccountID: item.DestinationAccountID,
+			Amount:               item.Amount,
+			Remittance:           transferBatchItemRemittance(item),
+		}, 0, store.fees, store.limits,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CompleteTransferBatchItem(
+		ctx, CompleteTransferBatchItemParams{
+			ID:         item.ID,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		},
+	)
+	return err
+}
+
+func transferBatchItemRemittance(item TransferBatchItem) Remittance {
+	return Remittance{
+		Memo:          item.Memo,
+		Reference:     item.Reference,
+		Category:      item.Category,
+		InvoiceNumber: item.InvoiceNumber,
+		EndToEndID:    item.EndToEndID,
+	}
+}
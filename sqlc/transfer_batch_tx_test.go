@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createTestTransferBatch(t *testing.T, store Store, source Account, mode string, amounts ...int64) (
+	TransferBatch, []Account,
+) {
+	arg := CreateTransferBatchTxParams{
+		CreateTransferBatchParams: CreateTransferBatchParams{
+			SourceAccountID: source.ID,
+			CreatedBy:       source.Owner,
+			Mode:            mode,
+			Currency:        source.Currency,
+			ItemCount:       int32(len(amounts)),
+		},
+	}
+
+	destinations := make([]Account, len(amounts))
+	for i, amount := range amounts {
+		var err error
+		destinations[i], err = createFundedAccount(0)
+		require.NoError(t, err)
+
+		arg.TotalAmount += amount
+		arg.Items = append(
+			arg.Items, CreateTransferBatchItemParams{
+				Position:             int32(i + 1),
+				DestinationAccountID: destinations[i].ID,
+				Amount:               amount,
+				Memo:                 sql.NullString{String: "Payroll", Valid: true},
+				EndToEndID:           sql.NullString{String: util.RandomString(12), Valid: true},
+			},
+		)
+	}
+
+	result, err := store.CreateTransferBatchTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, constants.TransferBatchStatusPending, result.Batch.Status)
+	require.Len(t, result.Items, len(amounts))
+	for _, item := range result.Items {
+		require.Equal(t, result.Batch.ID, item.BatchID)
+		require.Equal(t, constants.TransferBatchItemStatusPending, item.Status)
+	}
+
+	return result.Batch, destinations
+}
+
+func getTestTransferBatchItems(t *testing.T, batchID int64) []TransferBatchItem {
+	items, err := testQueries.GetTransferBatchItems(
+		context.Background(), GetTransferBatchItemsParams{BatchID: batchID, Limit: 10},
+	)
+	require.NoError(t, err)
+	return items
+}
+
+func TestRunTransferBatchAtomic(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(100)
+	require.NoError(t, err)
+
+	batch, destinations := createTestTransferBatch(t, store, source, constants.TransferBatchModeAtomic, 30, 20)
+
+	batch, err = store.RunTransferBatch(context.Background(), batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.TransferBatchStatusCompleted, batch.Status)
+	require.True(t, batch.CompletedAt.Valid)
+
+	for i, item := range getTestTransferBatchItems(t, batch.ID) {
+		require.Equal(t, constants.TransferBatchItemStatusSucceeded, item.Status)
+		require.True(t, item.TransferID.Valid)
+
+		transfer, err := testQueries.GetTransfer(context.Background(), item.TransferID.Int64)
+		require.NoError(t, err)
+		require.Equal(t, item.Memo, transfer.Memo)
+		require.Equal(t, item.EndToEndID, transfer.EndToEndID)
+
+		destination, err := testQueries.GetAccount(context.Background(), destinations[i].ID)
+		require.NoError(t, err)
+		require.Equal(t, item.Amount, destination.Balance)
+	}
+
+	updatedSource, err := testQueries.GetAccount(context.Background(), source.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(50), updatedSource.Balance)
+
+	_, err = store.RunTransferBatch(context.Background(), batch.ID)
+	require.ErrorIs(t, err, ErrTransferBatchNotPending)
+}
+
+func TestRunTransferBatchAtomicRollsBack(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(100)
+	require.NoError(t, err)
+
+	batch, destinations := createTestTransferBatch(t, store, source, constants.TransferBatchModeAtomic, 60, 60, 10)
+
+	batch, err = store.RunTransferBatch(context.Background(), batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.TransferBatchStatusFailed, batch.Status)
+	require.True(t, batch.Error.Valid)
+
+	items := getTestTransferBatchItems(t, batch.ID)
+	require.Equal(t, constants.TransferBatchItemStatusSkipped, items[0].Status)
+	require.Equal(t, constants.TransferBatchItemStatusFailed, items[1].Status)
+	require.Equal(t, constants.TransferBatchItemStatusSkipped, items[2].Status)
+
+	// The first item was rolled back with the rest
+	destination, err := testQueries.GetAccount(context.Background(), destinations[0].ID)
+	require.NoError(t, err)
+	require.Zero(t, destination.Balance)
+
+	updatedSource, err := testQueries.GetAccount(context.Background(), source.ID)
+	require.NoError(t, err)
+	require.Equal(t, source.Balance, updatedSource.Balance)
+}
+
+func TestRunTransferBatchBestEffort(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(100)
+	require.NoError(t, err)
+
+	batch, _ := createTestTransferBatch(t, store, source, constants.TransferBatchModeBestEffort, 60, 60, 10)
+
+	batch, err = store.RunTransferBatch(context.Background(), batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.TransferBatchStatusPartiallyCompleted, batch.Status)
+	require.False(t, batch.Error.Valid)
+
+	items := getTestTransferBatchItems(t, batch.ID)
+	require.Equal(t, constants.TransferBatchItemStatusSucceeded, items[0].Status)
+	require.Equal(t, constants.TransferBatchItemStatusFailed, items[1].Status)
+	require.True(t, items[1].Error.Valid)
+	require.Equal(t, constants.TransferBatchItemStatusSucceeded, items[2].Status)
+
+	updatedSource, err := testQueries.GetAccount(context.Background(), source.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(30), updatedSource.Balance)
+}
+
+func TestRunTransferBatchInterrupted(t *testing.T) {
+	store := NewStore(testDB)
+
+	source, err := createFundedAccount(100)
+	require.NoError(t, err)
+
+	batch, _ := createTestTransferBatch(t, store, source, constants.TransferBatchModeBestEffort, 30, 20)
+
+	// A run that claimed the batch, then stopped
+	claimed, err := testQueries.ClaimTransferBatch(
+		context.Background(), ClaimTransferBatchParams{ID: batch.ID, StartedAt: sql.NullTime{Time: time.Now()}},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.TransferBatchStatusProcessing, claimed.Status)
+
+	// Until the claim times out, the batch can't be run again
+	_, err = store.RunTransferBatch(context.Background(), batch.ID)
+	require.ErrorIs(t, err, ErrTransferBatchNotPending)
+
+	before := sql.NullTime{Time: claimed.StartedAt.Time.Add(-time.Minute), Valid: true}
+	runnable, err := testQueries.GetRunnableTransferBatches(
+		context.Background(), GetRunnableTransferBatchesParams{StartedAt: before, Limit: 1000},
+	)
+	require.NoError(t, err)
+	require.NotContains(t, runnable, claimed)
+
+	after := sql.NullTime{Time: claimed.StartedAt.Time.Add(time.Minute), Valid: true}
+	runnable, err = testQueries.GetRunnableTransferBatches(
+		context.Background(), GetRunnableTransferBatchesParams{StartedAt: after, Limit: 1000},
+	)
+	require.NoError(t, err)
+	require.Contains(t, runnable, claimed)
+
+	reclaimed, err := testQueries.ClaimTransferBatch(
+		context.Background(), ClaimTransferBatchParams{ID: batch.ID, StartedAt: after},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.TransferBatchStatusProcessing, reclaimed.Status)
+	require.True(t, reclaimed.StartedAt.Time.After(claimed.StartedAt.Time))
+}
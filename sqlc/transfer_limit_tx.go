@@ -0,0 +1,176 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/limit"
+	"time"
+)
+
+type TransferAllowanceParams struct {
+	Username string    `json:"username"`
+	Currency string    `json:"currency"`
+	Now      time.Time `json:"now"`
+}
+
+type TransferAllowanceResult struct {
+	Tier   string       `json:"tier"`
+	Limits limit.Limits `json:"limits"`
+	Usage  limit.Usage  `json:"usage"`
+	// The override in force, if an admin has set one
+	Override *TransferLimitOverride `json:"override"`
+}
+
+// TransferAllowance returns a user's limits in a currency and how much of them they have used on their own accounts.
+func (store *SQLStore) TransferAllowance(ctx context.Context, arg TransferAllowanceParams) (
+	TransferAllowanceResult, error,
+) {
+	var result TransferAllowanceResult
+
+	user, err := store.GetUser(ctx, arg.Username)
+	if err != nil {
+		return result, err
+	}
+	result.Tier = limitTier(user)
+
+	result.Limits, result.Override, err = userLimits(ctx, store.Queries, store.limits, user, arg.Currency, arg.Now)
+	if err != nil {
+		return result, err
+	}
+
+	result.Usage, err = transferUsage(ctx, store.Queries, user.Username, arg.Currency, arg.Now)
+	return result, err
+}
+
+// checkTransferLimits returns limit.ErrExceeded if the source account's holder transferring amount as count transfers
+// would go over their limits. The holder of an organization's account is the organization: its accounts share the
+// limits of its tier, counted against what all of them have sent, and the limits of the user who opened them don't
+// apply. It locks the holder's row first, so that their transfers are checked one at a time and concurrent ones can't
+// each see the usage from before the other. Callers must check before locking any accounts, to keep to the order of
+// holder, then accounts.
+func checkTransferLimits(
+	ctx context.Context, q *Queries, schedule limit.Schedule, source Account, amount int64, count int64,
+) error {
+	if source.Product == constants.ProductInternal {
+		return nil
+	}
+	if source.OrganizationID.Valid {
+		return checkOrganizationTransferLimits(ctx, q, schedule, source, amount, count)
+	}
+
+	user, err := q.GetUserForUpdate(ctx, source.Owner)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	limits, _, err := userLimits(ctx, q, schedule, user, source.Currency, now)
+	if err != nil || limits.Unlimited() {
+		return err
+	}
+
+	usage, err := transferUsage(ctx, q, user.Username, source.Currency, now)
+	if err != nil {
+		return err
+	}
+
+	return limits.Check(usage, amount, count)
+}
+
+// checkOrganizationTransferLimits is checkTransferLimits for an organization's account. Overrides are set for users, so
+// only the organization's tier applies.
+func checkOrganizationTransferLimits(
+	ctx context.Context, q *Queries, schedule limit.Schedule, source Account, amount int64, count int64,
+) error {
+	organization, err := q.GetOrganizationForUpdate(ctx, source.OrganizationID.Int64)
+	if err != nil {
+		return err
+	}
+
+	limits := schedule.Lookup(organization.Tier, source.Currency)
+	if limits.Unlimited() {
+		return nil
+	}
+
+	windows := limit.WindowsAt(time.Now())
+	usage, err := q.GetOrganizationTransferUsage(
+		ctx, GetOrganizationTransferUsageParams{
+			DayStart:       windows.DayStart,
+			MonthStart:     windows.MonthStart,
+			HourStart:      windows.HourStart,
+			OrganizationID: organization.ID,
+			Currency:       source.Currency,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return limits.Check(
+		limit.Usage{Daily: usage.Daily, Monthly: usage.Monthly, HourlyCount: usage.HourlyCount}, amount, count,
+	)
+}
+
+// limitTier returns the tier whose limits apply to user: their own once they have passed KYC, and the unverified tier
+// until then.
+func limitTier(user User) string {
+	if user.KycStatus != constants.KycStatusVerified {
+		return constants.UserTierUnverified
+	}
+	return user.Tier
+}
+
+// userLimits returns the limits of user's tier in currency, with the parts an active override sets in their place.
+func userLimits(
+	ctx context.Context, q *Queries, schedule limit.Schedule, user User, currency string, now time.Time,
+) (limit.Limits, *TransferLimitOverride, error) {
+	limits := schedule.Lookup(limitTier(user), currency)
+
+	override, err := q.GetActiveTransferLimitOverride(
+		ctx, GetActiveTransferLimitOverrideParams{
+			Username:  user.Username,
+			Currency:  currency,
+			ExpiresAt: now,
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return limits, nil, nil
+	}
+	if err != nil {
+		return limits, nil, err
+	}
+
+	if override.PerTransfer.Valid {
+		limits.PerTransfer = override.PerTransfer.Int64
+	}
+	if override.Daily.Valid {
+		limits.Daily = override.Daily.Int64
+	}
+	if override.Monthly.Valid {
+		limits.Monthly = override.Monthly.Int64
+	}
+	if override.HourlyCount.Valid {
+		limits.HourlyCount = int64(override.HourlyCount.Int32)
+	}
+
+	return limits, &override, nil
+}
+
+func transferUsage(ctx context.Context, q *Queries, username string, currency string, now time.Time) (
+	limit.Usage, error,
+) {
+	windows := limit.WindowsAt(now)
+	usage, err := q.GetTransferUsage(
+		ctx, GetTransferUsageParams{
+			DayStart:   windows.DayStart,
+			MonthStart: windows.MonthStart,
+			HourStart:  windows.HourStart,
+			Owner:      username,
+			Currency:   currency,
+		},
+	)
+
+	return limit.Usage{Daily: usage.Daily, Monthly: usage.Monthly, HourlyCount: usage.HourlyCount}, err
+}
@@ -0,0 +1,202 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/limit"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestTransferLimits(t *testing.T) {
+	store := NewStore(
+		testDB, WithLimitSchedule(
+			limit.Schedule{
+				Tiers: []limit.TierLimits{
+					{Tier: constants.UserTierStandard, Currency: constants.USD, Limits: limit.Limits{PerTransfer: 100}},
+				},
+			},
+		),
+	)
+
+	source, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(
+		context.Background(),
+		TransferTxParams{SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 101},
+	)
+	require.ErrorIs(t, err, limit.ErrExceeded)
+
+	_, err = store.TransferTx(
+		context.Background(),
+		TransferTxParams{SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 100},
+	)
+	require.NoError(t, err)
+
+	// The override keeps the tier's per transfer limit but allows only one more transfer today
+	_, err = testQueries.CreateTransferLimitOverride(
+		context.Background(), CreateTransferLimitOverrideParams{
+			Username:  source.Owner,
+			Currency:  constants.USD,
+			Daily:     sql.NullInt64{Int64: 150, Valid: true},
+			CreatedBy: constants.BankUsername,
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(
+		context.Background(),
+		TransferTxParams{SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 51},
+	)
+	require.ErrorIs(t, err, limit.ErrExceeded)
+
+	allowance, err := store.TransferAllowance(
+		context.Background(), TransferAllowanceParams{Username: source.Owner, Currency: constants.USD, Now: time.Now()},
+	)
+	require.NoError(t, err)
+	require.Equal(t, constants.UserTierStandard, allowance.Tier)
+	require.Equal(t, limit.Limits{PerTransfer: 100, Daily: 150}, allowance.Limits)
+	require.Equal(t, limit.Usage{Daily: 100, Monthly: 100, HourlyCount: 1}, allowance.Usage)
+	require.NotNil(t, allowance.Override)
+}
+
+// TestOrganizationTransferLimits checks that an organization's accounts have its tier's limits and usage, and not
+// those of the admin who opened them.
+func TestOrganizationTransferLimits(t *testing.T) {
+	store := NewStore(
+		testDB, WithLimitSchedule(
+			limit.Schedule{
+				Tiers: []limit.TierLimits{
+					{Tier: constants.UserTierStandard, Currency: constants.USD, Limits: limit.Limits{Daily: 150}},
+					{Tier: constants.UserTierBusiness, Currency: constants.USD, Limits: limit.Limits{Daily: 1000}},
+				},
+			},
+		),
+	)
+
+	organization, admin := createTestOrganization(t, store, 0)
+	require.Equal(t, constants.UserTierStandard, organization.Tier)
+	source := createOrganizationAccount(t, store, organization, 1000)
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	personal, err := createAccountTx(
+		t, store, CreateAccountTxParams{
+			CreateAccountParams: CreateAccountParams{Owner: admin.Username, Currency: constants.USD},
+		},
+	)
+	require.NoError(t, err)
+	personal, err = testQueries.UpdateAccountBalance(
+		context.Background(), UpdateAccountBalanceParams{ID: personal.ID, Amount: 1000},
+	)
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(
+		context.Background(),
+		TransferTxParams{SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 100},
+	)
+	require.NoError(t, err)
+
+	// The organization's spending doesn't count against the admin, or the admin's against the organization
+	_, err = store.TransferTx(
+		context.Background(),
+		TransferTxParams{SourceAccountID: personal.ID, DestinationAccountID: destination.ID, Amount: 100},
+	)
+	require.NoError(t, err)
+
+	allowance, err := store.TransferAllowance(
+		context.Background(),
+		TransferAllowanceParams{Username: admin.Username, Currency: constants.USD, Now: time.Now()},
+	)
+	require.NoError(t, err)
+	require.Equal(t, limit.Usage{Daily: 100, Monthly: 100, HourlyCount: 1}, allowance.Usage)
+
+	_, err = store.TransferTx(
+		context.Background(),
+		TransferTxParams{SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 100},
+	)
+	require.ErrorIs(t, err, limit.ErrExceeded)
+
+	// An override for the admin doesn't lift the organization's limits
+	_, err = testQueries.CreateTransferLimitOverride(
+		context.Background(), CreateTransferLimitOverrideParams{
+			Username:  admin.Username,
+			Currency:  constants.USD,
+			Daily:     sql.NullInt64{Int64: 1000, Valid: true},
+			CreatedBy: constants.BankUsername,
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(
+		context.Background(),
+		TransferTxParams{SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 100},
+	)
+	require.ErrorIs(t, err, limit.ErrExceeded)
+
+	_, err = testQueries.UpdateOrganizationTier(
+		context.Background(), UpdateOrganizationTierParams{ID: organization.ID, Tier: constants.UserTierBusiness},
+	)
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(
+		context.Background(),
+		TransferTxParams{SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 100},
+	)
+	require.NoError(t, err)
+}
+
+// TestTransferLimitsConcurrent runs transfers from one user's accounts at once, each within the daily limit, to check
+// that only as many as fit in the limit together get through.
+func TestTransferLimitsConcurrent(t *testing.T) {
+	store := NewStore(
+		testDB, WithLimitSchedule(
+			limit.Schedule{
+				Tiers: []limit.TierLimits{
+					{Tier: constants.UserTierStandard, Currency: constants.USD, Limits: limit.Limits{Daily: 50}},
+				},
+			},
+		),
+	)
+
+	source, err := createFundedAccount(1000)
+	require.NoError(t, err)
+	destination, err := createFundedAccount(0)
+	require.NoError(t, err)
+
+	n := 10
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.TransferTx(
+				context.Background(),
+				TransferTxParams{SourceAccountID: source.ID, DestinationAccountID: destination.ID, Amount: 10},
+			)
+
+			errs <- err
+		}()
+	}
+
+	var completed int
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			completed++
+			continue
+		}
+		require.ErrorIs(t, err, limit.ErrExceeded)
+	}
+	require.Equal(t, 5, completed)
+
+	updatedDestination, err := testQueries.GetAccount(context.Background(), destination.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(50), updatedDestination.Balance)
+}
@@ -100,6 +100,10 @@ func TestGetTransfers(t *testing.T) {
 
 	for _, transfer := range transfers {
 		require.NotEmpty(t, transfer)
+
+		source, err := testQueries.GetAccount(context.Background(), transfer.SourceAccountID)
+		require.NoError(t, err)
+		require.Equal(t, source.Currency, transfer.Currency)
 	}
 }
 
@@ -15,7 +15,7 @@ INSERT INTO "user" (username,
                     full_name,
                     email)
 VALUES ($1, $2, $3, $4)
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, role, tier, screening_status, kyc_status
 `
 
 type CreateUserParams struct {
@@ -40,12 +40,16 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
+		&i.Tier,
+		&i.ScreeningStatus,
+		&i.KycStatus,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, role, tier, screening_status, kyc_status
 FROM "user"
 WHERE username = $1
 LIMIT 1
@@ -61,6 +65,159 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
+		&i.Tier,
+		&i.ScreeningStatus,
+		&i.KycStatus,
+	)
+	return i, err
+}
+
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, role, tier, screening_status, kyc_status
+FROM "user"
+WHERE username = $1
+LIMIT 1
+    FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserForUpdate, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.Role,
+		&i.Tier,
+		&i.ScreeningStatus,
+		&i.KycStatus,
+	)
+	return i, err
+}
+
+const getUsersForScreening = `-- name: GetUsersForScreening :many
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, role, tier, screening_status, kyc_status
+FROM "user"
+WHERE username > $1
+ORDER BY username
+LIMIT $2
+`
+
+type GetUsersForScreeningParams struct {
+	Username string `json:"username"`
+	Limit    int32  `json:"limit"`
+}
+
+func (q *Queries) GetUsersForScreening(ctx context.Context, arg GetUsersForScreeningParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getUsersForScreening, arg.Username, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Username,
+			&i.HashedPassword,
+			&i.FullName,
+			&i.Email,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.Role,
+			&i.Tier,
+			&i.ScreeningStatus,
+			&i.KycStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateUserKycStatus = `-- name: UpdateUserKycStatus :one
+UPDATE "user"
+SET kyc_status = $2
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, role, tier, screening_status, kyc_status
+`
+
+type UpdateUserKycStatusParams struct {
+	Username  string `json:"username"`
+	KycStatus string `json:"kyc_status"`
+}
+
+func (q *Queries) UpdateUserKycStatus(ctx context.Context, arg UpdateUserKycStatusParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserKycStatus, arg.Username, arg.KycStatus)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.Role,
+		&i.Tier,
+		&i.ScreeningStatus,
+		&i.KycStatus,
+	)
+	return i, err
+}
+
+const updateUserScreeningStatus = `-- name: UpdateUserScreeningStatus :exec
+UPDATE "user"
+SET screening_status = $2
+WHERE username = $1
+`
+
+type UpdateUserScreeningStatusParams struct {
+	Username        string `json:"username"`
+	ScreeningStatus string `json:"screening_status"`
+}
+
+func (q *Queries) UpdateUserScreeningStatus(ctx context.Context, arg UpdateUserScreeningStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserScreeningStatus, arg.Username, arg.ScreeningStatus)
+	return err
+}
+
+const updateUserTier = `-- name: UpdateUserTier :one
+UPDATE "user"
+SET tier = $2
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, role, tier, screening_status, kyc_status
+`
+
+type UpdateUserTierParams struct {
+	Username string `json:"username"`
+	Tier     string `json:"tier"`
+}
+
+func (q *Queries) UpdateUserTier(ctx context.Context, arg UpdateUserTierParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserTier, arg.Username, arg.Tier)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.Role,
+		&i.Tier,
+		&i.ScreeningStatus,
+		&i.KycStatus,
 	)
 	return i, err
 }
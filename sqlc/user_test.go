@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ func TestCreateUser(t *testing.T) {
 	require.Equal(t, arg.HashedPassword, user.HashedPassword)
 	require.Equal(t, arg.FullName, user.FullName)
 	require.Equal(t, arg.Email, user.Email)
+	require.Equal(t, constants.RoleCustomer, user.Role)
 
 	require.NotZero(t, user.PasswordChangedAt.IsZero())
 	require.NotZero(t, user.CreatedAt)
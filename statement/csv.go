@@ -0,0 +1,49 @@
+package statement
+
+import (
+	"encoding/csv"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{
+	"posted_at", "entry_id", "kind", "description", "counterparty_account_id", "counterparty_name", "amount",
+	"running_balance",
+}
+
+// WriteCSV writes one row per line between an opening and a closing balance row. Amounts are decimal strings in the
+// statement's currency.
+func WriteCSV(w io.Writer, s Statement) error {
+	writer := csv.NewWriter(w)
+	amount := func(v int64) string {
+		return currency.Decimal(s.Currency, v)
+	}
+
+	rows := [][]string{
+		csvHeader,
+		{s.PeriodStart.Format(time.RFC3339), "", "", "Opening balance", "", "", "", amount(s.OpeningBalance)},
+	}
+	for _, line := range s.Lines {
+		counterparty := ""
+		if line.CounterpartyAccountID != nil {
+			counterparty = strconv.FormatInt(*line.CounterpartyAccountID, 10)
+		}
+		rows = append(
+			rows, []string{
+				line.PostedAt.UTC().Format(time.RFC3339), strconv.FormatInt(line.EntryID, 10), line.Kind,
+				line.Description, counterparty, line.CounterpartyName, amount(line.Amount),
+				amount(line.RunningBalance),
+			},
+		)
+	}
+	rows = append(
+		rows, []string{s.PeriodEnd.Format(time.RFC3339), "", "", "Closing balance", "", "", "", amount(s.ClosingBalance)},
+	)
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
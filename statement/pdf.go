@@ -0,0 +1,157 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
+	"io"
+	"strings"
+)
+
+const (
+	pdfPageWidth    = 612
+	pdfPageHeight   = 792
+	pdfMargin       = 40
+	pdfFontSize     = 8
+	pdfLeading      = 10
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+// WritePDF renders the statement as a plain text table on US Letter pages in the Courier base font, so that no fonts
+// have to be embedded.
+func WritePDF(w io.Writer, s Statement) error {
+	return writePDF(w, paginate(textLines(s)))
+}
+
+func textLines(s Statement) []string {
+	amount := func(v int64) string {
+		return currency.Decimal(s.Currency, v)
+	}
+	row := func(date, kind, description, counterparty, amount, balance string) string {
+		return fmt.Sprintf(
+			"%-10s %-10s %-30s %-24s %14s %14s",
+			truncate(date, 10), truncate(kind, 10), truncate(description, 30), truncate(counterparty, 24), amount,
+			balance,
+		)
+	}
+
+	lines := []string{
+		"Account statement",
+		"",
+		fmt.Sprintf("Account:  %d (%s, %s)", s.AccountID, s.Product, s.Currency),
+		fmt.Sprintf("Owner:    %s (%s)", s.OwnerName, s.Owner),
+		fmt.Sprintf(
+			"Period:   %s to %s", s.PeriodStart.Format("2006-01-02"),
+			s.PeriodEnd.AddDate(0, 0, -1).Format("2006-01-02"),
+		),
+		fmt.Sprintf("Interest: %d.%02d%%", s.InterestRateBps/100, s.InterestRateBps%100),
+		"",
+		fmt.Sprintf("Opening balance: %s", amount(s.OpeningBalance)),
+		fmt.Sprintf("Total in:        %s", amount(s.TotalIn)),
+		fmt.Sprintf("Total out:       %s", amount(s.TotalOut)),
+		fmt.Sprintf("Closing balance: %s", amount(s.ClosingBalance)),
+		"",
+		row("Date", "Kind", "Description", "Counterparty", "Amount", "Balance"),
+	}
+	for _, line := range s.Lines {
+		counterparty := line.CounterpartyName
+		if line.CounterpartyAccountID != nil {
+			counterparty = fmt.Sprintf("%d %s", *line.CounterpartyAccountID, line.CounterpartyName)
+		}
+		lines = append(
+			lines, row(
+				line.PostedAt.UTC().Format("2006-01-02"), line.Kind, line.Description, counterparty,
+				amount(line.Amount), amount(line.RunningBalance),
+			),
+		)
+	}
+	return lines
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width])
+}
+
+func paginate(lines []string) [][]string {
+	var pages [][]string
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	return append(pages, lines)
+}
+
+// writePDF lays out a minimal PDF 1.4 file: a catalog, a page tree, one font and a page with a content stream for
+// each page of text.
+func writePDF(w io.Writer, pages [][]string) error {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// Objects 1 to 3 are fixed; each page then takes a page object and a content stream.
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		var content bytes.Buffer
+		fmt.Fprintf(
+			&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLeading, pdfMargin,
+			pdfPageHeight-pdfMargin,
+		)
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) '\n", escapePDFText(line))
+		}
+		content.WriteString("ET")
+
+		object(
+			fmt.Sprintf(
+				"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> "+
+					"/Contents %d 0 R >>", pdfPageWidth, pdfPageHeight, 5+2*i,
+			),
+		)
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// escapePDFText escapes a line for a PDF string literal. Characters outside printable ASCII are replaced, since the
+// base fonts can't be relied on to have them.
+func escapePDFText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
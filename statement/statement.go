@@ -0,0 +1,72 @@
+package statement
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Statement is everything shown on an account statement. It is stored as generated, so names and rates on it are the
+// ones in effect at the time rather than whatever they have since changed to.
+type Statement struct {
+	AccountID       int64  `json:"account_id"`
+	Owner           string `json:"owner"`
+	OwnerName       string `json:"owner_name"`
+	Currency        string `json:"currency"`
+	Product         string `json:"product"`
+	InterestRateBps int32  `json:"interest_rate_bps"`
+	// PeriodStart is inclusive and PeriodEnd exclusive, both midnight UTC.
+	PeriodStart    time.Time `json:"period_start"`
+	PeriodEnd      time.Time `json:"period_end"`
+	OpeningBalance int64     `json:"opening_balance"`
+	TotalIn        int64     `json:"total_in"`
+	TotalOut       int64     `json:"total_out"`
+	ClosingBalance int64     `json:"closing_balance"`
+	Lines          []Line    `json:"lines"`
+}
+
+type Line struct {
+	EntryID     int64     `json:"entry_id"`
+	PostedAt    time.Time `json:"posted_at"`
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	Amount      int64     `json:"amount"`
+	// RunningBalance is the balance after this line.
+	RunningBalance        int64  `json:"running_balance"`
+	TransferID            *int64 `json:"transfer_id"`
+	CounterpartyAccountID *int64 `json:"counterparty_account_id"`
+	CounterpartyName      string `json:"counterparty_name"`
+}
+
+// Tally fills in the running balances, the totals in and out and the closing balance from the opening balance and
+// the line amounts.
+func (s *Statement) Tally() {
+	s.TotalIn, s.TotalOut = 0, 0
+	balance := s.OpeningBalance
+	for i := range s.Lines {
+		amount := s.Lines[i].Amount
+		if amount >= 0 {
+			s.TotalIn += amount
+		} else {
+			s.TotalOut -= amount
+		}
+		balance += amount
+		s.Lines[i].RunningBalance = balance
+	}
+	s.ClosingBalance = balance
+}
+
+// Encode returns the statement's stored form and the hex SHA-256 hash of it.
+func Encode(s Statement) ([]byte, string, error) {
+	content, err := json.Marshal(s)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, Hash(content), nil
+}
+
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,135 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestStatement(numLines int) Statement {
+	transferID := int64(42)
+	counterpartyID := int64(7)
+
+	s := Statement{
+		AccountID:       1,
+		Owner:           "alice",
+		OwnerName:       "Alice (Smith)",
+		Currency:        constants.USD,
+		Product:         constants.ProductChecking,
+		InterestRateBps: 125,
+		PeriodStart:     time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:       time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+		OpeningBalance:  10000,
+	}
+	for i := 0; i < numLines; i++ {
+		line := Line{
+			EntryID:     int64(100 + i),
+			PostedAt:    s.PeriodStart.Add(time.Duration(i) * time.Hour),
+			Kind:        constants.JournalKindDeposit,
+			Description: "counter",
+			Amount:      2500,
+		}
+		if i%2 == 1 {
+			line.Kind = constants.JournalKindTransfer
+			line.Description = ""
+			line.Amount = -1000
+			line.TransferID = &transferID
+			line.CounterpartyAccountID = &counterpartyID
+			line.CounterpartyName = "Bob"
+		}
+		s.Lines = append(s.Lines, line)
+	}
+	s.Tally()
+	return s
+}
+
+func TestTally(t *testing.T) {
+	s := newTestStatement(3)
+
+	require.Equal(t, int64(5000), s.TotalIn)
+	require.Equal(t, int64(1000), s.TotalOut)
+	require.Equal(t, int64(14000), s.ClosingBalance)
+	require.Equal(t, []int64{12500, 11500, 14000}, []int64{
+		s.Lines[0].RunningBalance, s.Lines[1].RunningBalance, s.Lines[2].RunningBalance,
+	})
+
+	empty := newTestStatement(0)
+	require.Equal(t, empty.OpeningBalance, empty.ClosingBalance)
+	require.Zero(t, empty.TotalIn)
+	require.Zero(t, empty.TotalOut)
+}
+
+func TestEncode(t *testing.T) {
+	s := newTestStatement(3)
+
+	content1, hash1, err := Encode(s)
+	require.NoError(t, err)
+	require.Len(t, hash1, 64)
+	require.Equal(t, Hash(content1), hash1)
+
+	content2, hash2, err := Encode(s)
+	require.NoError(t, err)
+	require.Equal(t, content1, content2)
+	require.Equal(t, hash1, hash2)
+
+	s.OwnerName = "Alice Jones"
+	_, hash3, err := Encode(s)
+	require.NoError(t, err)
+	require.NotEqual(t, hash1, hash3)
+}
+
+func TestWriteCSV(t *testing.T) {
+	s := newTestStatement(2)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, s))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+	require.Equal(t, csvHeader, rows[0])
+	require.Equal(t, "Opening balance", rows[1][3])
+	require.Equal(t, "100.00", rows[1][7])
+	require.Equal(t, []string{"2023-03-01T00:00:00Z", "100", "deposit", "counter", "", "", "25.00", "125.00"}, rows[2])
+	require.Equal(t, []string{"2023-03-01T01:00:00Z", "101", "transfer", "", "7", "Bob", "-10.00", "115.00"}, rows[3])
+	require.Equal(t, "Closing balance", rows[4][3])
+	require.Equal(t, "115.00", rows[4][7])
+}
+
+func TestWritePDF(t *testing.T) {
+	s := newTestStatement(2*pdfLinesPerPage + 5)
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePDF(&buf, s))
+	pdf := buf.String()
+
+	require.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	require.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	require.Contains(t, pdf, "/Count 3")
+	require.Contains(t, pdf, `(Owner:    Alice \(Smith\) \(alice\)) '`)
+
+	// Every cross-reference entry must point at the object it numbers.
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(pdf)
+	require.NotNil(t, startxref)
+	xrefOffset, err := strconv.Atoi(startxref[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(pdf[xrefOffset:], "xref\n"))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(pdf[xrefOffset:], -1)
+	require.Len(t, entries, 3+2*3)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[1])
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(pdf[offset:], strconv.Itoa(i+1)+" 0 obj\n"))
+	}
+}
+
+func TestEscapePDFText(t *testing.T) {
+	require.Equal(t, `a\(b\)c\\d?`, escapePDFText(`a(b)c\dé`))
+}
@@ -0,0 +1,130 @@
+package util
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// AccountNumberLength is the length of an account number, including its trailing check digit.
+const AccountNumberLength = 11
+
+var (
+	ErrInvalidCountryCode = errors.New("country code must be two uppercase letters")
+	ErrInvalidBankCode    = errors.New("bank code must be uppercase letters and digits")
+)
+
+// NewAccountNumber returns a random account number: ten digits followed by a Luhn check digit. It is drawn from a
+// cryptographic source so that account numbers can't be guessed from one another.
+func NewAccountNumber() (string, error) {
+	limit := big.NewInt(10_000_000_000)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+
+	digits := fmt.Sprintf("%010d", n)
+	return fmt.Sprintf("%s%d", digits, LuhnCheckDigit(digits)), nil
+}
+
+// LuhnCheckDigit returns the digit that makes digits followed by it pass the Luhn check.
+func LuhnCheckDigit(digits string) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
+
+// ValidAccountNumber reports whether number is an account number with a correct check digit.
+func ValidAccountNumber(number string) bool {
+	if len(number) != AccountNumberLength || !isDigits(number) {
+		return false
+	}
+
+	last := len(number) - 1
+	return int(number[last]-'0') == LuhnCheckDigit(number[:last])
+}
+
+// NewIBAN builds an IBAN from a country code, the bank's code and an account number, which together with the bank code
+// forms the BBAN. The result is in electronic form, without spaces.
+func NewIBAN(countryCode string, bankCode string, accountNumber string) (string, error) {
+	if len(countryCode) != 2 || !isUpperLetters(countryCode) {
+		return "", ErrInvalidCountryCode
+	}
+	if bankCode == "" || !isUpperAlphanumeric(bankCode) {
+		return "", ErrInvalidBankCode
+	}
+
+	bban := bankCode + accountNumber
+	check := 98 - mod97(bban+countryCode+"00")
+	return fmt.Sprintf("%s%02d%s", countryCode, check, bban), nil
+}
+
+// NormalizeIBAN returns iban in electronic form: without spaces and in upper case.
+func NormalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+}
+
+// ValidIBAN reports whether iban, in electronic form, is well formed and passes the mod-97 check.
+func ValidIBAN(iban string) bool {
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	if !isUpperLetters(iban[:2]) || !isDigits(iban[2:4]) || !isUpperAlphanumeric(iban[4:]) {
+		return false
+	}
+
+	return mod97(iban[4:]+iban[:4]) == 1
+}
+
+// mod97 returns s modulo 97, reading s as a number in which each letter stands for two digits, A = 10 to Z = 35.
+func mod97(s string) int {
+	remainder := 0
+	for _, c := range s {
+		if c >= 'A' && c <= 'Z' {
+			remainder = (remainder*100 + int(c-'A'+10)) % 97
+		} else {
+			remainder = (remainder*10 + int(c-'0')) % 97
+		}
+	}
+	return remainder
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpperLetters(s string) bool {
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpperAlphanumeric(s string) bool {
+	for _, c := range s {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
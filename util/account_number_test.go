@@ -0,0 +1,63 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewAccountNumber(t *testing.T) {
+	number1, err := NewAccountNumber()
+	require.NoError(t, err)
+	require.Len(t, number1, AccountNumberLength)
+	require.True(t, ValidAccountNumber(number1))
+
+	number2, err := NewAccountNumber()
+	require.NoError(t, err)
+	require.NotEqual(t, number1, number2)
+}
+
+func TestValidAccountNumber(t *testing.T) {
+	testCases := []struct {
+		name   string
+		number string
+		valid  bool
+	}{
+		{name: "Valid", number: "79927398713", valid: true},
+		{name: "WrongCheckDigit", number: "79927398710", valid: false},
+		{name: "TransposedDigits", number: "97927398713", valid: false},
+		{name: "TooShort", number: "7992739871", valid: false},
+		{name: "NotDigits", number: "7992739871a", valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				require.Equal(t, tc.valid, ValidAccountNumber(tc.number))
+			},
+		)
+	}
+}
+
+func TestIBAN(t *testing.T) {
+	// Example from the ISO 13616 standard.
+	require.True(t, ValidIBAN("GB82WEST12345698765432"))
+	require.False(t, ValidIBAN("GB83WEST12345698765432"))
+	require.False(t, ValidIBAN("GB82WEST1234569876543!"))
+	require.Equal(t, "GB82WEST12345698765432", NormalizeIBAN("gb82 west 1234 5698 7654 32"))
+
+	iban, err := NewIBAN("GB", "WEST", "12345698765432")
+	require.NoError(t, err)
+	require.Equal(t, "GB82WEST12345698765432", iban)
+
+	number, err := NewAccountNumber()
+	require.NoError(t, err)
+	iban, err = NewIBAN("DE", "GOLB", number)
+	require.NoError(t, err)
+	require.True(t, ValidIBAN(iban))
+
+	_, err = NewIBAN("de", "GOLB", number)
+	require.ErrorIs(t, err, ErrInvalidCountryCode)
+
+	_, err = NewIBAN("DE", "", number)
+	require.ErrorIs(t, err, ErrInvalidBankCode)
+}
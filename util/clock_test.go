@@ -0,0 +1,22 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2023, 1, 31, 23, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	require.Equal(t, start, clock.Now())
+
+	clock.Advance(2 * time.Hour)
+	require.Equal(t, start.Add(2*time.Hour), clock.Now())
+	require.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), StartOfDay(clock.Now()))
+	require.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), StartOfMonth(clock.Now()))
+
+	clock.Set(start)
+	require.Equal(t, start, clock.Now())
+	require.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), StartOfMonth(clock.Now()))
+}
@@ -6,12 +6,50 @@ import (
 )
 
 type Config struct {
-	AccessTokenDuration     time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	AccessTokenSymmetricKey string        `mapstructure:"ACCESS_TOKEN_SYMMETRIC_KEY"`
-	DBDriver                string        `mapstructure:"DB_DRIVER"`
-	DBSource                string        `mapstructure:"DB_SOURCE"`
-	RefreshTokenDuration    time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
-	ServerAddress           string        `mapstructure:"SERVER_ADDRESS"`
+	AMLMonitoringInterval          time.Duration `mapstructure:"AML_MONITORING_INTERVAL"`
+	AMLScenariosPath               string        `mapstructure:"AML_SCENARIOS_PATH"`
+	AccessTokenDuration            time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	AccessTokenSymmetricKey        string        `mapstructure:"ACCESS_TOKEN_SYMMETRIC_KEY"`
+	BalanceSnapshotInterval        time.Duration `mapstructure:"BALANCE_SNAPSHOT_INTERVAL"`
+	DBDriver                       string        `mapstructure:"DB_DRIVER"`
+	DBSource                       string        `mapstructure:"DB_SOURCE"`
+	DormancyInterval               time.Duration `mapstructure:"DORMANCY_INTERVAL"`
+	DormancyMonths                 int           `mapstructure:"DORMANCY_MONTHS"`
+	EnabledCurrencies              []string      `mapstructure:"ENABLED_CURRENCIES"`
+	FeeSchedulePath                string        `mapstructure:"FEE_SCHEDULE_PATH"`
+	HoldDuration                   time.Duration `mapstructure:"HOLD_DURATION"`
+	HoldExpiryInterval             time.Duration `mapstructure:"HOLD_EXPIRY_INTERVAL"`
+	IBANBankCode                   string        `mapstructure:"IBAN_BANK_CODE"`
+	IBANCountryCode                string        `mapstructure:"IBAN_COUNTRY_CODE"`
+	InterestInterval               time.Duration `mapstructure:"INTEREST_INTERVAL"`
+	KYCDocumentDir                 string        `mapstructure:"KYC_DOCUMENT_DIR"`
+	KYCDocumentMaxSize             int64         `mapstructure:"KYC_DOCUMENT_MAX_SIZE"`
+	KYCEncryptionKey               string        `mapstructure:"KYC_ENCRYPTION_KEY"`
+	MaintenanceFeeInterval         time.Duration `mapstructure:"MAINTENANCE_FEE_INTERVAL"`
+	MaxAccountsPerCurrency         int64         `mapstructure:"MAX_ACCOUNTS_PER_CURRENCY"`
+	MaxAccountsPerUser             int64         `mapstructure:"MAX_ACCOUNTS_PER_USER"`
+	PayeeCoolingOffLimit           int64         `mapstructure:"PAYEE_COOLING_OFF_LIMIT"`
+	PayeeCoolingOffPeriod          time.Duration `mapstructure:"PAYEE_COOLING_OFF_PERIOD"`
+	PaymentRequestExpiryInterval   time.Duration `mapstructure:"PAYMENT_REQUEST_EXPIRY_INTERVAL"`
+	PaymentRequestTTL              time.Duration `mapstructure:"PAYMENT_REQUEST_TTL"`
+	PendingTransferExpiryInterval  time.Duration `mapstructure:"PENDING_TRANSFER_EXPIRY_INTERVAL"`
+	PendingTransferTTL             time.Duration `mapstructure:"PENDING_TRANSFER_TTL"`
+	ReconciliationInterval         time.Duration `mapstructure:"RECONCILIATION_INTERVAL"`
+	RefreshTokenDuration           time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	RiskRulesPath                  string        `mapstructure:"RISK_RULES_PATH"`
+	RiskRulesReloadInterval        time.Duration `mapstructure:"RISK_RULES_RELOAD_INTERVAL"`
+	SanctionsListPath              string        `mapstructure:"SANCTIONS_LIST_PATH"`
+	SanctionsMatchThreshold        int           `mapstructure:"SANCTIONS_MATCH_THRESHOLD"`
+	SanctionsReloadInterval        time.Duration `mapstructure:"SANCTIONS_RELOAD_INTERVAL"`
+	ScheduledTransferAttempts      int32         `mapstructure:"SCHEDULED_TRANSFER_ATTEMPTS"`
+	ScheduledTransferInterval      time.Duration `mapstructure:"SCHEDULED_TRANSFER_INTERVAL"`
+	ScheduledTransferRetryInterval time.Duration `mapstructure:"SCHEDULED_TRANSFER_RETRY_INTERVAL"`
+	ServerAddress                  string        `mapstructure:"SERVER_ADDRESS"`
+	StatementInterval              time.Duration `mapstructure:"STATEMENT_INTERVAL"`
+	TransferBatchAsyncThreshold    int           `mapstructure:"TRANSFER_BATCH_ASYNC_THRESHOLD"`
+	TransferBatchInterval          time.Duration `mapstructure:"TRANSFER_BATCH_INTERVAL"`
+	TransferBatchMaxItems          int           `mapstructure:"TRANSFER_BATCH_MAX_ITEMS"`
+	TransferLimitSchedulePath      string        `mapstructure:"TRANSFER_LIMIT_SCHEDULE_PATH"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -1,11 +1,7 @@
 package util
 
-import "github.com/CrunchyBlue/Golang-Bank/constants"
+import "github.com/CrunchyBlue/Golang-Bank/currency"
 
-func IsSupportedCurrency(currency string) bool {
-	switch currency {
-	case constants.USD, constants.EUR, constants.CAD:
-		return true
-	}
-	return false
+func IsSupportedCurrency(code string) bool {
+	return currency.IsEnabled(code)
 }
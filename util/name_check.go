@@ -0,0 +1,58 @@
+package util
+
+import (
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"strings"
+	"unicode"
+)
+
+// CheckName compares the name a payer expects an account to be held under with the name it really is held under. It
+// ignores case, punctuation and spacing, and calls it a close match when every word of the shorter name is in the
+// longer one, or they share a last word and their first words start with the same letter, such as "J. Smith" and
+// "Jane Smith".
+func CheckName(expected, actual string) string {
+	expectedWords, actualWords := nameWords(expected), nameWords(actual)
+	if len(expectedWords) == 0 || len(actualWords) == 0 {
+		return constants.NameCheckNoMatch
+	}
+
+	if strings.Join(expectedWords, " ") == strings.Join(actualWords, " ") {
+		return constants.NameCheckMatch
+	}
+
+	shorter, longer := expectedWords, actualWords
+	if len(shorter) > len(longer) {
+		shorter, longer = longer, shorter
+	}
+	if containsWords(longer, shorter) {
+		return constants.NameCheckCloseMatch
+	}
+
+	if len(shorter) > 1 && shorter[len(shorter)-1] == longer[len(longer)-1] && shorter[0][0] == longer[0][0] {
+		return constants.NameCheckCloseMatch
+	}
+
+	return constants.NameCheckNoMatch
+}
+
+func nameWords(name string) []string {
+	return strings.FieldsFunc(
+		strings.ToLower(name), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		},
+	)
+}
+
+func containsWords(words, subset []string) bool {
+	seen := make(map[string]int, len(words))
+	for _, word := range words {
+		seen[word]++
+	}
+	for _, word := range subset {
+		if seen[word] == 0 {
+			return false
+		}
+		seen[word]--
+	}
+	return true
+}
@@ -0,0 +1,17 @@
+package util
+
+import (
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCheckName(t *testing.T) {
+	require.Equal(t, constants.NameCheckMatch, CheckName("Jane Smith", "jane  SMITH"))
+	require.Equal(t, constants.NameCheckMatch, CheckName("O'Brien, Pat", "o brien pat"))
+	require.Equal(t, constants.NameCheckCloseMatch, CheckName("Jane Smith", "Jane Alice Smith"))
+	require.Equal(t, constants.NameCheckCloseMatch, CheckName("J. Smith", "Jane Smith"))
+	require.Equal(t, constants.NameCheckNoMatch, CheckName("John Smith", "Jane Doe"))
+	require.Equal(t, constants.NameCheckNoMatch, CheckName("Smith", "Jane Doe"))
+	require.Equal(t, constants.NameCheckNoMatch, CheckName("...", "Jane Doe"))
+}
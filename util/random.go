@@ -2,7 +2,7 @@ package util
 
 import (
 	"fmt"
-	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/CrunchyBlue/Golang-Bank/currency"
 	"math/rand"
 	"strings"
 )
@@ -33,12 +33,14 @@ func RandomInt(min, max int64) int64 {
 	return min + rand.Int63n(max-min+1)
 }
 
+// RandomAccountNumber returns an account number with a valid check digit. Use NewAccountNumber for real accounts.
+func RandomAccountNumber() string {
+	digits := fmt.Sprintf("%010d", rand.Int63n(10_000_000_000))
+	return fmt.Sprintf("%s%d", digits, LuhnCheckDigit(digits))
+}
+
 func RandomCurrency() string {
-	currencies := []string{
-		constants.USD,
-		constants.EUR,
-		constants.CAD,
-	}
+	currencies := currency.Enabled()
 	n := len(currencies)
 	return currencies[rand.Intn(n)]
 }
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"time"
+)
+
+// NextOccurrence returns the occurrence that follows previous for the given frequency. Monthly schedules fall on
+// dayOfMonth, or on the last day of shorter months. A one-off schedule has no next occurrence and returns false.
+func NextOccurrence(frequency string, dayOfMonth int, previous time.Time) (time.Time, bool) {
+	switch frequency {
+	case constants.FrequencyDaily:
+		return previous.AddDate(0, 0, 1), true
+	case constants.FrequencyWeekly:
+		return previous.AddDate(0, 0, 7), true
+	case constants.FrequencyMonthly:
+		firstOfMonth := time.Date(previous.Year(), previous.Month()+1, 1, 0, 0, 0, 0, previous.Location())
+		return OnDayOfMonth(firstOfMonth, dayOfMonth, previous), true
+	}
+	return time.Time{}, false
+}
+
+// FirstOccurrence returns the first occurrence on or after start. Monthly schedules move forward to dayOfMonth, every
+// other frequency starts at start.
+func FirstOccurrence(frequency string, dayOfMonth int, start time.Time) time.Time {
+	if frequency != constants.FrequencyMonthly {
+		return start
+	}
+
+	first := OnDayOfMonth(start, dayOfMonth, start)
+	if first.Before(start) {
+		first, _ = NextOccurrence(frequency, dayOfMonth, first)
+	}
+	return first
+}
+
+// OnDayOfMonth returns the given day of month's date, clamped to the last day of the month, at the time of day of
+// clock.
+func OnDayOfMonth(month time.Time, dayOfMonth int, clock time.Time) time.Time {
+	lastDay := time.Date(month.Year(), month.Month()+1, 0, 0, 0, 0, 0, month.Location()).Day()
+	if dayOfMonth > lastDay {
+		dayOfMonth = lastDay
+	}
+
+	return time.Date(
+		month.Year(), month.Month(), dayOfMonth, clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(),
+		month.Location(),
+	)
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"github.com/CrunchyBlue/Golang-Bank/constants"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name       string
+		frequency  string
+		dayOfMonth int
+		previous   time.Time
+		next       time.Time
+		ok         bool
+	}{
+		{name: "Once", frequency: constants.FrequencyOnce, previous: date(2023, 1, 1), ok: false},
+		{name: "Daily", frequency: constants.FrequencyDaily, previous: date(2023, 12, 31), next: date(2024, 1, 1), ok: true},
+		{name: "Weekly", frequency: constants.FrequencyWeekly, previous: date(2023, 2, 25), next: date(2023, 3, 4), ok: true},
+		{name: "Monthly", frequency: constants.FrequencyMonthly, dayOfMonth: 15, previous: date(2023, 1, 15), next: date(2023, 2, 15), ok: true},
+		{name: "MonthlyShortMonth", frequency: constants.FrequencyMonthly, dayOfMonth: 31, previous: date(2023, 1, 31), next: date(2023, 2, 28), ok: true},
+		{name: "MonthlyAfterShortMonth", frequency: constants.FrequencyMonthly, dayOfMonth: 31, previous: date(2023, 2, 28), next: date(2023, 3, 31), ok: true},
+		{name: "MonthlyLeapYear", frequency: constants.FrequencyMonthly, dayOfMonth: 30, previous: date(2024, 1, 30), next: date(2024, 2, 29), ok: true},
+		{name: "MonthlyYearEnd", frequency: constants.FrequencyMonthly, dayOfMonth: 1, previous: date(2023, 12, 1), next: date(2024, 1, 1), ok: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				next, ok := NextOccurrence(tc.frequency, tc.dayOfMonth, tc.previous)
+				require.Equal(t, tc.ok, ok)
+				require.Equal(t, tc.next, next)
+			},
+		)
+	}
+}
+
+func TestFirstOccurrence(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	require.Equal(t, date(2023, 1, 10), FirstOccurrence(constants.FrequencyWeekly, 0, date(2023, 1, 10)))
+	require.Equal(t, date(2023, 1, 15), FirstOccurrence(constants.FrequencyMonthly, 15, date(2023, 1, 10)))
+	require.Equal(t, date(2023, 2, 5), FirstOccurrence(constants.FrequencyMonthly, 5, date(2023, 1, 10)))
+	require.Equal(t, date(2023, 2, 28), FirstOccurrence(constants.FrequencyMonthly, 31, date(2023, 2, 10)))
+}
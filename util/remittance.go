@@ -0,0 +1,40 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// remittanceReferencePattern is the SWIFT character set, which references have to fit in to survive being passed
+	// on to other banks.
+	remittanceReferencePattern = regexp.MustCompile(`^[A-Za-z0-9/\-?:().,'+ ]+$`)
+	categoryPattern            = regexp.MustCompile(`^[a-z0-9]+([_-][a-z0-9]+)*$`)
+)
+
+// ValidRemittanceText reports whether text can be used as a memo: printable characters only, with no leading or
+// trailing spaces.
+func ValidRemittanceText(text string) bool {
+	if text == "" || strings.TrimSpace(text) != text {
+		return false
+	}
+	for _, r := range text {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidRemittanceReference reports whether reference only uses the SWIFT character set and has no leading or
+// trailing spaces. References, invoice numbers and end-to-end IDs have to pass it.
+func ValidRemittanceReference(reference string) bool {
+	return strings.TrimSpace(reference) == reference && remittanceReferencePattern.MatchString(reference)
+}
+
+// ValidCategory reports whether category is lowercase letters and digits, optionally split by single hyphens or
+// underscores, such as "rent" or "office-supplies".
+func ValidCategory(category string) bool {
+	return categoryPattern.MatchString(category)
+}
@@ -0,0 +1,34 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestValidRemittanceText(t *testing.T) {
+	require.True(t, ValidRemittanceText("Rent for March"))
+	require.True(t, ValidRemittanceText("Café 50% off!"))
+	require.False(t, ValidRemittanceText(""))
+	require.False(t, ValidRemittanceText(" padded"))
+	require.False(t, ValidRemittanceText("two\nlines"))
+	require.False(t, ValidRemittanceText("tab\there"))
+}
+
+func TestValidRemittanceReference(t *testing.T) {
+	require.True(t, ValidRemittanceReference("INV-2023/0042"))
+	require.True(t, ValidRemittanceReference("Order (12), part 1+2"))
+	require.False(t, ValidRemittanceReference(""))
+	require.False(t, ValidRemittanceReference("INV_0042"))
+	require.False(t, ValidRemittanceReference("Café"))
+	require.False(t, ValidRemittanceReference("trailing "))
+}
+
+func TestValidCategory(t *testing.T) {
+	require.True(t, ValidCategory("rent"))
+	require.True(t, ValidCategory("office-supplies"))
+	require.True(t, ValidCategory("q1_payroll"))
+	require.False(t, ValidCategory("Rent"))
+	require.False(t, ValidCategory("office--supplies"))
+	require.False(t, ValidCategory("-rent"))
+	require.False(t, ValidCategory(""))
+}